@@ -19,7 +19,13 @@ import (
 )
 
 func main() {
-	log := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
+	// subcommands print their result to stdout, logs go to stderr
+	logOut := os.Stdout
+	if len(os.Args) > 1 {
+		logOut = os.Stderr
+	}
+
+	log := slog.New(slog.NewJSONHandler(logOut, &slog.HandlerOptions{
 		Level: slog.LevelDebug,
 	}))
 	slog.SetDefault(log)
@@ -29,6 +35,18 @@ func main() {
 	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 	defer stop()
 
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "verify-ledger":
+			code := runVerifyLedger(ctx, log, cfg, os.Args[2:], os.Stdout)
+			stop()
+			os.Exit(code)
+		default:
+			log.Error("unknown command", "command", os.Args[1])
+			os.Exit(2)
+		}
+	}
+
 	if err := runMigrations(ctx, log, cfg.PostgresURL); err != nil {
 		log.Error("migrations failed", "err", err)
 		os.Exit(1)
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"time"
+
+	"Beanefits/internal/config"
+	"Beanefits/internal/repository/postgres"
+	"Beanefits/internal/repository/postgres/repo"
+	"Beanefits/internal/repository/postgres/sqlc/gen"
+	"Beanefits/internal/service/admin"
+	"Beanefits/internal/service/dto"
+)
+
+// runVerifyLedger implements `beanefits verify-ledger [-user ID]`.
+// It prints one line per mismatch and a summary; exit code 1 means mismatches were found, 2 means the check failed.
+func runVerifyLedger(ctx context.Context, log *slog.Logger, cfg config.Config, args []string, stdout io.Writer) int {
+	fs := flag.NewFlagSet("verify-ledger", flag.ContinueOnError)
+	userID := fs.Int64("user", 0, "verify only this user's account (all accounts when 0)")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	pool, err := postgres.NewPool(ctx, cfg.PostgresURL)
+	if err != nil {
+		log.Error("postgres pool failed", "err", err)
+		return 2
+	}
+	defer pool.Close()
+
+	q := gen.New()
+	svc := admin.New(admin.Deps{
+		DB:       pool,
+		TXM:      postgres.NewTxManager(pool),
+		Rules:    repo.NewRulesRepo(q),
+		Accounts: repo.NewAccountsRepo(q),
+		Events:   repo.NewEventsRepo(q),
+		Now:      func() time.Time { return time.Now().UTC() },
+		Log:      log,
+	})
+
+	var in dto.VerifyLedgerIn
+	if *userID > 0 {
+		in.UserID = userID
+	}
+
+	report, err := svc.VerifyLedger(ctx, in)
+	if err != nil {
+		log.Error("verify-ledger failed", "err", err)
+		return 2
+	}
+
+	for _, m := range report.Mismatches {
+		event := ""
+		if m.EventID != nil {
+			event = fmt.Sprintf(" event=%d", *m.EventID)
+		}
+		fmt.Fprintf(stdout, "MISMATCH account=%d user=%d%s check=%s expected=%q actual=%q\n",
+			m.AccountID, m.UserID, event, m.Check, m.Expected, m.Actual)
+	}
+	fmt.Fprintf(stdout, "accounts=%d events=%d mismatches=%d\n",
+		report.AccountsChecked, report.EventsChecked, len(report.Mismatches))
+
+	if len(report.Mismatches) > 0 {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,16 @@
+-- +goose NO TRANSACTION
+-- +goose Up
+ALTER TYPE event_type ADD VALUE IF NOT EXISTS 'REFUND';
+
+ALTER TABLE events
+    ADD COLUMN ref_event_id BIGINT,
+    ADD CONSTRAINT fk_events_ref_event FOREIGN KEY (ref_event_id) REFERENCES events (id) ON DELETE RESTRICT;
+
+CREATE INDEX idx_events_ref_event_id ON events (ref_event_id) WHERE ref_event_id IS NOT NULL;
+
+-- +goose Down
+DROP INDEX idx_events_ref_event_id;
+ALTER TABLE events
+    DROP CONSTRAINT fk_events_ref_event,
+    DROP COLUMN ref_event_id;
+-- Enum values cannot be dropped in PostgreSQL; 'REFUND' stays in event_type.
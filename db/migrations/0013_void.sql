@@ -0,0 +1,10 @@
+-- +goose NO TRANSACTION
+-- +goose Up
+ALTER TYPE event_type ADD VALUE IF NOT EXISTS 'VOID';
+
+-- A SPEND can be voided only once.
+CREATE UNIQUE INDEX uq_events_void_ref_event ON events (ref_event_id) WHERE type = 'VOID';
+
+-- +goose Down
+DROP INDEX uq_events_void_ref_event;
+-- Enum values cannot be dropped in PostgreSQL; 'VOID' stays in event_type.
@@ -0,0 +1,40 @@
+-- +goose NO TRANSACTION
+-- +goose Up
+ALTER TYPE event_type ADD VALUE IF NOT EXISTS 'EXPIRE';
+
+-- Earned points are tracked as lots so that they can expire; SPEND takes the oldest lots first.
+-- Invariant: SUM(remaining_points) of an account equals accounts.balance_points.
+CREATE TABLE point_lots
+(
+    id               BIGSERIAL PRIMARY KEY,
+    account_id       BIGINT      NOT NULL,
+    source_event_id  BIGINT,
+    points           INT         NOT NULL,
+    remaining_points INT         NOT NULL,
+    earned_at        TIMESTAMPTZ NOT NULL,
+    expires_at       TIMESTAMPTZ NOT NULL,
+    created_at       TIMESTAMPTZ NOT NULL DEFAULT now(),
+
+    CONSTRAINT fk_point_lots_account FOREIGN KEY (account_id) REFERENCES accounts (id) ON DELETE RESTRICT,
+    CONSTRAINT fk_point_lots_source_event FOREIGN KEY (source_event_id) REFERENCES events (id) ON DELETE RESTRICT,
+
+    CONSTRAINT chk_point_lots_points_positive CHECK (points > 0),
+    CONSTRAINT chk_point_lots_remaining_range CHECK (remaining_points >= 0 AND remaining_points <= points)
+);
+
+CREATE INDEX idx_point_lots_account_open ON point_lots (account_id, expires_at, id) WHERE remaining_points > 0;
+CREATE INDEX idx_point_lots_expires_open ON point_lots (expires_at) WHERE remaining_points > 0;
+
+-- Balances accumulated before lots existed have no age: open one lot per account, aged from the migration.
+-- The lifetime is not known here: 12 months is a placeholder that the app replaces with
+-- POINTS_LIFETIME_MONTHS at startup (lots.Book.AgeLegacy), before any lot can expire.
+INSERT INTO point_lots (account_id, points, remaining_points, earned_at, expires_at)
+SELECT id, balance_points, balance_points, now(), now() + INTERVAL '12 months'
+FROM accounts
+WHERE balance_points > 0;
+
+-- +goose Down
+DROP INDEX idx_point_lots_expires_open;
+DROP INDEX idx_point_lots_account_open;
+DROP TABLE point_lots;
+-- Enum values cannot be dropped in PostgreSQL; 'EXPIRE' stays in event_type.
@@ -0,0 +1,15 @@
+-- +goose NO TRANSACTION
+-- +goose Up
+-- CHECKOUT is an operation type only (operations.op_type); its ledger entries are SPEND + EARN.
+ALTER TYPE event_type ADD VALUE IF NOT EXISTS 'CHECKOUT';
+
+-- Money value of one point redeemed at checkout.
+ALTER TABLE ruleset
+    ADD COLUMN redeem_rub_per_point NUMERIC(10, 2) NOT NULL DEFAULT 1.00,
+    ADD CONSTRAINT chk_ruleset_redeem_rub_per_point_positive CHECK (redeem_rub_per_point > 0);
+
+-- +goose Down
+ALTER TABLE ruleset
+    DROP CONSTRAINT chk_ruleset_redeem_rub_per_point_positive,
+    DROP COLUMN redeem_rub_per_point;
+-- Enum values cannot be dropped in PostgreSQL; 'CHECKOUT' stays in event_type.
@@ -0,0 +1,34 @@
+-- +goose NO TRANSACTION
+-- +goose Up
+ALTER TYPE event_type ADD VALUE IF NOT EXISTS 'ADJUST';
+
+-- Reasons an admin can pick for a manual balance adjustment.
+CREATE TABLE adjustment_reasons
+(
+    code TEXT PRIMARY KEY
+);
+
+INSERT INTO adjustment_reasons (code) VALUES
+                                          ('GOODWILL'),
+                                          ('COMPENSATION'),
+                                          ('CORRECTION'),
+                                          ('OTHER')
+ON CONFLICT (code) DO NOTHING;
+
+-- Why an ADJUST event was written; the admin is events.actor_user_id.
+CREATE TABLE adjustments
+(
+    event_id    BIGINT PRIMARY KEY,
+    reason_code TEXT NOT NULL,
+    comment     TEXT NOT NULL,
+
+    CONSTRAINT fk_adjustments_event FOREIGN KEY (event_id) REFERENCES events (id) ON DELETE RESTRICT,
+    CONSTRAINT fk_adjustments_reason FOREIGN KEY (reason_code) REFERENCES adjustment_reasons (code) ON DELETE RESTRICT,
+
+    CONSTRAINT chk_adjustments_comment_not_blank CHECK (length(btrim(comment)) > 0)
+);
+
+-- +goose Down
+DROP TABLE adjustments;
+DROP TABLE adjustment_reasons;
+-- Enum values cannot be dropped in PostgreSQL; 'ADJUST' stays in event_type.
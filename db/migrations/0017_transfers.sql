@@ -0,0 +1,10 @@
+-- +goose NO TRANSACTION
+-- +goose Up
+-- A transfer writes a TRANSFER_OUT on the sender and a TRANSFER_IN on the recipient
+-- (ref_event_id -> TRANSFER_OUT); TRANSFER is the operation type (operations.op_type).
+ALTER TYPE event_type ADD VALUE IF NOT EXISTS 'TRANSFER_OUT';
+ALTER TYPE event_type ADD VALUE IF NOT EXISTS 'TRANSFER_IN';
+ALTER TYPE event_type ADD VALUE IF NOT EXISTS 'TRANSFER';
+
+-- +goose Down
+-- Enum values cannot be dropped in PostgreSQL; 'TRANSFER_OUT', 'TRANSFER_IN' and 'TRANSFER' stay in event_type.
@@ -0,0 +1,20 @@
+-- +goose Up
+-- Per-account hash chain over the ledger: hash = sha256(prev_hash || canonical event content),
+-- prev_hash = hash of the account's previous event. Both are computed by the app when the event is
+-- inserted; rows written before this migration are hashed by the backfill on startup.
+ALTER TABLE events
+    ADD COLUMN prev_hash BYTEA,
+    ADD COLUMN hash BYTEA;
+
+-- chain head lookup on insert
+CREATE INDEX idx_events_account_id ON events (account_id, id);
+
+-- backfill scan
+CREATE INDEX idx_events_unhashed ON events (account_id) WHERE hash IS NULL;
+
+-- +goose Down
+DROP INDEX idx_events_unhashed;
+DROP INDEX idx_events_account_id;
+ALTER TABLE events
+    DROP COLUMN hash,
+    DROP COLUMN prev_hash;
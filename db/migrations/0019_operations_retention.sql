@@ -0,0 +1,21 @@
+-- +goose Up
+-- Idempotency records older than the retention window are removed by the app in small batches.
+-- Only their keys are kept here, so a late retry of an expired operationId is rejected
+-- instead of being executed a second time.
+CREATE TABLE expired_operations (
+    account_id   BIGINT      NOT NULL,
+    op_type      event_type  NOT NULL,
+    operation_id TEXT        NOT NULL,
+    created_at   TIMESTAMPTZ NOT NULL,
+    expired_at   TIMESTAMPTZ NOT NULL DEFAULT now(),
+
+    CONSTRAINT pk_expired_operations PRIMARY KEY (account_id, op_type, operation_id),
+    CONSTRAINT fk_expired_operations_account FOREIGN KEY (account_id) REFERENCES accounts (id) ON DELETE RESTRICT
+);
+
+-- retention scan, oldest first
+CREATE INDEX idx_operations_created_at ON operations (created_at, id);
+
+-- +goose Down
+DROP INDEX idx_operations_created_at;
+DROP TABLE expired_operations;
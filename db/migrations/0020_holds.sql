@@ -0,0 +1,41 @@
+-- +goose Up
+-- Two-phase spending: a hold reserves points (held_points, not part of the ledger balance) until it is
+-- captured (written as a SPEND event), released, or expires.
+ALTER TABLE accounts
+    ADD COLUMN held_points INT NOT NULL DEFAULT 0,
+    ADD CONSTRAINT chk_accounts_held_points_nonnegative CHECK (held_points >= 0);
+
+CREATE TABLE holds
+(
+    id               BIGSERIAL PRIMARY KEY,
+    account_id       BIGINT      NOT NULL,
+    operation_id     TEXT        NOT NULL,
+    points           INT         NOT NULL,
+    status           TEXT        NOT NULL DEFAULT 'ACTIVE',
+    expires_at       TIMESTAMPTZ NOT NULL,
+    captured_points  INT,
+    capture_event_id BIGINT,
+    actor_user_id    BIGINT,
+    created_at       TIMESTAMPTZ NOT NULL DEFAULT now(),
+    closed_at        TIMESTAMPTZ,
+
+    CONSTRAINT fk_holds_account FOREIGN KEY (account_id) REFERENCES accounts (id) ON DELETE RESTRICT,
+    CONSTRAINT fk_holds_capture_event FOREIGN KEY (capture_event_id) REFERENCES events (id) ON DELETE RESTRICT,
+    CONSTRAINT fk_holds_actor FOREIGN KEY (actor_user_id) REFERENCES users (id) ON DELETE SET NULL,
+
+    -- operationId of the authorize call, idempotency key of the hold
+    CONSTRAINT uq_holds_operation UNIQUE (account_id, operation_id),
+
+    CONSTRAINT chk_holds_points_positive CHECK (points > 0),
+    CONSTRAINT chk_holds_status CHECK (status IN ('ACTIVE', 'CAPTURED', 'RELEASED', 'EXPIRED')),
+    CONSTRAINT chk_holds_captured_range CHECK (captured_points IS NULL OR (captured_points > 0 AND captured_points <= points))
+);
+
+-- expiry sweep
+CREATE INDEX idx_holds_active_expires ON holds (expires_at) WHERE status = 'ACTIVE';
+
+-- +goose Down
+DROP TABLE holds;
+ALTER TABLE accounts
+    DROP CONSTRAINT chk_accounts_held_points_nonnegative,
+    DROP COLUMN held_points;
@@ -0,0 +1,31 @@
+-- +goose Up
+-- Velocity limits of cashier operations, managed by admins. One row per (kind, op_type); no row means no limit.
+--   POINTS_PER_OPERATION        - points of a single EARN/SPEND
+--   ACCOUNT_POINTS_PER_DAY      - points of EARN/SPEND on one account per UTC day
+--   CASHIER_OPERATIONS_PER_HOUR - EARN/SPEND operations made by one cashier in the last hour
+CREATE TABLE velocity_limits
+(
+    id         BIGSERIAL PRIMARY KEY,
+    kind       TEXT        NOT NULL,
+    op_type    event_type  NOT NULL,
+    max_value  INT         NOT NULL,
+    updated_by BIGINT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+
+    CONSTRAINT fk_velocity_limits_updated_by FOREIGN KEY (updated_by) REFERENCES users (id) ON DELETE SET NULL,
+    CONSTRAINT uq_velocity_limits_kind_op_type UNIQUE (kind, op_type),
+
+    CONSTRAINT chk_velocity_limits_kind CHECK (kind IN ('POINTS_PER_OPERATION', 'ACCOUNT_POINTS_PER_DAY', 'CASHIER_OPERATIONS_PER_HOUR')),
+    CONSTRAINT chk_velocity_limits_op_type CHECK (op_type IN ('EARN', 'SPEND')),
+    CONSTRAINT chk_velocity_limits_max_value_positive CHECK (max_value > 0)
+);
+
+-- usage windows are taken by server time (created_at): ts can be backdated by the till
+CREATE INDEX idx_events_account_created_at ON events (account_id, created_at);
+CREATE INDEX idx_events_actor_created_at ON events (actor_user_id, created_at);
+
+-- +goose Down
+DROP INDEX idx_events_actor_created_at;
+DROP INDEX idx_events_account_created_at;
+DROP TABLE velocity_limits;
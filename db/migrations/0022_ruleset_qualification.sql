@@ -0,0 +1,11 @@
+-- +goose Up
+-- Tier qualification window: NULL qualifies on lifetime spend (accounts.total_spend_money),
+-- N qualifies on spend of purchases made in the last N days.
+ALTER TABLE ruleset
+    ADD COLUMN qualification_window_days INT,
+    ADD CONSTRAINT chk_ruleset_qualification_window_days_positive CHECK (qualification_window_days IS NULL OR qualification_window_days > 0);
+
+-- +goose Down
+ALTER TABLE ruleset
+    DROP CONSTRAINT chk_ruleset_qualification_window_days_positive,
+    DROP COLUMN qualification_window_days;
@@ -0,0 +1,53 @@
+-- +goose Up
+-- Time-boxed earn promotions applied on top of the ruleset. A campaign is active at a moment when, in its
+-- timezone, the date is within [starts_on, ends_on], the ISO weekday (1 = Monday) is listed in weekdays
+-- (empty = every day) and the time of day is within [time_from, time_to) (NULL = all day; time_from > time_to
+-- wraps past midnight). It either multiplies the earned points or adds a flat bonus.
+CREATE TABLE campaigns
+(
+    id           BIGSERIAL PRIMARY KEY,
+    name         TEXT          NOT NULL,
+    starts_on    DATE          NOT NULL,
+    ends_on      DATE,
+    weekdays     SMALLINT[]    NOT NULL DEFAULT '{}',
+    time_from    TIME,
+    time_to      TIME,
+    timezone     TEXT          NOT NULL DEFAULT 'UTC',
+    multiplier   NUMERIC(6, 2),
+    bonus_points INT,
+    updated_by   BIGINT,
+    created_at   TIMESTAMPTZ   NOT NULL DEFAULT now(),
+    updated_at   TIMESTAMPTZ   NOT NULL DEFAULT now(),
+
+    CONSTRAINT fk_campaigns_updated_by FOREIGN KEY (updated_by) REFERENCES users (id) ON DELETE SET NULL,
+
+    CONSTRAINT chk_campaigns_name_not_blank CHECK (length(btrim(name)) > 0),
+    CONSTRAINT chk_campaigns_dates CHECK (ends_on IS NULL OR ends_on >= starts_on),
+    CONSTRAINT chk_campaigns_weekdays CHECK (weekdays <@ ARRAY [1, 2, 3, 4, 5, 6, 7]::SMALLINT[]),
+    CONSTRAINT chk_campaigns_time_window CHECK ((time_from IS NULL AND time_to IS NULL) OR (time_from IS NOT NULL AND time_to IS NOT NULL AND time_from <> time_to)),
+    CONSTRAINT chk_campaigns_reward CHECK (num_nonnulls(multiplier, bonus_points) = 1),
+    CONSTRAINT chk_campaigns_multiplier_above_one CHECK (multiplier IS NULL OR multiplier > 1),
+    CONSTRAINT chk_campaigns_bonus_points_positive CHECK (bonus_points IS NULL OR bonus_points > 0)
+);
+
+CREATE INDEX idx_campaigns_dates ON campaigns (starts_on, ends_on);
+
+-- Campaigns applied to an EARN event and the points each of them added.
+CREATE TABLE event_campaigns
+(
+    event_id     BIGINT NOT NULL,
+    campaign_id  BIGINT NOT NULL,
+    bonus_points INT    NOT NULL,
+
+    PRIMARY KEY (event_id, campaign_id),
+    CONSTRAINT fk_event_campaigns_event FOREIGN KEY (event_id) REFERENCES events (id) ON DELETE RESTRICT,
+    CONSTRAINT fk_event_campaigns_campaign FOREIGN KEY (campaign_id) REFERENCES campaigns (id) ON DELETE RESTRICT,
+
+    CONSTRAINT chk_event_campaigns_bonus_points_nonnegative CHECK (bonus_points >= 0)
+);
+
+CREATE INDEX idx_event_campaigns_campaign_id ON event_campaigns (campaign_id);
+
+-- +goose Down
+DROP TABLE event_campaigns;
+DROP TABLE campaigns;
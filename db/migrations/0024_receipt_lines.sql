@@ -0,0 +1,44 @@
+-- +goose Up
+-- Earn factor of a receipt line category under a ruleset: the line earns at factor * level percent
+-- (1 = the level percent, 0.5 = half, 0 = nothing). Categories without a row earn at the level percent.
+CREATE TABLE category_earn_rates
+(
+    id          BIGSERIAL PRIMARY KEY,
+    ruleset_id  BIGINT        NOT NULL,
+    category    TEXT          NOT NULL,
+    earn_factor NUMERIC(4, 2) NOT NULL,
+
+    CONSTRAINT fk_category_earn_rates_ruleset FOREIGN KEY (ruleset_id) REFERENCES ruleset (id) ON DELETE CASCADE,
+
+    CONSTRAINT uq_category_earn_rates_ruleset_category UNIQUE (ruleset_id, category),
+
+    CONSTRAINT chk_category_earn_rates_category_not_blank CHECK (length(btrim(category)) > 0),
+    CONSTRAINT chk_category_earn_rates_factor_range CHECK (earn_factor >= 0 AND earn_factor <= 10)
+);
+
+-- Receipt lines of an EARN, kept so refunds can be matched line by line.
+-- earn_factor is the category factor the line earned at.
+CREATE TABLE receipt_lines
+(
+    id          BIGSERIAL PRIMARY KEY,
+    event_id    BIGINT         NOT NULL,
+    line_no     INT            NOT NULL,
+    sku         TEXT           NOT NULL,
+    category    TEXT           NOT NULL,
+    quantity    INT            NOT NULL,
+    line_total  NUMERIC(12, 2) NOT NULL,
+    earn_factor NUMERIC(4, 2)  NOT NULL,
+
+    CONSTRAINT fk_receipt_lines_event FOREIGN KEY (event_id) REFERENCES events (id) ON DELETE RESTRICT,
+
+    CONSTRAINT uq_receipt_lines_event_line UNIQUE (event_id, line_no),
+
+    CONSTRAINT chk_receipt_lines_line_no_positive CHECK (line_no > 0),
+    CONSTRAINT chk_receipt_lines_quantity_positive CHECK (quantity > 0),
+    CONSTRAINT chk_receipt_lines_line_total_nonnegative CHECK (line_total >= 0),
+    CONSTRAINT chk_receipt_lines_factor_range CHECK (earn_factor >= 0 AND earn_factor <= 10)
+);
+
+-- +goose Down
+DROP TABLE receipt_lines;
+DROP TABLE category_earn_rates;
@@ -0,0 +1,43 @@
+-- +goose NO TRANSACTION
+-- +goose Up
+-- BONUS is a system credit of a ruleset bonus (welcome on registration, birthday once a year).
+ALTER TYPE event_type ADD VALUE IF NOT EXISTS 'BONUS';
+
+-- Bonus amounts of a ruleset; 0 disables the bonus.
+ALTER TABLE ruleset
+    ADD COLUMN welcome_bonus_points  INT NOT NULL DEFAULT 0,
+    ADD COLUMN birthday_bonus_points INT NOT NULL DEFAULT 0,
+    ADD CONSTRAINT chk_ruleset_welcome_bonus_points_nonnegative CHECK (welcome_bonus_points >= 0),
+    ADD CONSTRAINT chk_ruleset_birthday_bonus_points_nonnegative CHECK (birthday_bonus_points >= 0);
+
+-- Optional client birthday; the year is not used for the bonus.
+ALTER TABLE users
+    ADD COLUMN birthday DATE;
+
+-- Which BONUS event paid which bonus. The unique key makes each bonus a once-per-year grant
+-- (welcome is written once, in the year of registration).
+CREATE TABLE bonus_grants
+(
+    event_id   BIGINT PRIMARY KEY,
+    account_id BIGINT NOT NULL,
+    kind       TEXT   NOT NULL,
+    year       INT    NOT NULL,
+
+    CONSTRAINT fk_bonus_grants_event FOREIGN KEY (event_id) REFERENCES events (id) ON DELETE RESTRICT,
+    CONSTRAINT fk_bonus_grants_account FOREIGN KEY (account_id) REFERENCES accounts (id) ON DELETE RESTRICT,
+
+    CONSTRAINT uq_bonus_grants_account_kind_year UNIQUE (account_id, kind, year),
+
+    CONSTRAINT chk_bonus_grants_kind CHECK (kind IN ('WELCOME', 'BIRTHDAY'))
+);
+
+-- +goose Down
+DROP TABLE bonus_grants;
+ALTER TABLE users
+    DROP COLUMN birthday;
+ALTER TABLE ruleset
+    DROP CONSTRAINT chk_ruleset_birthday_bonus_points_nonnegative,
+    DROP CONSTRAINT chk_ruleset_welcome_bonus_points_nonnegative,
+    DROP COLUMN birthday_bonus_points,
+    DROP COLUMN welcome_bonus_points;
+-- Enum values cannot be dropped in PostgreSQL; 'BONUS' stays in event_type.
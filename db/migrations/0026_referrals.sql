@@ -0,0 +1,85 @@
+-- +goose Up
+-- Referral bonus amounts of a ruleset, paid as BONUS events on the referee's first EARN; 0 disables the bonus.
+ALTER TABLE ruleset
+    ADD COLUMN referrer_bonus_points INT NOT NULL DEFAULT 0,
+    ADD COLUMN referee_bonus_points  INT NOT NULL DEFAULT 0,
+    ADD CONSTRAINT chk_ruleset_referrer_bonus_points_nonnegative CHECK (referrer_bonus_points >= 0),
+    ADD CONSTRAINT chk_ruleset_referee_bonus_points_nonnegative CHECK (referee_bonus_points >= 0);
+
+-- Code a customer shares to refer others; one per account.
+CREATE TABLE referral_codes
+(
+    account_id BIGINT PRIMARY KEY,
+    code       TEXT        NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+
+    CONSTRAINT fk_referral_codes_account FOREIGN KEY (account_id) REFERENCES accounts (id) ON DELETE CASCADE,
+
+    CONSTRAINT uq_referral_codes_code UNIQUE (code)
+);
+
+-- existing accounts get a code too (new ones get it on registration);
+-- same alphabet and length as internal/infra/referralcode, a code already taken is drawn again
+-- +goose StatementBegin
+DO
+$$
+    DECLARE
+        alphabet CONSTANT TEXT := 'ABCDEFGHJKLMNPQRSTUVWXYZ23456789';
+        acc_id   BIGINT;
+        new_code TEXT;
+        inserted INT;
+    BEGIN
+        FOR acc_id IN SELECT a.id
+                      FROM accounts a
+                      WHERE NOT EXISTS (SELECT 1 FROM referral_codes rc WHERE rc.account_id = a.id)
+                      ORDER BY a.id
+            LOOP
+                LOOP
+                    SELECT string_agg(substr(alphabet, 1 + floor(random() * length(alphabet))::INT, 1), '')
+                    INTO new_code
+                    FROM generate_series(1, 8);
+
+                    INSERT INTO referral_codes (account_id, code)
+                    VALUES (acc_id, new_code)
+                    ON CONFLICT (code) DO NOTHING;
+                    GET DIAGNOSTICS inserted = ROW_COUNT;
+                    EXIT WHEN inserted > 0;
+                END LOOP;
+            END LOOP;
+    END
+$$;
+-- +goose StatementEnd
+
+-- Who referred whom. A referee has at most one referrer; the row is rewarded once,
+-- on the referee's first EARN (qualifying_event_id), with a BONUS event on each side.
+CREATE TABLE referrals
+(
+    referee_account_id  BIGINT PRIMARY KEY,
+    referrer_account_id BIGINT      NOT NULL,
+    created_at          TIMESTAMPTZ NOT NULL DEFAULT now(),
+    rewarded_at         TIMESTAMPTZ,
+    qualifying_event_id BIGINT,
+    referrer_event_id   BIGINT,
+    referee_event_id    BIGINT,
+
+    CONSTRAINT fk_referrals_referee FOREIGN KEY (referee_account_id) REFERENCES accounts (id) ON DELETE RESTRICT,
+    CONSTRAINT fk_referrals_referrer FOREIGN KEY (referrer_account_id) REFERENCES accounts (id) ON DELETE RESTRICT,
+    CONSTRAINT fk_referrals_qualifying_event FOREIGN KEY (qualifying_event_id) REFERENCES events (id) ON DELETE RESTRICT,
+    CONSTRAINT fk_referrals_referrer_event FOREIGN KEY (referrer_event_id) REFERENCES events (id) ON DELETE RESTRICT,
+    CONSTRAINT fk_referrals_referee_event FOREIGN KEY (referee_event_id) REFERENCES events (id) ON DELETE RESTRICT,
+
+    CONSTRAINT chk_referrals_not_self CHECK (referrer_account_id <> referee_account_id),
+    CONSTRAINT chk_referrals_rewarded_has_event CHECK (rewarded_at IS NULL OR qualifying_event_id IS NOT NULL)
+);
+
+-- monthly cap and stats of a referrer
+CREATE INDEX idx_referrals_referrer_created ON referrals (referrer_account_id, created_at);
+
+-- +goose Down
+DROP TABLE referrals;
+DROP TABLE referral_codes;
+ALTER TABLE ruleset
+    DROP CONSTRAINT chk_ruleset_referee_bonus_points_nonnegative,
+    DROP CONSTRAINT chk_ruleset_referrer_bonus_points_nonnegative,
+    DROP COLUMN referee_bonus_points,
+    DROP COLUMN referrer_bonus_points;
@@ -0,0 +1,36 @@
+-- +goose NO TRANSACTION
+-- +goose Up
+-- LEVEL_CHANGED records a level transition of an account; it moves no points (delta 0).
+ALTER TYPE event_type ADD VALUE IF NOT EXISTS 'LEVEL_CHANGED';
+
+-- One-time bonus for reaching a level; 0 disables it.
+ALTER TABLE level_rules
+    ADD COLUMN reached_bonus_points INT NOT NULL DEFAULT 0,
+    ADD CONSTRAINT chk_level_rules_reached_bonus_points_nonnegative CHECK (reached_bonus_points >= 0);
+
+-- Level transitions: the LEVEL_CHANGED event, the levels on both sides and the BONUS it paid, if any.
+-- Transitions before this migration are not recorded.
+CREATE TABLE level_changes
+(
+    event_id       BIGINT PRIMARY KEY,
+    account_id     BIGINT NOT NULL,
+    from_level     TEXT   NOT NULL,
+    to_level       TEXT   NOT NULL,
+    bonus_event_id BIGINT,
+
+    CONSTRAINT fk_level_changes_event FOREIGN KEY (event_id) REFERENCES events (id) ON DELETE RESTRICT,
+    CONSTRAINT fk_level_changes_account FOREIGN KEY (account_id) REFERENCES accounts (id) ON DELETE RESTRICT,
+    CONSTRAINT fk_level_changes_bonus_event FOREIGN KEY (bonus_event_id) REFERENCES events (id) ON DELETE RESTRICT,
+
+    CONSTRAINT chk_level_changes_levels_differ CHECK (from_level <> to_level)
+);
+
+-- a level bonus is paid once per account
+CREATE UNIQUE INDEX uq_level_changes_account_bonus_level ON level_changes (account_id, to_level) WHERE bonus_event_id IS NOT NULL;
+
+-- +goose Down
+DROP TABLE level_changes;
+ALTER TABLE level_rules
+    DROP CONSTRAINT chk_level_rules_reached_bonus_points_nonnegative,
+    DROP COLUMN reached_bonus_points;
+-- Enum values cannot be dropped in PostgreSQL; 'LEVEL_CHANGED' stays in event_type.
@@ -0,0 +1,14 @@
+-- +goose Up
+-- Rounding policy of the earn computation. FLOOR per step is how points were always computed.
+ALTER TABLE ruleset
+    ADD COLUMN rounding_mode  TEXT NOT NULL DEFAULT 'FLOOR',
+    ADD COLUMN rounding_stage TEXT NOT NULL DEFAULT 'PER_STEP',
+    ADD CONSTRAINT chk_ruleset_rounding_mode CHECK (rounding_mode IN ('FLOOR', 'HALF_UP', 'HALF_EVEN')),
+    ADD CONSTRAINT chk_ruleset_rounding_stage CHECK (rounding_stage IN ('PER_STEP', 'AT_END'));
+
+-- +goose Down
+ALTER TABLE ruleset
+    DROP CONSTRAINT chk_ruleset_rounding_stage,
+    DROP CONSTRAINT chk_ruleset_rounding_mode,
+    DROP COLUMN rounding_stage,
+    DROP COLUMN rounding_mode;
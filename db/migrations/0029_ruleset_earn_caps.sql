@@ -0,0 +1,19 @@
+-- +goose Up
+-- Earn caps of a ruleset: purchases below min_earn_amount earn nothing, the points of one purchase
+-- and of one account per UTC day are capped. NULL caps mean no cap.
+ALTER TABLE ruleset
+    ADD COLUMN min_earn_amount         NUMERIC(12, 2) NOT NULL DEFAULT 0,
+    ADD COLUMN max_points_per_purchase INT,
+    ADD COLUMN max_points_per_day      INT,
+    ADD CONSTRAINT chk_ruleset_min_earn_amount_nonnegative CHECK (min_earn_amount >= 0),
+    ADD CONSTRAINT chk_ruleset_max_points_per_purchase_positive CHECK (max_points_per_purchase IS NULL OR max_points_per_purchase > 0),
+    ADD CONSTRAINT chk_ruleset_max_points_per_day_positive CHECK (max_points_per_day IS NULL OR max_points_per_day > 0);
+
+-- +goose Down
+ALTER TABLE ruleset
+    DROP CONSTRAINT chk_ruleset_max_points_per_day_positive,
+    DROP CONSTRAINT chk_ruleset_max_points_per_purchase_positive,
+    DROP CONSTRAINT chk_ruleset_min_earn_amount_nonnegative,
+    DROP COLUMN max_points_per_day,
+    DROP COLUMN max_points_per_purchase,
+    DROP COLUMN min_earn_amount;
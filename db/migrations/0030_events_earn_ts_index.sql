@@ -0,0 +1,6 @@
+-- +goose Up
+-- Ruleset simulation replays EARN events of a time range across all accounts.
+CREATE INDEX idx_events_earn_ts ON events (ts) WHERE type = 'EARN';
+
+-- +goose Down
+DROP INDEX idx_events_earn_ts;
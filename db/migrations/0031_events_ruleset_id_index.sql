@@ -0,0 +1,6 @@
+-- +goose Up
+-- Scheduled rulesets may be edited or deleted only while no event references them.
+CREATE INDEX idx_events_ruleset_id ON events (ruleset_id) WHERE ruleset_id IS NOT NULL;
+
+-- +goose Down
+DROP INDEX idx_events_ruleset_id;
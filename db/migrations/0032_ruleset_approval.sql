@@ -0,0 +1,38 @@
+-- +goose Up
+-- Rulesets go through review: DRAFT -> PENDING_APPROVAL -> APPROVED or REJECTED.
+-- Only APPROVED rulesets ever take effect. Rulesets published before this migration stay approved.
+ALTER TABLE ruleset
+    ADD COLUMN status TEXT NOT NULL DEFAULT 'APPROVED',
+    ADD CONSTRAINT chk_ruleset_status CHECK (status IN ('DRAFT', 'PENDING_APPROVAL', 'APPROVED', 'REJECTED'));
+ALTER TABLE ruleset ALTER COLUMN status SET DEFAULT 'DRAFT';
+
+-- Only approved rulesets compete for a start time; competing drafts may share one.
+ALTER TABLE ruleset DROP CONSTRAINT uq_ruleset_effective_from;
+CREATE UNIQUE INDEX uq_ruleset_effective_from ON ruleset (effective_from) WHERE status = 'APPROVED';
+
+-- Who did what to a ruleset, and when.
+CREATE TABLE ruleset_history
+(
+    id            BIGSERIAL PRIMARY KEY,
+    ruleset_id    BIGINT      NOT NULL,
+    action        TEXT        NOT NULL,
+    actor_user_id BIGINT,
+    comment       TEXT,
+    created_at    TIMESTAMPTZ NOT NULL DEFAULT now(),
+
+    CONSTRAINT fk_ruleset_history_ruleset FOREIGN KEY (ruleset_id) REFERENCES ruleset (id) ON DELETE CASCADE,
+    CONSTRAINT fk_ruleset_history_actor FOREIGN KEY (actor_user_id) REFERENCES users (id) ON DELETE SET NULL,
+
+    CONSTRAINT chk_ruleset_history_action CHECK (action IN ('CREATED', 'UPDATED', 'SUBMITTED', 'APPROVED', 'REJECTED'))
+);
+
+CREATE INDEX idx_ruleset_history_ruleset_id ON ruleset_history (ruleset_id, id);
+
+-- +goose Down
+DROP TABLE ruleset_history;
+DELETE FROM ruleset WHERE status <> 'APPROVED';
+DROP INDEX uq_ruleset_effective_from;
+ALTER TABLE ruleset ADD CONSTRAINT uq_ruleset_effective_from UNIQUE (effective_from);
+ALTER TABLE ruleset
+    DROP CONSTRAINT chk_ruleset_status,
+    DROP COLUMN status;
@@ -0,0 +1,28 @@
+-- +goose Up
+-- A second admin for local/dev: ruleset approval needs an admin other than the author.
+-- Password is the same as for the users seeded in 0010.
+INSERT INTO users (phone, password_hash, is_active)
+VALUES ('+79990000004', '$2a$10$RiPgB5moANhOIqbI5eSZkOREyEnR.ktJmq3dABX1hm8yNGvBOZwJ.', true)
+ON CONFLICT (phone) DO NOTHING;
+
+INSERT INTO user_roles (user_id, role_code)
+SELECT u.id, 'ADMIN'
+FROM users u
+WHERE u.phone = '+79990000004'
+ON CONFLICT DO NOTHING;
+
+INSERT INTO accounts (user_id, public_code, level_code)
+SELECT u.id, '550e8400-e29b-41d4-a716-446655440004', 'Green Bean'
+FROM users u
+WHERE u.phone = '+79990000004'
+ON CONFLICT (user_id) DO NOTHING;
+
+-- +goose Down
+DELETE FROM user_roles
+WHERE user_id IN (SELECT id FROM users WHERE phone = '+79990000004');
+
+DELETE FROM accounts
+WHERE public_code = '550e8400-e29b-41d4-a716-446655440004';
+
+DELETE FROM users
+WHERE phone = '+79990000004';
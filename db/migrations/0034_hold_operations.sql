@@ -0,0 +1,10 @@
+-- +goose NO TRANSACTION
+-- +goose Up
+-- HOLD_AUTHORIZE and HOLD_CAPTURE are operation types only (operations.op_type): authorize and capture
+-- keep their canonical request in operations like every other cashier operation, so a reused key is detected.
+-- Holds authorized or captured before this migration have no operations record and are matched on the hold.
+ALTER TYPE event_type ADD VALUE IF NOT EXISTS 'HOLD_AUTHORIZE';
+ALTER TYPE event_type ADD VALUE IF NOT EXISTS 'HOLD_CAPTURE';
+
+-- +goose Down
+-- Enum values cannot be dropped in PostgreSQL; 'HOLD_AUTHORIZE' and 'HOLD_CAPTURE' stay in event_type.
@@ -0,0 +1,7 @@
+-- +goose Up
+-- Tombstones of expired idempotency records are removed by the app once they are older than
+-- OPERATIONS_TOMBSTONE_TTL; retention scan, oldest first.
+CREATE INDEX idx_expired_operations_expired_at ON expired_operations (expired_at);
+
+-- +goose Down
+DROP INDEX idx_expired_operations_expired_at;
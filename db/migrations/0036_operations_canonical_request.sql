@@ -0,0 +1,11 @@
+-- +goose Up
+-- request_json of rows stored before requests were canonical (money with 2 fraction digits, ts only when
+-- the client sent it, in UTC) is compared leniently on replay; later rows are marked canonical.
+ALTER TABLE operations
+    ADD COLUMN canonical_request BOOLEAN NOT NULL DEFAULT false;
+ALTER TABLE operations
+    ALTER COLUMN canonical_request SET DEFAULT true;
+
+-- +goose Down
+ALTER TABLE operations
+    DROP COLUMN canonical_request;
@@ -31,6 +31,8 @@ export function HomePage() {
     const publicCode = me.data?.account.publicCode ?? "ABCD-1234-XYZ";
     const points = bal.data?.balancePoints ?? me.data?.account.balancePoints ?? 0;
     const level = bal.data?.levelCode ?? me.data?.account.levelCode ?? "Green Bean";
+    const expiringPoints = bal.data?.expiringPoints;
+    const expiringOn = bal.data?.expiringOn;
 
     return (
         <div className="px-7 pt-10">
@@ -58,6 +60,11 @@ export function HomePage() {
                     <div className="text-5xl font-medium tracking-wide mt-1 tabular-nums overflow-hidden text-ellipsis whitespace-nowrap">
                         {points.toLocaleString("en-US")}
                     </div>
+                    {expiringPoints && expiringOn ? (
+                        <div className="text-lg text-muted mt-2">
+                            {expiringPoints.toLocaleString("en-US")} points expiring on {expiringOn}
+                        </div>
+                    ) : null}
                 </div>
 
                 <div className="mt-10 text-xl">Public code</div>
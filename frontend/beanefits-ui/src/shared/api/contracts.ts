@@ -1,8 +1,9 @@
 // src/shared/api/contracts.ts
 
 export type RoleCode = "CLIENT" | "CASHIER" | "ADMIN";
-export type EventType = "EARN" | "SPEND";
-export type OperationType = "EARN" | "SPEND";
+export type EventType = "EARN" | "SPEND" | "REFUND" | "VOID" | "EXPIRE" | "ADJUST" | "TRANSFER_OUT" | "TRANSFER_IN" | "BONUS" | "LEVEL_CHANGED";
+export type AdjustReasonCode = "GOODWILL" | "COMPENSATION" | "CORRECTION" | "OTHER";
+export type OperationType = "EARN" | "SPEND" | "REFUND" | "VOID" | "CHECKOUT" | "TRANSFER";
 
 export interface Problem {
     type: string; // "about:blank"
@@ -18,6 +19,7 @@ export interface User {
     phone: string; // E.164-like
     roles: RoleCode[];
     isActive: boolean;
+    birthday?: string; // YYYY-MM-DD, absent if not set
     createdAt: string; // ISO
 }
 
@@ -43,10 +45,14 @@ export interface ClientProfile {
 
 export interface BalanceResponse {
     accountId: number;
-    balancePoints: number;
+    balancePoints: number; // ledger balance, includes heldPoints
+    heldPoints: number; // reserved by active holds
+    availablePoints: number; // balancePoints - heldPoints
     totalSpendMoney: string;
     levelCode: string;
     asOf: string; // ISO
+    expiringPoints?: number; // nearest expiry: these points expire on expiringOn
+    expiringOn?: string; // YYYY-MM-DD (UTC)
 }
 
 export interface Event {
@@ -55,10 +61,17 @@ export interface Event {
     type: EventType;
     deltaPoints: number; // signed
     balanceAfter: number;
-    amountMoney?: string | null; // present for EARN
+    amountMoney?: string | null; // present for EARN/REFUND
     rulesetId?: number | null;
     actorUserId?: number | null;
+    refEventId?: number | null; // REFUND -> original EARN, VOID -> original SPEND
     ts: string; // ISO
+    campaigns?: AppliedCampaign[]; // EARN in operation results only
+}
+
+export interface AppliedCampaign {
+    campaignId: number;
+    bonusPoints: number; // points added on top of the ruleset
 }
 
 export interface EventsPage {
@@ -71,19 +84,52 @@ export interface UsersPage {
     total?: number | null;
 }
 
+export type RoundingMode = "FLOOR" | "HALF_UP" | "HALF_EVEN"; // HALF_EVEN = banker's
+export type RoundingStage = "PER_STEP" | "AT_END"; // round base points and the percent step, or the result once
+export type RulesetStatus = "DRAFT" | "PENDING_APPROVAL" | "APPROVED" | "REJECTED"; // only APPROVED ever takes effect
+export type RulesetHistoryAction = "CREATED" | "UPDATED" | "SUBMITTED" | "APPROVED" | "REJECTED";
+
 export interface LevelRule {
     id: number;
     levelCode: string;
     thresholdTotalSpend: string; // decimal string
     percentEarn: string; // decimal string
+    reachedBonusPoints: number; // BONUS the first time a customer is moved up to the level; 0 = none
 }
 
 export interface Ruleset {
     id: number;
     effectiveFrom: string; // ISO
+    effectiveTo: string | null; // ISO, effectiveFrom of the next ruleset; null = open-ended
     baseRubPerPoint: string; // decimal string
+    redeemRubPerPoint: string; // decimal string, money value of one redeemed point
     levels: LevelRule[];
+    qualificationWindowDays: number | null; // rolling window in days; null = lifetime spend
+    categoryRates: CategoryRate[]; // categories not listed earn at factor 1.00
+    welcomeBonusPoints: number; // BONUS on registration; 0 = none
+    birthdayBonusPoints: number; // BONUS once a year on the birthday; 0 = none
+    referrerBonusPoints: number; // BONUS to the referrer on the referee's first EARN; 0 = none
+    refereeBonusPoints: number; // BONUS to the referee on their first EARN; 0 = none
+    roundingMode: RoundingMode;
+    roundingStage: RoundingStage;
+    minEarnAmount: string; // decimal string, purchases below it earn nothing
+    maxPointsPerPurchase: number | null; // null = no cap
+    maxPointsPerDay: number | null; // per account per UTC day; null = no cap
     createdAt: string; // ISO
+    status: RulesetStatus;
+    history: RulesetHistoryEntry[]; // oldest first
+}
+
+export interface RulesetHistoryEntry {
+    action: RulesetHistoryAction;
+    actorUserId: number | null; // null = user deleted or backfilled ruleset
+    comment: string | null; // set on REJECTED
+    at: string; // ISO
+}
+
+export interface CategoryRate {
+    category: string;
+    earnFactor: string; // decimal string, 0..10 (multiplies the level percent)
 }
 
 export interface RulesetsPage {
@@ -91,10 +137,150 @@ export interface RulesetsPage {
     total?: number | null;
 }
 
+// POST /admin/rulesets/simulation — a draft replayed over historical EARN events, nothing is written
+export interface RulesetSimulation {
+    from: string; // ISO
+    to: string; // ISO, exclusive
+    actualRulesetId: number; // the actual levels are resolved with it
+    purchases: number;
+    accounts: number;
+    actualPoints: number; // issued, campaign rewards excluded
+    draftPoints: number;
+    actualLevels: LevelCount[]; // every level of the ruleset, threshold order
+    draftLevels: LevelCount[];
+    topAccounts: SimulatedAccount[]; // largest points difference first
+}
+
+export interface LevelCount {
+    levelCode: string;
+    accounts: number;
+}
+
+export interface SimulatedAccount {
+    accountId: number;
+    publicCode: string;
+    actualPoints: number;
+    draftPoints: number;
+    actualLevel: string;
+    draftLevel: string;
+}
+
+export interface ReferralStats {
+    referralCode: string; // share it; pass as referralCode to /auth/register
+    referredCount: number;
+    rewardedCount: number; // referees who made their first EARN
+    pendingCount: number;
+    referredThisMonth: number; // calendar month, UTC
+    monthlyLimit?: number | null; // null = no cap
+    bonusPointsEarned: number;
+}
+
+export interface TransferRequest {
+    operationId: string; // uuid, idempotency key
+    recipientPublicCode?: string; // exactly one of recipientPublicCode / recipientPhone
+    recipientPhone?: string;
+    points: number;
+}
+
 export interface OperationResult {
     operationId: string;
     opType: OperationType;
     event: Event;
     balance: BalanceResponse;
     idempotentReplay?: boolean;
+    levelChange?: LevelChange | null;
+}
+
+export interface LevelChange {
+    eventId: number; // LEVEL_CHANGED event
+    fromLevel: string;
+    toLevel: string;
+    bonusPoints: number; // reached bonus paid by this change; 0 = none
+    bonusEventId?: number | null;
+}
+
+export interface CheckoutResult {
+    operationId: string;
+    opType: OperationType;
+    billMoney: string; // decimal string
+    discountMoney: string; // decimal string, covered by redeemed points
+    paidMoney: string; // decimal string, points are earned on this amount
+    spendEvent?: Event;
+    earnEvent?: Event;
+    balance: BalanceResponse;
+    idempotentReplay?: boolean;
+    levelChange?: LevelChange | null;
+}
+
+// GET /cashier/accounts/by-code/{publicCode}/earn-quote?amountMoney= — read-only, nothing is written
+export interface EarnQuote {
+    publicCode: string;
+    amountMoney: string; // decimal string
+    rulesetId: number;
+    baseRubPerPoint: string; // decimal string
+    percentEarn: string; // decimal string, percent of levelBefore
+    levelBefore: string;
+    levelAfter: string; // differs from levelBefore when the purchase moves the customer up
+    rulesetPoints: number; // after rounding and earn caps
+    campaigns: AppliedCampaign[];
+    points: number; // rulesetPoints + campaign rewards
+    ts: string; // ISO, server time
+}
+
+export type BatchItemType = "EARN" | "SPEND";
+
+export interface BatchItem {
+    type: BatchItemType;
+    operationId: string; // uuid, idempotency key
+    publicCode: string;
+    amountMoney?: string | null; // EARN, decimal string
+    amountPoints?: number | null; // SPEND
+    ts?: string | null; // when the till recorded the operation
+}
+
+export interface BatchRequest {
+    items: BatchItem[]; // 1..100, processed in order
+}
+
+export interface BatchItemResult {
+    index: number;
+    type: BatchItemType;
+    operationId: string;
+    result?: OperationResult; // either result or problem
+    problem?: Problem;
+}
+
+export interface BatchResult {
+    items: BatchItemResult[];
+}
+
+export interface AdjustBalanceRequest {
+    deltaPoints: number; // signed, + credit / - debit
+    reasonCode: AdjustReasonCode;
+    comment: string;
+}
+
+export interface AdjustmentResult {
+    event: Event;
+    reasonCode: AdjustReasonCode;
+    comment: string;
+    balance: BalanceResponse;
+}
+
+export type LedgerCheck = "BALANCE_AFTER" | "BALANCE" | "TOTAL_SPEND" | "LEVEL" | "HASH_CHAIN";
+
+export interface LedgerMismatch {
+    accountId: number;
+    userId: number;
+    eventId?: number | null;
+    check: LedgerCheck;
+    expected: string;
+    actual: string;
+}
+
+export interface LedgerReport {
+    accountsChecked: number;
+    eventsChecked: number;
+    mismatches: LedgerMismatch[];
+    checkedAt: string;
 }
@@ -4,31 +4,68 @@ import type { Ruleset, RulesetsPage, LevelRule } from "@/shared/api/contracts";
 import { isoDaysAgo } from "./clock";
 
 const LEVELS: Omit<LevelRule, "id">[] = [
-    { levelCode: "Green Bean",   thresholdTotalSpend: "0.00",     percentEarn: "100.00" },
-    { levelCode: "Light Roast",  thresholdTotalSpend: "3000.00",  percentEarn: "105.00" },
-    { levelCode: "Medium Roast", thresholdTotalSpend: "7000.00",  percentEarn: "110.00" },
-    { levelCode: "Dark Roast",   thresholdTotalSpend: "15000.00", percentEarn: "115.00" },
-    { levelCode: "Premium Roast",thresholdTotalSpend: "30000.00", percentEarn: "120.00" },
+    { levelCode: "Green Bean",   thresholdTotalSpend: "0.00",     percentEarn: "100.00", reachedBonusPoints: 0 },
+    { levelCode: "Light Roast",  thresholdTotalSpend: "3000.00",  percentEarn: "105.00", reachedBonusPoints: 50 },
+    { levelCode: "Medium Roast", thresholdTotalSpend: "7000.00",  percentEarn: "110.00", reachedBonusPoints: 100 },
+    { levelCode: "Dark Roast",   thresholdTotalSpend: "15000.00", percentEarn: "115.00", reachedBonusPoints: 200 },
+    { levelCode: "Premium Roast",thresholdTotalSpend: "30000.00", percentEarn: "120.00", reachedBonusPoints: 500 },
 ];
 
 export const fixtureCurrentRuleset: Ruleset = {
     id: 200,
     effectiveFrom: isoDaysAgo(14),
+    effectiveTo: null,
     baseRubPerPoint: "10.00",
+    redeemRubPerPoint: "1.00",
     levels: LEVELS.map((l, i) => ({ id: 2000 + i, ...l })),
+    qualificationWindowDays: 365,
+    categoryRates: [
+        { category: "giftcards", earnFactor: "0.00" },
+        { category: "merch",     earnFactor: "0.50" },
+    ],
+    welcomeBonusPoints: 100,
+    birthdayBonusPoints: 200,
+    referrerBonusPoints: 300,
+    refereeBonusPoints: 150,
+    roundingMode: "HALF_UP",
+    roundingStage: "AT_END",
+    minEarnAmount: "100.00",
+    maxPointsPerPurchase: 500,
+    maxPointsPerDay: 2000,
     createdAt: isoDaysAgo(14),
+    status: "APPROVED",
+    history: [
+        { action: "CREATED",   actorUserId: 1, comment: null, at: isoDaysAgo(16) },
+        { action: "SUBMITTED", actorUserId: 1, comment: null, at: isoDaysAgo(16) },
+        { action: "APPROVED",  actorUserId: 4, comment: null, at: isoDaysAgo(15) },
+    ],
 };
 
 export const fixtureOldRuleset: Ruleset = {
     id: 199,
     effectiveFrom: isoDaysAgo(60),
+    effectiveTo: isoDaysAgo(14),
     baseRubPerPoint: "12.00",
+    redeemRubPerPoint: "1.00",
     levels: LEVELS.map((l, i) => ({
         id: 1900 + i,
         ...l,
         percentEarn: i === 0 ? "100.00" : String(100 + i * 3).padEnd(6, "0"), // чуть иные проценты
     })),
+    qualificationWindowDays: null,
+    categoryRates: [],
+    welcomeBonusPoints: 0,
+    birthdayBonusPoints: 0,
+    referrerBonusPoints: 0,
+    refereeBonusPoints: 0,
+    roundingMode: "FLOOR",
+    roundingStage: "PER_STEP",
+    minEarnAmount: "0.00",
+    maxPointsPerPurchase: null,
+    maxPointsPerDay: null,
     createdAt: isoDaysAgo(60),
+    status: "APPROVED",
+    history: [],
 };
 
 export function makeRulesetsPage(): RulesetsPage {
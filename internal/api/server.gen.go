@@ -14,6 +14,33 @@ import (
 
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
+	// List promotional campaigns (latest start first)
+	// (GET /admin/campaigns)
+	GetAdminCampaigns(w http.ResponseWriter, r *http.Request)
+	// Create a promotional campaign
+	// (POST /admin/campaigns)
+	PostAdminCampaigns(w http.ResponseWriter, r *http.Request)
+	// Delete a promotional campaign
+	// (DELETE /admin/campaigns/{campaignId})
+	DeleteAdminCampaignsCampaignId(w http.ResponseWriter, r *http.Request, campaignId CampaignIdParam)
+	// Get a promotional campaign
+	// (GET /admin/campaigns/{campaignId})
+	GetAdminCampaignsCampaignId(w http.ResponseWriter, r *http.Request, campaignId CampaignIdParam)
+	// Replace a promotional campaign
+	// (PUT /admin/campaigns/{campaignId})
+	PutAdminCampaignsCampaignId(w http.ResponseWriter, r *http.Request, campaignId CampaignIdParam)
+	// Verify account balances against the event history
+	// (GET /admin/ledger/verification)
+	GetAdminLedgerVerification(w http.ResponseWriter, r *http.Request, params GetAdminLedgerVerificationParams)
+	// List velocity limits of cashier operations
+	// (GET /admin/limits)
+	GetAdminLimits(w http.ResponseWriter, r *http.Request)
+	// Create or replace a velocity limit
+	// (PUT /admin/limits)
+	PutAdminLimits(w http.ResponseWriter, r *http.Request)
+	// Delete a velocity limit
+	// (DELETE /admin/limits/{limitId})
+	DeleteAdminLimitsLimitId(w http.ResponseWriter, r *http.Request, limitId int64)
 	// List rulesets (newest first)
 	// (GET /admin/rulesets)
 	GetAdminRulesets(w http.ResponseWriter, r *http.Request, params GetAdminRulesetsParams)
@@ -23,12 +50,36 @@ type ServerInterface interface {
 	// Get current ruleset (by server time)
 	// (GET /admin/rulesets/current)
 	GetAdminRulesetsCurrent(w http.ResponseWriter, r *http.Request)
+	// Simulate a draft ruleset against historical purchases (read-only)
+	// (POST /admin/rulesets/simulation)
+	PostAdminRulesetsSimulation(w http.ResponseWriter, r *http.Request)
+	// Delete a ruleset that has not taken effect
+	// (DELETE /admin/rulesets/{rulesetId})
+	DeleteAdminRulesetsRulesetId(w http.ResponseWriter, r *http.Request, rulesetId RulesetIdParam)
+	// Get a ruleset
+	// (GET /admin/rulesets/{rulesetId})
+	GetAdminRulesetsRulesetId(w http.ResponseWriter, r *http.Request, rulesetId RulesetIdParam)
+	// Replace a ruleset that has not taken effect
+	// (PUT /admin/rulesets/{rulesetId})
+	PutAdminRulesetsRulesetId(w http.ResponseWriter, r *http.Request, rulesetId RulesetIdParam)
+	// Approve a ruleset
+	// (POST /admin/rulesets/{rulesetId}/approve)
+	PostAdminRulesetsRulesetIdApprove(w http.ResponseWriter, r *http.Request, rulesetId RulesetIdParam)
+	// Reject a ruleset
+	// (POST /admin/rulesets/{rulesetId}/reject)
+	PostAdminRulesetsRulesetIdReject(w http.ResponseWriter, r *http.Request, rulesetId RulesetIdParam)
+	// Submit a ruleset for approval
+	// (POST /admin/rulesets/{rulesetId}/submit)
+	PostAdminRulesetsRulesetIdSubmit(w http.ResponseWriter, r *http.Request, rulesetId RulesetIdParam)
 	// List users
 	// (GET /admin/users)
 	GetAdminUsers(w http.ResponseWriter, r *http.Request, params GetAdminUsersParams)
 	// Delete (deactivate) user
 	// (DELETE /admin/users/{userId})
 	DeleteAdminUsersUserId(w http.ResponseWriter, r *http.Request, userId int64)
+	// Manually credit or debit the user's account
+	// (POST /admin/users/{userId}/adjustments)
+	PostAdminUsersUserIdAdjustments(w http.ResponseWriter, r *http.Request, userId int64)
 	// Login (phone + password)
 	// (POST /auth/login)
 	PostAuthLogin(w http.ResponseWriter, r *http.Request)
@@ -38,15 +89,39 @@ type ServerInterface interface {
 	// Lookup account by public code (QR payload)
 	// (GET /cashier/accounts/by-code/{publicCode})
 	GetCashierAccountsByCodePublicCode(w http.ResponseWriter, r *http.Request, publicCode PublicCode)
+	// Preview the points of a purchase (read-only)
+	// (GET /cashier/accounts/by-code/{publicCode}/earn-quote)
+	GetCashierAccountsByCodePublicCodeEarnQuote(w http.ResponseWriter, r *http.Request, publicCode PublicCode, params GetCashierAccountsByCodePublicCodeEarnQuoteParams)
 	// Get account events by public code
 	// (GET /cashier/accounts/by-code/{publicCode}/events)
 	GetCashierAccountsByCodePublicCodeEvents(w http.ResponseWriter, r *http.Request, publicCode PublicCode, params GetCashierAccountsByCodePublicCodeEventsParams)
+	// Replay queued EARN/SPEND operations of an offline till
+	// (POST /cashier/batch)
+	PostCashierBatch(w http.ResponseWriter, r *http.Request)
+	// Pay part of the bill with points and earn points on the rest (idempotent)
+	// (POST /cashier/checkout)
+	PostCashierCheckout(w http.ResponseWriter, r *http.Request)
 	// Earn points for a purchase (idempotent)
 	// (POST /cashier/earn)
 	PostCashierEarn(w http.ResponseWriter, r *http.Request)
+	// Authorize a points hold (idempotent)
+	// (POST /cashier/holds)
+	PostCashierHolds(w http.ResponseWriter, r *http.Request)
+	// Capture a points hold
+	// (POST /cashier/holds/{holdId}/capture)
+	PostCashierHoldsHoldIdCapture(w http.ResponseWriter, r *http.Request, holdId HoldIdParam)
+	// Release a points hold
+	// (POST /cashier/holds/{holdId}/release)
+	PostCashierHoldsHoldIdRelease(w http.ResponseWriter, r *http.Request, holdId HoldIdParam)
+	// Refund a purchase (full or partial), reverting earned points (idempotent)
+	// (POST /cashier/refund)
+	PostCashierRefund(w http.ResponseWriter, r *http.Request)
 	// Spend points (idempotent, concurrency-safe)
 	// (POST /cashier/spend)
 	PostCashierSpend(w http.ResponseWriter, r *http.Request)
+	// Void a SPEND operation and give the points back (idempotent)
+	// (POST /cashier/void)
+	PostCashierVoid(w http.ResponseWriter, r *http.Request)
 	// Liveness probe
 	// (GET /healthz)
 	GetHealthz(w http.ResponseWriter, r *http.Request)
@@ -56,9 +131,18 @@ type ServerInterface interface {
 	// Get current client balance
 	// (GET /me/balance)
 	GetMeBalance(w http.ResponseWriter, r *http.Request)
+	// Set or clear the client's birthday
+	// (PUT /me/birthday)
+	PutMeBirthday(w http.ResponseWriter, r *http.Request)
 	// Get client events (history)
 	// (GET /me/events)
 	GetMeEvents(w http.ResponseWriter, r *http.Request, params GetMeEventsParams)
+	// Get the client's referral code and referral stats
+	// (GET /me/referrals)
+	GetMeReferrals(w http.ResponseWriter, r *http.Request)
+	// Gift points to another customer (idempotent)
+	// (POST /me/transfers)
+	PostMeTransfers(w http.ResponseWriter, r *http.Request)
 	// Prometheus metrics endpoint
 	// (GET /metrics)
 	GetMetrics(w http.ResponseWriter, r *http.Request)
@@ -71,6 +155,60 @@ type ServerInterface interface {
 
 type Unimplemented struct{}
 
+// List promotional campaigns (latest start first)
+// (GET /admin/campaigns)
+func (_ Unimplemented) GetAdminCampaigns(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Create a promotional campaign
+// (POST /admin/campaigns)
+func (_ Unimplemented) PostAdminCampaigns(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Delete a promotional campaign
+// (DELETE /admin/campaigns/{campaignId})
+func (_ Unimplemented) DeleteAdminCampaignsCampaignId(w http.ResponseWriter, r *http.Request, campaignId CampaignIdParam) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get a promotional campaign
+// (GET /admin/campaigns/{campaignId})
+func (_ Unimplemented) GetAdminCampaignsCampaignId(w http.ResponseWriter, r *http.Request, campaignId CampaignIdParam) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Replace a promotional campaign
+// (PUT /admin/campaigns/{campaignId})
+func (_ Unimplemented) PutAdminCampaignsCampaignId(w http.ResponseWriter, r *http.Request, campaignId CampaignIdParam) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Verify account balances against the event history
+// (GET /admin/ledger/verification)
+func (_ Unimplemented) GetAdminLedgerVerification(w http.ResponseWriter, r *http.Request, params GetAdminLedgerVerificationParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List velocity limits of cashier operations
+// (GET /admin/limits)
+func (_ Unimplemented) GetAdminLimits(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Create or replace a velocity limit
+// (PUT /admin/limits)
+func (_ Unimplemented) PutAdminLimits(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Delete a velocity limit
+// (DELETE /admin/limits/{limitId})
+func (_ Unimplemented) DeleteAdminLimitsLimitId(w http.ResponseWriter, r *http.Request, limitId int64) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // List rulesets (newest first)
 // (GET /admin/rulesets)
 func (_ Unimplemented) GetAdminRulesets(w http.ResponseWriter, r *http.Request, params GetAdminRulesetsParams) {
@@ -89,6 +227,48 @@ func (_ Unimplemented) GetAdminRulesetsCurrent(w http.ResponseWriter, r *http.Re
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// Simulate a draft ruleset against historical purchases (read-only)
+// (POST /admin/rulesets/simulation)
+func (_ Unimplemented) PostAdminRulesetsSimulation(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Delete a ruleset that has not taken effect
+// (DELETE /admin/rulesets/{rulesetId})
+func (_ Unimplemented) DeleteAdminRulesetsRulesetId(w http.ResponseWriter, r *http.Request, rulesetId RulesetIdParam) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get a ruleset
+// (GET /admin/rulesets/{rulesetId})
+func (_ Unimplemented) GetAdminRulesetsRulesetId(w http.ResponseWriter, r *http.Request, rulesetId RulesetIdParam) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Replace a ruleset that has not taken effect
+// (PUT /admin/rulesets/{rulesetId})
+func (_ Unimplemented) PutAdminRulesetsRulesetId(w http.ResponseWriter, r *http.Request, rulesetId RulesetIdParam) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Approve a ruleset
+// (POST /admin/rulesets/{rulesetId}/approve)
+func (_ Unimplemented) PostAdminRulesetsRulesetIdApprove(w http.ResponseWriter, r *http.Request, rulesetId RulesetIdParam) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Reject a ruleset
+// (POST /admin/rulesets/{rulesetId}/reject)
+func (_ Unimplemented) PostAdminRulesetsRulesetIdReject(w http.ResponseWriter, r *http.Request, rulesetId RulesetIdParam) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Submit a ruleset for approval
+// (POST /admin/rulesets/{rulesetId}/submit)
+func (_ Unimplemented) PostAdminRulesetsRulesetIdSubmit(w http.ResponseWriter, r *http.Request, rulesetId RulesetIdParam) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // List users
 // (GET /admin/users)
 func (_ Unimplemented) GetAdminUsers(w http.ResponseWriter, r *http.Request, params GetAdminUsersParams) {
@@ -101,6 +281,12 @@ func (_ Unimplemented) DeleteAdminUsersUserId(w http.ResponseWriter, r *http.Req
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// Manually credit or debit the user's account
+// (POST /admin/users/{userId}/adjustments)
+func (_ Unimplemented) PostAdminUsersUserIdAdjustments(w http.ResponseWriter, r *http.Request, userId int64) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // Login (phone + password)
 // (POST /auth/login)
 func (_ Unimplemented) PostAuthLogin(w http.ResponseWriter, r *http.Request) {
@@ -119,24 +305,72 @@ func (_ Unimplemented) GetCashierAccountsByCodePublicCode(w http.ResponseWriter,
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// Preview the points of a purchase (read-only)
+// (GET /cashier/accounts/by-code/{publicCode}/earn-quote)
+func (_ Unimplemented) GetCashierAccountsByCodePublicCodeEarnQuote(w http.ResponseWriter, r *http.Request, publicCode PublicCode, params GetCashierAccountsByCodePublicCodeEarnQuoteParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // Get account events by public code
 // (GET /cashier/accounts/by-code/{publicCode}/events)
 func (_ Unimplemented) GetCashierAccountsByCodePublicCodeEvents(w http.ResponseWriter, r *http.Request, publicCode PublicCode, params GetCashierAccountsByCodePublicCodeEventsParams) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// Replay queued EARN/SPEND operations of an offline till
+// (POST /cashier/batch)
+func (_ Unimplemented) PostCashierBatch(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Pay part of the bill with points and earn points on the rest (idempotent)
+// (POST /cashier/checkout)
+func (_ Unimplemented) PostCashierCheckout(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // Earn points for a purchase (idempotent)
 // (POST /cashier/earn)
 func (_ Unimplemented) PostCashierEarn(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// Authorize a points hold (idempotent)
+// (POST /cashier/holds)
+func (_ Unimplemented) PostCashierHolds(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Capture a points hold
+// (POST /cashier/holds/{holdId}/capture)
+func (_ Unimplemented) PostCashierHoldsHoldIdCapture(w http.ResponseWriter, r *http.Request, holdId HoldIdParam) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Release a points hold
+// (POST /cashier/holds/{holdId}/release)
+func (_ Unimplemented) PostCashierHoldsHoldIdRelease(w http.ResponseWriter, r *http.Request, holdId HoldIdParam) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Refund a purchase (full or partial), reverting earned points (idempotent)
+// (POST /cashier/refund)
+func (_ Unimplemented) PostCashierRefund(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // Spend points (idempotent, concurrency-safe)
 // (POST /cashier/spend)
 func (_ Unimplemented) PostCashierSpend(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// Void a SPEND operation and give the points back (idempotent)
+// (POST /cashier/void)
+func (_ Unimplemented) PostCashierVoid(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // Liveness probe
 // (GET /healthz)
 func (_ Unimplemented) GetHealthz(w http.ResponseWriter, r *http.Request) {
@@ -155,12 +389,30 @@ func (_ Unimplemented) GetMeBalance(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// Set or clear the client's birthday
+// (PUT /me/birthday)
+func (_ Unimplemented) PutMeBirthday(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // Get client events (history)
 // (GET /me/events)
 func (_ Unimplemented) GetMeEvents(w http.ResponseWriter, r *http.Request, params GetMeEventsParams) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// Get the client's referral code and referral stats
+// (GET /me/referrals)
+func (_ Unimplemented) GetMeReferrals(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Gift points to another customer (idempotent)
+// (POST /me/transfers)
+func (_ Unimplemented) PostMeTransfers(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // Prometheus metrics endpoint
 // (GET /metrics)
 func (_ Unimplemented) GetMetrics(w http.ResponseWriter, r *http.Request) {
@@ -180,13 +432,765 @@ type ServerInterfaceWrapper struct {
 	ErrorHandlerFunc   func(w http.ResponseWriter, r *http.Request, err error)
 }
 
-type MiddlewareFunc func(http.Handler) http.Handler
-
-// GetAdminRulesets operation middleware
-func (siw *ServerInterfaceWrapper) GetAdminRulesets(w http.ResponseWriter, r *http.Request) {
+type MiddlewareFunc func(http.Handler) http.Handler
+
+// GetAdminCampaigns operation middleware
+func (siw *ServerInterfaceWrapper) GetAdminCampaigns(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetAdminCampaigns(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostAdminCampaigns operation middleware
+func (siw *ServerInterfaceWrapper) PostAdminCampaigns(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostAdminCampaigns(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteAdminCampaignsCampaignId operation middleware
+func (siw *ServerInterfaceWrapper) DeleteAdminCampaignsCampaignId(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "campaignId" -------------
+	var campaignId CampaignIdParam
+
+	err = runtime.BindStyledParameterWithOptions("simple", "campaignId", chi.URLParam(r, "campaignId"), &campaignId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "campaignId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteAdminCampaignsCampaignId(w, r, campaignId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetAdminCampaignsCampaignId operation middleware
+func (siw *ServerInterfaceWrapper) GetAdminCampaignsCampaignId(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "campaignId" -------------
+	var campaignId CampaignIdParam
+
+	err = runtime.BindStyledParameterWithOptions("simple", "campaignId", chi.URLParam(r, "campaignId"), &campaignId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "campaignId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetAdminCampaignsCampaignId(w, r, campaignId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PutAdminCampaignsCampaignId operation middleware
+func (siw *ServerInterfaceWrapper) PutAdminCampaignsCampaignId(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "campaignId" -------------
+	var campaignId CampaignIdParam
+
+	err = runtime.BindStyledParameterWithOptions("simple", "campaignId", chi.URLParam(r, "campaignId"), &campaignId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "campaignId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PutAdminCampaignsCampaignId(w, r, campaignId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetAdminLedgerVerification operation middleware
+func (siw *ServerInterfaceWrapper) GetAdminLedgerVerification(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetAdminLedgerVerificationParams
+
+	// ------------- Optional query parameter "userId" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "userId", r.URL.Query(), &params.UserId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "userId", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetAdminLedgerVerification(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetAdminLimits operation middleware
+func (siw *ServerInterfaceWrapper) GetAdminLimits(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetAdminLimits(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PutAdminLimits operation middleware
+func (siw *ServerInterfaceWrapper) PutAdminLimits(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PutAdminLimits(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteAdminLimitsLimitId operation middleware
+func (siw *ServerInterfaceWrapper) DeleteAdminLimitsLimitId(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "limitId" -------------
+	var limitId int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "limitId", chi.URLParam(r, "limitId"), &limitId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limitId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteAdminLimitsLimitId(w, r, limitId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetAdminRulesets operation middleware
+func (siw *ServerInterfaceWrapper) GetAdminRulesets(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetAdminRulesetsParams
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "offset", r.URL.Query(), &params.Offset)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "offset", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetAdminRulesets(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostAdminRulesets operation middleware
+func (siw *ServerInterfaceWrapper) PostAdminRulesets(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostAdminRulesets(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetAdminRulesetsCurrent operation middleware
+func (siw *ServerInterfaceWrapper) GetAdminRulesetsCurrent(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetAdminRulesetsCurrent(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostAdminRulesetsSimulation operation middleware
+func (siw *ServerInterfaceWrapper) PostAdminRulesetsSimulation(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostAdminRulesetsSimulation(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteAdminRulesetsRulesetId operation middleware
+func (siw *ServerInterfaceWrapper) DeleteAdminRulesetsRulesetId(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "rulesetId" -------------
+	var rulesetId RulesetIdParam
+
+	err = runtime.BindStyledParameterWithOptions("simple", "rulesetId", chi.URLParam(r, "rulesetId"), &rulesetId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "rulesetId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteAdminRulesetsRulesetId(w, r, rulesetId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetAdminRulesetsRulesetId operation middleware
+func (siw *ServerInterfaceWrapper) GetAdminRulesetsRulesetId(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "rulesetId" -------------
+	var rulesetId RulesetIdParam
+
+	err = runtime.BindStyledParameterWithOptions("simple", "rulesetId", chi.URLParam(r, "rulesetId"), &rulesetId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "rulesetId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetAdminRulesetsRulesetId(w, r, rulesetId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PutAdminRulesetsRulesetId operation middleware
+func (siw *ServerInterfaceWrapper) PutAdminRulesetsRulesetId(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "rulesetId" -------------
+	var rulesetId RulesetIdParam
+
+	err = runtime.BindStyledParameterWithOptions("simple", "rulesetId", chi.URLParam(r, "rulesetId"), &rulesetId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "rulesetId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PutAdminRulesetsRulesetId(w, r, rulesetId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostAdminRulesetsRulesetIdApprove operation middleware
+func (siw *ServerInterfaceWrapper) PostAdminRulesetsRulesetIdApprove(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "rulesetId" -------------
+	var rulesetId RulesetIdParam
+
+	err = runtime.BindStyledParameterWithOptions("simple", "rulesetId", chi.URLParam(r, "rulesetId"), &rulesetId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "rulesetId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostAdminRulesetsRulesetIdApprove(w, r, rulesetId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostAdminRulesetsRulesetIdReject operation middleware
+func (siw *ServerInterfaceWrapper) PostAdminRulesetsRulesetIdReject(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "rulesetId" -------------
+	var rulesetId RulesetIdParam
+
+	err = runtime.BindStyledParameterWithOptions("simple", "rulesetId", chi.URLParam(r, "rulesetId"), &rulesetId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "rulesetId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostAdminRulesetsRulesetIdReject(w, r, rulesetId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostAdminRulesetsRulesetIdSubmit operation middleware
+func (siw *ServerInterfaceWrapper) PostAdminRulesetsRulesetIdSubmit(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "rulesetId" -------------
+	var rulesetId RulesetIdParam
+
+	err = runtime.BindStyledParameterWithOptions("simple", "rulesetId", chi.URLParam(r, "rulesetId"), &rulesetId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "rulesetId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostAdminRulesetsRulesetIdSubmit(w, r, rulesetId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetAdminUsers operation middleware
+func (siw *ServerInterfaceWrapper) GetAdminUsers(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetAdminUsersParams
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "offset", r.URL.Query(), &params.Offset)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "offset", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "q" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "q", r.URL.Query(), &params.Q)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "q", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetAdminUsers(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteAdminUsersUserId operation middleware
+func (siw *ServerInterfaceWrapper) DeleteAdminUsersUserId(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "userId" -------------
+	var userId int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "userId", chi.URLParam(r, "userId"), &userId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "userId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteAdminUsersUserId(w, r, userId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostAdminUsersUserIdAdjustments operation middleware
+func (siw *ServerInterfaceWrapper) PostAdminUsersUserIdAdjustments(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "userId" -------------
+	var userId int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "userId", chi.URLParam(r, "userId"), &userId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "userId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostAdminUsersUserIdAdjustments(w, r, userId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostAuthLogin operation middleware
+func (siw *ServerInterfaceWrapper) PostAuthLogin(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostAuthLogin(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostAuthRegister operation middleware
+func (siw *ServerInterfaceWrapper) PostAuthRegister(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostAuthRegister(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetCashierAccountsByCodePublicCode operation middleware
+func (siw *ServerInterfaceWrapper) GetCashierAccountsByCodePublicCode(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "publicCode" -------------
+	var publicCode PublicCode
+
+	err = runtime.BindStyledParameterWithOptions("simple", "publicCode", chi.URLParam(r, "publicCode"), &publicCode, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "publicCode", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetCashierAccountsByCodePublicCode(w, r, publicCode)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetCashierAccountsByCodePublicCodeEarnQuote operation middleware
+func (siw *ServerInterfaceWrapper) GetCashierAccountsByCodePublicCodeEarnQuote(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "publicCode" -------------
+	var publicCode PublicCode
+
+	err = runtime.BindStyledParameterWithOptions("simple", "publicCode", chi.URLParam(r, "publicCode"), &publicCode, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "publicCode", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetCashierAccountsByCodePublicCodeEarnQuoteParams
+
+	// ------------- Required query parameter "amountMoney" -------------
+
+	if paramValue := r.URL.Query().Get("amountMoney"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "amountMoney"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "amountMoney", r.URL.Query(), &params.AmountMoney)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "amountMoney", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetCashierAccountsByCodePublicCodeEarnQuote(w, r, publicCode, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetCashierAccountsByCodePublicCodeEvents operation middleware
+func (siw *ServerInterfaceWrapper) GetCashierAccountsByCodePublicCodeEvents(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
+	// ------------- Path parameter "publicCode" -------------
+	var publicCode PublicCode
+
+	err = runtime.BindStyledParameterWithOptions("simple", "publicCode", chi.URLParam(r, "publicCode"), &publicCode, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "publicCode", Err: err})
+		return
+	}
+
 	ctx := r.Context()
 
 	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
@@ -194,7 +1198,7 @@ func (siw *ServerInterfaceWrapper) GetAdminRulesets(w http.ResponseWriter, r *ht
 	r = r.WithContext(ctx)
 
 	// Parameter object where we will unmarshal all parameters from the context
-	var params GetAdminRulesetsParams
+	var params GetCashierAccountsByCodePublicCodeEventsParams
 
 	// ------------- Optional query parameter "limit" -------------
 
@@ -204,16 +1208,16 @@ func (siw *ServerInterfaceWrapper) GetAdminRulesets(w http.ResponseWriter, r *ht
 		return
 	}
 
-	// ------------- Optional query parameter "offset" -------------
+	// ------------- Optional query parameter "beforeTs" -------------
 
-	err = runtime.BindQueryParameter("form", true, false, "offset", r.URL.Query(), &params.Offset)
+	err = runtime.BindQueryParameter("form", true, false, "beforeTs", r.URL.Query(), &params.BeforeTs)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "offset", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "beforeTs", Err: err})
 		return
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetAdminRulesets(w, r, params)
+		siw.Handler.GetCashierAccountsByCodePublicCodeEvents(w, r, publicCode, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -223,8 +1227,8 @@ func (siw *ServerInterfaceWrapper) GetAdminRulesets(w http.ResponseWriter, r *ht
 	handler.ServeHTTP(w, r)
 }
 
-// PostAdminRulesets operation middleware
-func (siw *ServerInterfaceWrapper) PostAdminRulesets(w http.ResponseWriter, r *http.Request) {
+// PostCashierBatch operation middleware
+func (siw *ServerInterfaceWrapper) PostCashierBatch(w http.ResponseWriter, r *http.Request) {
 
 	ctx := r.Context()
 
@@ -233,7 +1237,7 @@ func (siw *ServerInterfaceWrapper) PostAdminRulesets(w http.ResponseWriter, r *h
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.PostAdminRulesets(w, r)
+		siw.Handler.PostCashierBatch(w, r)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -243,8 +1247,8 @@ func (siw *ServerInterfaceWrapper) PostAdminRulesets(w http.ResponseWriter, r *h
 	handler.ServeHTTP(w, r)
 }
 
-// GetAdminRulesetsCurrent operation middleware
-func (siw *ServerInterfaceWrapper) GetAdminRulesetsCurrent(w http.ResponseWriter, r *http.Request) {
+// PostCashierCheckout operation middleware
+func (siw *ServerInterfaceWrapper) PostCashierCheckout(w http.ResponseWriter, r *http.Request) {
 
 	ctx := r.Context()
 
@@ -253,7 +1257,7 @@ func (siw *ServerInterfaceWrapper) GetAdminRulesetsCurrent(w http.ResponseWriter
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetAdminRulesetsCurrent(w, r)
+		siw.Handler.PostCashierCheckout(w, r)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -263,10 +1267,8 @@ func (siw *ServerInterfaceWrapper) GetAdminRulesetsCurrent(w http.ResponseWriter
 	handler.ServeHTTP(w, r)
 }
 
-// GetAdminUsers operation middleware
-func (siw *ServerInterfaceWrapper) GetAdminUsers(w http.ResponseWriter, r *http.Request) {
-
-	var err error
+// PostCashierEarn operation middleware
+func (siw *ServerInterfaceWrapper) PostCashierEarn(w http.ResponseWriter, r *http.Request) {
 
 	ctx := r.Context()
 
@@ -274,35 +1276,8 @@ func (siw *ServerInterfaceWrapper) GetAdminUsers(w http.ResponseWriter, r *http.
 
 	r = r.WithContext(ctx)
 
-	// Parameter object where we will unmarshal all parameters from the context
-	var params GetAdminUsersParams
-
-	// ------------- Optional query parameter "limit" -------------
-
-	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
-		return
-	}
-
-	// ------------- Optional query parameter "offset" -------------
-
-	err = runtime.BindQueryParameter("form", true, false, "offset", r.URL.Query(), &params.Offset)
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "offset", Err: err})
-		return
-	}
-
-	// ------------- Optional query parameter "q" -------------
-
-	err = runtime.BindQueryParameter("form", true, false, "q", r.URL.Query(), &params.Q)
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "q", Err: err})
-		return
-	}
-
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetAdminUsers(w, r, params)
+		siw.Handler.PostCashierEarn(w, r)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -312,19 +1287,8 @@ func (siw *ServerInterfaceWrapper) GetAdminUsers(w http.ResponseWriter, r *http.
 	handler.ServeHTTP(w, r)
 }
 
-// DeleteAdminUsersUserId operation middleware
-func (siw *ServerInterfaceWrapper) DeleteAdminUsersUserId(w http.ResponseWriter, r *http.Request) {
-
-	var err error
-
-	// ------------- Path parameter "userId" -------------
-	var userId int64
-
-	err = runtime.BindStyledParameterWithOptions("simple", "userId", chi.URLParam(r, "userId"), &userId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "userId", Err: err})
-		return
-	}
+// PostCashierHolds operation middleware
+func (siw *ServerInterfaceWrapper) PostCashierHolds(w http.ResponseWriter, r *http.Request) {
 
 	ctx := r.Context()
 
@@ -333,7 +1297,7 @@ func (siw *ServerInterfaceWrapper) DeleteAdminUsersUserId(w http.ResponseWriter,
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.DeleteAdminUsersUserId(w, r, userId)
+		siw.Handler.PostCashierHolds(w, r)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -343,25 +1307,28 @@ func (siw *ServerInterfaceWrapper) DeleteAdminUsersUserId(w http.ResponseWriter,
 	handler.ServeHTTP(w, r)
 }
 
-// PostAuthLogin operation middleware
-func (siw *ServerInterfaceWrapper) PostAuthLogin(w http.ResponseWriter, r *http.Request) {
+// PostCashierHoldsHoldIdCapture operation middleware
+func (siw *ServerInterfaceWrapper) PostCashierHoldsHoldIdCapture(w http.ResponseWriter, r *http.Request) {
 
-	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.PostAuthLogin(w, r)
-	}))
+	var err error
 
-	for _, middleware := range siw.HandlerMiddlewares {
-		handler = middleware(handler)
+	// ------------- Path parameter "holdId" -------------
+	var holdId HoldIdParam
+
+	err = runtime.BindStyledParameterWithOptions("simple", "holdId", chi.URLParam(r, "holdId"), &holdId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "holdId", Err: err})
+		return
 	}
 
-	handler.ServeHTTP(w, r)
-}
+	ctx := r.Context()
 
-// PostAuthRegister operation middleware
-func (siw *ServerInterfaceWrapper) PostAuthRegister(w http.ResponseWriter, r *http.Request) {
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.PostAuthRegister(w, r)
+		siw.Handler.PostCashierHoldsHoldIdCapture(w, r, holdId)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -371,17 +1338,17 @@ func (siw *ServerInterfaceWrapper) PostAuthRegister(w http.ResponseWriter, r *ht
 	handler.ServeHTTP(w, r)
 }
 
-// GetCashierAccountsByCodePublicCode operation middleware
-func (siw *ServerInterfaceWrapper) GetCashierAccountsByCodePublicCode(w http.ResponseWriter, r *http.Request) {
+// PostCashierHoldsHoldIdRelease operation middleware
+func (siw *ServerInterfaceWrapper) PostCashierHoldsHoldIdRelease(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
-	// ------------- Path parameter "publicCode" -------------
-	var publicCode PublicCode
+	// ------------- Path parameter "holdId" -------------
+	var holdId HoldIdParam
 
-	err = runtime.BindStyledParameterWithOptions("simple", "publicCode", chi.URLParam(r, "publicCode"), &publicCode, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	err = runtime.BindStyledParameterWithOptions("simple", "holdId", chi.URLParam(r, "holdId"), &holdId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "publicCode", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "holdId", Err: err})
 		return
 	}
 
@@ -392,7 +1359,7 @@ func (siw *ServerInterfaceWrapper) GetCashierAccountsByCodePublicCode(w http.Res
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetCashierAccountsByCodePublicCode(w, r, publicCode)
+		siw.Handler.PostCashierHoldsHoldIdRelease(w, r, holdId)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -402,19 +1369,8 @@ func (siw *ServerInterfaceWrapper) GetCashierAccountsByCodePublicCode(w http.Res
 	handler.ServeHTTP(w, r)
 }
 
-// GetCashierAccountsByCodePublicCodeEvents operation middleware
-func (siw *ServerInterfaceWrapper) GetCashierAccountsByCodePublicCodeEvents(w http.ResponseWriter, r *http.Request) {
-
-	var err error
-
-	// ------------- Path parameter "publicCode" -------------
-	var publicCode PublicCode
-
-	err = runtime.BindStyledParameterWithOptions("simple", "publicCode", chi.URLParam(r, "publicCode"), &publicCode, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "publicCode", Err: err})
-		return
-	}
+// PostCashierRefund operation middleware
+func (siw *ServerInterfaceWrapper) PostCashierRefund(w http.ResponseWriter, r *http.Request) {
 
 	ctx := r.Context()
 
@@ -422,27 +1378,8 @@ func (siw *ServerInterfaceWrapper) GetCashierAccountsByCodePublicCodeEvents(w ht
 
 	r = r.WithContext(ctx)
 
-	// Parameter object where we will unmarshal all parameters from the context
-	var params GetCashierAccountsByCodePublicCodeEventsParams
-
-	// ------------- Optional query parameter "limit" -------------
-
-	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
-		return
-	}
-
-	// ------------- Optional query parameter "beforeTs" -------------
-
-	err = runtime.BindQueryParameter("form", true, false, "beforeTs", r.URL.Query(), &params.BeforeTs)
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "beforeTs", Err: err})
-		return
-	}
-
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetCashierAccountsByCodePublicCodeEvents(w, r, publicCode, params)
+		siw.Handler.PostCashierRefund(w, r)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -452,8 +1389,8 @@ func (siw *ServerInterfaceWrapper) GetCashierAccountsByCodePublicCodeEvents(w ht
 	handler.ServeHTTP(w, r)
 }
 
-// PostCashierEarn operation middleware
-func (siw *ServerInterfaceWrapper) PostCashierEarn(w http.ResponseWriter, r *http.Request) {
+// PostCashierSpend operation middleware
+func (siw *ServerInterfaceWrapper) PostCashierSpend(w http.ResponseWriter, r *http.Request) {
 
 	ctx := r.Context()
 
@@ -462,7 +1399,7 @@ func (siw *ServerInterfaceWrapper) PostCashierEarn(w http.ResponseWriter, r *htt
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.PostCashierEarn(w, r)
+		siw.Handler.PostCashierSpend(w, r)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -472,8 +1409,8 @@ func (siw *ServerInterfaceWrapper) PostCashierEarn(w http.ResponseWriter, r *htt
 	handler.ServeHTTP(w, r)
 }
 
-// PostCashierSpend operation middleware
-func (siw *ServerInterfaceWrapper) PostCashierSpend(w http.ResponseWriter, r *http.Request) {
+// PostCashierVoid operation middleware
+func (siw *ServerInterfaceWrapper) PostCashierVoid(w http.ResponseWriter, r *http.Request) {
 
 	ctx := r.Context()
 
@@ -482,7 +1419,7 @@ func (siw *ServerInterfaceWrapper) PostCashierSpend(w http.ResponseWriter, r *ht
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.PostCashierSpend(w, r)
+		siw.Handler.PostCashierVoid(w, r)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -546,6 +1483,26 @@ func (siw *ServerInterfaceWrapper) GetMeBalance(w http.ResponseWriter, r *http.R
 	handler.ServeHTTP(w, r)
 }
 
+// PutMeBirthday operation middleware
+func (siw *ServerInterfaceWrapper) PutMeBirthday(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PutMeBirthday(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
 // GetMeEvents operation middleware
 func (siw *ServerInterfaceWrapper) GetMeEvents(w http.ResponseWriter, r *http.Request) {
 
@@ -587,6 +1544,46 @@ func (siw *ServerInterfaceWrapper) GetMeEvents(w http.ResponseWriter, r *http.Re
 	handler.ServeHTTP(w, r)
 }
 
+// GetMeReferrals operation middleware
+func (siw *ServerInterfaceWrapper) GetMeReferrals(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetMeReferrals(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostMeTransfers operation middleware
+func (siw *ServerInterfaceWrapper) PostMeTransfers(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostMeTransfers(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
 // GetMetrics operation middleware
 func (siw *ServerInterfaceWrapper) GetMetrics(w http.ResponseWriter, r *http.Request) {
 
@@ -728,6 +1725,33 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 		ErrorHandlerFunc:   options.ErrorHandlerFunc,
 	}
 
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/admin/campaigns", wrapper.GetAdminCampaigns)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/admin/campaigns", wrapper.PostAdminCampaigns)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/admin/campaigns/{campaignId}", wrapper.DeleteAdminCampaignsCampaignId)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/admin/campaigns/{campaignId}", wrapper.GetAdminCampaignsCampaignId)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/admin/campaigns/{campaignId}", wrapper.PutAdminCampaignsCampaignId)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/admin/ledger/verification", wrapper.GetAdminLedgerVerification)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/admin/limits", wrapper.GetAdminLimits)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/admin/limits", wrapper.PutAdminLimits)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/admin/limits/{limitId}", wrapper.DeleteAdminLimitsLimitId)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/admin/rulesets", wrapper.GetAdminRulesets)
 	})
@@ -737,12 +1761,36 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/admin/rulesets/current", wrapper.GetAdminRulesetsCurrent)
 	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/admin/rulesets/simulation", wrapper.PostAdminRulesetsSimulation)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/admin/rulesets/{rulesetId}", wrapper.DeleteAdminRulesetsRulesetId)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/admin/rulesets/{rulesetId}", wrapper.GetAdminRulesetsRulesetId)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/admin/rulesets/{rulesetId}", wrapper.PutAdminRulesetsRulesetId)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/admin/rulesets/{rulesetId}/approve", wrapper.PostAdminRulesetsRulesetIdApprove)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/admin/rulesets/{rulesetId}/reject", wrapper.PostAdminRulesetsRulesetIdReject)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/admin/rulesets/{rulesetId}/submit", wrapper.PostAdminRulesetsRulesetIdSubmit)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/admin/users", wrapper.GetAdminUsers)
 	})
 	r.Group(func(r chi.Router) {
 		r.Delete(options.BaseURL+"/admin/users/{userId}", wrapper.DeleteAdminUsersUserId)
 	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/admin/users/{userId}/adjustments", wrapper.PostAdminUsersUserIdAdjustments)
+	})
 	r.Group(func(r chi.Router) {
 		r.Post(options.BaseURL+"/auth/login", wrapper.PostAuthLogin)
 	})
@@ -752,15 +1800,39 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/cashier/accounts/by-code/{publicCode}", wrapper.GetCashierAccountsByCodePublicCode)
 	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/cashier/accounts/by-code/{publicCode}/earn-quote", wrapper.GetCashierAccountsByCodePublicCodeEarnQuote)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/cashier/accounts/by-code/{publicCode}/events", wrapper.GetCashierAccountsByCodePublicCodeEvents)
 	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/cashier/batch", wrapper.PostCashierBatch)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/cashier/checkout", wrapper.PostCashierCheckout)
+	})
 	r.Group(func(r chi.Router) {
 		r.Post(options.BaseURL+"/cashier/earn", wrapper.PostCashierEarn)
 	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/cashier/holds", wrapper.PostCashierHolds)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/cashier/holds/{holdId}/capture", wrapper.PostCashierHoldsHoldIdCapture)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/cashier/holds/{holdId}/release", wrapper.PostCashierHoldsHoldIdRelease)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/cashier/refund", wrapper.PostCashierRefund)
+	})
 	r.Group(func(r chi.Router) {
 		r.Post(options.BaseURL+"/cashier/spend", wrapper.PostCashierSpend)
 	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/cashier/void", wrapper.PostCashierVoid)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/healthz", wrapper.GetHealthz)
 	})
@@ -770,9 +1842,18 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/me/balance", wrapper.GetMeBalance)
 	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/me/birthday", wrapper.PutMeBirthday)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/me/events", wrapper.GetMeEvents)
 	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/me/referrals", wrapper.GetMeReferrals)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/me/transfers", wrapper.PostMeTransfers)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/metrics", wrapper.GetMetrics)
 	})
@@ -13,16 +13,70 @@ const (
 	BearerAuthScopes = "bearerAuth.Scopes"
 )
 
+// Defines values for AdjustReasonCode.
+const (
+	COMPENSATION AdjustReasonCode = "COMPENSATION"
+	CORRECTION   AdjustReasonCode = "CORRECTION"
+	GOODWILL     AdjustReasonCode = "GOODWILL"
+	OTHER        AdjustReasonCode = "OTHER"
+)
+
+// Defines values for BatchItemType.
+const (
+	BatchItemTypeEARN  BatchItemType = "EARN"
+	BatchItemTypeSPEND BatchItemType = "SPEND"
+)
+
+// Defines values for CampaignWeekday.
+const (
+	FRI CampaignWeekday = "FRI"
+	MON CampaignWeekday = "MON"
+	SAT CampaignWeekday = "SAT"
+	SUN CampaignWeekday = "SUN"
+	THU CampaignWeekday = "THU"
+	TUE CampaignWeekday = "TUE"
+	WED CampaignWeekday = "WED"
+)
+
 // Defines values for EventType.
 const (
-	EventTypeEARN  EventType = "EARN"
-	EventTypeSPEND EventType = "SPEND"
+	EventTypeADJUST       EventType = "ADJUST"
+	EventTypeBONUS        EventType = "BONUS"
+	EventTypeEARN         EventType = "EARN"
+	EventTypeEXPIRE       EventType = "EXPIRE"
+	EventTypeLEVELCHANGED EventType = "LEVEL_CHANGED"
+	EventTypeREFUND       EventType = "REFUND"
+	EventTypeSPEND        EventType = "SPEND"
+	EventTypeTRANSFERIN   EventType = "TRANSFER_IN"
+	EventTypeTRANSFEROUT  EventType = "TRANSFER_OUT"
+	EventTypeVOID         EventType = "VOID"
+)
+
+// Defines values for HoldStatus.
+const (
+	ACTIVE   HoldStatus = "ACTIVE"
+	CAPTURED HoldStatus = "CAPTURED"
+	EXPIRED  HoldStatus = "EXPIRED"
+	RELEASED HoldStatus = "RELEASED"
+)
+
+// Defines values for LedgerCheck.
+const (
+	BALANCE      LedgerCheck = "BALANCE"
+	BALANCEAFTER LedgerCheck = "BALANCE_AFTER"
+	HASHCHAIN    LedgerCheck = "HASH_CHAIN"
+	LEVEL        LedgerCheck = "LEVEL"
+	TOTALSPEND   LedgerCheck = "TOTAL_SPEND"
 )
 
 // Defines values for OperationType.
 const (
-	OperationTypeEARN  OperationType = "EARN"
-	OperationTypeSPEND OperationType = "SPEND"
+	OperationTypeCHECKOUT OperationType = "CHECKOUT"
+	OperationTypeEARN     OperationType = "EARN"
+	OperationTypeREFUND   OperationType = "REFUND"
+	OperationTypeSPEND    OperationType = "SPEND"
+	OperationTypeTRANSFER OperationType = "TRANSFER"
+	OperationTypeVOID     OperationType = "VOID"
 )
 
 // Defines values for RoleCode.
@@ -32,6 +86,49 @@ const (
 	CLIENT  RoleCode = "CLIENT"
 )
 
+// Defines values for RoundingMode.
+const (
+	FLOOR    RoundingMode = "FLOOR"
+	HALFEVEN RoundingMode = "HALF_EVEN"
+	HALFUP   RoundingMode = "HALF_UP"
+)
+
+// Defines values for RoundingStage.
+const (
+	ATEND   RoundingStage = "AT_END"
+	PERSTEP RoundingStage = "PER_STEP"
+)
+
+// Defines values for RulesetStatus.
+const (
+	RulesetStatusAPPROVED        RulesetStatus = "APPROVED"
+	RulesetStatusDRAFT           RulesetStatus = "DRAFT"
+	RulesetStatusPENDINGAPPROVAL RulesetStatus = "PENDING_APPROVAL"
+	RulesetStatusREJECTED        RulesetStatus = "REJECTED"
+)
+
+// Defines values for RulesetHistoryEntryAction.
+const (
+	RulesetHistoryEntryActionAPPROVED  RulesetHistoryEntryAction = "APPROVED"
+	RulesetHistoryEntryActionCREATED   RulesetHistoryEntryAction = "CREATED"
+	RulesetHistoryEntryActionREJECTED  RulesetHistoryEntryAction = "REJECTED"
+	RulesetHistoryEntryActionSUBMITTED RulesetHistoryEntryAction = "SUBMITTED"
+	RulesetHistoryEntryActionUPDATED   RulesetHistoryEntryAction = "UPDATED"
+)
+
+// Defines values for VelocityLimitKind.
+const (
+	ACCOUNTPOINTSPERDAY      VelocityLimitKind = "ACCOUNT_POINTS_PER_DAY"
+	CASHIEROPERATIONSPERHOUR VelocityLimitKind = "CASHIER_OPERATIONS_PER_HOUR"
+	POINTSPEROPERATION       VelocityLimitKind = "POINTS_PER_OPERATION"
+)
+
+// Defines values for VelocityLimitOpType.
+const (
+	VelocityLimitOpTypeEARN  VelocityLimitOpType = "EARN"
+	VelocityLimitOpTypeSPEND VelocityLimitOpType = "SPEND"
+)
+
 // Account defines model for Account.
 type Account struct {
 	BalancePoints int       `json:"balancePoints"`
@@ -48,6 +145,33 @@ type Account struct {
 	TotalSpendMoney string `json:"totalSpendMoney"`
 }
 
+// AdjustBalanceRequest defines model for AdjustBalanceRequest.
+type AdjustBalanceRequest struct {
+	Comment string `json:"comment"`
+
+	// DeltaPoints Signed change of the balance (+ credit, - debit), must not be 0
+	DeltaPoints int              `json:"deltaPoints"`
+	ReasonCode  AdjustReasonCode `json:"reasonCode"`
+}
+
+// AdjustReasonCode defines model for AdjustReasonCode.
+type AdjustReasonCode string
+
+// AdjustmentResult defines model for AdjustmentResult.
+type AdjustmentResult struct {
+	Balance    BalanceResponse  `json:"balance"`
+	Comment    string           `json:"comment"`
+	Event      Event            `json:"event"`
+	ReasonCode AdjustReasonCode `json:"reasonCode"`
+}
+
+// AppliedCampaign defines model for AppliedCampaign.
+type AppliedCampaign struct {
+	// BonusPoints Points the campaign added on top of the ruleset
+	BonusPoints int   `json:"bonusPoints"`
+	CampaignId  int64 `json:"campaignId"`
+}
+
 // AuthResponse defines model for AuthResponse.
 type AuthResponse struct {
 	// AccessToken JWT access token
@@ -58,15 +182,125 @@ type AuthResponse struct {
 
 // BalanceResponse defines model for BalanceResponse.
 type BalanceResponse struct {
-	AccountId     int64     `json:"accountId"`
-	AsOf          time.Time `json:"asOf"`
-	BalancePoints int       `json:"balancePoints"`
+	AccountId int64     `json:"accountId"`
+	AsOf      time.Time `json:"asOf"`
+
+	// AvailablePoints Points that can be spent now (balancePoints - heldPoints)
+	AvailablePoints int `json:"availablePoints"`
+
+	// BalancePoints Ledger balance (sum of event deltas), including held points
+	BalancePoints int `json:"balancePoints"`
+
+	// ExpiringOn UTC date when expiringPoints expire
+	ExpiringOn *openapi_types.Date `json:"expiringOn,omitempty"`
+
+	// ExpiringPoints Points expiring on expiringOn (nearest expiry day); absent if nothing expires
+	ExpiringPoints *int `json:"expiringPoints,omitempty"`
+
+	// HeldPoints Points reserved by ACTIVE holds
+	HeldPoints int `json:"heldPoints"`
 
 	// LevelCode Business level label (free-form in ruleset)
 	LevelCode       LevelCode `json:"levelCode"`
 	TotalSpendMoney string    `json:"totalSpendMoney"`
 }
 
+// BatchItem defines model for BatchItem.
+type BatchItem struct {
+	// AmountMoney Required for EARN. Decimal as string (money spent)
+	AmountMoney *string `json:"amountMoney"`
+
+	// AmountPoints Required for SPEND
+	AmountPoints *int `json:"amountPoints"`
+
+	// OperationId Client-generated idempotency key; reusing it with a different request fails with 422 IDEMPOTENCY_KEY_REUSED, reusing it after the retention window (90 days by default) with 422 OPERATION_EXPIRED
+	OperationId openapi_types.UUID `json:"operationId"`
+
+	// PublicCode Public code encoded into QR (customer identifier for POS)
+	PublicCode PublicCode `json:"publicCode"`
+
+	// Ts Time the till recorded the operation. If omitted, server time is used.
+	Ts   *time.Time    `json:"ts"`
+	Type BatchItemType `json:"type"`
+}
+
+// BatchItemResult defines model for BatchItemResult.
+type BatchItemResult struct {
+	// Index Position of the item in the request
+	Index       int                `json:"index"`
+	OperationId openapi_types.UUID `json:"operationId"`
+	Problem     *Problem           `json:"problem,omitempty"`
+	Result      *OperationResult   `json:"result,omitempty"`
+	Type        BatchItemType      `json:"type"`
+}
+
+// BatchItemType defines model for BatchItemType.
+type BatchItemType string
+
+// BatchRequest defines model for BatchRequest.
+type BatchRequest struct {
+	Items []BatchItem `json:"items"`
+}
+
+// BatchResult defines model for BatchResult.
+type BatchResult struct {
+	Items []BatchItemResult `json:"items"`
+}
+
+// Campaign defines model for Campaign.
+type Campaign struct {
+	BonusPoints *int                `json:"bonusPoints"`
+	EndsOn      *openapi_types.Date `json:"endsOn"`
+	Id          int64               `json:"id"`
+	Multiplier  *string             `json:"multiplier"`
+	Name        string              `json:"name"`
+	StartsOn    openapi_types.Date  `json:"startsOn"`
+	TimeFrom    *string             `json:"timeFrom"`
+	TimeTo      *string             `json:"timeTo"`
+	Timezone    string              `json:"timezone"`
+	UpdatedAt   time.Time           `json:"updatedAt"`
+
+	// UpdatedBy Admin who made the last change
+	UpdatedBy *int64            `json:"updatedBy"`
+	Weekdays  []CampaignWeekday `json:"weekdays"`
+}
+
+// CampaignInput Exactly one of multiplier and bonusPoints is required.
+type CampaignInput struct {
+	// BonusPoints Flat points added to every EARN with a purchase > 0
+	BonusPoints *int `json:"bonusPoints"`
+
+	// EndsOn Last day (inclusive), in timezone; omitted - open-ended
+	EndsOn *openapi_types.Date `json:"endsOn"`
+
+	// Multiplier Decimal as string, > 1 and <= 100. Earned points are multiplied (rounded down).
+	Multiplier *string `json:"multiplier"`
+	Name       string  `json:"name"`
+
+	// StartsOn First day, in timezone
+	StartsOn openapi_types.Date `json:"startsOn"`
+
+	// TimeFrom Start of the daily window (HH:MM), together with timeTo; omitted - all day
+	TimeFrom *string `json:"timeFrom"`
+
+	// TimeTo End of the daily window (exclusive). Earlier than timeFrom - the window runs past midnight.
+	TimeTo *string `json:"timeTo"`
+
+	// Timezone IANA timezone of dates and times. Defaults to UTC.
+	Timezone *string `json:"timezone,omitempty"`
+
+	// Weekdays Days the campaign runs on; empty or omitted - every day
+	Weekdays *[]CampaignWeekday `json:"weekdays,omitempty"`
+}
+
+// CampaignWeekday defines model for CampaignWeekday.
+type CampaignWeekday string
+
+// CampaignsList defines model for CampaignsList.
+type CampaignsList struct {
+	Items []Campaign `json:"items"`
+}
+
 // CashierAccountSummary defines model for CashierAccountSummary.
 type CashierAccountSummary struct {
 	AccountId     int64 `json:"accountId"`
@@ -80,6 +314,54 @@ type CashierAccountSummary struct {
 	TotalSpendMoney string     `json:"totalSpendMoney"`
 }
 
+// CategoryRate defines model for CategoryRate.
+type CategoryRate struct {
+	Category string `json:"category"`
+
+	// EarnFactor Decimal as string, 0..10. A line earns at earnFactor * the level percent: 1.00 - the level percent, 0.50 - half of it, 0 - nothing (e.g. gift cards).
+	EarnFactor string `json:"earnFactor"`
+}
+
+// CheckoutRequest defines model for CheckoutRequest.
+type CheckoutRequest struct {
+	// BillMoney Decimal as string. Whole bill before the points discount. Must be > 0.
+	BillMoney string `json:"billMoney"`
+
+	// OperationId Client-generated idempotency key; reusing it with a different request fails with 422 IDEMPOTENCY_KEY_REUSED, reusing it after the retention window (90 days by default) with 422 OPERATION_EXPIRED
+	OperationId openapi_types.UUID `json:"operationId"`
+
+	// PublicCode Public code encoded into QR (customer identifier for POS)
+	PublicCode PublicCode `json:"publicCode"`
+
+	// RedeemPoints Points to pay with
+	RedeemPoints *int `json:"redeemPoints,omitempty"`
+
+	// Ts Operation timestamp. If omitted, server time is used.
+	Ts *time.Time `json:"ts"`
+}
+
+// CheckoutResult defines model for CheckoutResult.
+type CheckoutResult struct {
+	Balance   BalanceResponse `json:"balance"`
+	BillMoney string          `json:"billMoney"`
+
+	// DiscountMoney Money covered by redeemed points
+	DiscountMoney string `json:"discountMoney"`
+	EarnEvent     *Event `json:"earnEvent,omitempty"`
+
+	// IdempotentReplay true if returned from idempotency cache
+	IdempotentReplay *bool `json:"idempotentReplay,omitempty"`
+
+	// LevelChange A level transition caused by the operation, recorded as a LEVEL_CHANGED event.
+	LevelChange *LevelChange       `json:"levelChange,omitempty"`
+	OpType      OperationType      `json:"opType"`
+	OperationId openapi_types.UUID `json:"operationId"`
+
+	// PaidMoney Money actually paid; points are earned on this amount
+	PaidMoney  string `json:"paidMoney"`
+	SpendEvent *Event `json:"spendEvent,omitempty"`
+}
+
 // ClientProfile defines model for ClientProfile.
 type ClientProfile struct {
 	Account Account `json:"account"`
@@ -89,9 +371,74 @@ type ClientProfile struct {
 // CreateRulesetRequest defines model for CreateRulesetRequest.
 type CreateRulesetRequest struct {
 	// BaseRubPerPoint Decimal as string. Must be > 0.
-	BaseRubPerPoint string           `json:"baseRubPerPoint"`
-	EffectiveFrom   time.Time        `json:"effectiveFrom"`
-	Levels          []LevelRuleInput `json:"levels"`
+	BaseRubPerPoint string `json:"baseRubPerPoint"`
+
+	// BirthdayBonusPoints Credited as a BONUS event once a year on the client's birthday. Omit or 0 for none.
+	BirthdayBonusPoints *int `json:"birthdayBonusPoints,omitempty"`
+
+	// CategoryRates Earn factors of receipt line categories; categories not listed earn at factor 1.00.
+	CategoryRates *[]CategoryRate  `json:"categoryRates,omitempty"`
+	EffectiveFrom time.Time        `json:"effectiveFrom"`
+	Levels        []LevelRuleInput `json:"levels"`
+
+	// MaxPointsPerDay Caps the EARN points of an account per UTC day of the purchase ts; campaign rewards come on top and refunded points no longer count. Must be >= maxPointsPerPurchase when both are set. Omit for no cap.
+	MaxPointsPerDay *int `json:"maxPointsPerDay,omitempty"`
+
+	// MaxPointsPerPurchase Caps the points of one purchase (before campaigns). Omit for no cap.
+	MaxPointsPerPurchase *int `json:"maxPointsPerPurchase,omitempty"`
+
+	// MinEarnAmount Decimal as string, >= 0. Purchases below it earn no points. Defaults to "0.00".
+	MinEarnAmount *string `json:"minEarnAmount,omitempty"`
+
+	// QualificationWindowDays Levels qualify on spend of purchases made in the last N days (net of their refunds) instead of lifetime spend. Accounts that fall below their level's threshold are downgraded by a nightly job. Omit for lifetime qualification.
+	QualificationWindowDays *int `json:"qualificationWindowDays,omitempty"`
+
+	// RedeemRubPerPoint Decimal as string. Money value of one point redeemed at checkout. Must be > 0. Defaults to "1.00".
+	RedeemRubPerPoint *string `json:"redeemRubPerPoint,omitempty"`
+
+	// RefereeBonusPoints Credited as a BONUS event to the referee on their first EARN. Omit or 0 for none.
+	RefereeBonusPoints *int `json:"refereeBonusPoints,omitempty"`
+
+	// ReferrerBonusPoints Credited as a BONUS event to the referrer on the referee's first EARN. Omit or 0 for none.
+	ReferrerBonusPoints *int `json:"referrerBonusPoints,omitempty"`
+
+	// RoundingMode How fractional earn points are rounded: FLOOR always down, HALF_UP .5 up, HALF_EVEN (banker's) .5 to the even neighbour. Default FLOOR.
+	RoundingMode *RoundingMode `json:"roundingMode,omitempty"`
+
+	// RoundingStage PER_STEP rounds basePoints and again after the level percent; AT_END rounds amountMoney / baseRubPerPoint * percentEarn / 100 once. Default PER_STEP.
+	RoundingStage *RoundingStage `json:"roundingStage,omitempty"`
+
+	// WelcomeBonusPoints Credited as a BONUS event on registration while the ruleset is effective. Omit or 0 for none.
+	WelcomeBonusPoints *int `json:"welcomeBonusPoints,omitempty"`
+}
+
+// EarnQuote defines model for EarnQuote.
+type EarnQuote struct {
+	AmountMoney     string            `json:"amountMoney"`
+	BaseRubPerPoint string            `json:"baseRubPerPoint"`
+	Campaigns       []AppliedCampaign `json:"campaigns"`
+
+	// LevelAfter Business level label (free-form in ruleset)
+	LevelAfter LevelCode `json:"levelAfter"`
+
+	// LevelBefore Business level label (free-form in ruleset)
+	LevelBefore LevelCode `json:"levelBefore"`
+
+	// PercentEarn Percent of levelBefore the points are computed at
+	PercentEarn string `json:"percentEarn"`
+
+	// Points rulesetPoints plus the campaign rewards - what the EARN would credit
+	Points int `json:"points"`
+
+	// PublicCode Public code encoded into QR (customer identifier for POS)
+	PublicCode PublicCode `json:"publicCode"`
+	RulesetId  int64      `json:"rulesetId"`
+
+	// RulesetPoints Points under the ruleset after rounding and earn caps
+	RulesetPoints int `json:"rulesetPoints"`
+
+	// Ts Server time the quote was computed at
+	Ts time.Time `json:"ts"`
 }
 
 // EarnRequest defines model for EarnRequest.
@@ -99,7 +446,10 @@ type EarnRequest struct {
 	// AmountMoney Decimal as string (money spent)
 	AmountMoney string `json:"amountMoney"`
 
-	// OperationId Client-generated idempotency key
+	// Lines Optional itemized receipt. Line totals must add up to amountMoney (422 INVALID_RECEIPT otherwise). Each line earns at the earn factor of its category in the ruleset (see Ruleset.categoryRates); points are computed on sum(lineTotal * factor) instead of amountMoney. The lines are stored with the EARN.
+	Lines *[]ReceiptLine `json:"lines,omitempty"`
+
+	// OperationId Client-generated idempotency key; reusing it with a different request fails with 422 IDEMPOTENCY_KEY_REUSED, reusing it after the retention window (90 days by default) with 422 OPERATION_EXPIRED
 	OperationId openapi_types.UUID `json:"operationId"`
 
 	// PublicCode Public code encoded into QR (customer identifier for POS)
@@ -114,16 +464,22 @@ type Event struct {
 	AccountId   int64  `json:"accountId"`
 	ActorUserId *int64 `json:"actorUserId"`
 
-	// AmountMoney Present for EARN; decimal as string
+	// AmountMoney Present for EARN (purchase) and REFUND (refunded amount); decimal as string
 	AmountMoney  *string `json:"amountMoney"`
 	BalanceAfter int     `json:"balanceAfter"`
 
-	// DeltaPoints Signed delta (+ for EARN, - for SPEND)
-	DeltaPoints int       `json:"deltaPoints"`
-	Id          int64     `json:"id"`
-	RulesetId   *int64    `json:"rulesetId"`
-	Ts          time.Time `json:"ts"`
-	Type        EventType `json:"type"`
+	// Campaigns Campaigns applied to an EARN; present in operation results only
+	Campaigns *[]AppliedCampaign `json:"campaigns,omitempty"`
+
+	// DeltaPoints Signed delta (+ for EARN/VOID, - for SPEND/REFUND)
+	DeltaPoints int   `json:"deltaPoints"`
+	Id          int64 `json:"id"`
+
+	// RefEventId Original event for compensating entries (REFUND -> EARN, VOID -> SPEND)
+	RefEventId *int64    `json:"refEventId"`
+	RulesetId  *int64    `json:"rulesetId"`
+	Ts         time.Time `json:"ts"`
+	Type       EventType `json:"type"`
 }
 
 // EventType defines model for EventType.
@@ -142,9 +498,106 @@ type HealthResponse struct {
 	Status string `json:"status"`
 }
 
+// Hold defines model for Hold.
+type Hold struct {
+	AccountId int64 `json:"accountId"`
+
+	// CapturedPoints Present for CAPTURED holds
+	CapturedPoints *int `json:"capturedPoints,omitempty"`
+
+	// ClosedAt When the hold was captured, released or expired
+	ClosedAt    *time.Time         `json:"closedAt,omitempty"`
+	CreatedAt   time.Time          `json:"createdAt"`
+	ExpiresAt   time.Time          `json:"expiresAt"`
+	Id          int64              `json:"id"`
+	OperationId openapi_types.UUID `json:"operationId"`
+	Points      int                `json:"points"`
+	Status      HoldStatus         `json:"status"`
+}
+
+// HoldAuthorizeRequest defines model for HoldAuthorizeRequest.
+type HoldAuthorizeRequest struct {
+	// OperationId Client-generated idempotency key; reusing it with a different points value fails with 422 IDEMPOTENCY_KEY_REUSED
+	OperationId openapi_types.UUID `json:"operationId"`
+	Points      int                `json:"points"`
+
+	// PublicCode Public code encoded into QR (customer identifier for POS)
+	PublicCode PublicCode `json:"publicCode"`
+}
+
+// HoldCaptureRequest defines model for HoldCaptureRequest.
+type HoldCaptureRequest struct {
+	// Points Points to spend (<= held points). If omitted, the whole hold is captured.
+	Points *int `json:"points,omitempty"`
+}
+
+// HoldResult defines model for HoldResult.
+type HoldResult struct {
+	Balance BalanceResponse `json:"balance"`
+	Event   *Event          `json:"event,omitempty"`
+	Hold    Hold            `json:"hold"`
+
+	// IdempotentReplay true if the hold was already in the requested state
+	IdempotentReplay *bool `json:"idempotentReplay,omitempty"`
+}
+
+// HoldStatus defines model for HoldStatus.
+type HoldStatus string
+
+// LedgerCheck BALANCE_AFTER - event balanceAfter differs from the previous balanceAfter plus deltaPoints; BALANCE - balancePoints differs from the sum of deltaPoints; TOTAL_SPEND - totalSpendMoney differs from EARN minus REFUND amounts; LEVEL - levelCode differs from the level resolved for the replayed totalSpendMoney; HASH_CHAIN - first event whose stored hash does not match the per-account hash chain (expected/actual are hex hashes).
+type LedgerCheck string
+
+// LedgerMismatch defines model for LedgerMismatch.
+type LedgerMismatch struct {
+	AccountId int64 `json:"accountId"`
+
+	// Actual Stored value
+	Actual string `json:"actual"`
+
+	// Check BALANCE_AFTER - event balanceAfter differs from the previous balanceAfter plus deltaPoints; BALANCE - balancePoints differs from the sum of deltaPoints; TOTAL_SPEND - totalSpendMoney differs from EARN minus REFUND amounts; LEVEL - levelCode differs from the level resolved for the replayed totalSpendMoney; HASH_CHAIN - first event whose stored hash does not match the per-account hash chain (expected/actual are hex hashes).
+	Check LedgerCheck `json:"check"`
+
+	// EventId Present for BALANCE_AFTER and HASH_CHAIN
+	EventId *int64 `json:"eventId"`
+
+	// Expected Value replayed from events
+	Expected string `json:"expected"`
+	UserId   int64  `json:"userId"`
+}
+
+// LedgerReport defines model for LedgerReport.
+type LedgerReport struct {
+	AccountsChecked int              `json:"accountsChecked"`
+	CheckedAt       time.Time        `json:"checkedAt"`
+	EventsChecked   int              `json:"eventsChecked"`
+	Mismatches      []LedgerMismatch `json:"mismatches"`
+}
+
+// LevelChange A level transition caused by the operation, recorded as a LEVEL_CHANGED event.
+type LevelChange struct {
+	// BonusEventId The BONUS event of the reached bonus
+	BonusEventId *int64 `json:"bonusEventId"`
+
+	// BonusPoints Reached bonus of toLevel credited by this change; 0 if none was paid
+	BonusPoints int `json:"bonusPoints"`
+
+	// EventId The LEVEL_CHANGED event
+	EventId   int64  `json:"eventId"`
+	FromLevel string `json:"fromLevel"`
+	ToLevel   string `json:"toLevel"`
+}
+
 // LevelCode Business level label (free-form in ruleset)
 type LevelCode = string
 
+// LevelCount defines model for LevelCount.
+type LevelCount struct {
+	Accounts int `json:"accounts"`
+
+	// LevelCode Business level label (free-form in ruleset)
+	LevelCode LevelCode `json:"levelCode"`
+}
+
 // LevelRule defines model for LevelRule.
 type LevelRule struct {
 	Id int64 `json:"id"`
@@ -152,6 +605,7 @@ type LevelRule struct {
 	// LevelCode Business level label (free-form in ruleset)
 	LevelCode           LevelCode `json:"levelCode"`
 	PercentEarn         string    `json:"percentEarn"`
+	ReachedBonusPoints  int       `json:"reachedBonusPoints"`
 	ThresholdTotalSpend string    `json:"thresholdTotalSpend"`
 }
 
@@ -163,6 +617,9 @@ type LevelRuleInput struct {
 	// PercentEarn Percent multiplier as string (e.g., "100.00" = base; "110.00" = +10%). Must be > 0.
 	PercentEarn string `json:"percentEarn"`
 
+	// ReachedBonusPoints Credited as a BONUS event the first time a customer is moved up to this level. Omit or 0 for none.
+	ReachedBonusPoints *int `json:"reachedBonusPoints,omitempty"`
+
 	// ThresholdTotalSpend Decimal as string. Must be >= 0.
 	ThresholdTotalSpend string `json:"thresholdTotalSpend"`
 }
@@ -181,9 +638,12 @@ type OperationResult struct {
 	Event   Event           `json:"event"`
 
 	// IdempotentReplay true if returned from idempotency cache
-	IdempotentReplay *bool              `json:"idempotentReplay,omitempty"`
-	OpType           OperationType      `json:"opType"`
-	OperationId      openapi_types.UUID `json:"operationId"`
+	IdempotentReplay *bool `json:"idempotentReplay,omitempty"`
+
+	// LevelChange A level transition caused by the operation, recorded as a LEVEL_CHANGED event.
+	LevelChange *LevelChange       `json:"levelChange,omitempty"`
+	OpType      OperationType      `json:"opType"`
+	OperationId openapi_types.UUID `json:"operationId"`
 }
 
 // OperationType defines model for OperationType.
@@ -206,24 +666,172 @@ type Problem struct {
 // PublicCode Public code encoded into QR (customer identifier for POS)
 type PublicCode = string
 
+// ReceiptLine defines model for ReceiptLine.
+type ReceiptLine struct {
+	Category string `json:"category"`
+
+	// LineTotal Decimal as string, >= 0 (money for the whole line)
+	LineTotal string `json:"lineTotal"`
+	Quantity  int    `json:"quantity"`
+	Sku       string `json:"sku"`
+}
+
+// ReferralStats defines model for ReferralStats.
+type ReferralStats struct {
+	// BonusPointsEarned Referral bonuses credited to the caller.
+	BonusPointsEarned int `json:"bonusPointsEarned"`
+
+	// MonthlyLimit Referrals allowed per calendar month; null means no cap.
+	MonthlyLimit *int   `json:"monthlyLimit"`
+	PendingCount int    `json:"pendingCount"`
+	ReferralCode string `json:"referralCode"`
+
+	// ReferredCount Customers registered with the code.
+	ReferredCount int `json:"referredCount"`
+
+	// ReferredThisMonth Referrals of the current calendar month (UTC).
+	ReferredThisMonth int `json:"referredThisMonth"`
+
+	// RewardedCount Referees who made their first EARN.
+	RewardedCount int `json:"rewardedCount"`
+}
+
+// RefundRequest defines model for RefundRequest.
+type RefundRequest struct {
+	// AmountMoney Decimal as string (money refunded). If omitted, the remaining amount is refunded.
+	AmountMoney *string `json:"amountMoney"`
+
+	// EventId Original EARN event id
+	EventId int64 `json:"eventId"`
+
+	// OperationId Client-generated idempotency key; reusing it with a different request fails with 422 IDEMPOTENCY_KEY_REUSED, reusing it after the retention window (90 days by default) with 422 OPERATION_EXPIRED
+	OperationId openapi_types.UUID `json:"operationId"`
+
+	// PublicCode Public code encoded into QR (customer identifier for POS)
+	PublicCode PublicCode `json:"publicCode"`
+
+	// Ts Operation timestamp. If omitted, server time is used.
+	Ts *time.Time `json:"ts"`
+}
+
 // RegisterRequest defines model for RegisterRequest.
 type RegisterRequest struct {
-	Password string `json:"password"`
+	// Birthday Optional; not in the future (422 INVALID_BIRTHDAY).
+	Birthday *openapi_types.Date `json:"birthday,omitempty"`
+	Password string              `json:"password"`
 
 	// Phone E.164-like phone format (MVP)
 	Phone Phone `json:"phone"`
+
+	// ReferralCode Optional referral code of an existing customer (case-insensitive).
+	ReferralCode *string `json:"referralCode,omitempty"`
+}
+
+// RejectRulesetRequest defines model for RejectRulesetRequest.
+type RejectRulesetRequest struct {
+	Comment string `json:"comment"`
 }
 
 // RoleCode defines model for RoleCode.
 type RoleCode string
 
+// RoundingMode How fractional earn points are rounded: FLOOR always down, HALF_UP .5 up, HALF_EVEN (banker's) .5 to the even neighbour. Default FLOOR.
+type RoundingMode string
+
+// RoundingStage PER_STEP rounds basePoints and again after the level percent; AT_END rounds amountMoney / baseRubPerPoint * percentEarn / 100 once. Default PER_STEP.
+type RoundingStage string
+
 // Ruleset defines model for Ruleset.
 type Ruleset struct {
-	BaseRubPerPoint string      `json:"baseRubPerPoint"`
-	CreatedAt       time.Time   `json:"createdAt"`
-	EffectiveFrom   time.Time   `json:"effectiveFrom"`
-	Id              int64       `json:"id"`
-	Levels          []LevelRule `json:"levels"`
+	BaseRubPerPoint     string         `json:"baseRubPerPoint"`
+	BirthdayBonusPoints int            `json:"birthdayBonusPoints"`
+	CategoryRates       []CategoryRate `json:"categoryRates"`
+	CreatedAt           time.Time      `json:"createdAt"`
+	EffectiveFrom       time.Time      `json:"effectiveFrom"`
+
+	// EffectiveTo effectiveFrom of the next approved ruleset; null while no later ruleset is approved.
+	EffectiveTo *time.Time `json:"effectiveTo"`
+
+	// History Who did what to the ruleset, oldest first.
+	History []RulesetHistoryEntry `json:"history"`
+	Id      int64                 `json:"id"`
+	Levels  []LevelRule           `json:"levels"`
+
+	// MaxPointsPerDay Null means no daily cap.
+	MaxPointsPerDay *int `json:"maxPointsPerDay"`
+
+	// MaxPointsPerPurchase Null means no per-purchase cap.
+	MaxPointsPerPurchase *int   `json:"maxPointsPerPurchase"`
+	MinEarnAmount        string `json:"minEarnAmount"`
+
+	// QualificationWindowDays Rolling qualification window in days; null means lifetime spend.
+	QualificationWindowDays *int   `json:"qualificationWindowDays"`
+	RedeemRubPerPoint       string `json:"redeemRubPerPoint"`
+	RefereeBonusPoints      int    `json:"refereeBonusPoints"`
+	ReferrerBonusPoints     int    `json:"referrerBonusPoints"`
+
+	// RoundingMode How fractional earn points are rounded: FLOOR always down, HALF_UP .5 up, HALF_EVEN (banker's) .5 to the even neighbour. Default FLOOR.
+	RoundingMode RoundingMode `json:"roundingMode"`
+
+	// RoundingStage PER_STEP rounds basePoints and again after the level percent; AT_END rounds amountMoney / baseRubPerPoint * percentEarn / 100 once. Default PER_STEP.
+	RoundingStage RoundingStage `json:"roundingStage"`
+
+	// Status Only APPROVED rulesets take effect.
+	Status             RulesetStatus `json:"status"`
+	WelcomeBonusPoints int           `json:"welcomeBonusPoints"`
+}
+
+// RulesetStatus Only APPROVED rulesets take effect.
+type RulesetStatus string
+
+// RulesetHistoryEntry defines model for RulesetHistoryEntry.
+type RulesetHistoryEntry struct {
+	Action      RulesetHistoryEntryAction `json:"action"`
+	ActorUserId *int64                    `json:"actorUserId"`
+	At          time.Time                 `json:"at"`
+
+	// Comment Set on REJECTED.
+	Comment *string `json:"comment"`
+}
+
+// RulesetHistoryEntryAction defines model for RulesetHistoryEntry.Action.
+type RulesetHistoryEntryAction string
+
+// RulesetSimulation defines model for RulesetSimulation.
+type RulesetSimulation struct {
+	// Accounts Customers with purchases in the range
+	Accounts     int          `json:"accounts"`
+	ActualLevels []LevelCount `json:"actualLevels"`
+
+	// ActualPoints Points actually issued for the purchases, without campaign rewards
+	ActualPoints int `json:"actualPoints"`
+
+	// ActualRulesetId Ruleset the actual levels are resolved with
+	ActualRulesetId int64        `json:"actualRulesetId"`
+	DraftLevels     []LevelCount `json:"draftLevels"`
+
+	// DraftPoints Points the draft would have issued
+	DraftPoints int       `json:"draftPoints"`
+	From        time.Time `json:"from"`
+
+	// Purchases EARN events replayed
+	Purchases int       `json:"purchases"`
+	To        time.Time `json:"to"`
+
+	// TopAccounts Customers whose points or level change, largest points difference first
+	TopAccounts []SimulatedAccount `json:"topAccounts"`
+}
+
+// RulesetSimulationRequest defines model for RulesetSimulationRequest.
+type RulesetSimulationRequest struct {
+	Draft CreateRulesetRequest `json:"draft"`
+	From  time.Time            `json:"from"`
+
+	// To Exclusive
+	To time.Time `json:"to"`
+
+	// TopLimit How many of the most changed accounts to return
+	TopLimit *int `json:"topLimit,omitempty"`
 }
 
 // RulesetsPage defines model for RulesetsPage.
@@ -232,11 +840,33 @@ type RulesetsPage struct {
 	Total *int      `json:"total"`
 }
 
+// SetBirthdayRequest defines model for SetBirthdayRequest.
+type SetBirthdayRequest struct {
+	// Birthday Not in the future (422 INVALID_BIRTHDAY); null clears it.
+	Birthday *openapi_types.Date `json:"birthday"`
+}
+
+// SimulatedAccount defines model for SimulatedAccount.
+type SimulatedAccount struct {
+	AccountId int64 `json:"accountId"`
+
+	// ActualLevel Business level label (free-form in ruleset)
+	ActualLevel  LevelCode `json:"actualLevel"`
+	ActualPoints int       `json:"actualPoints"`
+
+	// DraftLevel Business level label (free-form in ruleset)
+	DraftLevel  LevelCode `json:"draftLevel"`
+	DraftPoints int       `json:"draftPoints"`
+
+	// PublicCode Public code encoded into QR (customer identifier for POS)
+	PublicCode PublicCode `json:"publicCode"`
+}
+
 // SpendRequest defines model for SpendRequest.
 type SpendRequest struct {
 	AmountPoints int `json:"amountPoints"`
 
-	// OperationId Client-generated idempotency key
+	// OperationId Client-generated idempotency key; reusing it with a different request fails with 422 IDEMPOTENCY_KEY_REUSED, reusing it after the retention window (90 days by default) with 422 OPERATION_EXPIRED
 	OperationId openapi_types.UUID `json:"operationId"`
 
 	// PublicCode Public code encoded into QR (customer identifier for POS)
@@ -246,11 +876,26 @@ type SpendRequest struct {
 	Ts *time.Time `json:"ts"`
 }
 
+// TransferRequest defines model for TransferRequest.
+type TransferRequest struct {
+	// OperationId Client-generated idempotency key; reusing it with a different request fails with 422 IDEMPOTENCY_KEY_REUSED, reusing it after the retention window (90 days by default) with 422 OPERATION_EXPIRED
+	OperationId openapi_types.UUID `json:"operationId"`
+	Points      int                `json:"points"`
+
+	// RecipientPhone E.164-like phone format (MVP)
+	RecipientPhone *Phone `json:"recipientPhone,omitempty"`
+
+	// RecipientPublicCode Public code encoded into QR (customer identifier for POS)
+	RecipientPublicCode *PublicCode `json:"recipientPublicCode,omitempty"`
+}
+
 // User defines model for User.
 type User struct {
-	CreatedAt time.Time `json:"createdAt"`
-	Id        int64     `json:"id"`
-	IsActive  bool      `json:"isActive"`
+	// Birthday Absent if not set
+	Birthday  *openapi_types.Date `json:"birthday,omitempty"`
+	CreatedAt time.Time           `json:"createdAt"`
+	Id        int64               `json:"id"`
+	IsActive  bool                `json:"isActive"`
 
 	// Phone E.164-like phone format (MVP)
 	Phone Phone      `json:"phone"`
@@ -265,12 +910,71 @@ type UsersPage struct {
 	Total *int `json:"total"`
 }
 
+// VelocityLimit defines model for VelocityLimit.
+type VelocityLimit struct {
+	Id int64 `json:"id"`
+
+	// Kind POINTS_PER_OPERATION - points of a single operation; ACCOUNT_POINTS_PER_DAY - points of one account per UTC day; CASHIER_OPERATIONS_PER_HOUR - operations made by one cashier in the last hour. Usage is counted by server time, so a backdated ts does not move an operation out of a window.
+	Kind      VelocityLimitKind   `json:"kind"`
+	MaxValue  int                 `json:"maxValue"`
+	OpType    VelocityLimitOpType `json:"opType"`
+	UpdatedAt time.Time           `json:"updatedAt"`
+
+	// UpdatedBy Admin who set the current value
+	UpdatedBy *int64 `json:"updatedBy"`
+}
+
+// VelocityLimitInput defines model for VelocityLimitInput.
+type VelocityLimitInput struct {
+	// Kind POINTS_PER_OPERATION - points of a single operation; ACCOUNT_POINTS_PER_DAY - points of one account per UTC day; CASHIER_OPERATIONS_PER_HOUR - operations made by one cashier in the last hour. Usage is counted by server time, so a backdated ts does not move an operation out of a window.
+	Kind VelocityLimitKind `json:"kind"`
+
+	// MaxValue Points for the points limits, a count for CASHIER_OPERATIONS_PER_HOUR
+	MaxValue int                 `json:"maxValue"`
+	OpType   VelocityLimitOpType `json:"opType"`
+}
+
+// VelocityLimitKind POINTS_PER_OPERATION - points of a single operation; ACCOUNT_POINTS_PER_DAY - points of one account per UTC day; CASHIER_OPERATIONS_PER_HOUR - operations made by one cashier in the last hour. Usage is counted by server time, so a backdated ts does not move an operation out of a window.
+type VelocityLimitKind string
+
+// VelocityLimitOpType defines model for VelocityLimitOpType.
+type VelocityLimitOpType string
+
+// VelocityLimitsList defines model for VelocityLimitsList.
+type VelocityLimitsList struct {
+	Items []VelocityLimit `json:"items"`
+}
+
+// VoidRequest defines model for VoidRequest.
+type VoidRequest struct {
+	// OperationId Client-generated idempotency key; reusing it with a different request fails with 422 IDEMPOTENCY_KEY_REUSED, reusing it after the retention window (90 days by default) with 422 OPERATION_EXPIRED
+	OperationId openapi_types.UUID `json:"operationId"`
+
+	// PublicCode Public code encoded into QR (customer identifier for POS)
+	PublicCode PublicCode `json:"publicCode"`
+
+	// SpendOperationId operationId of the SPEND being voided
+	SpendOperationId openapi_types.UUID `json:"spendOperationId"`
+
+	// Ts Operation timestamp. If omitted, server time is used.
+	Ts *time.Time `json:"ts"`
+}
+
 // BeforeTsParam defines model for BeforeTsParam.
 type BeforeTsParam = time.Time
 
+// CampaignIdParam defines model for CampaignIdParam.
+type CampaignIdParam = int64
+
+// HoldIdParam defines model for HoldIdParam.
+type HoldIdParam = int64
+
 // LimitParam defines model for LimitParam.
 type LimitParam = int
 
+// RulesetIdParam defines model for RulesetIdParam.
+type RulesetIdParam = int64
+
 // Forbidden defines model for Forbidden.
 type Forbidden = Problem
 
@@ -283,6 +987,12 @@ type Unauthorized = Problem
 // ValidationError defines model for ValidationError.
 type ValidationError = Problem
 
+// GetAdminLedgerVerificationParams defines parameters for GetAdminLedgerVerification.
+type GetAdminLedgerVerificationParams struct {
+	// UserId Verify only this user's account; all accounts when omitted
+	UserId *int64 `form:"userId,omitempty" json:"userId,omitempty"`
+}
+
 // GetAdminRulesetsParams defines parameters for GetAdminRulesets.
 type GetAdminRulesetsParams struct {
 	Limit  *LimitParam `form:"limit,omitempty" json:"limit,omitempty"`
@@ -298,6 +1008,12 @@ type GetAdminUsersParams struct {
 	Q *string `form:"q,omitempty" json:"q,omitempty"`
 }
 
+// GetCashierAccountsByCodePublicCodeEarnQuoteParams defines parameters for GetCashierAccountsByCodePublicCodeEarnQuote.
+type GetCashierAccountsByCodePublicCodeEarnQuoteParams struct {
+	// AmountMoney Purchase amount, decimal as string (up to 2 fractional digits). Must be >= 0.
+	AmountMoney string `form:"amountMoney" json:"amountMoney"`
+}
+
 // GetCashierAccountsByCodePublicCodeEventsParams defines parameters for GetCashierAccountsByCodePublicCodeEvents.
 type GetCashierAccountsByCodePublicCodeEventsParams struct {
 	Limit *LimitParam `form:"limit,omitempty" json:"limit,omitempty"`
@@ -314,17 +1030,62 @@ type GetMeEventsParams struct {
 	BeforeTs *BeforeTsParam `form:"beforeTs,omitempty" json:"beforeTs,omitempty"`
 }
 
+// PostAdminCampaignsJSONRequestBody defines body for PostAdminCampaigns for application/json ContentType.
+type PostAdminCampaignsJSONRequestBody = CampaignInput
+
+// PutAdminCampaignsCampaignIdJSONRequestBody defines body for PutAdminCampaignsCampaignId for application/json ContentType.
+type PutAdminCampaignsCampaignIdJSONRequestBody = CampaignInput
+
+// PutAdminLimitsJSONRequestBody defines body for PutAdminLimits for application/json ContentType.
+type PutAdminLimitsJSONRequestBody = VelocityLimitInput
+
 // PostAdminRulesetsJSONRequestBody defines body for PostAdminRulesets for application/json ContentType.
 type PostAdminRulesetsJSONRequestBody = CreateRulesetRequest
 
+// PostAdminRulesetsSimulationJSONRequestBody defines body for PostAdminRulesetsSimulation for application/json ContentType.
+type PostAdminRulesetsSimulationJSONRequestBody = RulesetSimulationRequest
+
+// PutAdminRulesetsRulesetIdJSONRequestBody defines body for PutAdminRulesetsRulesetId for application/json ContentType.
+type PutAdminRulesetsRulesetIdJSONRequestBody = CreateRulesetRequest
+
+// PostAdminRulesetsRulesetIdRejectJSONRequestBody defines body for PostAdminRulesetsRulesetIdReject for application/json ContentType.
+type PostAdminRulesetsRulesetIdRejectJSONRequestBody = RejectRulesetRequest
+
+// PostAdminUsersUserIdAdjustmentsJSONRequestBody defines body for PostAdminUsersUserIdAdjustments for application/json ContentType.
+type PostAdminUsersUserIdAdjustmentsJSONRequestBody = AdjustBalanceRequest
+
 // PostAuthLoginJSONRequestBody defines body for PostAuthLogin for application/json ContentType.
 type PostAuthLoginJSONRequestBody = LoginRequest
 
 // PostAuthRegisterJSONRequestBody defines body for PostAuthRegister for application/json ContentType.
 type PostAuthRegisterJSONRequestBody = RegisterRequest
 
+// PostCashierBatchJSONRequestBody defines body for PostCashierBatch for application/json ContentType.
+type PostCashierBatchJSONRequestBody = BatchRequest
+
+// PostCashierCheckoutJSONRequestBody defines body for PostCashierCheckout for application/json ContentType.
+type PostCashierCheckoutJSONRequestBody = CheckoutRequest
+
 // PostCashierEarnJSONRequestBody defines body for PostCashierEarn for application/json ContentType.
 type PostCashierEarnJSONRequestBody = EarnRequest
 
+// PostCashierHoldsJSONRequestBody defines body for PostCashierHolds for application/json ContentType.
+type PostCashierHoldsJSONRequestBody = HoldAuthorizeRequest
+
+// PostCashierHoldsHoldIdCaptureJSONRequestBody defines body for PostCashierHoldsHoldIdCapture for application/json ContentType.
+type PostCashierHoldsHoldIdCaptureJSONRequestBody = HoldCaptureRequest
+
+// PostCashierRefundJSONRequestBody defines body for PostCashierRefund for application/json ContentType.
+type PostCashierRefundJSONRequestBody = RefundRequest
+
 // PostCashierSpendJSONRequestBody defines body for PostCashierSpend for application/json ContentType.
 type PostCashierSpendJSONRequestBody = SpendRequest
+
+// PostCashierVoidJSONRequestBody defines body for PostCashierVoid for application/json ContentType.
+type PostCashierVoidJSONRequestBody = VoidRequest
+
+// PutMeBirthdayJSONRequestBody defines body for PutMeBirthday for application/json ContentType.
+type PutMeBirthdayJSONRequestBody = SetBirthdayRequest
+
+// PostMeTransfersJSONRequestBody defines body for PostMeTransfers for application/json ContentType.
+type PostMeTransfersJSONRequestBody = TransferRequest
@@ -14,14 +14,22 @@ import (
 	"Beanefits/internal/infra/jwtverifier"
 	kafkainfra "Beanefits/internal/infra/kafka"
 	"Beanefits/internal/infra/publiccode"
+	"Beanefits/internal/infra/referralcode"
 	"Beanefits/internal/infra/security"
 	"Beanefits/internal/repository/postgres"
 	"Beanefits/internal/repository/postgres/repo"
 	"Beanefits/internal/repository/postgres/sqlc/gen"
 	"Beanefits/internal/service/admin"
 	"Beanefits/internal/service/auth"
+	"Beanefits/internal/service/bonuses"
 	"Beanefits/internal/service/cashier"
 	"Beanefits/internal/service/client"
+	"Beanefits/internal/service/expiry"
+	"Beanefits/internal/service/limits"
+	"Beanefits/internal/service/lots"
+	"Beanefits/internal/service/referrals"
+	"Beanefits/internal/service/retention"
+	"Beanefits/internal/service/tiers"
 	"Beanefits/internal/service/validation"
 
 	"github.com/go-playground/validator/v10"
@@ -36,6 +44,12 @@ type App struct {
 	// Важно: не храним *Producer/*AutoPublisher в структуре, только cleanup-функции
 	stopKafka  func()
 	closeKafka func() error
+
+	stopExpiry      func()
+	stopHoldsExpiry func()
+	stopRetention   func()
+	stopTiers       func()
+	stopBonuses     func()
 }
 
 func New(ctx context.Context, cfg config.Config, log *slog.Logger) (*App, error) {
@@ -73,6 +87,15 @@ func New(ctx context.Context, cfg config.Config, log *slog.Logger) (*App, error)
 	rulesRepo := repo.NewRulesRepo(q)
 	opsRepo := repo.NewOperationsRepo(q)
 	eventsRepo := repo.NewEventsRepo(q)
+	lotsRepo := repo.NewLotsRepo(q)
+	adjustmentsRepo := repo.NewAdjustmentsRepo(q)
+	holdsRepo := repo.NewHoldsRepo(q)
+	limitsRepo := repo.NewLimitsRepo(q)
+	campaignsRepo := repo.NewCampaignsRepo(q)
+	receiptsRepo := repo.NewReceiptsRepo(q)
+	bonusesRepo := repo.NewBonusesRepo(q)
+	referralsRepo := repo.NewReferralsRepo(q)
+	levelChangesRepo := repo.NewLevelChangesRepo(q)
 
 	txm := postgres.NewTxManager(pool)
 
@@ -85,28 +108,47 @@ func New(ctx context.Context, cfg config.Config, log *slog.Logger) (*App, error)
 	// time source
 	now := func() time.Time { return time.Now().UTC() }
 
+	lotBook := lots.NewBook(lotsRepo, cfg.PointsLifetimeMonths)
+	bonusGranter := bonuses.NewGranter(accountsRepo, eventsRepo, bonusesRepo, lotBook)
+	referralProgram := referrals.NewProgram(accountsRepo, usersRepo, referralsRepo, bonusGranter, referralcode.NewGenerator(), referrals.Config{
+		MonthlyLimit: cfg.ReferralMonthlyLimit,
+	})
+	levelRecorder := tiers.NewRecorder(eventsRepo, levelChangesRepo, bonusGranter)
+
 	// services
 	authSvc := auth.New(auth.Deps{
-		DB:       pool,
-		TXM:      txm,
-		Users:    usersRepo,
-		Roles:    rolesRepo,
-		Accounts: accountsRepo,
-		Hasher:   hasher,
-		Issuer:   jwtIssuer,
-		CodeGen:  codeGen,
-		Now:      now,
-		Log:      l,
+		DB:        pool,
+		TXM:       txm,
+		Users:     usersRepo,
+		Roles:     rolesRepo,
+		Accounts:  accountsRepo,
+		Rules:     rulesRepo,
+		Bonuses:   bonusGranter,
+		Referrals: referralProgram,
+		Hasher:    hasher,
+		Issuer:    jwtIssuer,
+		CodeGen:   codeGen,
+		Now:       now,
+		Log:       l,
 	})
 
 	clientSvc := client.New(client.Deps{
-		DB:       pool,
-		Users:    usersRepo,
-		Roles:    rolesRepo,
-		Accounts: accountsRepo,
-		Events:   eventsRepo,
-		Now:      now,
-		Log:      l,
+		DB:         pool,
+		TXM:        txm,
+		Users:      usersRepo,
+		Roles:      rolesRepo,
+		Accounts:   accountsRepo,
+		Events:     eventsRepo,
+		Lots:       lotsRepo,
+		Operations: opsRepo,
+		LotBook:    lotBook,
+		Referrals:  referralProgram,
+		TransferLimits: client.TransferLimits{
+			DailyPoints: cfg.TransferDailyLimitPoints,
+			DailyCount:  cfg.TransferDailyLimitCount,
+		},
+		Now: now,
+		Log: l,
 	})
 
 	cashierSvc := cashier.New(cashier.Deps{
@@ -116,18 +158,96 @@ func New(ctx context.Context, cfg config.Config, log *slog.Logger) (*App, error)
 		Events:     eventsRepo,
 		Operations: opsRepo,
 		Rules:      rulesRepo,
+		Holds:      holdsRepo,
+		Lots:       lotBook,
+		Limits:     limits.NewGuard(limitsRepo, eventsRepo),
+		Receipts:   receiptsRepo,
+		Campaigns:  campaignsRepo,
+		Referrals:  referralProgram,
+		Levels:     levelRecorder,
+		VoidWindow: cfg.CashierVoidWindow,
+		HoldTTL:    cfg.HoldTTL,
 		Now:        now,
 		Log:        l,
 	})
 
 	adminSvc := admin.New(admin.Deps{
-		DB:    pool,
-		TXM:   txm,
-		Users: usersRepo,
-		Roles: rolesRepo,
-		Rules: rulesRepo,
-		Now:   now,
-		Log:   l,
+		DB:          pool,
+		TXM:         txm,
+		Users:       usersRepo,
+		Roles:       rolesRepo,
+		Rules:       rulesRepo,
+		Accounts:    accountsRepo,
+		Events:      eventsRepo,
+		Adjustments: adjustmentsRepo,
+		Lots:        lotBook,
+		Limits:      limitsRepo,
+		Campaigns:   campaignsRepo,
+		Receipts:    receiptsRepo,
+		Now:         now,
+		Log:         l,
+	})
+
+	// events written before the hash chain existed; later ones are linked on insert,
+	// so the app must not serve writes until every account's chain is complete
+	if _, err := adminSvc.BackfillEventHashes(ctx); err != nil {
+		l.ErrorContext(ctx, "app.init event hash backfill failed", "err", err)
+		pool.Close()
+		return nil, err
+	}
+
+	expirySvc := expiry.New(expiry.Deps{
+		TXM:      txm,
+		Accounts: accountsRepo,
+		Events:   eventsRepo,
+		LotsRepo: lotsRepo,
+		Holds:    holdsRepo,
+		Lots:     lotBook,
+		Now:      now,
+		Log:      l,
+	})
+
+	// migration 0014 aged pre-lot balances with 12 months; they must follow POINTS_LIFETIME_MONTHS
+	// before the expiry runner writes any of them off
+	if _, err := expirySvc.AgeLegacyLots(ctx); err != nil {
+		l.ErrorContext(ctx, "app.init legacy lot ageing failed", "err", err)
+		pool.Close()
+		return nil, err
+	}
+
+	tiersSvc := tiers.New(tiers.Deps{
+		TXM:      txm,
+		Rules:    rulesRepo,
+		Accounts: accountsRepo,
+		Events:   eventsRepo,
+		Levels:   levelRecorder,
+		Now:      now,
+		Log:      l,
+	})
+
+	bonusesSvc := bonuses.New(bonuses.Deps{
+		TXM:      txm,
+		Rules:    rulesRepo,
+		Accounts: accountsRepo,
+		Bonuses:  bonusesRepo,
+		Granter:  bonusGranter,
+		Now:      now,
+		Log:      l,
+	})
+
+	// void finds the SPEND by its operation record, so records must outlive the void window
+	if cfg.OperationsRetention > 0 && cfg.OperationsRetention < cfg.CashierVoidWindow {
+		l.WarnContext(ctx, "app.init operations retention is shorter than the void window",
+			"retention", cfg.OperationsRetention, "voidWindow", cfg.CashierVoidWindow)
+	}
+
+	retentionSvc := retention.New(retention.Deps{
+		TXM:          txm,
+		Operations:   opsRepo,
+		Retention:    cfg.OperationsRetention,
+		TombstoneTTL: cfg.OperationsTombstoneTTL,
+		Now:          now,
+		Log:          l,
 	})
 
 	// http handler
@@ -167,6 +287,12 @@ func New(ctx context.Context, cfg config.Config, log *slog.Logger) (*App, error)
 		HTTP:       srv,
 		stopKafka:  func() {},
 		closeKafka: prod.Close,
+		stopExpiry: func() {},
+
+		stopHoldsExpiry: func() {},
+		stopRetention:   func() {},
+		stopTiers:       func() {},
+		stopBonuses:     func() {},
 	}
 
 	if cfg.KafkaAutoPublish {
@@ -179,6 +305,31 @@ func New(ctx context.Context, cfg config.Config, log *slog.Logger) (*App, error)
 		app.stopKafka = stop
 	}
 
+	app.stopExpiry = expiry.Start(ctx, expirySvc, expiry.RunConfig{
+		Interval: cfg.PointsExpireInterval,
+		Batch:    cfg.PointsExpireBatch,
+	})
+
+	app.stopHoldsExpiry = expiry.StartHolds(ctx, expirySvc, expiry.RunConfig{
+		Interval: cfg.HoldsExpireInterval,
+		Batch:    cfg.HoldsExpireBatch,
+	})
+
+	app.stopRetention = retention.Start(ctx, retentionSvc, retention.RunConfig{
+		Interval: cfg.OperationsGCInterval,
+		Batch:    cfg.OperationsGCBatch,
+	})
+
+	app.stopTiers = tiers.Start(ctx, tiersSvc, tiers.RunConfig{
+		At:    cfg.TiersRequalifyAt,
+		Batch: cfg.TiersRequalifyBatch,
+	})
+
+	app.stopBonuses = bonuses.Start(ctx, bonusesSvc, bonuses.RunConfig{
+		At:    cfg.BirthdayBonusAt,
+		Batch: cfg.BirthdayBonusBatch,
+	})
+
 	l.InfoContext(ctx, "app.init ok", "httpAddr", httpCfg.Addr)
 
 	return app, nil
@@ -191,6 +342,26 @@ func (a *App) Close() error {
 		a.stopKafka()
 	}
 
+	if a.stopExpiry != nil {
+		a.stopExpiry()
+	}
+
+	if a.stopHoldsExpiry != nil {
+		a.stopHoldsExpiry()
+	}
+
+	if a.stopRetention != nil {
+		a.stopRetention()
+	}
+
+	if a.stopTiers != nil {
+		a.stopTiers()
+	}
+
+	if a.stopBonuses != nil {
+		a.stopBonuses()
+	}
+
 	if a.closeKafka != nil {
 		if err := a.closeKafka(); err != nil {
 			first = err
@@ -18,6 +18,32 @@ type Config struct {
 	JWTIssuer string
 	JWTTTL    time.Duration
 
+	CashierVoidWindow time.Duration
+
+	HoldTTL             time.Duration
+	HoldsExpireInterval time.Duration
+	HoldsExpireBatch    int
+
+	PointsLifetimeMonths int
+	PointsExpireInterval time.Duration
+	PointsExpireBatch    int
+
+	TiersRequalifyAt    time.Duration
+	TiersRequalifyBatch int
+
+	BirthdayBonusAt    time.Duration
+	BirthdayBonusBatch int
+
+	TransferDailyLimitPoints int
+	TransferDailyLimitCount  int
+
+	ReferralMonthlyLimit int
+
+	OperationsRetention    time.Duration
+	OperationsTombstoneTTL time.Duration
+	OperationsGCInterval   time.Duration
+	OperationsGCBatch      int
+
 	KafkaBrokers         []string
 	KafkaTopic           string
 	KafkaWriteTimeout    time.Duration
@@ -39,6 +65,32 @@ func MustLoad() Config {
 		JWTIssuer: getenv("JWT_ISSUER", "beanefits"),
 		JWTTTL:    mustDuration(getenv("JWT_TTL", "24h")),
 
+		CashierVoidWindow: mustDuration(getenv("CASHIER_VOID_WINDOW", "24h")),
+
+		HoldTTL:             mustDuration(getenv("HOLD_TTL", "24h")),
+		HoldsExpireInterval: mustDuration(getenv("HOLDS_EXPIRE_INTERVAL", "1m")),
+		HoldsExpireBatch:    mustInt(getenv("HOLDS_EXPIRE_BATCH", "100")),
+
+		PointsLifetimeMonths: mustInt(getenv("POINTS_LIFETIME_MONTHS", "12")),
+		PointsExpireInterval: mustDuration(getenv("POINTS_EXPIRE_INTERVAL", "1h")),
+		PointsExpireBatch:    mustInt(getenv("POINTS_EXPIRE_BATCH", "100")),
+
+		TiersRequalifyAt:    mustDuration(getenv("TIERS_REQUALIFY_AT", "3h")),
+		TiersRequalifyBatch: mustInt(getenv("TIERS_REQUALIFY_BATCH", "200")),
+
+		BirthdayBonusAt:    mustDuration(getenv("BIRTHDAY_BONUS_AT", "1h")),
+		BirthdayBonusBatch: mustInt(getenv("BIRTHDAY_BONUS_BATCH", "200")),
+
+		TransferDailyLimitPoints: mustInt(getenv("TRANSFER_DAILY_LIMIT_POINTS", "1000")),
+		TransferDailyLimitCount:  mustInt(getenv("TRANSFER_DAILY_LIMIT_COUNT", "5")),
+
+		ReferralMonthlyLimit: mustInt(getenv("REFERRAL_MONTHLY_LIMIT", "10")),
+
+		OperationsRetention:    mustDuration(getenv("OPERATIONS_RETENTION", "2160h")),
+		OperationsTombstoneTTL: mustDuration(getenv("OPERATIONS_TOMBSTONE_TTL", "8760h")),
+		OperationsGCInterval:   mustDuration(getenv("OPERATIONS_GC_INTERVAL", "10m")),
+		OperationsGCBatch:      mustInt(getenv("OPERATIONS_GC_BATCH", "500")),
+
 		KafkaBrokers:         mustCSVStrings(getenv("KAFKA_BROKERS", "localhost:9092")),
 		KafkaTopic:           getenv("KAFKA_TOPIC", "ints"),
 		KafkaWriteTimeout:    mustDuration(getenv("KAFKA_WRITE_TIMEOUT", "3s")),
@@ -2,6 +2,7 @@ package account
 
 import (
 	"Beanefits/internal/domain/errs"
+	"fmt"
 	"github.com/google/uuid"
 	"time"
 
@@ -27,6 +28,18 @@ type Account struct {
 	Balance    ledger.Points
 	TotalSpend ledger.Money
 	LevelCode  rules.LevelCode
+
+	// Held is reserved by active holds: still part of Balance (the ledger), but not spendable.
+	Held ledger.Points
+}
+
+// Available is the part of the balance that is not reserved by holds.
+// Balance can drop below Held (expiry, refund), then nothing is available.
+func (a Account) Available() ledger.Points {
+	if a.Balance <= a.Held {
+		return 0
+	}
+	return a.Balance - a.Held
 }
 
 // CanSpend checks spend invariants.
@@ -34,7 +47,7 @@ func (a Account) CanSpend(p ledger.Points) error {
 	if err := p.ValidatePositive(); err != nil {
 		return err
 	}
-	if a.Balance < p {
+	if a.Available() < p {
 		return ErrNotEnoughBalance
 	}
 	return nil
@@ -67,8 +80,146 @@ func (a Account) ApplyEarn(earned ledger.Points, purchase ledger.Money, newLevel
 	return a, ev, nil
 }
 
+// ApplyRefund reverts points earned by a purchase and decreases total spend by the refunded amount.
+// Usecase is expected to compute reverted points + new level based on ruleset.
+func (a Account) ApplyRefund(reverted ledger.Points, refund ledger.Money, newLevel rules.LevelCode, refEventID int64, rulesetID *int64, actorUserID *int64, ts time.Time) (Account, ledger.EventDraft, error) {
+	if err := reverted.ValidateNonNegative(); err != nil {
+		return Account{}, ledger.EventDraft{}, err
+	}
+	if refund.IsNegative() || refund.IsZero() {
+		return Account{}, ledger.EventDraft{}, ErrInvalidRefundAmount
+	}
+	if a.Balance < reverted {
+		return Account{}, ledger.EventDraft{}, ErrNotEnoughBalance
+	}
+	if a.TotalSpend.LT(refund) {
+		return Account{}, ledger.EventDraft{}, ErrInvalidRefundAmount
+	}
+	a.Balance -= reverted
+	a.TotalSpend = a.TotalSpend.Sub(refund)
+	a.LevelCode = newLevel
+
+	ev := ledger.NewRefundDraft(a.ID, reverted, a.Balance, refund, refEventID, rulesetID, actorUserID, ts)
+	return a, ev, nil
+}
+
+// ApplyVoid gives back points taken by a SPEND that is being voided.
+func (a Account) ApplyVoid(restored ledger.Points, refEventID int64, actorUserID *int64, ts time.Time) (Account, ledger.EventDraft, error) {
+	if err := restored.ValidatePositive(); err != nil {
+		return Account{}, ledger.EventDraft{}, err
+	}
+	a.Balance += restored
+
+	ev := ledger.NewVoidDraft(a.ID, restored, a.Balance, refEventID, actorUserID, ts)
+	return a, ev, nil
+}
+
+// ApplyExpire writes off points of expired lots.
+func (a Account) ApplyExpire(expired ledger.Points, ts time.Time) (Account, ledger.EventDraft, error) {
+	if err := expired.ValidatePositive(); err != nil {
+		return Account{}, ledger.EventDraft{}, err
+	}
+	if a.Balance < expired {
+		return Account{}, ledger.EventDraft{}, ErrNotEnoughBalance
+	}
+	a.Balance -= expired
+
+	ev := ledger.NewExpireDraft(a.ID, expired, a.Balance, ts)
+	return a, ev, nil
+}
+
+// ApplyAdjust changes the balance by a signed delta set manually by an admin.
+// TotalSpend and level are not touched. A debit may take only available points, held ones stay reserved.
+func (a Account) ApplyAdjust(delta ledger.Points, actorUserID *int64, ts time.Time) (Account, ledger.EventDraft, error) {
+	if delta == 0 {
+		return Account{}, ledger.EventDraft{}, fmt.Errorf("%w: %d", ledger.ErrInvalidPoints, delta)
+	}
+	if delta < 0 && a.Available() < -delta {
+		return Account{}, ledger.EventDraft{}, ErrNotEnoughBalance
+	}
+	a.Balance += delta
+
+	ev := ledger.NewAdjustDraft(a.ID, delta, a.Balance, actorUserID, ts)
+	return a, ev, nil
+}
+
+// ApplyTransferOut takes points sent to another account.
+func (a Account) ApplyTransferOut(p ledger.Points, actorUserID *int64, ts time.Time) (Account, ledger.EventDraft, error) {
+	if err := a.CanSpend(p); err != nil {
+		return Account{}, ledger.EventDraft{}, err
+	}
+	a.Balance -= p
+
+	ev := ledger.NewTransferOutDraft(a.ID, p, a.Balance, actorUserID, ts)
+	return a, ev, nil
+}
+
+// ApplyTransferIn adds points received from another account (refEventID is the sender's TRANSFER_OUT).
+func (a Account) ApplyTransferIn(p ledger.Points, refEventID int64, actorUserID *int64, ts time.Time) (Account, ledger.EventDraft, error) {
+	if err := p.ValidatePositive(); err != nil {
+		return Account{}, ledger.EventDraft{}, err
+	}
+	a.Balance += p
+
+	ev := ledger.NewTransferInDraft(a.ID, p, a.Balance, refEventID, actorUserID, ts)
+	return a, ev, nil
+}
+
+// ApplyBonus credits a welcome or birthday bonus; totalSpend and level are not touched.
+func (a Account) ApplyBonus(p ledger.Points, rulesetID *int64, ts time.Time) (Account, ledger.EventDraft, error) {
+	if err := p.ValidatePositive(); err != nil {
+		return Account{}, ledger.EventDraft{}, err
+	}
+	a.Balance += p
+
+	ev := ledger.NewBonusDraft(a.ID, p, a.Balance, rulesetID, ts)
+	return a, ev, nil
+}
+
+// ApplyAuthorizeHold reserves points for a later capture; the ledger balance is not changed.
+func (a Account) ApplyAuthorizeHold(p ledger.Points) (Account, error) {
+	if err := a.CanSpend(p); err != nil {
+		return Account{}, err
+	}
+	a.Held += p
+	return a, nil
+}
+
+// ApplyCaptureHold ends a hold of held points by spending captured of them (the rest is released).
+func (a Account) ApplyCaptureHold(held, captured ledger.Points, actorUserID *int64, ts time.Time) (Account, ledger.EventDraft, error) {
+	if err := captured.ValidatePositive(); err != nil {
+		return Account{}, ledger.EventDraft{}, err
+	}
+	if captured > held {
+		return Account{}, ledger.EventDraft{}, fmt.Errorf("%w: capture %d > held %d", ledger.ErrInvalidPoints, captured, held)
+	}
+	// the points reserved by this hold become available again; other holds stay reserved
+	a = a.releaseHeld(held)
+	if a.Available() < captured {
+		return Account{}, ledger.EventDraft{}, ErrNotEnoughBalance
+	}
+	a.Balance -= captured
+
+	ev := ledger.NewSpendDraft(a.ID, captured, a.Balance, actorUserID, ts)
+	return a, ev, nil
+}
+
+// ApplyReleaseHold returns held points of a released or expired hold to the available balance.
+func (a Account) ApplyReleaseHold(held ledger.Points) Account {
+	return a.releaseHeld(held)
+}
+
+func (a Account) releaseHeld(p ledger.Points) Account {
+	a.Held -= p
+	if a.Held < 0 {
+		a.Held = 0
+	}
+	return a
+}
+
 var (
 	ErrNotEnoughBalance      = errs.New(errs.CodeNotEnoughBalance, "not enough balance")
 	ErrInvalidPublicCode     = errs.New(errs.CodeInvalidPublicCode, "invalid public code format")
 	ErrInvalidPurchaseAmount = errs.New(errs.CodeInvalidPurchaseAmount, "purchase amount must be >= 0")
+	ErrInvalidRefundAmount   = errs.New(errs.CodeInvalidRefundAmount, "refund amount must be > 0 and not exceed purchase")
 )
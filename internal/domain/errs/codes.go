@@ -4,22 +4,52 @@ type Code string
 
 const (
 	CodeInvalidPhone          Code = "INVALID_PHONE"
+	CodeInvalidBirthday       Code = "INVALID_BIRTHDAY"
 	CodeInvalidPublicCode     Code = "INVALID_PUBLIC_CODE"
 	CodeInvalidPoints         Code = "INVALID_POINTS"
 	CodeNotEnoughBalance      Code = "NOT_ENOUGH_BALANCE"
 	CodeInvalidPurchaseAmount Code = "INVALID_PURCHASE_AMOUNT"
+	CodeInvalidReceipt        Code = "INVALID_RECEIPT"
+	CodeInvalidRefundAmount   Code = "INVALID_REFUND_AMOUNT"
+	CodeRefundNotAllowed      Code = "REFUND_NOT_ALLOWED"
+	CodeAlreadyVoided         Code = "ALREADY_VOIDED"
+	CodeVoidWindowExpired     Code = "VOID_WINDOW_EXPIRED"
+	CodeRedeemExceedsBill     Code = "REDEEM_EXCEEDS_BILL"
+	CodeInvalidAdjustment     Code = "INVALID_ADJUSTMENT"
+	CodeInvalidTransfer       Code = "INVALID_TRANSFER"
+	CodeTransferLimitExceeded Code = "TRANSFER_LIMIT_EXCEEDED"
+	CodeInvalidBatch          Code = "INVALID_BATCH"
+	CodeIdempotencyKeyReused  Code = "IDEMPOTENCY_KEY_REUSED"
+	CodeOperationExpired      Code = "OPERATION_EXPIRED"
+	CodeHoldNotActive         Code = "HOLD_NOT_ACTIVE"
+	CodeHoldExpired           Code = "HOLD_EXPIRED"
+	CodeVelocityLimitExceeded Code = "VELOCITY_LIMIT_EXCEEDED"
+	CodeInvalidLimit          Code = "INVALID_LIMIT"
+	CodeInvalidReferralCode   Code = "INVALID_REFERRAL_CODE"
+	CodeReferralLimitExceeded Code = "REFERRAL_LIMIT_EXCEEDED"
 
 	CodeInvalidUserID Code = "INVALID_USER_ID"
 
-	CodeInvalidRuleset Code = "INVALID_RULESET"
-	CodeRulesetNotFound Code = "RULESET_NOT_FOUND"
-	CodeInvalidLevels  Code = "INVALID_LEVELS"
-	CodeInvalidMoney   Code = "INVALID_MONEY"
+	CodeInvalidRuleset        Code = "INVALID_RULESET"
+	CodeRulesetNotFound       Code = "RULESET_NOT_FOUND"
+	CodeRulesetImmutable      Code = "RULESET_IMMUTABLE"
+	CodeRulesetStatusConflict Code = "RULESET_STATUS_CONFLICT"
+	CodeSelfApproval          Code = "SELF_APPROVAL"
+	CodeInvalidLevels         Code = "INVALID_LEVELS"
+	CodeInvalidSimulation     Code = "INVALID_SIMULATION"
+	CodeInvalidMoney          Code = "INVALID_MONEY"
+	CodeInvalidCampaign       Code = "INVALID_CAMPAIGN"
+	CodeCampaignInUse         Code = "CAMPAIGN_IN_USE"
 
 	CodePhoneAlreadyExists Code = "PHONE_ALREADY_EXISTS"
 	CodeInvalidCredentials Code = "INVALID_CREDENTIALS"
 	CodeUserInactive       Code = "USER_INACTIVE"
 	CodeAccountNotFound    Code = "ACCOUNT_NOT_FOUND"
+	CodeEventNotFound      Code = "EVENT_NOT_FOUND"
+	CodeOperationNotFound  Code = "OPERATION_NOT_FOUND"
+	CodeHoldNotFound       Code = "HOLD_NOT_FOUND"
+	CodeLimitNotFound      Code = "LIMIT_NOT_FOUND"
+	CodeCampaignNotFound   Code = "CAMPAIGN_NOT_FOUND"
 	CodeUserNotFound       Code = "USER_NOT_FOUND"
 	CodeRolesNotFound      Code = "ROLES_NOT_FOUND"
 
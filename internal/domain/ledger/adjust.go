@@ -0,0 +1,21 @@
+package ledger
+
+// AdjustReason explains why an admin changed a balance by hand.
+// The same codes are seeded into the adjustment_reasons table.
+type AdjustReason string
+
+const (
+	AdjustGoodwill     AdjustReason = "GOODWILL"
+	AdjustCompensation AdjustReason = "COMPENSATION"
+	AdjustCorrection   AdjustReason = "CORRECTION"
+	AdjustOther        AdjustReason = "OTHER"
+)
+
+func ParseAdjustReason(s string) (AdjustReason, error) {
+	switch r := AdjustReason(s); r {
+	case AdjustGoodwill, AdjustCompensation, AdjustCorrection, AdjustOther:
+		return r, nil
+	default:
+		return "", ErrInvalidAdjustReason
+	}
+}
@@ -5,5 +5,7 @@ import (
 )
 
 var (
-	ErrInvalidPoints = errs.New(errs.CodeInvalidPoints, "points must be positive (or non-negative where allowed)")
+	ErrInvalidPoints       = errs.New(errs.CodeInvalidPoints, "points must be positive (or non-negative where allowed)")
+	ErrInvalidAdjustReason = errs.New(errs.CodeInvalidAdjustment, "unknown adjustment reason code")
+	ErrLotsExhausted       = errs.New(errs.CodeInternal, "point lots do not cover the balance")
 )
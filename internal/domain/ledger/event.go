@@ -5,8 +5,16 @@ import "time"
 type EventType string
 
 const (
-	EventEarn  EventType = "EARN"
-	EventSpend EventType = "SPEND"
+	EventEarn         EventType = "EARN"
+	EventSpend        EventType = "SPEND"
+	EventRefund       EventType = "REFUND"
+	EventVoid         EventType = "VOID"
+	EventExpire       EventType = "EXPIRE"
+	EventAdjust       EventType = "ADJUST"
+	EventTransferOut  EventType = "TRANSFER_OUT"
+	EventTransferIn   EventType = "TRANSFER_IN"
+	EventBonus        EventType = "BONUS"
+	EventLevelChanged EventType = "LEVEL_CHANGED"
 )
 
 // EventDraft is a domain-level "event to be persisted" model.
@@ -19,6 +27,7 @@ type EventDraft struct {
 	AmountMoney  *Money
 	RulesetID    *int64
 	ActorUserID  *int64
+	RefEventID   *int64 // original event for compensating entries (REFUND, VOID) and TRANSFER_IN -> TRANSFER_OUT
 	Ts           time.Time
 }
 
@@ -48,3 +57,124 @@ func NewSpendDraft(accountID int64, spent Points, balanceAfter Points, actorUser
 		Ts:           ts,
 	}
 }
+
+// NewRefundDraft builds a compensating entry for a (partially) refunded EARN.
+// AmountMoney holds the refunded purchase amount, RefEventID points to the original EARN.
+func NewRefundDraft(accountID int64, reverted Points, balanceAfter Points, refund Money, refEventID int64, rulesetID *int64, actorUserID *int64, ts time.Time) EventDraft {
+	neg := -reverted
+	return EventDraft{
+		AccountID:    accountID,
+		Type:         EventRefund,
+		DeltaPoints:  neg,
+		BalanceAfter: balanceAfter,
+		AmountMoney:  &refund,
+		RulesetID:    rulesetID,
+		ActorUserID:  actorUserID,
+		RefEventID:   &refEventID,
+		Ts:           ts,
+	}
+}
+
+// NewVoidDraft builds a compensating entry that gives back points of a voided SPEND.
+func NewVoidDraft(accountID int64, restored Points, balanceAfter Points, refEventID int64, actorUserID *int64, ts time.Time) EventDraft {
+	return EventDraft{
+		AccountID:    accountID,
+		Type:         EventVoid,
+		DeltaPoints:  restored,
+		BalanceAfter: balanceAfter,
+		AmountMoney:  nil,
+		RulesetID:    nil,
+		ActorUserID:  actorUserID,
+		RefEventID:   &refEventID,
+		Ts:           ts,
+	}
+}
+
+// NewExpireDraft builds a system entry that writes off points of expired lots.
+func NewExpireDraft(accountID int64, expired Points, balanceAfter Points, ts time.Time) EventDraft {
+	neg := -expired
+	return EventDraft{
+		AccountID:    accountID,
+		Type:         EventExpire,
+		DeltaPoints:  neg,
+		BalanceAfter: balanceAfter,
+		AmountMoney:  nil,
+		RulesetID:    nil,
+		ActorUserID:  nil,
+		Ts:           ts,
+	}
+}
+
+// NewAdjustDraft builds a manual entry by an admin; delta is signed.
+func NewAdjustDraft(accountID int64, delta Points, balanceAfter Points, actorUserID *int64, ts time.Time) EventDraft {
+	return EventDraft{
+		AccountID:    accountID,
+		Type:         EventAdjust,
+		DeltaPoints:  delta,
+		BalanceAfter: balanceAfter,
+		AmountMoney:  nil,
+		RulesetID:    nil,
+		ActorUserID:  actorUserID,
+		Ts:           ts,
+	}
+}
+
+// NewTransferOutDraft builds the sender side of a points transfer.
+func NewTransferOutDraft(accountID int64, sent Points, balanceAfter Points, actorUserID *int64, ts time.Time) EventDraft {
+	neg := -sent
+	return EventDraft{
+		AccountID:    accountID,
+		Type:         EventTransferOut,
+		DeltaPoints:  neg,
+		BalanceAfter: balanceAfter,
+		AmountMoney:  nil,
+		RulesetID:    nil,
+		ActorUserID:  actorUserID,
+		Ts:           ts,
+	}
+}
+
+// NewTransferInDraft builds the recipient side of a points transfer; RefEventID points to the TRANSFER_OUT.
+func NewTransferInDraft(accountID int64, received Points, balanceAfter Points, refEventID int64, actorUserID *int64, ts time.Time) EventDraft {
+	return EventDraft{
+		AccountID:    accountID,
+		Type:         EventTransferIn,
+		DeltaPoints:  received,
+		BalanceAfter: balanceAfter,
+		AmountMoney:  nil,
+		RulesetID:    nil,
+		ActorUserID:  actorUserID,
+		RefEventID:   &refEventID,
+		Ts:           ts,
+	}
+}
+
+// NewBonusDraft builds a system entry that credits a welcome or birthday bonus of the ruleset.
+func NewBonusDraft(accountID int64, credited Points, balanceAfter Points, rulesetID *int64, ts time.Time) EventDraft {
+	return EventDraft{
+		AccountID:    accountID,
+		Type:         EventBonus,
+		DeltaPoints:  credited,
+		BalanceAfter: balanceAfter,
+		AmountMoney:  nil,
+		RulesetID:    rulesetID,
+		ActorUserID:  nil,
+		Ts:           ts,
+	}
+}
+
+// NewLevelChangedDraft builds a zero-delta entry that records a level transition of the account;
+// RefEventID points to the operation that caused it (nil for the nightly requalification).
+func NewLevelChangedDraft(accountID int64, balanceAfter Points, rulesetID *int64, actorUserID *int64, refEventID *int64, ts time.Time) EventDraft {
+	return EventDraft{
+		AccountID:    accountID,
+		Type:         EventLevelChanged,
+		DeltaPoints:  0,
+		BalanceAfter: balanceAfter,
+		AmountMoney:  nil,
+		RulesetID:    rulesetID,
+		ActorUserID:  actorUserID,
+		RefEventID:   refEventID,
+		Ts:           ts,
+	}
+}
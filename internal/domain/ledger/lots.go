@@ -0,0 +1,48 @@
+package ledger
+
+import "time"
+
+// Lot is a portion of earned points with its own expiration date.
+type Lot struct {
+	ID        int64
+	Remaining Points
+	ExpiresAt time.Time
+}
+
+// LotTake tells how many points are taken from a lot and what is left in it.
+type LotTake struct {
+	LotID     int64
+	Taken     Points
+	Remaining Points
+}
+
+// LotExpiresAt returns the expiration time of points earned at earnedAt.
+func LotExpiresAt(earnedAt time.Time, lifetimeMonths int) time.Time {
+	return earnedAt.AddDate(0, lifetimeMonths, 0)
+}
+
+// TakeFIFO takes p points from lots in the given order (oldest first).
+// It fails with ErrLotsExhausted when the lots hold less than p.
+func TakeFIFO(lots []Lot, p Points) ([]LotTake, error) {
+	if err := p.ValidateNonNegative(); err != nil {
+		return nil, err
+	}
+
+	out := make([]LotTake, 0, len(lots))
+	left := p
+	for _, l := range lots {
+		if left == 0 {
+			break
+		}
+		if l.Remaining <= 0 {
+			continue
+		}
+		take := min(l.Remaining, left)
+		left -= take
+		out = append(out, LotTake{LotID: l.ID, Taken: take, Remaining: l.Remaining - take})
+	}
+	if left > 0 {
+		return nil, ErrLotsExhausted
+	}
+	return out, nil
+}
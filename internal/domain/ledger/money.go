@@ -47,6 +47,9 @@ func (m Money) IsZero() bool     { return m.d.IsZero() }
 func (m Money) Add(x Money) Money { return Money{d: m.d.Add(x.d)} }
 func (m Money) Sub(x Money) Money { return Money{d: m.d.Sub(x.d)} }
 
+// MulInt multiplies the amount by an integer (e.g. points × money per point).
+func (m Money) MulInt(n int64) Money { return Money{d: m.d.Mul(decimal.NewFromInt(n))} }
+
 func (m Money) Cmp(x Money) int  { return m.d.Cmp(x.d) }
 func (m Money) GT(x Money) bool  { return m.Cmp(x) > 0 }
 func (m Money) GTE(x Money) bool { return m.Cmp(x) >= 0 }
@@ -0,0 +1,180 @@
+package rules
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"Beanefits/internal/domain/errs"
+	"Beanefits/internal/domain/ledger"
+)
+
+var ErrInvalidCampaign = errs.New(errs.CodeInvalidCampaign, "invalid campaign")
+
+// Schedule says when a campaign is active; dates, weekdays and times are taken in Location.
+type Schedule struct {
+	StartsOn time.Time      // only the date is used
+	EndsOn   *time.Time     // inclusive; nil: open-ended
+	Weekdays []time.Weekday // empty: every day
+	TimeFrom *time.Duration // [TimeFrom, TimeTo) as time of day; nil: all day
+	TimeTo   *time.Duration // TimeFrom > TimeTo wraps past midnight (e.g. 22:00-02:00)
+	Location *time.Location
+}
+
+// Campaign is a time-boxed earn promotion on top of the ruleset:
+// it either multiplies the earned points or adds a flat bonus.
+type Campaign struct {
+	ID          int64
+	Schedule    Schedule
+	Multiplier  *decimal.Decimal
+	BonusPoints ledger.Points
+}
+
+// AppliedCampaign is a campaign that took part in an EARN and the points it added.
+type AppliedCampaign struct {
+	CampaignID  int64
+	BonusPoints ledger.Points
+}
+
+// Validate checks the invariants of a campaign definition.
+func (c Campaign) Validate() error {
+	s := c.Schedule
+	if s.Location == nil {
+		return fmt.Errorf("%w: timezone is required", ErrInvalidCampaign)
+	}
+	if s.EndsOn != nil && dateOf(*s.EndsOn).Before(dateOf(s.StartsOn)) {
+		return fmt.Errorf("%w: endsOn is before startsOn", ErrInvalidCampaign)
+	}
+	seen := make(map[time.Weekday]struct{}, len(s.Weekdays))
+	for _, d := range s.Weekdays {
+		if d < time.Sunday || d > time.Saturday {
+			return fmt.Errorf("%w: unknown weekday", ErrInvalidCampaign)
+		}
+		if _, ok := seen[d]; ok {
+			return fmt.Errorf("%w: duplicate weekday %s", ErrInvalidCampaign, d)
+		}
+		seen[d] = struct{}{}
+	}
+	if (s.TimeFrom == nil) != (s.TimeTo == nil) {
+		return fmt.Errorf("%w: timeFrom and timeTo go together", ErrInvalidCampaign)
+	}
+	if s.TimeFrom != nil {
+		for _, d := range []time.Duration{*s.TimeFrom, *s.TimeTo} {
+			if d < 0 || d >= 24*time.Hour {
+				return fmt.Errorf("%w: time of day out of range", ErrInvalidCampaign)
+			}
+		}
+		if *s.TimeFrom == *s.TimeTo {
+			return fmt.Errorf("%w: empty time window", ErrInvalidCampaign)
+		}
+	}
+	if (c.Multiplier == nil) == (c.BonusPoints == 0) {
+		return fmt.Errorf("%w: exactly one of multiplier and bonusPoints is required", ErrInvalidCampaign)
+	}
+	if c.Multiplier != nil && !c.Multiplier.GreaterThan(decimal.NewFromInt(1)) {
+		return fmt.Errorf("%w: multiplier must be > 1", ErrInvalidCampaign)
+	}
+	if c.BonusPoints < 0 {
+		return fmt.Errorf("%w: bonusPoints must be > 0", ErrInvalidCampaign)
+	}
+	return nil
+}
+
+// ActiveAt reports whether the schedule covers the moment t.
+func (s Schedule) ActiveAt(t time.Time) bool {
+	loc := s.Location
+	if loc == nil {
+		loc = time.UTC
+	}
+	lt := t.In(loc)
+
+	day := dateOf(lt)
+	if day.Before(dateOf(s.StartsOn)) {
+		return false
+	}
+	if s.EndsOn != nil && day.After(dateOf(*s.EndsOn)) {
+		return false
+	}
+
+	if len(s.Weekdays) > 0 {
+		found := false
+		for _, d := range s.Weekdays {
+			if d == lt.Weekday() {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	if s.TimeFrom == nil || s.TimeTo == nil {
+		return true
+	}
+	tod := time.Duration(lt.Hour())*time.Hour + time.Duration(lt.Minute())*time.Minute +
+		time.Duration(lt.Second())*time.Second + time.Duration(lt.Nanosecond())
+	from, to := *s.TimeFrom, *s.TimeTo
+	if from < to {
+		return tod >= from && tod < to
+	}
+	return tod >= from || tod < to
+}
+
+// ApplyCampaigns adds the rewards of active campaigns to the points earned under the ruleset.
+// Multipliers go first, in the given order, each on the running total (floor); flat bonuses are added
+// after them and only for a paid purchase. It returns the new total and the campaigns that took part.
+func ApplyCampaigns(earned ledger.Points, purchase ledger.Money, active []Campaign) (ledger.Points, []AppliedCampaign) {
+	total := earned
+	applied := make([]AppliedCampaign, 0, len(active))
+
+	for _, c := range active {
+		if c.Multiplier == nil {
+			continue
+		}
+		next := ledger.Points(int(decimal.NewFromInt(int64(total)).Mul(*c.Multiplier).Floor().IntPart()))
+		applied = append(applied, AppliedCampaign{CampaignID: c.ID, BonusPoints: next - total})
+		total = next
+	}
+
+	if purchase.GT(ledger.ZeroMoney()) {
+		for _, c := range active {
+			if c.Multiplier != nil {
+				continue
+			}
+			applied = append(applied, AppliedCampaign{CampaignID: c.ID, BonusPoints: c.BonusPoints})
+			total += c.BonusPoints
+		}
+	}
+
+	return total, applied
+}
+
+// ParseWeekday parses a three-letter weekday name (MON..SUN).
+func ParseWeekday(s string) (time.Weekday, bool) {
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		if strings.EqualFold(s, d.String()[:3]) {
+			return d, true
+		}
+	}
+	return 0, false
+}
+
+// ISOWeekday numbers weekdays from 1 (Monday) to 7 (Sunday).
+func ISOWeekday(d time.Weekday) int {
+	if d == time.Sunday {
+		return 7
+	}
+	return int(d)
+}
+
+// WeekdayFromISO is the inverse of ISOWeekday.
+func WeekdayFromISO(n int) time.Weekday {
+	return time.Weekday(n % 7)
+}
+
+func dateOf(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,135 @@
+package rules
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"Beanefits/internal/domain/ledger"
+)
+
+func dur(h, m int) *time.Duration {
+	d := time.Duration(h)*time.Hour + time.Duration(m)*time.Minute
+	return &d
+}
+
+func utc(s string) time.Time {
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func TestScheduleActiveAt(t *testing.T) {
+	msk := time.FixedZone("MSK", 3*60*60)
+	ends := time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC)
+
+	march := Schedule{StartsOn: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), EndsOn: &ends, Location: msk}
+
+	night := march
+	night.TimeFrom, night.TimeTo = dur(22, 0), dur(2, 0)
+
+	lunch := march
+	lunch.TimeFrom, lunch.TimeTo = dur(12, 0), dur(14, 30)
+
+	mondays := march
+	mondays.Weekdays = []time.Weekday{time.Monday}
+
+	tests := []struct {
+		name     string
+		schedule Schedule
+		at       string
+		want     bool
+	}{
+		{name: "first day starts at local midnight", schedule: march, at: "2026-02-28T21:00:00Z", want: true},
+		{name: "before the first local day", schedule: march, at: "2026-02-28T20:59:59Z", want: false},
+		{name: "last day is inclusive", schedule: march, at: "2026-03-31T20:59:59Z", want: true},
+		{name: "after the last local day", schedule: march, at: "2026-03-31T21:00:00Z", want: false},
+		{name: "open-ended", schedule: Schedule{StartsOn: march.StartsOn, Location: msk}, at: "2030-01-01T00:00:00Z", want: true},
+
+		{name: "window start is inclusive", schedule: lunch, at: "2026-03-10T09:00:00Z", want: true},
+		{name: "window end is exclusive", schedule: lunch, at: "2026-03-10T11:30:00Z", want: false},
+		{name: "window is in local time", schedule: lunch, at: "2026-03-10T12:30:00Z", want: false},
+
+		{name: "past midnight window, evening", schedule: night, at: "2026-03-10T19:30:00Z", want: true},
+		{name: "past midnight window, after midnight", schedule: night, at: "2026-03-10T22:30:00Z", want: true},
+		{name: "past midnight window, at its end", schedule: night, at: "2026-03-10T23:00:00Z", want: false},
+		{name: "past midnight window, before its start", schedule: night, at: "2026-03-10T18:59:59Z", want: false},
+		{name: "past midnight window, midday", schedule: night, at: "2026-03-10T09:00:00Z", want: false},
+
+		{name: "weekday in local time", schedule: mondays, at: "2026-03-08T21:30:00Z", want: true},
+		{name: "UTC Monday is local Tuesday", schedule: mondays, at: "2026-03-09T22:00:00Z", want: false},
+		{name: "UTC Sunday evening is local Sunday", schedule: mondays, at: "2026-03-08T20:30:00Z", want: false},
+
+		{
+			name:     "no location is UTC",
+			schedule: Schedule{StartsOn: march.StartsOn, TimeFrom: dur(22, 0), TimeTo: dur(2, 0)},
+			at:       "2026-03-10T22:30:00Z",
+			want:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.schedule.ActiveAt(utc(tt.at)); got != tt.want {
+				t.Errorf("ActiveAt(%s) = %v, want %v", tt.at, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyCampaigns(t *testing.T) {
+	x15 := decimal.RequireFromString("1.5")
+	x2 := decimal.NewFromInt(2)
+	bonus := Campaign{ID: 3, BonusPoints: 5}
+
+	tests := []struct {
+		name     string
+		earned   ledger.Points
+		purchase string
+		active   []Campaign
+		want     ledger.Points
+		applied  []AppliedCampaign
+	}{
+		{name: "none", earned: 10, purchase: "100", want: 10, applied: []AppliedCampaign{}},
+		{
+			name:     "multipliers stack, floored",
+			earned:   7,
+			purchase: "100",
+			active:   []Campaign{{ID: 1, Multiplier: &x15}, {ID: 2, Multiplier: &x2}},
+			want:     20,
+			applied:  []AppliedCampaign{{CampaignID: 1, BonusPoints: 3}, {CampaignID: 2, BonusPoints: 10}},
+		},
+		{
+			name:     "flat bonus after multipliers",
+			earned:   10,
+			purchase: "100",
+			active:   []Campaign{bonus, {ID: 1, Multiplier: &x2}},
+			want:     25,
+			applied:  []AppliedCampaign{{CampaignID: 1, BonusPoints: 10}, {CampaignID: 3, BonusPoints: 5}},
+		},
+		{
+			name:     "no flat bonus for an unpaid purchase",
+			earned:   0,
+			purchase: "0",
+			active:   []Campaign{bonus},
+			want:     0,
+			applied:  []AppliedCampaign{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, applied := ApplyCampaigns(tt.earned, ledger.MustMoney(tt.purchase), tt.active)
+			if got != tt.want {
+				t.Errorf("ApplyCampaigns = %d, want %d", got, tt.want)
+			}
+			if !reflect.DeepEqual(applied, tt.applied) {
+				t.Errorf("applied = %+v, want %+v", applied, tt.applied)
+			}
+		})
+	}
+}
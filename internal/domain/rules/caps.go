@@ -0,0 +1,53 @@
+package rules
+
+import (
+	"fmt"
+
+	"Beanefits/internal/domain/ledger"
+)
+
+// EarnCaps are the per-ruleset limits of what a purchase earns under the ruleset.
+// Campaign rewards are added on top of the capped points.
+type EarnCaps struct {
+	// MinPurchase is the smallest purchase that earns points; zero: every purchase earns.
+	MinPurchase ledger.Money
+	// MaxPointsPerPurchase caps the points of a single purchase; nil: no cap.
+	MaxPointsPerPurchase *ledger.Points
+	// MaxPointsPerDay caps the EARN points of an account per UTC day of the purchase ts; nil: no cap.
+	MaxPointsPerDay *ledger.Points
+}
+
+// Validate checks the caps: the minimum is non-negative and set caps are positive.
+func (c EarnCaps) Validate() error {
+	if c.MinPurchase.IsNegative() {
+		return fmt.Errorf("%w: minimum purchase must be >= 0", ErrInvalidRuleset)
+	}
+	if c.MaxPointsPerPurchase != nil && *c.MaxPointsPerPurchase <= 0 {
+		return fmt.Errorf("%w: max points per purchase must be > 0", ErrInvalidRuleset)
+	}
+	if c.MaxPointsPerDay != nil && *c.MaxPointsPerDay <= 0 {
+		return fmt.Errorf("%w: max points per day must be > 0", ErrInvalidRuleset)
+	}
+	return nil
+}
+
+// Apply limits the points computed for a purchase; earnedToday is what the account has
+// already earned under the ruleset that day (campaign rewards excluded, net of refunds).
+func (c EarnCaps) Apply(points ledger.Points, purchase ledger.Money, earnedToday ledger.Points) ledger.Points {
+	if purchase.LT(c.MinPurchase) {
+		return 0
+	}
+	if c.MaxPointsPerPurchase != nil && points > *c.MaxPointsPerPurchase {
+		points = *c.MaxPointsPerPurchase
+	}
+	if c.MaxPointsPerDay != nil {
+		left := *c.MaxPointsPerDay - earnedToday
+		if left < 0 {
+			left = 0
+		}
+		if points > left {
+			points = left
+		}
+	}
+	return points
+}
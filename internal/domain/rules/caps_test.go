@@ -0,0 +1,110 @@
+package rules
+
+import (
+	"testing"
+
+	"Beanefits/internal/domain/ledger"
+)
+
+func pointsPtr(p ledger.Points) *ledger.Points { return &p }
+
+func TestEarnCapsApply(t *testing.T) {
+	tests := []struct {
+		name        string
+		caps        EarnCaps
+		points      ledger.Points
+		purchase    string
+		earnedToday ledger.Points
+		want        ledger.Points
+	}{
+		{name: "no caps", caps: EarnCaps{MinPurchase: ledger.ZeroMoney()}, points: 10, purchase: "100", want: 10},
+		{name: "below minimum purchase", caps: EarnCaps{MinPurchase: ledger.MustMoney("100")}, points: 10, purchase: "99.99", want: 0},
+		{name: "at minimum purchase", caps: EarnCaps{MinPurchase: ledger.MustMoney("100")}, points: 10, purchase: "100.00", want: 10},
+		{
+			name:     "per purchase cap",
+			caps:     EarnCaps{MinPurchase: ledger.ZeroMoney(), MaxPointsPerPurchase: pointsPtr(5)},
+			points:   10,
+			purchase: "100",
+			want:     5,
+		},
+		{
+			name:     "under per purchase cap",
+			caps:     EarnCaps{MinPurchase: ledger.ZeroMoney(), MaxPointsPerPurchase: pointsPtr(50)},
+			points:   10,
+			purchase: "100",
+			want:     10,
+		},
+		{
+			name:        "daily cap takes what is left of the day",
+			caps:        EarnCaps{MinPurchase: ledger.ZeroMoney(), MaxPointsPerDay: pointsPtr(20)},
+			points:      10,
+			purchase:    "100",
+			earnedToday: 15,
+			want:        5,
+		},
+		{
+			name:        "daily cap already used up",
+			caps:        EarnCaps{MinPurchase: ledger.ZeroMoney(), MaxPointsPerDay: pointsPtr(20)},
+			points:      10,
+			purchase:    "100",
+			earnedToday: 20,
+			want:        0,
+		},
+		{
+			name:        "daily cap overrun by an earlier ruleset",
+			caps:        EarnCaps{MinPurchase: ledger.ZeroMoney(), MaxPointsPerDay: pointsPtr(20)},
+			points:      10,
+			purchase:    "100",
+			earnedToday: 25,
+			want:        0,
+		},
+		{
+			name:        "per purchase cap before the daily one",
+			caps:        EarnCaps{MinPurchase: ledger.ZeroMoney(), MaxPointsPerPurchase: pointsPtr(8), MaxPointsPerDay: pointsPtr(20)},
+			points:      10,
+			purchase:    "100",
+			earnedToday: 10,
+			want:        8,
+		},
+		{
+			name:        "daily cap below the per purchase one",
+			caps:        EarnCaps{MinPurchase: ledger.ZeroMoney(), MaxPointsPerPurchase: pointsPtr(8), MaxPointsPerDay: pointsPtr(20)},
+			points:      10,
+			purchase:    "100",
+			earnedToday: 15,
+			want:        5,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.caps.Apply(tt.points, ledger.MustMoney(tt.purchase), tt.earnedToday)
+			if got != tt.want {
+				t.Errorf("Apply = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEarnCapsValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		caps    EarnCaps
+		wantErr bool
+	}{
+		{name: "no caps", caps: EarnCaps{MinPurchase: ledger.ZeroMoney()}},
+		{name: "all set", caps: EarnCaps{MinPurchase: ledger.MustMoney("50"), MaxPointsPerPurchase: pointsPtr(1), MaxPointsPerDay: pointsPtr(1)}},
+		{name: "negative minimum", caps: EarnCaps{MinPurchase: ledger.MustMoney("-0.01")}, wantErr: true},
+		{name: "zero per purchase cap", caps: EarnCaps{MinPurchase: ledger.ZeroMoney(), MaxPointsPerPurchase: pointsPtr(0)}, wantErr: true},
+		{name: "negative daily cap", caps: EarnCaps{MinPurchase: ledger.ZeroMoney(), MaxPointsPerDay: pointsPtr(-1)}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.caps.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package rules
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/shopspring/decimal"
 
@@ -26,12 +27,29 @@ func ResolveLevel(totalSpend ledger.Money, levels []LevelRule) (LevelRule, error
 	return best, nil
 }
 
-// ComputeEarnPoints computes earned points using:
-// basePoints = floor(amountMoney / baseRubPerPoint)
-// earned = floor(basePoints * (percentEarn / 100))
+// QualificationSince returns the start of a rolling qualification window of windowDays ending at at.
+func QualificationSince(at time.Time, windowDays int) time.Time {
+	return at.AddDate(0, 0, -windowDays)
+}
+
+// Qualifies reports whether a purchase made at purchaseTs counts towards the level resolved at at.
+// A nil window means lifetime qualification: every purchase counts.
+func Qualifies(purchaseTs, at time.Time, windowDays *int) bool {
+	if windowDays == nil {
+		return true
+	}
+	return !purchaseTs.Before(QualificationSince(at, *windowDays))
+}
+
+// ComputeEarnPoints computes earned points with the rounding policy of the ruleset.
+// Rounding per step (round = the rounding mode; floor by default):
+// basePoints = round(amountMoney / baseRubPerPoint)
+// earned = round(basePoints * (percentEarn / 100))
+// Rounding at the end:
+// earned = round(amountMoney / baseRubPerPoint * (percentEarn / 100))
 //
 // Note: percent is determined by current level (usually based on totalSpend BEFORE this purchase).
-func ComputeEarnPoints(amountMoney ledger.Money, baseRubPerPoint ledger.Money, percentEarn Percent) (ledger.Points, error) {
+func ComputeEarnPoints(amountMoney ledger.Money, baseRubPerPoint ledger.Money, percentEarn Percent, rounding Rounding) (ledger.Points, error) {
 	if amountMoney.IsNegative() {
 		return 0, fmt.Errorf("%w: amountMoney", ErrInvalidRuleset)
 	}
@@ -42,15 +60,79 @@ func ComputeEarnPoints(amountMoney ledger.Money, baseRubPerPoint ledger.Money, p
 		return 0, fmt.Errorf("%w: percentEarn must be > 0", ErrInvalidRuleset)
 	}
 
-	// basePoints = floor(amount / base)
-	basePointsDec := amountMoney.Decimal().Div(baseRubPerPoint.Decimal()).Floor()
-	basePoints := basePointsDec.IntPart() // safe: floor => integer
+	hundred := decimal.NewFromInt(100)
+
+	if rounding.Stage == RoundAtEnd {
+		// one division, so no precision is lost before the only rounding
+		earnedDec := amountMoney.Decimal().
+			Mul(percentEarn.Decimal()).
+			Div(baseRubPerPoint.Decimal().Mul(hundred))
+		return ledger.Points(int(rounding.round(earnedDec).IntPart())), nil
+	}
+
+	// basePoints = round(amount / base)
+	basePointsDec := rounding.round(amountMoney.Decimal().Div(baseRubPerPoint.Decimal()))
+	basePoints := basePointsDec.IntPart() // safe: rounded => integer
 
-	// earned = floor(basePoints * percent / 100)
-	earnedDec := decimal.NewFromInt(basePoints).
+	// earned = round(basePoints * percent / 100)
+	earnedDec := rounding.round(decimal.NewFromInt(basePoints).
 		Mul(percentEarn.Decimal()).
-		Div(decimal.NewFromInt(100)).
-		Floor()
+		Div(hundred))
 
 	return ledger.Points(int(earnedDec.IntPart())), nil
 }
+
+// EarnPoints computes the points of a purchase earned at level lv under rs:
+// earnable at the level percent with the ruleset's rounding, then limited by its earn caps.
+func (rs Ruleset) EarnPoints(lv LevelRule, purchase, earnable ledger.Money, earnedToday ledger.Points) (ledger.Points, error) {
+	pts, err := ComputeEarnPoints(earnable, rs.BaseRubPerPoint, lv.PercentEarn, rs.Rounding)
+	if err != nil {
+		return 0, err
+	}
+	return rs.Caps.Apply(pts, purchase, earnedToday), nil
+}
+
+// ComputeRefundPoints computes points to revert for a (partial) refund of an EARN:
+// reverted = floor(earned * (refundedBefore + refund) / purchase) - revertedBefore
+//
+// The cumulative form guarantees that refunding the whole purchase (in one or several steps)
+// reverts exactly the earned points, no more and no less.
+func ComputeRefundPoints(earned ledger.Points, purchase ledger.Money, refundedBefore ledger.Money, revertedBefore ledger.Points, refund ledger.Money) (ledger.Points, error) {
+	if !refund.Decimal().GreaterThan(decimal.Zero) {
+		return 0, fmt.Errorf("%w: refund must be > 0", ErrInvalidRuleset)
+	}
+	if !purchase.Decimal().GreaterThan(decimal.Zero) {
+		return 0, fmt.Errorf("%w: purchase must be > 0", ErrInvalidRuleset)
+	}
+
+	cumulative := refundedBefore.Add(refund)
+	if cumulative.GT(purchase) {
+		return 0, fmt.Errorf("%w: refund exceeds purchase", ErrInvalidRuleset)
+	}
+	if cumulative.Cmp(purchase) == 0 {
+		return earned - revertedBefore, nil
+	}
+
+	totalDec := decimal.NewFromInt(int64(earned)).
+		Mul(cumulative.Decimal()).
+		Div(purchase.Decimal()).
+		Floor()
+
+	reverted := ledger.Points(int(totalDec.IntPart())) - revertedBefore
+	if reverted < 0 {
+		reverted = 0
+	}
+	return reverted, nil
+}
+
+// ComputeRedeemDiscount converts redeemed points to a money discount:
+// discount = points * redeemRubPerPoint
+func ComputeRedeemDiscount(points ledger.Points, redeemRubPerPoint ledger.Money) (ledger.Money, error) {
+	if points.IsNegative() {
+		return ledger.Money{}, fmt.Errorf("%w: points must be >= 0", ErrInvalidRuleset)
+	}
+	if !redeemRubPerPoint.Decimal().GreaterThan(decimal.Zero) {
+		return ledger.Money{}, fmt.Errorf("%w: redeemRubPerPoint must be > 0", ErrInvalidRuleset)
+	}
+	return redeemRubPerPoint.MulInt(int64(points)), nil
+}
@@ -0,0 +1,64 @@
+package rules
+
+import (
+	"testing"
+
+	"Beanefits/internal/domain/ledger"
+)
+
+func TestComputeRefundPoints(t *testing.T) {
+	tests := []struct {
+		name           string
+		earned         ledger.Points
+		purchase       string
+		refundedBefore string
+		revertedBefore ledger.Points
+		refund         string
+		want           ledger.Points
+		wantErr        bool
+	}{
+		{name: "whole purchase at once", earned: 100, purchase: "1000", refundedBefore: "0", refund: "1000", want: 100},
+		{name: "first third", earned: 100, purchase: "1000", refundedBefore: "0", refund: "333.33", want: 33},
+		{name: "second third", earned: 100, purchase: "1000", refundedBefore: "333.33", revertedBefore: 33, refund: "333.33", want: 33},
+		{name: "last third reverts the rest", earned: 100, purchase: "1000", refundedBefore: "666.66", revertedBefore: 66, refund: "333.34", want: 34},
+		{name: "small refund floors to zero", earned: 10, purchase: "1000", refundedBefore: "0", refund: "99.99", want: 0},
+		{name: "nothing earned", earned: 0, purchase: "1000", refundedBefore: "0", refund: "500", want: 0},
+		{name: "never negative", earned: 10, purchase: "100", refundedBefore: "0", revertedBefore: 5, refund: "10", want: 0},
+		{name: "exceeds the purchase", earned: 100, purchase: "1000", refundedBefore: "900", revertedBefore: 90, refund: "100.01", wantErr: true},
+		{name: "zero refund", earned: 100, purchase: "1000", refundedBefore: "0", refund: "0", wantErr: true},
+		{name: "zero purchase", earned: 0, purchase: "0", refundedBefore: "0", refund: "1", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ComputeRefundPoints(tt.earned, ledger.MustMoney(tt.purchase), ledger.MustMoney(tt.refundedBefore),
+				tt.revertedBefore, ledger.MustMoney(tt.refund))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ComputeRefundPoints error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ComputeRefundPoints = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestComputeRefundPointsStepsAddUp(t *testing.T) {
+	purchase := ledger.MustMoney("1000")
+	steps := []string{"0.01", "123.45", "400", "76.54", "400"}
+
+	var earned ledger.Points = 97
+	refunded, reverted := ledger.ZeroMoney(), ledger.Points(0)
+	for _, s := range steps {
+		refund := ledger.MustMoney(s)
+		p, err := ComputeRefundPoints(earned, purchase, refunded, reverted, refund)
+		if err != nil {
+			t.Fatalf("refund %s: %v", s, err)
+		}
+		refunded = refunded.Add(refund)
+		reverted += p
+	}
+	if reverted != earned {
+		t.Errorf("reverted %d over %d steps, want %d", reverted, len(steps), earned)
+	}
+}
@@ -0,0 +1,64 @@
+package rules
+
+import (
+	"fmt"
+
+	"github.com/shopspring/decimal"
+
+	"Beanefits/internal/domain/errs"
+	"Beanefits/internal/domain/ledger"
+)
+
+var ErrInvalidReceipt = errs.New(errs.CodeInvalidReceipt, "invalid receipt")
+
+// ReceiptLine is one itemized line of a purchase.
+type ReceiptLine struct {
+	SKU       string
+	Category  string
+	Quantity  int
+	LineTotal ledger.Money
+}
+
+// CategoryRates maps a receipt line category to its earn factor under a ruleset.
+type CategoryRates map[string]decimal.Decimal
+
+// Factor is the earn factor of the category; categories without a rate earn at the level percent (1).
+func (r CategoryRates) Factor(category string) decimal.Decimal {
+	if f, ok := r[category]; ok {
+		return f
+	}
+	return decimal.NewFromInt(1)
+}
+
+// ValidateReceipt checks the lines and that their totals add up to the purchase amount.
+func ValidateReceipt(lines []ReceiptLine, purchase ledger.Money) error {
+	total := ledger.ZeroMoney()
+	for i, l := range lines {
+		if l.Category == "" {
+			return fmt.Errorf("%w: lines[%d].category is required", ErrInvalidReceipt, i)
+		}
+		if l.Quantity <= 0 {
+			return fmt.Errorf("%w: lines[%d].quantity must be > 0", ErrInvalidReceipt, i)
+		}
+		if l.LineTotal.IsNegative() {
+			return fmt.Errorf("%w: lines[%d].lineTotal must be >= 0", ErrInvalidReceipt, i)
+		}
+		total = total.Add(l.LineTotal)
+	}
+	if total.Cmp(purchase) != 0 {
+		return fmt.Errorf("%w: line totals add up to %s, amountMoney is %s", ErrInvalidReceipt,
+			total.Decimal().StringFixed(2), purchase.Decimal().StringFixed(2))
+	}
+	return nil
+}
+
+// EarnableAmount is the part of the purchase that earns at the level percent:
+// sum(lineTotal * factor of its category), rounded down to kopecks.
+// Points are then computed on it with ComputeEarnPoints, so points are rounded once per receipt.
+func EarnableAmount(lines []ReceiptLine, rates CategoryRates) (ledger.Money, error) {
+	sum := decimal.Zero
+	for _, l := range lines {
+		sum = sum.Add(l.LineTotal.Decimal().Mul(rates.Factor(l.Category)))
+	}
+	return ledger.ParseMoney(sum.RoundFloor(2).StringFixed(2))
+}
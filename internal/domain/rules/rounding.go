@@ -0,0 +1,72 @@
+package rules
+
+import (
+	"fmt"
+
+	"github.com/shopspring/decimal"
+)
+
+// RoundingMode is how a fractional number of points is turned into whole points.
+type RoundingMode string
+
+const (
+	RoundFloor    RoundingMode = "FLOOR"     // always down
+	RoundHalfUp   RoundingMode = "HALF_UP"   // .5 goes up
+	RoundHalfEven RoundingMode = "HALF_EVEN" // banker's: .5 goes to the even neighbour
+)
+
+// RoundingStage is where earn points are rounded.
+type RoundingStage string
+
+const (
+	// RoundPerStep rounds the base points and again after the level percent is applied.
+	RoundPerStep RoundingStage = "PER_STEP"
+	// RoundAtEnd keeps the exact value through the computation and rounds the result once.
+	RoundAtEnd RoundingStage = "AT_END"
+)
+
+// Rounding is the rounding policy of a ruleset's earn computation.
+type Rounding struct {
+	Mode  RoundingMode
+	Stage RoundingStage
+}
+
+// DefaultRounding floors per step, which is how points were always computed.
+func DefaultRounding() Rounding {
+	return Rounding{Mode: RoundFloor, Stage: RoundPerStep}
+}
+
+// ParseRounding validates stored or requested mode and stage; empty values take the default.
+func ParseRounding(mode, stage string) (Rounding, error) {
+	r := DefaultRounding()
+	if mode != "" {
+		r.Mode = RoundingMode(mode)
+	}
+	if stage != "" {
+		r.Stage = RoundingStage(stage)
+	}
+
+	switch r.Mode {
+	case RoundFloor, RoundHalfUp, RoundHalfEven:
+	default:
+		return Rounding{}, fmt.Errorf("%w: unknown rounding mode %q", ErrInvalidRuleset, mode)
+	}
+	switch r.Stage {
+	case RoundPerStep, RoundAtEnd:
+	default:
+		return Rounding{}, fmt.Errorf("%w: unknown rounding stage %q", ErrInvalidRuleset, stage)
+	}
+	return r, nil
+}
+
+// round rounds a non-negative amount of points to a whole number.
+func (r Rounding) round(d decimal.Decimal) decimal.Decimal {
+	switch r.Mode {
+	case RoundHalfUp:
+		return d.Round(0)
+	case RoundHalfEven:
+		return d.RoundBank(0)
+	default:
+		return d.Floor()
+	}
+}
@@ -0,0 +1,107 @@
+package rules
+
+import (
+	"testing"
+
+	"Beanefits/internal/domain/ledger"
+)
+
+func mustPercent(t *testing.T, s string) Percent {
+	t.Helper()
+	p, err := ParsePercent(s)
+	if err != nil {
+		t.Fatalf("ParsePercent(%q): %v", s, err)
+	}
+	return p
+}
+
+func TestComputeEarnPointsRounding(t *testing.T) {
+	tests := []struct {
+		name    string
+		amount  string
+		base    string
+		percent string
+		mode    RoundingMode
+		stage   RoundingStage
+		want    ledger.Points
+	}{
+		{name: "floor per step", amount: "250", base: "100", percent: "110", mode: RoundFloor, stage: RoundPerStep, want: 2},
+		{name: "half up per step", amount: "250", base: "100", percent: "110", mode: RoundHalfUp, stage: RoundPerStep, want: 3},
+		{name: "half even per step", amount: "250", base: "100", percent: "110", mode: RoundHalfEven, stage: RoundPerStep, want: 2},
+		{name: "floor at end", amount: "250", base: "100", percent: "110", mode: RoundFloor, stage: RoundAtEnd, want: 2},
+		{name: "half up at end", amount: "250", base: "100", percent: "110", mode: RoundHalfUp, stage: RoundAtEnd, want: 3},
+		{name: "half even at end", amount: "250", base: "100", percent: "110", mode: RoundHalfEven, stage: RoundAtEnd, want: 3},
+		{name: "half even rounds base points up to even", amount: "350", base: "100", percent: "150", mode: RoundHalfEven, stage: RoundPerStep, want: 6},
+		{name: "per step loses the fraction of base points", amount: "350", base: "100", percent: "150", mode: RoundFloor, stage: RoundPerStep, want: 4},
+		{name: "at end keeps it", amount: "350", base: "100", percent: "150", mode: RoundFloor, stage: RoundAtEnd, want: 5},
+		{name: "half even at end, tie goes down to even", amount: "450", base: "100", percent: "100", mode: RoundHalfEven, stage: RoundAtEnd, want: 4},
+		{name: "half up at end, tie goes up", amount: "450", base: "100", percent: "100", mode: RoundHalfUp, stage: RoundAtEnd, want: 5},
+		{name: "kopecks", amount: "99.99", base: "10", percent: "100", mode: RoundHalfUp, stage: RoundPerStep, want: 10},
+		{name: "zero purchase", amount: "0", base: "100", percent: "110", mode: RoundHalfUp, stage: RoundAtEnd, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ComputeEarnPoints(ledger.MustMoney(tt.amount), ledger.MustMoney(tt.base),
+				mustPercent(t, tt.percent), Rounding{Mode: tt.mode, Stage: tt.stage})
+			if err != nil {
+				t.Fatalf("ComputeEarnPoints: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("ComputeEarnPoints = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestComputeEarnPointsInvalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		amount  string
+		base    string
+		percent string
+	}{
+		{name: "negative amount", amount: "-1", base: "100", percent: "100"},
+		{name: "zero base", amount: "100", base: "0", percent: "100"},
+		{name: "zero percent", amount: "100", base: "100", percent: "0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ComputeEarnPoints(ledger.MustMoney(tt.amount), ledger.MustMoney(tt.base),
+				mustPercent(t, tt.percent), DefaultRounding())
+			if err == nil {
+				t.Fatal("ComputeEarnPoints: want error")
+			}
+		})
+	}
+}
+
+func TestParseRounding(t *testing.T) {
+	tests := []struct {
+		name    string
+		mode    string
+		stage   string
+		want    Rounding
+		wantErr bool
+	}{
+		{name: "empty takes the default", want: DefaultRounding()},
+		{name: "mode only", mode: "HALF_EVEN", want: Rounding{Mode: RoundHalfEven, Stage: RoundPerStep}},
+		{name: "both", mode: "HALF_UP", stage: "AT_END", want: Rounding{Mode: RoundHalfUp, Stage: RoundAtEnd}},
+		{name: "unknown mode", mode: "CEIL", wantErr: true},
+		{name: "unknown stage", stage: "NEVER", wantErr: true},
+		{name: "modes are case sensitive", mode: "floor", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseRounding(tt.mode, tt.stage)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseRounding error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseRounding = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -34,6 +34,7 @@ type LevelRule struct {
 	LevelCode           LevelCode
 	ThresholdTotalSpend ledger.Money
 	PercentEarn         Percent
+	ReachedBonusPoints  ledger.Points // one-time bonus for first reaching the level, 0 = none
 }
 
 // Ruleset is a versioned rules configuration (no retroactive changes).
@@ -41,6 +42,8 @@ type Ruleset struct {
 	ID              int64
 	BaseRubPerPoint ledger.Money
 	Levels          []LevelRule // must be validated & sorted by ThresholdTotalSpend
+	Rounding        Rounding    // rounding of earn points; DefaultRounding() if not configured
+	Caps            EarnCaps    // minimum purchase and points caps; zero value: no limits
 }
 
 // ValidateLevels checks basic invariants for levels.
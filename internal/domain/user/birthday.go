@@ -0,0 +1,42 @@
+package user
+
+import (
+	"fmt"
+	"time"
+
+	"Beanefits/internal/domain/errs"
+)
+
+var ErrInvalidBirthday = errs.New(errs.CodeInvalidBirthday, "invalid birthday")
+
+const minBirthYear = 1900
+
+// ParseBirthday parses a "YYYY-MM-DD" birthday; it must not be after today.
+func ParseBirthday(s string, today time.Time) (time.Time, error) {
+	d, err := time.Parse(time.DateOnly, s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: expected YYYY-MM-DD", ErrInvalidBirthday)
+	}
+	if d.Year() < minBirthYear {
+		return time.Time{}, fmt.Errorf("%w: year must be >= %d", ErrInvalidBirthday, minBirthYear)
+	}
+	if d.After(today.UTC()) {
+		return time.Time{}, fmt.Errorf("%w: must not be in the future", ErrInvalidBirthday)
+	}
+	return d, nil
+}
+
+// BirthdayDays lists the birthdays ("MM-DD") celebrated on the UTC date of day.
+// In a non-leap year 29 February birthdays are celebrated on 28 February.
+func BirthdayDays(day time.Time) []string {
+	d := day.UTC()
+	days := []string{d.Format("01-02")}
+	if d.Month() == time.February && d.Day() == 28 && !isLeap(d.Year()) {
+		days = append(days, "02-29")
+	}
+	return days
+}
+
+func isLeap(year int) bool {
+	return year%4 == 0 && (year%100 != 0 || year%400 == 0)
+}
@@ -7,6 +7,8 @@ import (
 	"Beanefits/internal/api"
 	"Beanefits/internal/http/middleware"
 	"Beanefits/internal/service/dto"
+
+	openapi_types "github.com/oapi-codegen/runtime/types"
 )
 
 // ===== Admin: Users =====
@@ -40,6 +42,7 @@ func (h *Handler) GetAdminUsers(w http.ResponseWriter, r *http.Request, params a
 			Phone:     u.Phone,
 			Roles:     mapRolesToAPI(u.Roles),
 			IsActive:  u.IsActive,
+			Birthday:  birthdayToAPI(u.Birthday),
 			CreatedAt: u.CreatedAt,
 		})
 	}
@@ -61,6 +64,229 @@ func (h *Handler) DeleteAdminUsersUserId(w http.ResponseWriter, r *http.Request,
 	w.WriteHeader(http.StatusNoContent)
 }
 
+func (h *Handler) PostAdminUsersUserIdAdjustments(w http.ResponseWriter, r *http.Request, userId int64) {
+	actorUserID, ok := h.requireAdmin(w, r)
+	if !ok {
+		return
+	}
+
+	var req api.PostAdminUsersUserIdAdjustmentsJSONRequestBody
+	if err := DecodeJSON(r, &req); err != nil {
+		detail := err.Error()
+		WriteProblem(w, http.StatusUnprocessableEntity, "Validation error", &detail, ptr("INVALID_ADJUSTMENT"), instanceFromRequest(r))
+		return
+	}
+
+	out, err := h.adminSvc.AdjustBalance(r.Context(), actorUserID, dto.AdjustBalanceIn{
+		UserID:      userId,
+		DeltaPoints: req.DeltaPoints,
+		ReasonCode:  string(req.ReasonCode),
+		Comment:     req.Comment,
+	})
+	if err != nil {
+		h.WriteServiceError(w, r, err)
+		return
+	}
+
+	h.helpers.JSON(w, http.StatusOK, api.AdjustmentResult{
+		Event:      mapEvent(out.Event),
+		ReasonCode: api.AdjustReasonCode(out.ReasonCode),
+		Comment:    out.Comment,
+		Balance:    mapBalance(out.Balance),
+	})
+}
+
+// ===== Admin: Ledger =====
+
+func (h *Handler) GetAdminLedgerVerification(w http.ResponseWriter, r *http.Request, params api.GetAdminLedgerVerificationParams) {
+	_, ok := h.requireAdmin(w, r)
+	if !ok {
+		return
+	}
+
+	out, err := h.adminSvc.VerifyLedger(r.Context(), dto.VerifyLedgerIn{UserID: params.UserId})
+	if err != nil {
+		h.WriteServiceError(w, r, err)
+		return
+	}
+
+	resp := api.LedgerReport{
+		AccountsChecked: out.AccountsChecked,
+		EventsChecked:   out.EventsChecked,
+		Mismatches:      make([]api.LedgerMismatch, 0, len(out.Mismatches)),
+		CheckedAt:       out.CheckedAt,
+	}
+	for _, m := range out.Mismatches {
+		resp.Mismatches = append(resp.Mismatches, api.LedgerMismatch{
+			AccountId: m.AccountID,
+			UserId:    m.UserID,
+			EventId:   m.EventID,
+			Check:     api.LedgerCheck(m.Check),
+			Expected:  m.Expected,
+			Actual:    m.Actual,
+		})
+	}
+
+	h.helpers.JSON(w, http.StatusOK, resp)
+}
+
+// ===== Admin: Velocity limits =====
+
+func (h *Handler) GetAdminLimits(w http.ResponseWriter, r *http.Request) {
+	_, ok := h.requireAdmin(w, r)
+	if !ok {
+		return
+	}
+
+	out, err := h.adminSvc.ListLimits(r.Context())
+	if err != nil {
+		h.WriteServiceError(w, r, err)
+		return
+	}
+
+	resp := api.VelocityLimitsList{Items: make([]api.VelocityLimit, 0, len(out))}
+	for _, l := range out {
+		resp.Items = append(resp.Items, mapLimitToAPI(l))
+	}
+
+	h.helpers.JSON(w, http.StatusOK, resp)
+}
+
+func (h *Handler) PutAdminLimits(w http.ResponseWriter, r *http.Request) {
+	actorUserID, ok := h.requireAdmin(w, r)
+	if !ok {
+		return
+	}
+
+	var req api.PutAdminLimitsJSONRequestBody
+	if err := DecodeJSON(r, &req); err != nil {
+		detail := err.Error()
+		WriteProblem(w, http.StatusUnprocessableEntity, "Validation error", &detail, ptr("INVALID_LIMIT"), instanceFromRequest(r))
+		return
+	}
+
+	out, err := h.adminSvc.SetLimit(r.Context(), actorUserID, dto.SetLimitIn{
+		Kind:     dto.LimitKind(req.Kind),
+		OpType:   dto.OperationType(req.OpType),
+		MaxValue: req.MaxValue,
+	})
+	if err != nil {
+		h.WriteServiceError(w, r, err)
+		return
+	}
+
+	h.helpers.JSON(w, http.StatusOK, mapLimitToAPI(out))
+}
+
+func (h *Handler) DeleteAdminLimitsLimitId(w http.ResponseWriter, r *http.Request, limitId int64) {
+	_, ok := h.requireAdmin(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.adminSvc.DeleteLimit(r.Context(), limitId); err != nil {
+		h.WriteServiceError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ===== Admin: Campaigns =====
+
+func (h *Handler) GetAdminCampaigns(w http.ResponseWriter, r *http.Request) {
+	_, ok := h.requireAdmin(w, r)
+	if !ok {
+		return
+	}
+
+	out, err := h.adminSvc.ListCampaigns(r.Context())
+	if err != nil {
+		h.WriteServiceError(w, r, err)
+		return
+	}
+
+	resp := api.CampaignsList{Items: make([]api.Campaign, 0, len(out))}
+	for _, c := range out {
+		resp.Items = append(resp.Items, mapCampaignToAPI(c))
+	}
+
+	h.helpers.JSON(w, http.StatusOK, resp)
+}
+
+func (h *Handler) PostAdminCampaigns(w http.ResponseWriter, r *http.Request) {
+	actorUserID, ok := h.requireAdmin(w, r)
+	if !ok {
+		return
+	}
+
+	var req api.PostAdminCampaignsJSONRequestBody
+	if err := DecodeJSON(r, &req); err != nil {
+		detail := err.Error()
+		WriteProblem(w, http.StatusUnprocessableEntity, "Validation error", &detail, ptr("INVALID_CAMPAIGN"), instanceFromRequest(r))
+		return
+	}
+
+	out, err := h.adminSvc.CreateCampaign(r.Context(), actorUserID, mapCampaignInput(req))
+	if err != nil {
+		h.WriteServiceError(w, r, err)
+		return
+	}
+
+	h.helpers.JSON(w, http.StatusCreated, mapCampaignToAPI(out))
+}
+
+func (h *Handler) GetAdminCampaignsCampaignId(w http.ResponseWriter, r *http.Request, campaignId api.CampaignIdParam) {
+	_, ok := h.requireAdmin(w, r)
+	if !ok {
+		return
+	}
+
+	out, err := h.adminSvc.GetCampaign(r.Context(), campaignId)
+	if err != nil {
+		h.WriteServiceError(w, r, err)
+		return
+	}
+
+	h.helpers.JSON(w, http.StatusOK, mapCampaignToAPI(out))
+}
+
+func (h *Handler) PutAdminCampaignsCampaignId(w http.ResponseWriter, r *http.Request, campaignId api.CampaignIdParam) {
+	actorUserID, ok := h.requireAdmin(w, r)
+	if !ok {
+		return
+	}
+
+	var req api.PutAdminCampaignsCampaignIdJSONRequestBody
+	if err := DecodeJSON(r, &req); err != nil {
+		detail := err.Error()
+		WriteProblem(w, http.StatusUnprocessableEntity, "Validation error", &detail, ptr("INVALID_CAMPAIGN"), instanceFromRequest(r))
+		return
+	}
+
+	out, err := h.adminSvc.UpdateCampaign(r.Context(), actorUserID, campaignId, mapCampaignInput(req))
+	if err != nil {
+		h.WriteServiceError(w, r, err)
+		return
+	}
+
+	h.helpers.JSON(w, http.StatusOK, mapCampaignToAPI(out))
+}
+
+func (h *Handler) DeleteAdminCampaignsCampaignId(w http.ResponseWriter, r *http.Request, campaignId api.CampaignIdParam) {
+	_, ok := h.requireAdmin(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.adminSvc.DeleteCampaign(r.Context(), campaignId); err != nil {
+		h.WriteServiceError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
 // ===== Admin: Rulesets =====
 
 func (h *Handler) GetAdminRulesets(w http.ResponseWriter, r *http.Request, params api.GetAdminRulesetsParams) {
@@ -112,18 +338,7 @@ func (h *Handler) PostAdminRulesets(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	in := dto.CreateRulesetIn{
-		EffectiveFrom:   req.EffectiveFrom,
-		BaseRubPerPoint: req.BaseRubPerPoint,
-		Levels:          make([]dto.LevelRuleIn, 0, len(req.Levels)),
-	}
-	for _, lvl := range req.Levels {
-		in.Levels = append(in.Levels, dto.LevelRuleIn{
-			LevelCode:           string(lvl.LevelCode),
-			ThresholdTotalSpend: lvl.ThresholdTotalSpend,
-			PercentEarn:         lvl.PercentEarn,
-		})
-	}
+	in := mapCreateRulesetRequest(req)
 
 	out, err := h.adminSvc.CreateRuleset(r.Context(), actorUserID, in)
 	if err != nil {
@@ -149,6 +364,138 @@ func (h *Handler) GetAdminRulesetsCurrent(w http.ResponseWriter, r *http.Request
 	h.helpers.JSON(w, http.StatusOK, mapRulesetToAPI(out))
 }
 
+func (h *Handler) PostAdminRulesetsSimulation(w http.ResponseWriter, r *http.Request) {
+	_, ok := h.requireAdmin(w, r)
+	if !ok {
+		return
+	}
+
+	var req api.PostAdminRulesetsSimulationJSONRequestBody
+	if err := DecodeJSON(r, &req); err != nil {
+		detail := err.Error()
+		WriteProblem(w, http.StatusUnprocessableEntity, "Validation error", &detail, ptr("INVALID_SIMULATION"), instanceFromRequest(r))
+		return
+	}
+
+	in := dto.SimulateRulesetIn{
+		Draft:    mapCreateRulesetRequest(req.Draft),
+		From:     req.From,
+		To:       req.To,
+		TopLimit: derefInt(req.TopLimit, 0),
+	}
+
+	out, err := h.adminSvc.SimulateRuleset(r.Context(), in)
+	if err != nil {
+		h.WriteServiceError(w, r, err)
+		return
+	}
+
+	h.helpers.JSON(w, http.StatusOK, mapRulesetSimulationToAPI(out))
+}
+
+func (h *Handler) GetAdminRulesetsRulesetId(w http.ResponseWriter, r *http.Request, rulesetId api.RulesetIdParam) {
+	_, ok := h.requireAdmin(w, r)
+	if !ok {
+		return
+	}
+
+	out, err := h.adminSvc.GetRuleset(r.Context(), rulesetId)
+	if err != nil {
+		h.WriteServiceError(w, r, err)
+		return
+	}
+
+	h.helpers.JSON(w, http.StatusOK, mapRulesetToAPI(out))
+}
+
+func (h *Handler) PutAdminRulesetsRulesetId(w http.ResponseWriter, r *http.Request, rulesetId api.RulesetIdParam) {
+	actorUserID, ok := h.requireAdmin(w, r)
+	if !ok {
+		return
+	}
+
+	var req api.PutAdminRulesetsRulesetIdJSONRequestBody
+	if err := DecodeJSON(r, &req); err != nil {
+		detail := err.Error()
+		WriteProblem(w, http.StatusUnprocessableEntity, "Validation error", &detail, ptr("INVALID_RULESET"), instanceFromRequest(r))
+		return
+	}
+
+	out, err := h.adminSvc.UpdateRuleset(r.Context(), actorUserID, rulesetId, mapCreateRulesetRequest(req))
+	if err != nil {
+		h.WriteServiceError(w, r, err)
+		return
+	}
+
+	h.helpers.JSON(w, http.StatusOK, mapRulesetToAPI(out))
+}
+
+func (h *Handler) DeleteAdminRulesetsRulesetId(w http.ResponseWriter, r *http.Request, rulesetId api.RulesetIdParam) {
+	_, ok := h.requireAdmin(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.adminSvc.DeleteRuleset(r.Context(), rulesetId); err != nil {
+		h.WriteServiceError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) PostAdminRulesetsRulesetIdSubmit(w http.ResponseWriter, r *http.Request, rulesetId api.RulesetIdParam) {
+	actorUserID, ok := h.requireAdmin(w, r)
+	if !ok {
+		return
+	}
+
+	out, err := h.adminSvc.SubmitRuleset(r.Context(), actorUserID, rulesetId)
+	if err != nil {
+		h.WriteServiceError(w, r, err)
+		return
+	}
+
+	h.helpers.JSON(w, http.StatusOK, mapRulesetToAPI(out))
+}
+
+func (h *Handler) PostAdminRulesetsRulesetIdApprove(w http.ResponseWriter, r *http.Request, rulesetId api.RulesetIdParam) {
+	actorUserID, ok := h.requireAdmin(w, r)
+	if !ok {
+		return
+	}
+
+	out, err := h.adminSvc.ApproveRuleset(r.Context(), actorUserID, rulesetId)
+	if err != nil {
+		h.WriteServiceError(w, r, err)
+		return
+	}
+
+	h.helpers.JSON(w, http.StatusOK, mapRulesetToAPI(out))
+}
+
+func (h *Handler) PostAdminRulesetsRulesetIdReject(w http.ResponseWriter, r *http.Request, rulesetId api.RulesetIdParam) {
+	actorUserID, ok := h.requireAdmin(w, r)
+	if !ok {
+		return
+	}
+
+	var req api.PostAdminRulesetsRulesetIdRejectJSONRequestBody
+	if err := DecodeJSON(r, &req); err != nil {
+		detail := err.Error()
+		WriteProblem(w, http.StatusUnprocessableEntity, "Validation error", &detail, ptr("INVALID_RULESET"), instanceFromRequest(r))
+		return
+	}
+
+	out, err := h.adminSvc.RejectRuleset(r.Context(), actorUserID, rulesetId, dto.RejectRulesetIn{Comment: req.Comment})
+	if err != nil {
+		h.WriteServiceError(w, r, err)
+		return
+	}
+
+	h.helpers.JSON(w, http.StatusOK, mapRulesetToAPI(out))
+}
+
 // ===== RBAC =====
 
 func (h *Handler) requireAdmin(w http.ResponseWriter, r *http.Request) (int64, bool) {
@@ -187,6 +534,81 @@ func mapRolesToAPI(in []dto.RoleCode) []api.RoleCode {
 	return out
 }
 
+func mapCreateRulesetRequest(req api.CreateRulesetRequest) dto.CreateRulesetIn {
+	in := dto.CreateRulesetIn{
+		EffectiveFrom:     req.EffectiveFrom,
+		BaseRubPerPoint:   req.BaseRubPerPoint,
+		RedeemRubPerPoint: req.RedeemRubPerPoint,
+		Levels:            make([]dto.LevelRuleIn, 0, len(req.Levels)),
+
+		QualificationWindowDays: req.QualificationWindowDays,
+		WelcomeBonusPoints:      derefInt(req.WelcomeBonusPoints, 0),
+		BirthdayBonusPoints:     derefInt(req.BirthdayBonusPoints, 0),
+		ReferrerBonusPoints:     derefInt(req.ReferrerBonusPoints, 0),
+		RefereeBonusPoints:      derefInt(req.RefereeBonusPoints, 0),
+		MinEarnAmount:           req.MinEarnAmount,
+		MaxPointsPerPurchase:    req.MaxPointsPerPurchase,
+		MaxPointsPerDay:         req.MaxPointsPerDay,
+	}
+	if req.RoundingMode != nil {
+		in.RoundingMode = string(*req.RoundingMode)
+	}
+	if req.RoundingStage != nil {
+		in.RoundingStage = string(*req.RoundingStage)
+	}
+	if req.CategoryRates != nil {
+		for _, cr := range *req.CategoryRates {
+			in.CategoryRates = append(in.CategoryRates, dto.CategoryRateIn{
+				Category:   cr.Category,
+				EarnFactor: cr.EarnFactor,
+			})
+		}
+	}
+	for _, lvl := range req.Levels {
+		in.Levels = append(in.Levels, dto.LevelRuleIn{
+			LevelCode:           string(lvl.LevelCode),
+			ThresholdTotalSpend: lvl.ThresholdTotalSpend,
+			PercentEarn:         lvl.PercentEarn,
+			ReachedBonusPoints:  derefInt(lvl.ReachedBonusPoints, 0),
+		})
+	}
+	return in
+}
+
+func mapRulesetSimulationToAPI(in dto.SimulateRulesetOut) api.RulesetSimulation {
+	out := api.RulesetSimulation{
+		From:            in.From,
+		To:              in.To,
+		ActualRulesetId: in.ActualRulesetID,
+		Purchases:       in.Purchases,
+		Accounts:        in.Accounts,
+		ActualPoints:    in.ActualPoints,
+		DraftPoints:     in.DraftPoints,
+		ActualLevels:    mapLevelCountsToAPI(in.ActualLevels),
+		DraftLevels:     mapLevelCountsToAPI(in.DraftLevels),
+		TopAccounts:     make([]api.SimulatedAccount, 0, len(in.TopAccounts)),
+	}
+	for _, a := range in.TopAccounts {
+		out.TopAccounts = append(out.TopAccounts, api.SimulatedAccount{
+			AccountId:    a.AccountID,
+			PublicCode:   api.PublicCode(a.PublicCode),
+			ActualPoints: a.ActualPoints,
+			DraftPoints:  a.DraftPoints,
+			ActualLevel:  api.LevelCode(a.ActualLevel),
+			DraftLevel:   api.LevelCode(a.DraftLevel),
+		})
+	}
+	return out
+}
+
+func mapLevelCountsToAPI(in []dto.LevelCountOut) []api.LevelCount {
+	out := make([]api.LevelCount, 0, len(in))
+	for _, c := range in {
+		out = append(out, api.LevelCount{LevelCode: api.LevelCode(c.LevelCode), Accounts: c.Accounts})
+	}
+	return out
+}
+
 func mapRulesetToAPI(in dto.RulesetOut) api.Ruleset {
 	levels := make([]api.LevelRule, 0, len(in.Levels))
 	for _, lvl := range in.Levels {
@@ -195,14 +617,108 @@ func mapRulesetToAPI(in dto.RulesetOut) api.Ruleset {
 			LevelCode:           lvl.LevelCode,
 			ThresholdTotalSpend: lvl.ThresholdTotalSpend,
 			PercentEarn:         lvl.PercentEarn,
+			ReachedBonusPoints:  lvl.ReachedBonusPoints,
+		})
+	}
+	rates := make([]api.CategoryRate, 0, len(in.CategoryRates))
+	for _, cr := range in.CategoryRates {
+		rates = append(rates, api.CategoryRate{
+			Category:   cr.Category,
+			EarnFactor: cr.EarnFactor,
+		})
+	}
+	history := make([]api.RulesetHistoryEntry, 0, len(in.History))
+	for _, h := range in.History {
+		history = append(history, api.RulesetHistoryEntry{
+			Action:      api.RulesetHistoryEntryAction(h.Action),
+			ActorUserId: h.ActorUserID,
+			Comment:     h.Comment,
+			At:          h.At,
 		})
 	}
 	return api.Ruleset{
-		Id:              in.ID,
-		EffectiveFrom:   in.EffectiveFrom,
-		BaseRubPerPoint: in.BaseRubPerPoint,
-		Levels:          levels,
-		CreatedAt:       in.CreatedAt,
+		Id:                in.ID,
+		EffectiveFrom:     in.EffectiveFrom,
+		EffectiveTo:       in.EffectiveTo,
+		BaseRubPerPoint:   in.BaseRubPerPoint,
+		RedeemRubPerPoint: in.RedeemRubPerPoint,
+		Levels:            levels,
+		CreatedAt:         in.CreatedAt,
+
+		QualificationWindowDays: in.QualificationWindowDays,
+		CategoryRates:           rates,
+		WelcomeBonusPoints:      in.WelcomeBonusPoints,
+		BirthdayBonusPoints:     in.BirthdayBonusPoints,
+		ReferrerBonusPoints:     in.ReferrerBonusPoints,
+		RefereeBonusPoints:      in.RefereeBonusPoints,
+		RoundingMode:            api.RoundingMode(in.RoundingMode),
+		RoundingStage:           api.RoundingStage(in.RoundingStage),
+		MinEarnAmount:           in.MinEarnAmount,
+		MaxPointsPerPurchase:    in.MaxPointsPerPurchase,
+		MaxPointsPerDay:         in.MaxPointsPerDay,
+
+		Status:  api.RulesetStatus(in.Status),
+		History: history,
+	}
+}
+
+func mapLimitToAPI(in dto.LimitOut) api.VelocityLimit {
+	return api.VelocityLimit{
+		Id:        in.ID,
+		Kind:      api.VelocityLimitKind(in.Kind),
+		OpType:    api.VelocityLimitOpType(in.OpType),
+		MaxValue:  in.MaxValue,
+		UpdatedBy: in.UpdatedBy,
+		UpdatedAt: in.UpdatedAt,
+	}
+}
+
+func mapCampaignInput(req api.CampaignInput) dto.CampaignIn {
+	in := dto.CampaignIn{
+		Name:        req.Name,
+		StartsOn:    req.StartsOn.Time,
+		TimeFrom:    req.TimeFrom,
+		TimeTo:      req.TimeTo,
+		Timezone:    derefString(req.Timezone, ""),
+		Multiplier:  req.Multiplier,
+		BonusPoints: req.BonusPoints,
+	}
+	if req.EndsOn != nil {
+		endsOn := req.EndsOn.Time
+		in.EndsOn = &endsOn
+	}
+	if req.Weekdays != nil {
+		for _, d := range *req.Weekdays {
+			in.Weekdays = append(in.Weekdays, string(d))
+		}
+	}
+	return in
+}
+
+func mapCampaignToAPI(in dto.CampaignOut) api.Campaign {
+	weekdays := make([]api.CampaignWeekday, 0, len(in.Weekdays))
+	for _, d := range in.Weekdays {
+		weekdays = append(weekdays, api.CampaignWeekday(d))
+	}
+
+	var endsOn *openapi_types.Date
+	if in.EndsOn != nil {
+		endsOn = &openapi_types.Date{Time: *in.EndsOn}
+	}
+
+	return api.Campaign{
+		Id:          in.ID,
+		Name:        in.Name,
+		StartsOn:    openapi_types.Date{Time: in.StartsOn},
+		EndsOn:      endsOn,
+		Weekdays:    weekdays,
+		TimeFrom:    in.TimeFrom,
+		TimeTo:      in.TimeTo,
+		Timezone:    in.Timezone,
+		Multiplier:  in.Multiplier,
+		BonusPoints: in.BonusPoints,
+		UpdatedBy:   in.UpdatedBy,
+		UpdatedAt:   in.UpdatedAt,
 	}
 }
 
@@ -24,8 +24,10 @@ func (h *Handler) PostAuthRegister(w http.ResponseWriter, r *http.Request) {
 	}
 
 	out, err := h.authSvc.RegisterClient(r.Context(), sdto.RegisterIn{
-		Phone:    string(req.Phone),
-		Password: req.Password,
+		Phone:        string(req.Phone),
+		Password:     req.Password,
+		Birthday:     birthdayFromAPI(req.Birthday),
+		ReferralCode: req.ReferralCode,
 	})
 	if err != nil {
 		h.WriteServiceError(w, r, err)
@@ -69,6 +71,7 @@ func mapAuthOutToAPI(out sdto.AuthOut) api.AuthResponse {
 		Phone:     api.Phone(out.User.Phone),
 		Roles:     mapRolesToAPI(out.User.Roles),
 		IsActive:  out.User.IsActive,
+		Birthday:  birthdayToAPI(out.User.Birthday),
 		CreatedAt: out.User.CreatedAt,
 	}
 
@@ -1,7 +1,9 @@
 package handler
 
 import (
+	"errors"
 	"github.com/google/uuid"
+	"io"
 	"net/http"
 
 	"Beanefits/internal/api"
@@ -53,6 +55,32 @@ func (h *Handler) GetCashierAccountsByCodePublicCodeEvents(
 	h.helpers.JSON(w, http.StatusOK, mapEventsPage(out))
 }
 
+// GET /cashier/accounts/by-code/{publicCode}/earn-quote
+func (h *Handler) GetCashierAccountsByCodePublicCodeEarnQuote(
+	w http.ResponseWriter,
+	r *http.Request,
+	publicCode api.PublicCode,
+	params api.GetCashierAccountsByCodePublicCodeEarnQuoteParams,
+) {
+	_, ok := h.requireCashier(w, r)
+	if !ok {
+		return
+	}
+
+	in := sdto.EarnQuoteIn{
+		PublicCode:  string(publicCode),
+		AmountMoney: params.AmountMoney,
+	}
+
+	out, err := h.cashierSvc.QuoteEarn(r.Context(), in)
+	if err != nil {
+		h.WriteServiceError(w, r, err)
+		return
+	}
+
+	h.helpers.JSON(w, http.StatusOK, mapEarnQuote(out))
+}
+
 // POST /cashier/earn
 func (h *Handler) PostCashierEarn(w http.ResponseWriter, r *http.Request) {
 	actorUserID, ok := h.requireCashier(w, r)
@@ -67,12 +95,24 @@ func (h *Handler) PostCashierEarn(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	out, err := h.cashierSvc.Earn(r.Context(), actorUserID, sdto.EarnIn{
+	in := sdto.EarnIn{
 		OperationID: req.OperationId.String(),
 		PublicCode:  string(req.PublicCode),
 		AmountMoney: req.AmountMoney,
 		Ts:          req.Ts,
-	})
+	}
+	if req.Lines != nil {
+		for _, l := range *req.Lines {
+			in.Lines = append(in.Lines, sdto.ReceiptLineIn{
+				SKU:       l.Sku,
+				Category:  l.Category,
+				Quantity:  l.Quantity,
+				LineTotal: l.LineTotal,
+			})
+		}
+	}
+
+	out, err := h.cashierSvc.Earn(r.Context(), actorUserID, in)
 	if err != nil {
 		h.WriteServiceError(w, r, err)
 		return
@@ -109,6 +149,201 @@ func (h *Handler) PostCashierSpend(w http.ResponseWriter, r *http.Request) {
 	h.helpers.JSON(w, http.StatusOK, mapOperationResult(out))
 }
 
+// POST /cashier/refund
+func (h *Handler) PostCashierRefund(w http.ResponseWriter, r *http.Request) {
+	actorUserID, ok := h.requireCashier(w, r)
+	if !ok {
+		return
+	}
+
+	var req api.PostCashierRefundJSONRequestBody
+	if err := DecodeJSON(r, &req); err != nil {
+		detail := err.Error()
+		WriteProblem(w, http.StatusUnprocessableEntity, "Validation error", &detail, ptr("INVALID_REQUEST"), instanceFromRequest(r))
+		return
+	}
+
+	out, err := h.cashierSvc.Refund(r.Context(), actorUserID, sdto.RefundIn{
+		OperationID: req.OperationId.String(),
+		PublicCode:  string(req.PublicCode),
+		EventID:     req.EventId,
+		AmountMoney: req.AmountMoney,
+		Ts:          req.Ts,
+	})
+	if err != nil {
+		h.WriteServiceError(w, r, err)
+		return
+	}
+
+	h.helpers.JSON(w, http.StatusOK, mapOperationResult(out))
+}
+
+// POST /cashier/void
+func (h *Handler) PostCashierVoid(w http.ResponseWriter, r *http.Request) {
+	actorUserID, ok := h.requireCashier(w, r)
+	if !ok {
+		return
+	}
+
+	var req api.PostCashierVoidJSONRequestBody
+	if err := DecodeJSON(r, &req); err != nil {
+		detail := err.Error()
+		WriteProblem(w, http.StatusUnprocessableEntity, "Validation error", &detail, ptr("INVALID_REQUEST"), instanceFromRequest(r))
+		return
+	}
+
+	out, err := h.cashierSvc.Void(r.Context(), actorUserID, sdto.VoidIn{
+		OperationID:      req.OperationId.String(),
+		PublicCode:       string(req.PublicCode),
+		SpendOperationID: req.SpendOperationId.String(),
+		Ts:               req.Ts,
+	})
+	if err != nil {
+		h.WriteServiceError(w, r, err)
+		return
+	}
+
+	h.helpers.JSON(w, http.StatusOK, mapOperationResult(out))
+}
+
+func (h *Handler) PostCashierCheckout(w http.ResponseWriter, r *http.Request) {
+	actorUserID, ok := h.requireCashier(w, r)
+	if !ok {
+		return
+	}
+
+	var req api.PostCashierCheckoutJSONRequestBody
+	if err := DecodeJSON(r, &req); err != nil {
+		detail := err.Error()
+		WriteProblem(w, http.StatusUnprocessableEntity, "Validation error", &detail, ptr("INVALID_REQUEST"), instanceFromRequest(r))
+		return
+	}
+
+	redeem := 0
+	if req.RedeemPoints != nil {
+		redeem = *req.RedeemPoints
+	}
+
+	out, err := h.cashierSvc.Checkout(r.Context(), actorUserID, sdto.CheckoutIn{
+		OperationID:  req.OperationId.String(),
+		PublicCode:   string(req.PublicCode),
+		BillMoney:    req.BillMoney,
+		RedeemPoints: redeem,
+		Ts:           req.Ts,
+	})
+	if err != nil {
+		h.WriteServiceError(w, r, err)
+		return
+	}
+
+	h.helpers.JSON(w, http.StatusOK, mapCheckoutResult(out))
+}
+
+// POST /cashier/batch
+func (h *Handler) PostCashierBatch(w http.ResponseWriter, r *http.Request) {
+	actorUserID, ok := h.requireCashier(w, r)
+	if !ok {
+		return
+	}
+
+	var req api.PostCashierBatchJSONRequestBody
+	if err := DecodeJSON(r, &req); err != nil {
+		detail := err.Error()
+		WriteProblem(w, http.StatusUnprocessableEntity, "Validation error", &detail, ptr("INVALID_REQUEST"), instanceFromRequest(r))
+		return
+	}
+
+	in := sdto.BatchIn{Items: make([]sdto.BatchItemIn, 0, len(req.Items))}
+	for _, it := range req.Items {
+		in.Items = append(in.Items, sdto.BatchItemIn{
+			Type:         sdto.OperationType(it.Type),
+			OperationID:  it.OperationId.String(),
+			PublicCode:   string(it.PublicCode),
+			AmountMoney:  it.AmountMoney,
+			AmountPoints: it.AmountPoints,
+			Ts:           it.Ts,
+		})
+	}
+
+	out, err := h.cashierSvc.Batch(r.Context(), actorUserID, in)
+	if err != nil {
+		h.WriteServiceError(w, r, err)
+		return
+	}
+
+	h.helpers.JSON(w, http.StatusOK, mapBatchResult(out, instanceFromRequest(r)))
+}
+
+// POST /cashier/holds
+func (h *Handler) PostCashierHolds(w http.ResponseWriter, r *http.Request) {
+	actorUserID, ok := h.requireCashier(w, r)
+	if !ok {
+		return
+	}
+
+	var req api.PostCashierHoldsJSONRequestBody
+	if err := DecodeJSON(r, &req); err != nil {
+		detail := err.Error()
+		WriteProblem(w, http.StatusUnprocessableEntity, "Validation error", &detail, ptr("INVALID_REQUEST"), instanceFromRequest(r))
+		return
+	}
+
+	out, err := h.cashierSvc.AuthorizeHold(r.Context(), actorUserID, sdto.HoldAuthorizeIn{
+		OperationID: req.OperationId.String(),
+		PublicCode:  string(req.PublicCode),
+		Points:      req.Points,
+	})
+	if err != nil {
+		h.WriteServiceError(w, r, err)
+		return
+	}
+
+	h.helpers.JSON(w, http.StatusOK, mapHoldResult(out))
+}
+
+// POST /cashier/holds/{holdId}/capture
+func (h *Handler) PostCashierHoldsHoldIdCapture(w http.ResponseWriter, r *http.Request, holdId api.HoldIdParam) {
+	actorUserID, ok := h.requireCashier(w, r)
+	if !ok {
+		return
+	}
+
+	// the body is optional: without it the whole hold is captured
+	var req api.PostCashierHoldsHoldIdCaptureJSONRequestBody
+	if err := DecodeJSON(r, &req); err != nil && !errors.Is(err, io.EOF) {
+		detail := err.Error()
+		WriteProblem(w, http.StatusUnprocessableEntity, "Validation error", &detail, ptr("INVALID_REQUEST"), instanceFromRequest(r))
+		return
+	}
+
+	out, err := h.cashierSvc.CaptureHold(r.Context(), actorUserID, sdto.HoldCaptureIn{
+		HoldID: holdId,
+		Points: req.Points,
+	})
+	if err != nil {
+		h.WriteServiceError(w, r, err)
+		return
+	}
+
+	h.helpers.JSON(w, http.StatusOK, mapHoldResult(out))
+}
+
+// POST /cashier/holds/{holdId}/release
+func (h *Handler) PostCashierHoldsHoldIdRelease(w http.ResponseWriter, r *http.Request, holdId api.HoldIdParam) {
+	actorUserID, ok := h.requireCashier(w, r)
+	if !ok {
+		return
+	}
+
+	out, err := h.cashierSvc.ReleaseHold(r.Context(), actorUserID, holdId)
+	if err != nil {
+		h.WriteServiceError(w, r, err)
+		return
+	}
+
+	h.helpers.JSON(w, http.StatusOK, mapHoldResult(out))
+}
+
 // ===== RBAC =====
 
 func (h *Handler) requireCashier(w http.ResponseWriter, r *http.Request) (int64, bool) {
@@ -154,7 +389,7 @@ func mapEventsPage(out sdto.EventsOut) api.EventsPage {
 }
 
 func mapEvent(e sdto.EventOut) api.Event {
-	return api.Event{
+	out := api.Event{
 		Id:           e.ID,
 		AccountId:    e.AccountID,
 		Type:         api.EventType(e.Type),
@@ -163,8 +398,37 @@ func mapEvent(e sdto.EventOut) api.Event {
 		AmountMoney:  e.AmountMoney,
 		RulesetId:    e.RulesetID,
 		ActorUserId:  e.ActorUserID,
+		RefEventId:   e.RefEventID,
 		Ts:           e.Ts,
 	}
+	if len(e.Campaigns) > 0 {
+		campaigns := make([]api.AppliedCampaign, 0, len(e.Campaigns))
+		for _, c := range e.Campaigns {
+			campaigns = append(campaigns, api.AppliedCampaign{CampaignId: c.CampaignID, BonusPoints: c.BonusPoints})
+		}
+		out.Campaigns = &campaigns
+	}
+	return out
+}
+
+func mapEarnQuote(out sdto.EarnQuoteOut) api.EarnQuote {
+	campaigns := make([]api.AppliedCampaign, 0, len(out.Campaigns))
+	for _, c := range out.Campaigns {
+		campaigns = append(campaigns, api.AppliedCampaign{CampaignId: c.CampaignID, BonusPoints: c.BonusPoints})
+	}
+	return api.EarnQuote{
+		PublicCode:      api.PublicCode(out.PublicCode),
+		AmountMoney:     out.AmountMoney,
+		RulesetId:       out.RulesetID,
+		BaseRubPerPoint: out.BaseRubPerPoint,
+		PercentEarn:     out.PercentEarn,
+		LevelBefore:     api.LevelCode(out.LevelBefore),
+		LevelAfter:      api.LevelCode(out.LevelAfter),
+		RulesetPoints:   out.RulesetPoints,
+		Campaigns:       campaigns,
+		Points:          out.Points,
+		Ts:              out.Ts,
+	}
 }
 
 func mapOperationResult(out sdto.OperationOut) api.OperationResult {
@@ -176,15 +440,114 @@ func mapOperationResult(out sdto.OperationOut) api.OperationResult {
 		Event:            mapEvent(out.Event),
 		Balance:          mapBalance(out.Balance),
 		IdempotentReplay: &replay,
+		LevelChange:      mapLevelChange(out.LevelChange),
 	}
 }
 
+func mapLevelChange(in *sdto.LevelChangeOut) *api.LevelChange {
+	if in == nil {
+		return nil
+	}
+	return &api.LevelChange{
+		EventId:      in.EventID,
+		FromLevel:    in.FromLevel,
+		ToLevel:      in.ToLevel,
+		BonusPoints:  in.BonusPoints,
+		BonusEventId: in.BonusEventID,
+	}
+}
+
+func mapCheckoutResult(out sdto.CheckoutOut) api.CheckoutResult {
+	replay := out.IdempotentReplay
+	uid, _ := uuid.Parse(out.OperationID)
+
+	var spendEv, earnEv *api.Event
+	if out.SpendEvent != nil {
+		ev := mapEvent(*out.SpendEvent)
+		spendEv = &ev
+	}
+	if out.EarnEvent != nil {
+		ev := mapEvent(*out.EarnEvent)
+		earnEv = &ev
+	}
+
+	return api.CheckoutResult{
+		OperationId:      openapi_types.UUID(uid),
+		OpType:           api.OperationType(out.OpType),
+		BillMoney:        out.BillMoney,
+		DiscountMoney:    out.DiscountMoney,
+		PaidMoney:        out.PaidMoney,
+		SpendEvent:       spendEv,
+		EarnEvent:        earnEv,
+		Balance:          mapBalance(out.Balance),
+		IdempotentReplay: &replay,
+		LevelChange:      mapLevelChange(out.LevelChange),
+	}
+}
+
+func mapHoldResult(out sdto.HoldResultOut) api.HoldResult {
+	replay := out.IdempotentReplay
+	uid, _ := uuid.Parse(out.Hold.OperationID)
+
+	var ev *api.Event
+	if out.Event != nil {
+		e := mapEvent(*out.Event)
+		ev = &e
+	}
+
+	return api.HoldResult{
+		Hold: api.Hold{
+			Id:             out.Hold.ID,
+			AccountId:      out.Hold.AccountID,
+			OperationId:    openapi_types.UUID(uid),
+			Points:         out.Hold.Points,
+			Status:         api.HoldStatus(out.Hold.Status),
+			ExpiresAt:      out.Hold.ExpiresAt,
+			CapturedPoints: out.Hold.CapturedPoints,
+			CreatedAt:      out.Hold.CreatedAt,
+			ClosedAt:       out.Hold.ClosedAt,
+		},
+		Event:            ev,
+		Balance:          mapBalance(out.Balance),
+		IdempotentReplay: &replay,
+	}
+}
+
+func mapBatchResult(out sdto.BatchOut, instance *string) api.BatchResult {
+	resp := api.BatchResult{Items: make([]api.BatchItemResult, 0, len(out.Items))}
+	for _, it := range out.Items {
+		uid, _ := uuid.Parse(it.OperationID)
+		item := api.BatchItemResult{
+			Index:       it.Index,
+			Type:        api.BatchItemType(it.Type),
+			OperationId: openapi_types.UUID(uid),
+		}
+		if it.Err != nil {
+			p := problemFromError(it.Err, instance)
+			item.Problem = &p
+		} else if it.Result != nil {
+			res := mapOperationResult(*it.Result)
+			item.Result = &res
+		}
+		resp.Items = append(resp.Items, item)
+	}
+	return resp
+}
+
 func mapBalance(b sdto.BalanceOut) api.BalanceResponse {
+	var expiringOn *openapi_types.Date
+	if b.ExpiringOn != nil {
+		expiringOn = &openapi_types.Date{Time: *b.ExpiringOn}
+	}
 	return api.BalanceResponse{
 		AccountId:       b.AccountID,
 		BalancePoints:   b.BalancePoints,
+		HeldPoints:      b.HeldPoints,
+		AvailablePoints: b.AvailablePoints,
 		TotalSpendMoney: b.TotalSpendMoney,
 		LevelCode:       api.LevelCode(b.LevelCode),
 		AsOf:            b.AsOf,
+		ExpiringPoints:  b.ExpiringPoints,
+		ExpiringOn:      expiringOn,
 	}
 }
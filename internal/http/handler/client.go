@@ -2,6 +2,9 @@ package handler
 
 import (
 	"net/http"
+	"time"
+
+	openapi_types "github.com/oapi-codegen/runtime/types"
 
 	"Beanefits/internal/api"
 	sdto "Beanefits/internal/service/dto"
@@ -23,6 +26,31 @@ func (h *Handler) GetMe(w http.ResponseWriter, r *http.Request) {
 	h.helpers.JSON(w, http.StatusOK, mapClientProfile(out))
 }
 
+// PUT /me/birthday
+func (h *Handler) PutMeBirthday(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.requireClient(w, r)
+	if !ok {
+		return
+	}
+
+	var req api.PutMeBirthdayJSONRequestBody
+	if err := DecodeJSON(r, &req); err != nil {
+		detail := err.Error()
+		WriteProblem(w, http.StatusUnprocessableEntity, "Validation error", &detail, ptr("INVALID_BIRTHDAY"), instanceFromRequest(r))
+		return
+	}
+
+	out, err := h.clientSvc.SetBirthday(r.Context(), userID, sdto.SetBirthdayIn{
+		Birthday: birthdayFromAPI(req.Birthday),
+	})
+	if err != nil {
+		h.WriteServiceError(w, r, err)
+		return
+	}
+
+	h.helpers.JSON(w, http.StatusOK, mapClientProfile(out))
+}
+
 // GET /me/balance
 func (h *Handler) GetMeBalance(w http.ResponseWriter, r *http.Request) {
 	userID, ok := h.requireClient(w, r)
@@ -60,6 +88,63 @@ func (h *Handler) GetMeEvents(w http.ResponseWriter, r *http.Request, params api
 	h.helpers.JSON(w, http.StatusOK, mapEventsPage(out))
 }
 
+// GET /me/referrals
+func (h *Handler) GetMeReferrals(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.requireClient(w, r)
+	if !ok {
+		return
+	}
+
+	out, err := h.clientSvc.GetReferrals(r.Context(), userID)
+	if err != nil {
+		h.WriteServiceError(w, r, err)
+		return
+	}
+
+	h.helpers.JSON(w, http.StatusOK, api.ReferralStats{
+		ReferralCode:      out.ReferralCode,
+		ReferredCount:     out.ReferredCount,
+		RewardedCount:     out.RewardedCount,
+		PendingCount:      out.PendingCount,
+		ReferredThisMonth: out.ReferredThisMonth,
+		MonthlyLimit:      out.MonthlyLimit,
+		BonusPointsEarned: out.BonusPointsEarned,
+	})
+}
+
+// POST /me/transfers
+func (h *Handler) PostMeTransfers(w http.ResponseWriter, r *http.Request) {
+	userID, ok := h.requireClient(w, r)
+	if !ok {
+		return
+	}
+
+	var req api.PostMeTransfersJSONRequestBody
+	if err := DecodeJSON(r, &req); err != nil {
+		detail := err.Error()
+		WriteProblem(w, http.StatusUnprocessableEntity, "Validation error", &detail, ptr("INVALID_REQUEST"), instanceFromRequest(r))
+		return
+	}
+
+	in := sdto.TransferIn{
+		OperationID:         req.OperationId.String(),
+		RecipientPublicCode: req.RecipientPublicCode,
+		Points:              req.Points,
+	}
+	if req.RecipientPhone != nil {
+		phone := string(*req.RecipientPhone)
+		in.RecipientPhone = &phone
+	}
+
+	out, err := h.clientSvc.Transfer(r.Context(), userID, in)
+	if err != nil {
+		h.WriteServiceError(w, r, err)
+		return
+	}
+
+	h.helpers.JSON(w, http.StatusOK, mapOperationResult(out))
+}
+
 func mapClientProfile(out sdto.ClientProfileOut) api.ClientProfile {
 	return api.ClientProfile{
 		User: api.User{
@@ -67,6 +152,7 @@ func mapClientProfile(out sdto.ClientProfileOut) api.ClientProfile {
 			Phone:     api.Phone(out.User.Phone),
 			Roles:     mapRolesToAPI(out.User.Roles),
 			IsActive:  out.User.IsActive,
+			Birthday:  birthdayToAPI(out.User.Birthday),
 			CreatedAt: out.User.CreatedAt,
 		},
 		Account: api.Account{
@@ -79,3 +165,18 @@ func mapClientProfile(out sdto.ClientProfileOut) api.ClientProfile {
 		},
 	}
 }
+
+func birthdayFromAPI(d *openapi_types.Date) *string {
+	if d == nil {
+		return nil
+	}
+	s := d.Format(time.DateOnly)
+	return &s
+}
+
+func birthdayToAPI(t *time.Time) *openapi_types.Date {
+	if t == nil {
+		return nil
+	}
+	return &openapi_types.Date{Time: *t}
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"net/http"
 
+	"Beanefits/internal/api"
 	"Beanefits/internal/domain/errs"
 )
 
@@ -44,17 +45,48 @@ func problemForCode(code errs.Code) (problemSpec, bool) {
 
 	// 422
 	case errs.CodeInvalidPhone,
+		errs.CodeInvalidBirthday,
 		errs.CodeInvalidPublicCode,
 		errs.CodeInvalidPoints,
 		errs.CodeInvalidPurchaseAmount,
+		errs.CodeInvalidReceipt,
+		errs.CodeInvalidRefundAmount,
+		errs.CodeRedeemExceedsBill,
+		errs.CodeInvalidAdjustment,
+		errs.CodeInvalidTransfer,
+		errs.CodeInvalidBatch,
+		errs.CodeIdempotencyKeyReused,
+		errs.CodeOperationExpired,
+		errs.CodeInvalidLimit,
+		errs.CodeInvalidReferralCode,
 		errs.CodeInvalidRuleset,
 		errs.CodeInvalidLevels,
-		errs.CodeInvalidMoney:
+		errs.CodeInvalidSimulation,
+		errs.CodeInvalidMoney,
+		errs.CodeInvalidCampaign:
 		return problemSpec{status: http.StatusUnprocessableEntity, title: "Validation error"}, true
 
 	// 409
 	case errs.CodeNotEnoughBalance:
 		return problemSpec{status: http.StatusConflict, title: "Not enough balance"}, true
+	case errs.CodeRefundNotAllowed:
+		return problemSpec{status: http.StatusConflict, title: "Refund not allowed"}, true
+	case errs.CodeAlreadyVoided, errs.CodeVoidWindowExpired:
+		return problemSpec{status: http.StatusConflict, title: "Void not allowed"}, true
+	case errs.CodeTransferLimitExceeded:
+		return problemSpec{status: http.StatusConflict, title: "Transfer limit exceeded"}, true
+	case errs.CodeVelocityLimitExceeded:
+		return problemSpec{status: http.StatusConflict, title: "Limit exceeded"}, true
+	case errs.CodeReferralLimitExceeded:
+		return problemSpec{status: http.StatusConflict, title: "Referral limit exceeded"}, true
+	case errs.CodeHoldNotActive, errs.CodeHoldExpired:
+		return problemSpec{status: http.StatusConflict, title: "Hold not active"}, true
+	case errs.CodeCampaignInUse:
+		return problemSpec{status: http.StatusConflict, title: "Campaign in use"}, true
+	case errs.CodeRulesetImmutable:
+		return problemSpec{status: http.StatusConflict, title: "Ruleset immutable"}, true
+	case errs.CodeRulesetStatusConflict:
+		return problemSpec{status: http.StatusConflict, title: "Ruleset status conflict"}, true
 	case errs.CodePhoneAlreadyExists:
 		return problemSpec{status: http.StatusConflict, title: "Phone already exists"}, true
 	case errs.CodePublicCodeCollision:
@@ -67,9 +99,12 @@ func problemForCode(code errs.Code) (problemSpec, bool) {
 	// 403
 	case errs.CodeUserInactive:
 		return problemSpec{status: http.StatusForbidden, title: "User inactive"}, true
+	case errs.CodeSelfApproval:
+		return problemSpec{status: http.StatusForbidden, title: "Self-approval not allowed"}, true
 
 	// 404
-	case errs.CodeAccountNotFound:
+	case errs.CodeAccountNotFound, errs.CodeEventNotFound, errs.CodeOperationNotFound, errs.CodeHoldNotFound,
+		errs.CodeLimitNotFound, errs.CodeCampaignNotFound, errs.CodeRulesetNotFound:
 		return problemSpec{status: http.StatusNotFound, title: "Not Found"}, true
 
 	// 500
@@ -85,16 +120,21 @@ func (h *Handler) WriteServiceError(w http.ResponseWriter, r *http.Request, err
 		return
 	}
 
+	p := problemFromError(err, instanceFromRequest(r))
+	WriteProblem(w, p.Status, p.Title, p.Detail, p.Code, p.Instance)
+}
+
+// problemFromError maps a service error to a problem body (unknown errors become 500 INTERNAL_ERROR).
+func problemFromError(err error, instance *string) api.Problem {
+	detail := err.Error()
+
 	if c, ok := domainCode(err); ok {
 		if ps, ok := problemForCode(c); ok {
-			detail := err.Error()
 			code := string(c)
-			WriteProblem(w, ps.status, ps.title, &detail, &code, instanceFromRequest(r))
-			return
+			return api.Problem{Type: "about:blank", Title: ps.title, Status: ps.status, Detail: &detail, Code: &code, Instance: instance}
 		}
 	}
 
-	detail := err.Error()
 	code := string(errs.CodeInternal)
-	WriteProblem(w, http.StatusInternalServerError, "Internal Server Error", &detail, &code, instanceFromRequest(r))
+	return api.Problem{Type: "about:blank", Title: "Internal Server Error", Status: http.StatusInternalServerError, Detail: &detail, Code: &code, Instance: instance}
 }
@@ -56,7 +56,7 @@ func New(cfg Config, h *apphandler.Handler, verifier TokenVerifier, log *slog.Lo
 		},
 		ErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
 			reqID := chimw.GetReqID(r.Context())
-			log.Error("request validation/binding error", reqID)
+			log.Error("request validation/binding error", "reqID", reqID, "err", err)
 			h.WriteServiceError(w, r, err)
 		},
 	})
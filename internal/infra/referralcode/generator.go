@@ -0,0 +1,30 @@
+package referralcode
+
+import (
+	"context"
+	"crypto/rand"
+	"math/big"
+)
+
+// alphabet has no 0/O and 1/I, so codes survive being read out or retyped.
+const (
+	alphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+	length   = 8
+)
+
+type Generator struct{}
+
+func NewGenerator() *Generator { return &Generator{} }
+
+func (g *Generator) New(ctx context.Context) (string, error) {
+	b := make([]byte, length)
+	max := big.NewInt(int64(len(alphabet)))
+	for i := range b {
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", err
+		}
+		b[i] = alphabet[n.Int64()]
+	}
+	return string(b), nil
+}
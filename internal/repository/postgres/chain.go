@@ -0,0 +1,75 @@
+package postgres
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"strings"
+	"time"
+
+	"Beanefits/internal/repository/postgres/dto"
+)
+
+// EventHash is one link of an account's hash chain: sha256(prevHash || canonical content).
+// id and created_at are assigned by the database and are not hashed; the previous link fixes the order.
+// ts must already have microsecond precision (as stored by PostgreSQL).
+func EventHash(prevHash []byte, e dto.EventRow) []byte {
+	h := sha256.New()
+	h.Write(prevHash)
+	h.Write([]byte(canonicalEvent(e)))
+	return h.Sum(nil)
+}
+
+// canonicalEvent is the versioned, '|'-separated content covered by the hash; absent values are empty.
+func canonicalEvent(e dto.EventRow) string {
+	amount := ""
+	if e.AmountMoney != nil {
+		amount = e.AmountMoney.StringFixed(2)
+	}
+
+	return strings.Join([]string{
+		"v1",
+		strconv.FormatInt(e.AccountID, 10),
+		string(e.Type),
+		strconv.Itoa(e.DeltaPoints),
+		strconv.Itoa(e.BalanceAfter),
+		amount,
+		optInt64(e.RulesetID),
+		optInt64(e.ActorUserID),
+		optInt64(e.RefEventID),
+		e.Ts.UTC().Format(time.RFC3339Nano),
+	}, "|")
+}
+
+// ChainBreak describes the first event whose link does not match the replayed chain.
+type ChainBreak struct {
+	EventID  int64
+	Expected string // hex hash recomputed from the previous link
+	Actual   string // hex hash stored on the row ("" if missing)
+}
+
+// VerifyChain walks events of one account in id order and returns the first broken link.
+// A link is broken when prev_hash is not the previous event's hash or hash does not match the content.
+func VerifyChain(events []dto.EventRow) (ChainBreak, bool) {
+	var prev []byte
+	for _, e := range events {
+		want := EventHash(prev, e)
+		if e.Hash == nil || !bytes.Equal(e.PrevHash, prev) || !bytes.Equal(e.Hash, want) {
+			return ChainBreak{
+				EventID:  e.ID,
+				Expected: hex.EncodeToString(want),
+				Actual:   hex.EncodeToString(e.Hash),
+			}, false
+		}
+		prev = e.Hash
+	}
+	return ChainBreak{}, true
+}
+
+func optInt64(v *int64) string {
+	if v == nil {
+		return ""
+	}
+	return strconv.FormatInt(*v, 10)
+}
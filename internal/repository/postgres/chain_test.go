@@ -0,0 +1,135 @@
+package postgres
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"Beanefits/internal/repository/postgres/dto"
+)
+
+func sampleEvent() dto.EventRow {
+	amount := decimal.RequireFromString("1234.50")
+	ruleset, actor := int64(3), int64(7)
+	return dto.EventRow{
+		ID:           10,
+		AccountID:    1,
+		Type:         dto.EventEarn,
+		DeltaPoints:  12,
+		BalanceAfter: 112,
+		AmountMoney:  &amount,
+		RulesetID:    &ruleset,
+		ActorUserID:  &actor,
+		Ts:           time.Date(2026, 3, 1, 12, 30, 0, 123456000, time.UTC),
+		CreatedAt:    time.Date(2026, 3, 1, 12, 30, 1, 0, time.UTC),
+	}
+}
+
+func TestEventHashContent(t *testing.T) {
+	base := EventHash(nil, sampleEvent())
+
+	tests := []struct {
+		name   string
+		mutate func(e *dto.EventRow)
+		same   bool
+	}{
+		{name: "id is not hashed", mutate: func(e *dto.EventRow) { e.ID = 11 }, same: true},
+		{name: "created_at is not hashed", mutate: func(e *dto.EventRow) { e.CreatedAt = e.CreatedAt.Add(time.Hour) }, same: true},
+		{name: "money digits", mutate: func(e *dto.EventRow) { m := decimal.RequireFromString("1234.5"); e.AmountMoney = &m }, same: true},
+		{name: "ts offset", mutate: func(e *dto.EventRow) { e.Ts = e.Ts.In(time.FixedZone("MSK", 3*60*60)) }, same: true},
+		{name: "account", mutate: func(e *dto.EventRow) { e.AccountID = 2 }},
+		{name: "type", mutate: func(e *dto.EventRow) { e.Type = dto.EventSpend }},
+		{name: "delta", mutate: func(e *dto.EventRow) { e.DeltaPoints = 13 }},
+		{name: "balance", mutate: func(e *dto.EventRow) { e.BalanceAfter = 113 }},
+		{name: "amount", mutate: func(e *dto.EventRow) { m := decimal.RequireFromString("1234.51"); e.AmountMoney = &m }},
+		{name: "no amount", mutate: func(e *dto.EventRow) { e.AmountMoney = nil }},
+		{name: "ruleset", mutate: func(e *dto.EventRow) { e.RulesetID = nil }},
+		{name: "actor", mutate: func(e *dto.EventRow) { a := int64(8); e.ActorUserID = &a }},
+		{name: "ref event", mutate: func(e *dto.EventRow) { r := int64(9); e.RefEventID = &r }},
+		{name: "ts", mutate: func(e *dto.EventRow) { e.Ts = e.Ts.Add(time.Microsecond) }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := sampleEvent()
+			tt.mutate(&e)
+			if got := bytes.Equal(EventHash(nil, e), base); got != tt.same {
+				t.Errorf("same hash = %v, want %v", got, tt.same)
+			}
+		})
+	}
+}
+
+func TestEventHashLinksPrevious(t *testing.T) {
+	e := sampleEvent()
+	if bytes.Equal(EventHash(nil, e), EventHash([]byte{1}, e)) {
+		t.Error("the previous link is not part of the hash")
+	}
+}
+
+// chain builds a correctly linked history of n events of one account.
+func chain(n int) []dto.EventRow {
+	events := make([]dto.EventRow, 0, n)
+	var prev []byte
+	for i := 0; i < n; i++ {
+		e := sampleEvent()
+		e.ID = int64(i + 1)
+		e.BalanceAfter = 12 * (i + 1)
+		e.Ts = e.Ts.Add(time.Duration(i) * time.Minute)
+		e.PrevHash = prev
+		e.Hash = EventHash(prev, e)
+		prev = e.Hash
+		events = append(events, e)
+	}
+	return events
+}
+
+func TestVerifyChain(t *testing.T) {
+	tests := []struct {
+		name     string
+		tamper   func(events []dto.EventRow)
+		ok       bool
+		brokenAt int64
+	}{
+		{name: "intact", tamper: func([]dto.EventRow) {}, ok: true},
+		{name: "content changed", tamper: func(ev []dto.EventRow) { ev[1].DeltaPoints = 100 }, brokenAt: 2},
+		{name: "hash missing", tamper: func(ev []dto.EventRow) { ev[2].Hash = nil }, brokenAt: 3},
+		{name: "event removed", tamper: func(ev []dto.EventRow) { copy(ev[1:], ev[2:]) }, brokenAt: 3},
+		{name: "rehashed without relinking", tamper: func(ev []dto.EventRow) {
+			ev[0].DeltaPoints = 100
+			ev[0].Hash = EventHash(nil, ev[0])
+		}, brokenAt: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events := chain(4)
+			tt.tamper(events)
+
+			brk, ok := VerifyChain(events)
+			if ok != tt.ok {
+				t.Fatalf("VerifyChain ok = %v, want %v (break %+v)", ok, tt.ok, brk)
+			}
+			if ok {
+				return
+			}
+			if brk.EventID != tt.brokenAt {
+				t.Errorf("broken at event %d, want %d", brk.EventID, tt.brokenAt)
+			}
+			for _, e := range events {
+				if e.ID == brk.EventID && brk.Actual != hex.EncodeToString(e.Hash) {
+					t.Errorf("actual = %s, want the stored hash", brk.Actual)
+				}
+			}
+		})
+	}
+}
+
+func TestVerifyChainEmpty(t *testing.T) {
+	if _, ok := VerifyChain(nil); !ok {
+		t.Error("an empty history must verify")
+	}
+}
@@ -7,5 +7,6 @@ type AccountRow struct {
 	BalancePoints   int
 	TotalSpendMoney Money
 	LevelCode       string
+	HeldPoints      int // reserved by active holds; included in BalancePoints
 	CreatedAt       Ts
 }
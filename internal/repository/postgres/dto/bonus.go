@@ -0,0 +1,16 @@
+package dto
+
+type BonusKind string
+
+const (
+	BonusWelcome  BonusKind = "WELCOME"
+	BonusBirthday BonusKind = "BIRTHDAY"
+)
+
+// BonusGrantRow links a BONUS event to the bonus it paid; Year is the calendar year of the grant.
+type BonusGrantRow struct {
+	EventID   int64
+	AccountID int64
+	Kind      BonusKind
+	Year      int
+}
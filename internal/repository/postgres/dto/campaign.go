@@ -0,0 +1,42 @@
+package dto
+
+import "time"
+
+// CampaignRow is a time-boxed earn promotion; exactly one of Multiplier and BonusPoints is set.
+// Dates, weekdays and the time-of-day window are in the campaign's Timezone.
+type CampaignRow struct {
+	ID          int64
+	Name        string
+	StartsOn    Ts  // date, UTC midnight
+	EndsOn      *Ts // date, inclusive; nil: open-ended
+	Weekdays    []int
+	TimeFrom    *time.Duration // time of day; nil with TimeTo: all day
+	TimeTo      *time.Duration
+	Timezone    string
+	Multiplier  *Money
+	BonusPoints *int
+	UpdatedBy   *int64
+	CreatedAt   Ts
+	UpdatedAt   Ts
+}
+
+// CampaignWrite is the editable part of a campaign (insert and update).
+type CampaignWrite struct {
+	Name        string
+	StartsOn    Ts
+	EndsOn      *Ts
+	Weekdays    []int
+	TimeFrom    *time.Duration
+	TimeTo      *time.Duration
+	Timezone    string
+	Multiplier  *Money
+	BonusPoints *int
+	UpdatedBy   *int64
+}
+
+// EventCampaignRow records a campaign applied to an EARN event and the points it added.
+type EventCampaignRow struct {
+	EventID     int64
+	CampaignID  int64
+	BonusPoints int
+}
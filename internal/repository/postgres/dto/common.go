@@ -18,15 +18,30 @@ const (
 type EventType string
 
 const (
-	EventEarn  EventType = "EARN"
-	EventSpend EventType = "SPEND"
+	EventEarn         EventType = "EARN"
+	EventSpend        EventType = "SPEND"
+	EventRefund       EventType = "REFUND"
+	EventVoid         EventType = "VOID"
+	EventExpire       EventType = "EXPIRE"
+	EventAdjust       EventType = "ADJUST"
+	EventTransferOut  EventType = "TRANSFER_OUT"
+	EventTransferIn   EventType = "TRANSFER_IN"
+	EventBonus        EventType = "BONUS"
+	EventLevelChanged EventType = "LEVEL_CHANGED"
 )
 
 type OperationType string
 
 const (
-	OpEarn  OperationType = "EARN"
-	OpSpend OperationType = "SPEND"
+	OpEarn     OperationType = "EARN"
+	OpSpend    OperationType = "SPEND"
+	OpRefund   OperationType = "REFUND"
+	OpVoid     OperationType = "VOID"
+	OpCheckout OperationType = "CHECKOUT"
+	OpTransfer OperationType = "TRANSFER"
+
+	OpHoldAuthorize OperationType = "HOLD_AUTHORIZE"
+	OpHoldCapture   OperationType = "HOLD_CAPTURE"
 )
 
 type JSON = json.RawMessage
@@ -0,0 +1,41 @@
+package dto
+
+type HoldStatus string
+
+const (
+	HoldActive   HoldStatus = "ACTIVE"
+	HoldCaptured HoldStatus = "CAPTURED"
+	HoldReleased HoldStatus = "RELEASED"
+	HoldExpired  HoldStatus = "EXPIRED"
+)
+
+// HoldRow is a reservation of points; while ACTIVE its points are counted in accounts.held_points.
+type HoldRow struct {
+	ID             int64
+	AccountID      int64
+	OperationID    string // operationId of the authorize call
+	Points         int
+	Status         HoldStatus
+	ExpiresAt      Ts
+	CapturedPoints *int   // set when CAPTURED
+	CaptureEventID *int64 // SPEND event written by the capture
+	ActorUserID    *int64
+	CreatedAt      Ts
+	ClosedAt       *Ts
+}
+
+type HoldInsert struct {
+	AccountID   int64
+	OperationID string
+	Points      int
+	ExpiresAt   Ts
+	ActorUserID *int64
+}
+
+type HoldClose struct {
+	ID             int64
+	Status         HoldStatus
+	CapturedPoints *int
+	CaptureEventID *int64
+	ClosedAt       Ts
+}
@@ -4,13 +4,22 @@ type EventRow struct {
 	ID           int64
 	AccountID    int64
 	Type         EventType
-	DeltaPoints  int // signed: + for EARN, - for SPEND
+	DeltaPoints  int // signed: + for EARN, - for SPEND/REFUND
 	BalanceAfter int
-	AmountMoney  *Money // present for EARN
+	AmountMoney  *Money // present for EARN/REFUND
 	RulesetID    *int64
 	ActorUserID  *int64
-	Ts           Ts // event time (business timestamp)
-	CreatedAt    Ts // insertion time (optional, if you store separately)
+	RefEventID   *int64 // original event for compensating entries (REFUND, VOID)
+	Ts           Ts     // event time (business timestamp)
+	CreatedAt    Ts     // insertion time (optional, if you store separately)
+	PrevHash     []byte // hash chain; filled by Insert and ListAllByAccount only
+	Hash         []byte
+}
+
+// RefundTotals aggregates REFUND entries already recorded against an EARN event.
+type RefundTotals struct {
+	RefundedMoney  Money
+	RevertedPoints int
 }
 
 type EventInsert struct {
@@ -21,5 +30,70 @@ type EventInsert struct {
 	AmountMoney  *Money
 	RulesetID    *int64
 	ActorUserID  *int64
+	RefEventID   *int64
 	Ts           Ts
 }
+
+// EventTotals counts events of one type and sums their points (absolute values).
+type EventTotals struct {
+	Count  int
+	Points int
+}
+
+// EarnReplayRow is a purchase (EARN event) replayed by the ruleset simulation.
+type EarnReplayRow struct {
+	EventID        int64
+	AccountID      int64
+	PublicCode     string
+	DeltaPoints    int // points actually credited, campaign rewards included
+	CampaignPoints int // part of DeltaPoints added by campaigns
+	AmountMoney    Money
+	Ts             Ts
+}
+
+// AccountSpend is the purchase spend of one account.
+type AccountSpend struct {
+	AccountID  int64
+	SpendMoney Money
+}
+
+// PurchaseAmountRow is a purchase (positive) or a refund (negative) replayed by the ruleset simulation.
+type PurchaseAmountRow struct {
+	AccountID   int64
+	PurchaseTs  Ts // ts of the purchase; for a refund, of the purchase it refunds
+	Ts          Ts
+	AmountMoney Money
+}
+
+// AdjustmentRow explains an ADJUST event.
+type AdjustmentRow struct {
+	EventID    int64
+	ReasonCode string
+	Comment    string
+}
+
+// LotRow is a portion of earned points that expires on its own date.
+type LotRow struct {
+	ID              int64
+	AccountID       int64
+	SourceEventID   *int64 // event that added the points (nil for migrated balances)
+	Points          int
+	RemainingPoints int
+	EarnedAt        Ts
+	ExpiresAt       Ts
+	CreatedAt       Ts
+}
+
+type LotInsert struct {
+	AccountID     int64
+	SourceEventID *int64
+	Points        int
+	EarnedAt      Ts
+	ExpiresAt     Ts
+}
+
+// ExpiringPoints is the nearest (UTC) day on which open lots expire and how many points they hold.
+type ExpiringPoints struct {
+	Points int
+	On     Ts
+}
@@ -0,0 +1,11 @@
+package dto
+
+// LevelChangeRow links a LEVEL_CHANGED event to the transition it records;
+// BonusEventID is the BONUS event paid for reaching ToLevel, if any.
+type LevelChangeRow struct {
+	EventID      int64
+	AccountID    int64
+	FromLevel    string
+	ToLevel      string
+	BonusEventID *int64
+}
@@ -0,0 +1,27 @@
+package dto
+
+type LimitKind string
+
+const (
+	LimitPointsPerOperation       LimitKind = "POINTS_PER_OPERATION"
+	LimitAccountPointsPerDay      LimitKind = "ACCOUNT_POINTS_PER_DAY"
+	LimitCashierOperationsPerHour LimitKind = "CASHIER_OPERATIONS_PER_HOUR"
+)
+
+// VelocityLimitRow caps EARN or SPEND operations; at most one row per (Kind, OpType).
+type VelocityLimitRow struct {
+	ID        int64
+	Kind      LimitKind
+	OpType    EventType
+	MaxValue  int
+	UpdatedBy *int64
+	CreatedAt Ts
+	UpdatedAt Ts
+}
+
+type VelocityLimitUpsert struct {
+	Kind      LimitKind
+	OpType    EventType
+	MaxValue  int
+	UpdatedBy *int64
+}
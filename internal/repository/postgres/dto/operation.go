@@ -8,8 +8,10 @@ type OperationRecord struct {
 	RequestJSON  JSON
 	ResponseJSON *JSON
 	HTTPStatus   *int
+	EventID      *int64 // event written by a successful operation
 
-	CreatedAt Ts
+	CreatedAt        Ts
+	CanonicalRequest bool // false for requests stored before they were canonical, see migration 0036
 }
 
 type OperationPendingInsert struct {
@@ -26,4 +28,5 @@ type OperationFinalize struct {
 
 	HTTPStatus   int
 	ResponseJSON JSON
+	EventID      *int64
 }
@@ -0,0 +1,12 @@
+package dto
+
+// ReceiptLineRow is a receipt line of an EARN event and the category earn factor it earned at.
+type ReceiptLineRow struct {
+	EventID    int64
+	LineNo     int // 1-based position on the receipt
+	SKU        string
+	Category   string
+	Quantity   int
+	LineTotal  Money
+	EarnFactor Money
+}
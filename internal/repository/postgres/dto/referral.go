@@ -0,0 +1,30 @@
+package dto
+
+// ReferralRow is a referee's link to the account whose code they registered with;
+// it is pending until the referee's first EARN pays the referral bonuses.
+type ReferralRow struct {
+	RefereeAccountID  int64
+	ReferrerAccountID int64
+	CreatedAt         Ts
+	RewardedAt        *Ts
+	QualifyingEventID *int64 // referee's first EARN
+	ReferrerEventID   *int64 // BONUS credited to the referrer; nil if the ruleset had none
+	RefereeEventID    *int64 // BONUS credited to the referee; nil if the ruleset had none
+}
+
+// ReferralRewardUpdate closes a pending referral.
+type ReferralRewardUpdate struct {
+	RefereeAccountID  int64
+	RewardedAt        Ts
+	QualifyingEventID int64
+	ReferrerEventID   *int64
+	RefereeEventID    *int64
+}
+
+// ReferralStatsRow sums up the referrals of one referrer.
+type ReferralStatsRow struct {
+	Referred      int
+	Rewarded      int
+	ReferredSince int // referred at or after the requested moment
+	BonusPoints   int // credited to the referrer
+}
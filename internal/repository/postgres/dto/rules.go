@@ -1,10 +1,61 @@
 package dto
 
+// RulesetStatus is the review state of a ruleset; only APPROVED rulesets take effect.
+type RulesetStatus string
+
+const (
+	RulesetDraft           RulesetStatus = "DRAFT"
+	RulesetPendingApproval RulesetStatus = "PENDING_APPROVAL"
+	RulesetApproved        RulesetStatus = "APPROVED"
+	RulesetRejected        RulesetStatus = "REJECTED"
+)
+
+// RulesetAction is an entry kind of ruleset_history.
+type RulesetAction string
+
+const (
+	RulesetActionCreated   RulesetAction = "CREATED"
+	RulesetActionUpdated   RulesetAction = "UPDATED"
+	RulesetActionSubmitted RulesetAction = "SUBMITTED"
+	RulesetActionApproved  RulesetAction = "APPROVED"
+	RulesetActionRejected  RulesetAction = "REJECTED"
+)
+
 type RulesetRow struct {
-	ID              int64
-	EffectiveFrom   Ts
-	BaseRubPerPoint Money
-	CreatedAt       Ts
+	ID                      int64
+	EffectiveFrom           Ts
+	BaseRubPerPoint         Money
+	RedeemRubPerPoint       Money  // money value of one point redeemed at checkout
+	QualificationWindowDays *int   // nil: levels qualify on lifetime spend
+	WelcomeBonusPoints      int    // credited on registration; 0: no bonus
+	BirthdayBonusPoints     int    // credited once a year on the client's birthday; 0: no bonus
+	ReferrerBonusPoints     int    // credited to the referrer on the referee's first EARN; 0: no bonus
+	RefereeBonusPoints      int    // credited to the referee on their first EARN; 0: no bonus
+	RoundingMode            string // FLOOR, HALF_UP or HALF_EVEN
+	RoundingStage           string // PER_STEP or AT_END
+	MinEarnAmount           Money  // purchases below it earn no points; 0: no minimum
+	MaxPointsPerPurchase    *int   // nil: no cap
+	MaxPointsPerDay         *int   // EARN points per account and UTC day; nil: no cap
+	CreatedAt               Ts
+	Status                  RulesetStatus
+	EffectiveTo             *Ts // effective_from of the next approved ruleset; nil: open-ended
+}
+
+type RulesetInsert struct {
+	EffectiveFrom           Ts
+	BaseRubPerPoint         Money
+	RedeemRubPerPoint       Money
+	QualificationWindowDays *int
+	WelcomeBonusPoints      int
+	BirthdayBonusPoints     int
+	ReferrerBonusPoints     int
+	RefereeBonusPoints      int
+	RoundingMode            string
+	RoundingStage           string
+	MinEarnAmount           Money
+	MaxPointsPerPurchase    *int
+	MaxPointsPerDay         *int
+	CreatedBy               *int64
 }
 
 type LevelRuleRow struct {
@@ -13,9 +64,35 @@ type LevelRuleRow struct {
 	LevelCode           string
 	ThresholdTotalSpend Money
 	PercentEarn         Money // percent stored as decimal, e.g. 110.00
+	ReachedBonusPoints  int   // paid once per account when the level is first reached, 0 = none
+}
+
+// CategoryEarnRateRow is the earn factor of a receipt line category (1 = the level percent, 0 = nothing).
+type CategoryEarnRateRow struct {
+	Category   string
+	EarnFactor Money
+}
+
+// RulesetHistoryRow records who did what to a ruleset, and when.
+type RulesetHistoryRow struct {
+	ID          int64
+	RulesetID   int64
+	Action      RulesetAction
+	ActorUserID *int64
+	Comment     *string
+	CreatedAt   Ts
+}
+
+type RulesetHistoryInsert struct {
+	RulesetID   int64
+	Action      RulesetAction
+	ActorUserID *int64
+	Comment     *string
 }
 
 type RulesetWithLevels struct {
-	Ruleset RulesetRow
-	Levels  []LevelRuleRow
+	Ruleset       RulesetRow
+	Levels        []LevelRuleRow
+	CategoryRates []CategoryEarnRateRow // categories without a rate earn at factor 1
+	History       []RulesetHistoryRow   // oldest first
 }
@@ -5,6 +5,7 @@ type UserRow struct {
 	Phone        string
 	PasswordHash string
 	IsActive     bool
+	Birthday     *Ts // date, UTC midnight; nil if not set
 	CreatedAt    Ts
 }
 
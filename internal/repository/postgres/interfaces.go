@@ -8,11 +8,13 @@ import (
 )
 
 type UsersRepo interface {
-	Create(ctx context.Context, db DBTX, phone, passwordHash string) (userID int64, err error)
+	Create(ctx context.Context, db DBTX, phone, passwordHash string, birthday *dto.Ts) (userID int64, err error)
 	GetByPhone(ctx context.Context, db DBTX, phone string) (dto.UserRow, bool, error)
 	GetByID(ctx context.Context, db DBTX, id int64) (dto.UserRow, bool, error)
 	List(ctx context.Context, db DBTX, q string, limit, offset int) ([]dto.UserRow, error)
 	Deactivate(ctx context.Context, db DBTX, id int64) error
+	// SetBirthday sets or (nil) clears the client's birthday.
+	SetBirthday(ctx context.Context, db DBTX, id int64, birthday *dto.Ts) error
 }
 
 type RolesRepo interface {
@@ -27,26 +29,207 @@ type AccountsRepo interface {
 	GetByUserID(ctx context.Context, db DBTX, userID int64) (dto.AccountRow, bool, error)
 	GetByPublicCode(ctx context.Context, db DBTX, publicCode string) (dto.AccountRow, bool, error)
 
+	// ListIDsAfter pages through all account ids in ascending order (keyset on id).
+	ListIDsAfter(ctx context.Context, db DBTX, afterID int64, limit int) ([]int64, error)
+
 	// LockByID must use SELECT ... FOR UPDATE to protect concurrent spend/earn.
 	LockByID(ctx context.Context, db DBTX, accountID int64) (dto.AccountRow, error)
 
 	UpdateAfterEarn(ctx context.Context, db DBTX, accountID int64, balancePoints int, totalSpend dto.Money, levelCode string) (dto.AccountRow, error)
 	UpdateAfterSpend(ctx context.Context, db DBTX, accountID int64, balancePoints int) (dto.AccountRow, error)
+
+	// SetLevel changes only the level (tier requalification); balance and totalSpend stay as they are.
+	SetLevel(ctx context.Context, db DBTX, accountID int64, levelCode string) (dto.AccountRow, error)
+
+	// SetHeld stores the points reserved by active holds.
+	SetHeld(ctx context.Context, db DBTX, accountID int64, heldPoints int) (dto.AccountRow, error)
 }
 
 type RulesRepo interface {
-	// GetEffectiveAt returns the ruleset effective at the provided timestamp (effective_from <= at, newest).
+	// GetEffectiveAt returns the approved ruleset effective at the provided timestamp (effective_from <= at, newest).
 	GetEffectiveAt(ctx context.Context, db DBTX, at time.Time) (dto.RulesetWithLevels, bool, error)
+	GetByID(ctx context.Context, db DBTX, id int64) (dto.RulesetWithLevels, bool, error)
 
-	CreateRuleset(ctx context.Context, db DBTX, effectiveFrom time.Time, baseRubPerPoint dto.Money, levels []dto.LevelRuleRow) (dto.RulesetWithLevels, error)
+	CreateRuleset(ctx context.Context, db DBTX, in dto.RulesetInsert, levels []dto.LevelRuleRow, rates []dto.CategoryEarnRateRow) (dto.RulesetWithLevels, error)
+	// LockByID locks the ruleset row (SELECT ... FOR UPDATE); levels, rates and effective_to are not loaded.
+	LockByID(ctx context.Context, db DBTX, id int64) (dto.RulesetRow, bool, error)
+	// HasEvents reports whether any event references the ruleset.
+	HasEvents(ctx context.Context, db DBTX, id int64) (bool, error)
+	UpdateRuleset(ctx context.Context, db DBTX, id int64, in dto.RulesetInsert, levels []dto.LevelRuleRow, rates []dto.CategoryEarnRateRow) (dto.RulesetWithLevels, error)
+	DeleteRuleset(ctx context.Context, db DBTX, id int64) error
+	SetStatus(ctx context.Context, db DBTX, id int64, status dto.RulesetStatus) error
+	AddHistory(ctx context.Context, db DBTX, in dto.RulesetHistoryInsert) error
 	ListRulesets(ctx context.Context, db DBTX, limit, offset int) ([]dto.RulesetWithLevels, error)
 }
 
 type EventsRepo interface {
 	Insert(ctx context.Context, db DBTX, in dto.EventInsert) (dto.EventRow, error)
+	GetByID(ctx context.Context, db DBTX, id int64) (dto.EventRow, bool, error)
+
+	// SumRefunds aggregates REFUND entries that reference the given EARN event.
+	SumRefunds(ctx context.Context, db DBTX, refEventID int64) (dto.RefundTotals, error)
+
+	// CountByRef counts compensating entries of the given type that reference an event.
+	CountByRef(ctx context.Context, db DBTX, refEventID int64, typ dto.EventType) (int, error)
+
+	// SumByTypeInRange totals events of the given type on the account with from <= ts < to.
+	SumByTypeInRange(ctx context.Context, db DBTX, accountID int64, typ dto.EventType, from, to time.Time) (dto.EventTotals, error)
+
+	// SumCappedEarnPointsInRange totals the ruleset points of EARN events on the account with from <= ts < to:
+	// campaign rewards are left out and refunded points are taken off.
+	SumCappedEarnPointsInRange(ctx context.Context, db DBTX, accountID int64, from, to time.Time) (int, error)
+
+	// SumQualifyingSpendSince totals EARN purchase amounts with ts >= since, net of their refunds.
+	SumQualifyingSpendSince(ctx context.Context, db DBTX, accountID int64, since time.Time) (dto.Money, error)
+
+	// SumByTypeCreatedSince totals events of the given type written on the account at or after since (server time).
+	SumByTypeCreatedSince(ctx context.Context, db DBTX, accountID int64, typ dto.EventType, since time.Time) (dto.EventTotals, error)
+
+	// ListPurchaseAmounts returns the purchases and refunds (negative) with ts < to and a purchase ts >= since
+	// or ts >= from, of the accounts having EARN events in [from, to); oldest first.
+	ListPurchaseAmounts(ctx context.Context, db DBTX, since, from, to time.Time) ([]dto.PurchaseAmountRow, error)
+
+	// ListEarnInRange returns EARN events with from <= ts < to, oldest first.
+	ListEarnInRange(ctx context.Context, db DBTX, from, to time.Time) ([]dto.EarnReplayRow, error)
+
+	// SumEarnSpendBefore totals EARN purchase amounts with ts < from, net of the refunds made before from,
+	// of the accounts having EARN events in [from, to).
+	SumEarnSpendBefore(ctx context.Context, db DBTX, from, to time.Time) ([]dto.AccountSpend, error)
+
+	// CountByActorCreatedSince counts events of the given type written by the actor at or after since (server time).
+	CountByActorCreatedSince(ctx context.Context, db DBTX, actorUserID int64, typ dto.EventType, since time.Time) (int, error)
 
 	// ListByAccount returns newest-first; beforeTs is optional for pagination.
 	ListByAccount(ctx context.Context, db DBTX, accountID int64, limit int, beforeTs *time.Time) ([]dto.EventRow, error)
+
+	// ListAllByAccount returns the whole history oldest-first, in insertion (id) order, with chain hashes.
+	ListAllByAccount(ctx context.Context, db DBTX, accountID int64) ([]dto.EventRow, error)
+
+	// ListUnhashedAccounts returns accounts having events written before the hash chain existed.
+	ListUnhashedAccounts(ctx context.Context, db DBTX, limit int) ([]int64, error)
+	SetHash(ctx context.Context, db DBTX, eventID int64, prevHash, hash []byte) error
+}
+
+type LimitsRepo interface {
+	// List returns all velocity limits ordered by op type and kind.
+	List(ctx context.Context, db DBTX) ([]dto.VelocityLimitRow, error)
+	ListByOpType(ctx context.Context, db DBTX, opType dto.EventType) ([]dto.VelocityLimitRow, error)
+
+	// Upsert creates the limit or replaces the max value of an existing (kind, opType) limit.
+	Upsert(ctx context.Context, db DBTX, in dto.VelocityLimitUpsert) (dto.VelocityLimitRow, error)
+
+	// Delete removes a limit; false if there is no such limit.
+	Delete(ctx context.Context, db DBTX, id int64) (bool, error)
+}
+
+type ReceiptsRepo interface {
+	// InsertLines stores the receipt lines of an EARN event.
+	InsertLines(ctx context.Context, db DBTX, lines []dto.ReceiptLineRow) error
+
+	// ListByEvent returns the receipt lines of an EARN event in receipt order (empty if it was not itemized).
+	ListByEvent(ctx context.Context, db DBTX, eventID int64) ([]dto.ReceiptLineRow, error)
+
+	// ListForEarnInRange returns the receipt lines of the EARN events with from <= ts < to, by event and line.
+	ListForEarnInRange(ctx context.Context, db DBTX, from, to time.Time) ([]dto.ReceiptLineRow, error)
+}
+
+type CampaignsRepo interface {
+	// List returns all campaigns, latest start first.
+	List(ctx context.Context, db DBTX) ([]dto.CampaignRow, error)
+
+	// ListAround returns campaigns whose date range may cover at in some timezone, by id;
+	// the caller checks the schedule exactly.
+	ListAround(ctx context.Context, db DBTX, at time.Time) ([]dto.CampaignRow, error)
+
+	GetByID(ctx context.Context, db DBTX, id int64) (dto.CampaignRow, bool, error)
+	Insert(ctx context.Context, db DBTX, in dto.CampaignWrite) (dto.CampaignRow, error)
+
+	// Update replaces the editable fields; false if there is no such campaign.
+	Update(ctx context.Context, db DBTX, id int64, in dto.CampaignWrite) (dto.CampaignRow, bool, error)
+
+	// Delete removes a campaign; false if there is no such campaign.
+	// A campaign already applied to events cannot be deleted (foreign key violation).
+	Delete(ctx context.Context, db DBTX, id int64) (bool, error)
+
+	// InsertApplied records a campaign applied to an EARN event.
+	InsertApplied(ctx context.Context, db DBTX, in dto.EventCampaignRow) error
+}
+
+type BonusesRepo interface {
+	// InsertGrant records the BONUS event that paid a bonus; a second grant of the kind in the year
+	// violates uq_bonus_grants_account_kind_year.
+	InsertGrant(ctx context.Context, db DBTX, in dto.BonusGrantRow) error
+
+	// ListBirthdayAccountIDsAfter pages accounts of active clients whose birthday falls on one of days
+	// ("MM-DD") and who have no BIRTHDAY grant in year.
+	ListBirthdayAccountIDsAfter(ctx context.Context, db DBTX, afterID int64, days []string, year, limit int) ([]int64, error)
+}
+
+type ReferralsRepo interface {
+	// InsertCode gives an account its referral code; a taken code violates uq_referral_codes_code.
+	InsertCode(ctx context.Context, db DBTX, accountID int64, code string) error
+	GetCode(ctx context.Context, db DBTX, accountID int64) (string, bool, error)
+	GetAccountIDByCode(ctx context.Context, db DBTX, code string) (int64, bool, error)
+
+	Insert(ctx context.Context, db DBTX, refereeAccountID, referrerAccountID int64, at time.Time) error
+	CountByReferrerSince(ctx context.Context, db DBTX, referrerAccountID int64, since time.Time) (int, error)
+	GetPendingByReferee(ctx context.Context, db DBTX, refereeAccountID int64) (dto.ReferralRow, bool, error)
+
+	// MarkRewarded closes a pending referral; false if it was no longer pending.
+	MarkRewarded(ctx context.Context, db DBTX, in dto.ReferralRewardUpdate) (bool, error)
+
+	// GetStats counts the referrals of a referrer; ReferredSince counts those created at or after since.
+	GetStats(ctx context.Context, db DBTX, referrerAccountID int64, since time.Time) (dto.ReferralStatsRow, error)
+}
+
+type LevelChangesRepo interface {
+	// Insert links a LEVEL_CHANGED event to its transition; a second bonus for the same level
+	// violates uq_level_changes_account_bonus_level.
+	Insert(ctx context.Context, db DBTX, in dto.LevelChangeRow) error
+
+	// HasReached reports whether the account has been moved to the level before.
+	HasReached(ctx context.Context, db DBTX, accountID int64, levelCode string) (bool, error)
+}
+
+type AdjustmentsRepo interface {
+	Insert(ctx context.Context, db DBTX, in dto.AdjustmentRow) (dto.AdjustmentRow, error)
+}
+
+type LotsRepo interface {
+	Insert(ctx context.Context, db DBTX, in dto.LotInsert) (dto.LotRow, error)
+
+	// ListOpenForUpdate returns lots with remaining points, oldest expiry first, locked FOR UPDATE.
+	ListOpenForUpdate(ctx context.Context, db DBTX, accountID int64) ([]dto.LotRow, error)
+
+	// ListExpiredForUpdate returns open lots with expires_at <= at, locked FOR UPDATE.
+	ListExpiredForUpdate(ctx context.Context, db DBTX, accountID int64, at time.Time) ([]dto.LotRow, error)
+
+	SetRemaining(ctx context.Context, db DBTX, lotID int64, remaining int) error
+
+	// ListLegacyForUpdate returns the open lots migration 0014 opened for pre-lot balances
+	// that still carry its 12-month expiry, locked FOR UPDATE.
+	ListLegacyForUpdate(ctx context.Context, db DBTX) ([]dto.LotRow, error)
+	SetExpiresAt(ctx context.Context, db DBTX, lotID int64, expiresAt time.Time) error
+
+	// ListAccountsWithExpired returns accounts that have open lots with expires_at <= at.
+	ListAccountsWithExpired(ctx context.Context, db DBTX, at time.Time, limit int) ([]int64, error)
+
+	// NextExpiring returns the nearest expiry day of open lots; false if nothing expires.
+	NextExpiring(ctx context.Context, db DBTX, accountID int64) (dto.ExpiringPoints, bool, error)
+}
+
+type HoldsRepo interface {
+	// Insert creates an ACTIVE hold; inserted=false if the account already has a hold with this operationId.
+	Insert(ctx context.Context, db DBTX, in dto.HoldInsert) (dto.HoldRow, bool, error)
+
+	GetByID(ctx context.Context, db DBTX, id int64) (dto.HoldRow, bool, error)
+	GetByOperation(ctx context.Context, db DBTX, accountID int64, operationID string) (dto.HoldRow, bool, error)
+
+	// Close moves an ACTIVE hold to its final status.
+	Close(ctx context.Context, db DBTX, in dto.HoldClose) (dto.HoldRow, error)
+
+	// ListExpired returns ACTIVE holds with expires_at <= at, oldest first.
+	ListExpired(ctx context.Context, db DBTX, at time.Time, limit int) ([]dto.HoldRow, error)
 }
 
 type OperationsRepo interface {
@@ -60,4 +243,16 @@ type OperationsRepo interface {
 
 	// Finalize stores the HTTP status and response JSON for replay.
 	Finalize(ctx context.Context, db DBTX, in dto.OperationFinalize) error
+
+	// ExpireBefore deletes up to limit records created before the cutoff, oldest first, keeping only
+	// their keys in expired_operations. Rows locked by running operations are skipped.
+	// It returns the number of records removed.
+	ExpireBefore(ctx context.Context, db DBTX, before time.Time, limit int) (int, error)
+
+	// PurgeExpiredBefore deletes up to limit keys kept by ExpireBefore that were expired before the cutoff,
+	// oldest first. It returns the number of keys removed.
+	PurgeExpiredBefore(ctx context.Context, db DBTX, before time.Time, limit int) (int, error)
+
+	// IsExpired reports whether the key belonged to a record removed by ExpireBefore.
+	IsExpired(ctx context.Context, db DBTX, accountID int64, opType dto.OperationType, operationID string) (bool, error)
 }
@@ -6,7 +6,10 @@ import (
 	"github.com/jackc/pgx/v5/pgconn"
 )
 
-const pgUniqueViolation = "23505"
+const (
+	pgUniqueViolation     = "23505"
+	pgForeignKeyViolation = "23503"
+)
 
 // IsUniqueViolation reports whether err is a Postgres unique_violation.
 // If constraintName is empty, it matches any unique_violation.
@@ -21,3 +24,16 @@ func IsUniqueViolation(err error, constraintName string) bool {
 	}
 	return false
 }
+
+// IsForeignKeyViolation reports whether err is a Postgres foreign_key_violation of the given constraint
+// (any constraint if constraintName is empty).
+func IsForeignKeyViolation(err error, constraintName string) bool {
+	var pgerr *pgconn.PgError
+	if errors.As(err, &pgerr) && pgerr != nil && pgerr.Code == pgForeignKeyViolation {
+		if constraintName == "" {
+			return true
+		}
+		return pgerr.ConstraintName == constraintName
+	}
+	return false
+}
@@ -60,6 +60,13 @@ func (r *AccountsRepo) GetByPublicCode(ctx context.Context, db pg.DBTX, publicCo
 	return mapAccountRowFromGetPub(row), true, nil
 }
 
+func (r *AccountsRepo) ListIDsAfter(ctx context.Context, db pg.DBTX, afterID int64, limit int) ([]int64, error) {
+	return r.q.ListAccountIDsAfter(ctx, db, gen.ListAccountIDsAfterParams{
+		ID:    afterID,
+		Limit: int32(limit),
+	})
+}
+
 func (r *AccountsRepo) LockByID(ctx context.Context, db pg.DBTX, accountID int64) (pgdto.AccountRow, error) {
 	row, err := r.q.LockAccountByID(ctx, db, accountID)
 	if err != nil {
@@ -99,6 +106,28 @@ func (r *AccountsRepo) UpdateAfterSpend(ctx context.Context, db pg.DBTX, account
 	return mapAccountRowFromUpdateSpend(row), nil
 }
 
+func (r *AccountsRepo) SetHeld(ctx context.Context, db pg.DBTX, accountID int64, heldPoints int) (pgdto.AccountRow, error) {
+	row, err := r.q.SetAccountHeldPoints(ctx, db, gen.SetAccountHeldPointsParams{
+		ID:         accountID,
+		HeldPoints: int32(heldPoints),
+	})
+	if err != nil {
+		return pgdto.AccountRow{}, err
+	}
+	return mapAccountRowFromSetHeld(row), nil
+}
+
+func (r *AccountsRepo) SetLevel(ctx context.Context, db pg.DBTX, accountID int64, levelCode string) (pgdto.AccountRow, error) {
+	row, err := r.q.SetAccountLevel(ctx, db, gen.SetAccountLevelParams{
+		ID:        accountID,
+		LevelCode: text(levelCode),
+	})
+	if err != nil {
+		return pgdto.AccountRow{}, err
+	}
+	return mapAccountRowFromSetLevel(row), nil
+}
+
 func text(s string) pgtype.Text {
 	return pgtype.Text{String: s, Valid: true}
 }
@@ -111,6 +140,7 @@ func mapAccountBase(
 	balancePoints int32,
 	totalSpendMoney pgdto.Money,
 	levelCode string,
+	heldPoints int32,
 ) pgdto.AccountRow {
 	return pgdto.AccountRow{
 		ID:              id,
@@ -119,6 +149,7 @@ func mapAccountBase(
 		BalancePoints:   int(balancePoints),
 		TotalSpendMoney: totalSpendMoney,
 		LevelCode:       levelCode,
+		HeldPoints:      int(heldPoints),
 		CreatedAt:       createdAt.Time,
 	}
 }
@@ -132,6 +163,7 @@ func mapAccountRowFromCreate(rw gen.CreateAccountForUserRow) pgdto.AccountRow {
 		rw.BalancePoints,
 		rw.TotalSpendMoney,
 		rw.LevelCode,
+		rw.HeldPoints,
 	)
 }
 
@@ -144,6 +176,7 @@ func mapAccountRowFromGet(rw gen.GetAccountByUserIDRow) pgdto.AccountRow {
 		rw.BalancePoints,
 		rw.TotalSpendMoney,
 		rw.LevelCode,
+		rw.HeldPoints,
 	)
 }
 
@@ -156,6 +189,7 @@ func mapAccountRowFromGetPub(rw gen.GetAccountByPublicCodeRow) pgdto.AccountRow
 		rw.BalancePoints,
 		rw.TotalSpendMoney,
 		rw.LevelCode,
+		rw.HeldPoints,
 	)
 }
 
@@ -168,6 +202,7 @@ func mapAccountRowFromLock(rw gen.LockAccountByIDRow) pgdto.AccountRow {
 		rw.BalancePoints,
 		rw.TotalSpendMoney,
 		rw.LevelCode,
+		rw.HeldPoints,
 	)
 }
 
@@ -180,6 +215,7 @@ func mapAccountRowFromUpdateEarn(rw gen.UpdateAccountAfterEarnRow) pgdto.Account
 		rw.BalancePoints,
 		rw.TotalSpendMoney,
 		rw.LevelCode,
+		rw.HeldPoints,
 	)
 }
 
@@ -192,5 +228,32 @@ func mapAccountRowFromUpdateSpend(rw gen.UpdateAccountAfterSpendRow) pgdto.Accou
 		rw.BalancePoints,
 		rw.TotalSpendMoney,
 		rw.LevelCode,
+		rw.HeldPoints,
+	)
+}
+
+func mapAccountRowFromSetLevel(rw gen.SetAccountLevelRow) pgdto.AccountRow {
+	return mapAccountBase(
+		rw.ID,
+		rw.UserID,
+		rw.PublicCode,
+		rw.CreatedAt,
+		rw.BalancePoints,
+		rw.TotalSpendMoney,
+		rw.LevelCode,
+		rw.HeldPoints,
+	)
+}
+
+func mapAccountRowFromSetHeld(rw gen.SetAccountHeldPointsRow) pgdto.AccountRow {
+	return mapAccountBase(
+		rw.ID,
+		rw.UserID,
+		rw.PublicCode,
+		rw.CreatedAt,
+		rw.BalancePoints,
+		rw.TotalSpendMoney,
+		rw.LevelCode,
+		rw.HeldPoints,
 	)
 }
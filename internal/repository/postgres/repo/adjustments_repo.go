@@ -0,0 +1,31 @@
+package repo
+
+import (
+	"context"
+
+	pg "Beanefits/internal/repository/postgres"
+	pgdto "Beanefits/internal/repository/postgres/dto"
+	"Beanefits/internal/repository/postgres/sqlc/gen"
+)
+
+type AdjustmentsRepo struct {
+	q *gen.Queries
+}
+
+func NewAdjustmentsRepo(q *gen.Queries) *AdjustmentsRepo { return &AdjustmentsRepo{q: q} }
+
+func (r *AdjustmentsRepo) Insert(ctx context.Context, db pg.DBTX, in pgdto.AdjustmentRow) (pgdto.AdjustmentRow, error) {
+	row, err := r.q.InsertAdjustment(ctx, db, gen.InsertAdjustmentParams{
+		EventID:    in.EventID,
+		ReasonCode: in.ReasonCode,
+		Comment:    in.Comment,
+	})
+	if err != nil {
+		return pgdto.AdjustmentRow{}, err
+	}
+	return pgdto.AdjustmentRow{
+		EventID:    row.EventID,
+		ReasonCode: row.ReasonCode,
+		Comment:    row.Comment,
+	}, nil
+}
@@ -0,0 +1,33 @@
+package repo
+
+import (
+	"context"
+
+	pg "Beanefits/internal/repository/postgres"
+	pgdto "Beanefits/internal/repository/postgres/dto"
+	"Beanefits/internal/repository/postgres/sqlc/gen"
+)
+
+type BonusesRepo struct {
+	q *gen.Queries
+}
+
+func NewBonusesRepo(q *gen.Queries) *BonusesRepo { return &BonusesRepo{q: q} }
+
+func (r *BonusesRepo) InsertGrant(ctx context.Context, db pg.DBTX, in pgdto.BonusGrantRow) error {
+	return r.q.InsertBonusGrant(ctx, db, gen.InsertBonusGrantParams{
+		EventID:   in.EventID,
+		AccountID: in.AccountID,
+		Kind:      string(in.Kind),
+		Year:      int32(in.Year),
+	})
+}
+
+func (r *BonusesRepo) ListBirthdayAccountIDsAfter(ctx context.Context, db pg.DBTX, afterID int64, days []string, year, limit int) ([]int64, error) {
+	return r.q.ListBirthdayAccountIDsAfter(ctx, db, gen.ListBirthdayAccountIDsAfterParams{
+		ID:      afterID,
+		Column2: days,
+		Year:    int32(year),
+		Limit:   int32(limit),
+	})
+}
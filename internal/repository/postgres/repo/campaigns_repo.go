@@ -0,0 +1,189 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	pg "Beanefits/internal/repository/postgres"
+	pgdto "Beanefits/internal/repository/postgres/dto"
+	"Beanefits/internal/repository/postgres/sqlc/gen"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type CampaignsRepo struct {
+	q *gen.Queries
+}
+
+func NewCampaignsRepo(q *gen.Queries) *CampaignsRepo { return &CampaignsRepo{q: q} }
+
+func (r *CampaignsRepo) List(ctx context.Context, db pg.DBTX) ([]pgdto.CampaignRow, error) {
+	rows, err := r.q.ListCampaigns(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+	return mapCampaigns(rows)
+}
+
+func (r *CampaignsRepo) ListAround(ctx context.Context, db pg.DBTX, at time.Time) ([]pgdto.CampaignRow, error) {
+	rows, err := r.q.ListCampaignsAround(ctx, db, date(at.UTC()))
+	if err != nil {
+		return nil, err
+	}
+	return mapCampaigns(rows)
+}
+
+func (r *CampaignsRepo) GetByID(ctx context.Context, db pg.DBTX, id int64) (pgdto.CampaignRow, bool, error) {
+	row, err := r.q.GetCampaignByID(ctx, db, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return pgdto.CampaignRow{}, false, nil
+		}
+		return pgdto.CampaignRow{}, false, err
+	}
+	c, err := mapCampaign(row)
+	if err != nil {
+		return pgdto.CampaignRow{}, false, err
+	}
+	return c, true, nil
+}
+
+func (r *CampaignsRepo) Insert(ctx context.Context, db pg.DBTX, in pgdto.CampaignWrite) (pgdto.CampaignRow, error) {
+	row, err := r.q.InsertCampaign(ctx, db, gen.InsertCampaignParams{
+		Name:        in.Name,
+		StartsOn:    date(in.StartsOn),
+		EndsOn:      datePtr(in.EndsOn),
+		Weekdays:    int16s(in.Weekdays),
+		TimeFrom:    timeOfDay(in.TimeFrom),
+		TimeTo:      timeOfDay(in.TimeTo),
+		Timezone:    in.Timezone,
+		Multiplier:  numericFromMoneyPtr(in.Multiplier),
+		BonusPoints: int4FromPtr(in.BonusPoints),
+		UpdatedBy:   int8FromPtr(in.UpdatedBy),
+	})
+	if err != nil {
+		return pgdto.CampaignRow{}, err
+	}
+	return mapCampaign(row)
+}
+
+func (r *CampaignsRepo) Update(ctx context.Context, db pg.DBTX, id int64, in pgdto.CampaignWrite) (pgdto.CampaignRow, bool, error) {
+	row, err := r.q.UpdateCampaign(ctx, db, gen.UpdateCampaignParams{
+		ID:          id,
+		Name:        in.Name,
+		StartsOn:    date(in.StartsOn),
+		EndsOn:      datePtr(in.EndsOn),
+		Weekdays:    int16s(in.Weekdays),
+		TimeFrom:    timeOfDay(in.TimeFrom),
+		TimeTo:      timeOfDay(in.TimeTo),
+		Timezone:    in.Timezone,
+		Multiplier:  numericFromMoneyPtr(in.Multiplier),
+		BonusPoints: int4FromPtr(in.BonusPoints),
+		UpdatedBy:   int8FromPtr(in.UpdatedBy),
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return pgdto.CampaignRow{}, false, nil
+		}
+		return pgdto.CampaignRow{}, false, err
+	}
+	c, err := mapCampaign(row)
+	if err != nil {
+		return pgdto.CampaignRow{}, false, err
+	}
+	return c, true, nil
+}
+
+func (r *CampaignsRepo) Delete(ctx context.Context, db pg.DBTX, id int64) (bool, error) {
+	n, err := r.q.DeleteCampaign(ctx, db, id)
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
+
+func (r *CampaignsRepo) InsertApplied(ctx context.Context, db pg.DBTX, in pgdto.EventCampaignRow) error {
+	return r.q.InsertEventCampaign(ctx, db, gen.InsertEventCampaignParams{
+		EventID:     in.EventID,
+		CampaignID:  in.CampaignID,
+		BonusPoints: int32(in.BonusPoints),
+	})
+}
+
+// ---------- mapping ----------
+
+func mapCampaign(rw gen.Campaign) (pgdto.CampaignRow, error) {
+	multiplier, err := moneyPtrFromNumeric(rw.Multiplier)
+	if err != nil {
+		return pgdto.CampaignRow{}, err
+	}
+
+	c := pgdto.CampaignRow{
+		ID:          rw.ID,
+		Name:        rw.Name,
+		StartsOn:    rw.StartsOn.Time,
+		Timezone:    rw.Timezone,
+		Multiplier:  multiplier,
+		BonusPoints: ptrFromInt4(rw.BonusPoints),
+		UpdatedBy:   ptrFromInt8(rw.UpdatedBy),
+		CreatedAt:   rw.CreatedAt.Time,
+		UpdatedAt:   rw.UpdatedAt.Time,
+	}
+	if rw.EndsOn.Valid {
+		t := rw.EndsOn.Time
+		c.EndsOn = &t
+	}
+	c.Weekdays = make([]int, 0, len(rw.Weekdays))
+	for _, d := range rw.Weekdays {
+		c.Weekdays = append(c.Weekdays, int(d))
+	}
+	if rw.TimeFrom.Valid {
+		d := time.Duration(rw.TimeFrom.Microseconds) * time.Microsecond
+		c.TimeFrom = &d
+	}
+	if rw.TimeTo.Valid {
+		d := time.Duration(rw.TimeTo.Microseconds) * time.Microsecond
+		c.TimeTo = &d
+	}
+	return c, nil
+}
+
+func mapCampaigns(rows []gen.Campaign) ([]pgdto.CampaignRow, error) {
+	out := make([]pgdto.CampaignRow, 0, len(rows))
+	for _, rw := range rows {
+		c, err := mapCampaign(rw)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, c)
+	}
+	return out, nil
+}
+
+func date(t time.Time) pgtype.Date {
+	return pgtype.Date{Time: time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC), Valid: true}
+}
+
+func datePtr(t *time.Time) pgtype.Date {
+	if t == nil {
+		return pgtype.Date{}
+	}
+	return date(*t)
+}
+
+func timeOfDay(d *time.Duration) pgtype.Time {
+	if d == nil {
+		return pgtype.Time{}
+	}
+	return pgtype.Time{Microseconds: d.Microseconds(), Valid: true}
+}
+
+func int16s(in []int) []int16 {
+	out := make([]int16, 0, len(in))
+	for _, v := range in {
+		out = append(out, int16(v))
+	}
+	return out
+}
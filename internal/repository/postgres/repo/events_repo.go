@@ -4,6 +4,7 @@ package repo
 import (
 	"context"
 	"database/sql/driver"
+	"errors"
 	"fmt"
 	"time"
 
@@ -11,6 +12,7 @@ import (
 	pgdto "Beanefits/internal/repository/postgres/dto"
 	"Beanefits/internal/repository/postgres/sqlc/gen"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/shopspring/decimal"
 )
@@ -21,7 +23,28 @@ type EventsRepo struct {
 
 func NewEventsRepo(q *gen.Queries) *EventsRepo { return &EventsRepo{q: q} }
 
+// Insert appends the event to the account's hash chain (see pg.EventHash).
+// Callers hold the account lock, so the chain head cannot move between reading it and inserting.
 func (r *EventsRepo) Insert(ctx context.Context, db pg.DBTX, in pgdto.EventInsert) (pgdto.EventRow, error) {
+	prevHash, err := r.q.GetLastEventHash(ctx, db, in.AccountID)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return pgdto.EventRow{}, err
+	}
+
+	// hash exactly what PostgreSQL stores: timestamptz keeps microseconds
+	ts := in.Ts.Truncate(time.Microsecond)
+	hash := pg.EventHash(prevHash, pgdto.EventRow{
+		AccountID:    in.AccountID,
+		Type:         in.Type,
+		DeltaPoints:  in.DeltaPoints,
+		BalanceAfter: in.BalanceAfter,
+		AmountMoney:  in.AmountMoney,
+		RulesetID:    in.RulesetID,
+		ActorUserID:  in.ActorUserID,
+		RefEventID:   in.RefEventID,
+		Ts:           ts,
+	})
+
 	row, err := r.q.InsertEvent(ctx, db, gen.InsertEventParams{
 		AccountID:    in.AccountID,
 		Column2:      gen.EventType(in.Type), // важно: это sqlc enum
@@ -30,13 +53,21 @@ func (r *EventsRepo) Insert(ctx context.Context, db pg.DBTX, in pgdto.EventInser
 		AmountMoney:  numericFromMoneyPtr(in.AmountMoney),
 		RulesetID:    int8FromPtr(in.RulesetID),
 		ActorUserID:  int8FromPtr(in.ActorUserID),
-		Ts:           timestamptz(in.Ts),
+		Ts:           timestamptz(ts),
+		RefEventID:   int8FromPtr(in.RefEventID),
+		PrevHash:     prevHash,
+		Hash:         hash,
 	})
 	if err != nil {
 		return pgdto.EventRow{}, err
 	}
 
-	return mapEventInsertRow(row)
+	ev, err := mapEventInsertRow(row)
+	if err != nil {
+		return pgdto.EventRow{}, err
+	}
+	ev.PrevHash, ev.Hash = row.PrevHash, row.Hash
+	return ev, nil
 }
 
 func (r *EventsRepo) ListByAccount(ctx context.Context, db pg.DBTX, accountID int64, limit int, beforeTs *time.Time) ([]pgdto.EventRow, error) {
@@ -71,6 +102,215 @@ func (r *EventsRepo) ListByAccount(ctx context.Context, db pg.DBTX, accountID in
 	return out, nil
 }
 
+func (r *EventsRepo) ListAllByAccount(ctx context.Context, db pg.DBTX, accountID int64) ([]pgdto.EventRow, error) {
+	rows, err := r.q.ListAllEventsByAccountAsc(ctx, db, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]pgdto.EventRow, 0, len(rows))
+	for _, rw := range rows {
+		ev, err := mapEventBase(
+			rw.ID,
+			rw.AccountID,
+			rw.Type,
+			rw.DeltaPoints,
+			rw.BalanceAfter,
+			rw.AmountMoney,
+			rw.RulesetID,
+			rw.ActorUserID,
+			rw.Ts,
+			rw.CreatedAt,
+			rw.RefEventID,
+		)
+		if err != nil {
+			return nil, err
+		}
+		ev.PrevHash, ev.Hash = rw.PrevHash, rw.Hash
+		out = append(out, ev)
+	}
+	return out, nil
+}
+
+// ListUnhashedAccounts returns accounts that still have events without a hash.
+func (r *EventsRepo) ListUnhashedAccounts(ctx context.Context, db pg.DBTX, limit int) ([]int64, error) {
+	return r.q.ListAccountsWithUnhashedEvents(ctx, db, int32(limit))
+}
+
+func (r *EventsRepo) SetHash(ctx context.Context, db pg.DBTX, eventID int64, prevHash, hash []byte) error {
+	return r.q.SetEventHash(ctx, db, gen.SetEventHashParams{
+		ID:       eventID,
+		PrevHash: prevHash,
+		Hash:     hash,
+	})
+}
+
+func (r *EventsRepo) GetByID(ctx context.Context, db pg.DBTX, id int64) (pgdto.EventRow, bool, error) {
+	row, err := r.q.GetEventByID(ctx, db, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return pgdto.EventRow{}, false, nil
+		}
+		return pgdto.EventRow{}, false, err
+	}
+
+	ev, err := mapEventGetRow(row)
+	if err != nil {
+		return pgdto.EventRow{}, false, err
+	}
+	return ev, true, nil
+}
+
+func (r *EventsRepo) SumRefunds(ctx context.Context, db pg.DBTX, refEventID int64) (pgdto.RefundTotals, error) {
+	row, err := r.q.SumRefundsByEvent(ctx, db, int8FromPtr(&refEventID))
+	if err != nil {
+		return pgdto.RefundTotals{}, err
+	}
+	return pgdto.RefundTotals{
+		RefundedMoney:  pgdto.Money(row.RefundedMoney),
+		RevertedPoints: int(row.RevertedPoints),
+	}, nil
+}
+
+func (r *EventsRepo) CountByRef(ctx context.Context, db pg.DBTX, refEventID int64, typ pgdto.EventType) (int, error) {
+	n, err := r.q.CountEventsByRef(ctx, db, gen.CountEventsByRefParams{
+		RefEventID: int8FromPtr(&refEventID),
+		Column2:    gen.EventType(typ),
+	})
+	if err != nil {
+		return 0, err
+	}
+	return int(n), nil
+}
+
+func (r *EventsRepo) SumByTypeInRange(ctx context.Context, db pg.DBTX, accountID int64, typ pgdto.EventType, from, to time.Time) (pgdto.EventTotals, error) {
+	row, err := r.q.SumEventsByTypeInRange(ctx, db, gen.SumEventsByTypeInRangeParams{
+		AccountID: accountID,
+		Column2:   gen.EventType(typ),
+		Ts:        timestamptz(from),
+		Ts_2:      timestamptz(to),
+	})
+	if err != nil {
+		return pgdto.EventTotals{}, err
+	}
+	return pgdto.EventTotals{
+		Count:  int(row.EventsCount),
+		Points: int(row.Points),
+	}, nil
+}
+
+func (r *EventsRepo) SumCappedEarnPointsInRange(ctx context.Context, db pg.DBTX, accountID int64, from, to time.Time) (int, error) {
+	points, err := r.q.SumCappedEarnPointsInRange(ctx, db, gen.SumCappedEarnPointsInRangeParams{
+		AccountID: accountID,
+		Ts:        timestamptz(from),
+		Ts_2:      timestamptz(to),
+	})
+	if err != nil {
+		return 0, err
+	}
+	return int(points), nil
+}
+
+func (r *EventsRepo) SumQualifyingSpendSince(ctx context.Context, db pg.DBTX, accountID int64, since time.Time) (pgdto.Money, error) {
+	spend, err := r.q.SumQualifyingSpendSince(ctx, db, gen.SumQualifyingSpendSinceParams{
+		AccountID: accountID,
+		Column2:   timestamptz(since),
+	})
+	if err != nil {
+		return pgdto.Money{}, err
+	}
+	return pgdto.Money(spend), nil
+}
+
+func (r *EventsRepo) ListEarnInRange(ctx context.Context, db pg.DBTX, from, to time.Time) ([]pgdto.EarnReplayRow, error) {
+	rows, err := r.q.ListEarnEventsInRange(ctx, db, gen.ListEarnEventsInRangeParams{
+		Ts:   timestamptz(from),
+		Ts_2: timestamptz(to),
+	})
+	if err != nil {
+		return nil, err
+	}
+	out := make([]pgdto.EarnReplayRow, 0, len(rows))
+	for _, rw := range rows {
+		out = append(out, pgdto.EarnReplayRow{
+			EventID:        rw.ID,
+			AccountID:      rw.AccountID,
+			PublicCode:     rw.PublicCode,
+			DeltaPoints:    int(rw.DeltaPoints),
+			CampaignPoints: int(rw.CampaignPoints),
+			AmountMoney:    pgdto.Money(rw.AmountMoney),
+			Ts:             rw.Ts.Time,
+		})
+	}
+	return out, nil
+}
+
+func (r *EventsRepo) ListPurchaseAmounts(ctx context.Context, db pg.DBTX, since, from, to time.Time) ([]pgdto.PurchaseAmountRow, error) {
+	rows, err := r.q.ListPurchaseAmountsForEarnAccounts(ctx, db, gen.ListPurchaseAmountsForEarnAccountsParams{
+		Column1: timestamptz(since),
+		Ts:      timestamptz(from),
+		Ts_2:    timestamptz(to),
+	})
+	if err != nil {
+		return nil, err
+	}
+	out := make([]pgdto.PurchaseAmountRow, 0, len(rows))
+	for _, rw := range rows {
+		out = append(out, pgdto.PurchaseAmountRow{
+			AccountID:   rw.AccountID,
+			PurchaseTs:  rw.PurchaseTs.Time,
+			Ts:          rw.Ts.Time,
+			AmountMoney: pgdto.Money(rw.AmountMoney),
+		})
+	}
+	return out, nil
+}
+
+func (r *EventsRepo) SumEarnSpendBefore(ctx context.Context, db pg.DBTX, from, to time.Time) ([]pgdto.AccountSpend, error) {
+	rows, err := r.q.SumEarnSpendBeforeByAccount(ctx, db, gen.SumEarnSpendBeforeByAccountParams{
+		Ts:   timestamptz(from),
+		Ts_2: timestamptz(to),
+	})
+	if err != nil {
+		return nil, err
+	}
+	out := make([]pgdto.AccountSpend, 0, len(rows))
+	for _, rw := range rows {
+		out = append(out, pgdto.AccountSpend{
+			AccountID:  rw.AccountID,
+			SpendMoney: pgdto.Money(rw.SpendMoney),
+		})
+	}
+	return out, nil
+}
+
+func (r *EventsRepo) SumByTypeCreatedSince(ctx context.Context, db pg.DBTX, accountID int64, typ pgdto.EventType, since time.Time) (pgdto.EventTotals, error) {
+	row, err := r.q.SumEventsByTypeCreatedSince(ctx, db, gen.SumEventsByTypeCreatedSinceParams{
+		AccountID: accountID,
+		Column2:   gen.EventType(typ),
+		CreatedAt: timestamptz(since),
+	})
+	if err != nil {
+		return pgdto.EventTotals{}, err
+	}
+	return pgdto.EventTotals{
+		Count:  int(row.EventsCount),
+		Points: int(row.Points),
+	}, nil
+}
+
+func (r *EventsRepo) CountByActorCreatedSince(ctx context.Context, db pg.DBTX, actorUserID int64, typ pgdto.EventType, since time.Time) (int, error) {
+	n, err := r.q.CountEventsByActorCreatedSince(ctx, db, gen.CountEventsByActorCreatedSinceParams{
+		ActorUserID: int8FromPtr(&actorUserID),
+		Column2:     gen.EventType(typ),
+		CreatedAt:   timestamptz(since),
+	})
+	if err != nil {
+		return 0, err
+	}
+	return int(n), nil
+}
+
 // ---------- mapping ----------
 
 func mapEventInsertRow(rw gen.InsertEventRow) (pgdto.EventRow, error) {
@@ -85,6 +325,7 @@ func mapEventInsertRow(rw gen.InsertEventRow) (pgdto.EventRow, error) {
 		rw.ActorUserID,
 		rw.Ts,
 		rw.CreatedAt,
+		rw.RefEventID,
 	)
 }
 
@@ -102,6 +343,7 @@ func mapEventListRows(rows []gen.ListEventsByAccountRow) ([]pgdto.EventRow, erro
 			rw.ActorUserID,
 			rw.Ts,
 			rw.CreatedAt,
+			rw.RefEventID,
 		)
 		if err != nil {
 			return nil, err
@@ -123,6 +365,23 @@ func mapEventListBeforeRow(rw gen.ListEventsByAccountBeforeRow) (pgdto.EventRow,
 		rw.ActorUserID,
 		rw.Ts,
 		rw.CreatedAt,
+		rw.RefEventID,
+	)
+}
+
+func mapEventGetRow(rw gen.GetEventByIDRow) (pgdto.EventRow, error) {
+	return mapEventBase(
+		rw.ID,
+		rw.AccountID,
+		rw.Type,
+		rw.DeltaPoints,
+		rw.BalanceAfter,
+		rw.AmountMoney,
+		rw.RulesetID,
+		rw.ActorUserID,
+		rw.Ts,
+		rw.CreatedAt,
+		rw.RefEventID,
 	)
 }
 
@@ -137,6 +396,7 @@ func mapEventBase(
 	actorUserID pgtype.Int8,
 	ts pgtype.Timestamptz,
 	createdAt pgtype.Timestamptz,
+	refEventID pgtype.Int8,
 ) (pgdto.EventRow, error) {
 	amt, err := moneyPtrFromNumeric(amountMoney)
 	if err != nil {
@@ -152,6 +412,7 @@ func mapEventBase(
 		AmountMoney:  amt,
 		RulesetID:    ptrFromInt8(rulesetID),
 		ActorUserID:  ptrFromInt8(actorUserID),
+		RefEventID:   ptrFromInt8(refEventID),
 		Ts:           ts.Time,
 		CreatedAt:    createdAt.Time,
 	}, nil
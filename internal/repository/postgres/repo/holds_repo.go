@@ -0,0 +1,132 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	pg "Beanefits/internal/repository/postgres"
+	pgdto "Beanefits/internal/repository/postgres/dto"
+	"Beanefits/internal/repository/postgres/sqlc/gen"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type HoldsRepo struct {
+	q *gen.Queries
+}
+
+func NewHoldsRepo(q *gen.Queries) *HoldsRepo { return &HoldsRepo{q: q} }
+
+func (r *HoldsRepo) Insert(ctx context.Context, db pg.DBTX, in pgdto.HoldInsert) (pgdto.HoldRow, bool, error) {
+	row, err := r.q.InsertHold(ctx, db, gen.InsertHoldParams{
+		AccountID:   in.AccountID,
+		OperationID: in.OperationID,
+		Points:      int32(in.Points),
+		ExpiresAt:   timestamptz(in.ExpiresAt),
+		ActorUserID: int8FromPtr(in.ActorUserID),
+	})
+	if err != nil {
+		// ON CONFLICT DO NOTHING returns no row
+		if errors.Is(err, pgx.ErrNoRows) {
+			return pgdto.HoldRow{}, false, nil
+		}
+		return pgdto.HoldRow{}, false, err
+	}
+	return mapHold(row), true, nil
+}
+
+func (r *HoldsRepo) GetByID(ctx context.Context, db pg.DBTX, id int64) (pgdto.HoldRow, bool, error) {
+	row, err := r.q.GetHoldByID(ctx, db, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return pgdto.HoldRow{}, false, nil
+		}
+		return pgdto.HoldRow{}, false, err
+	}
+	return mapHold(row), true, nil
+}
+
+func (r *HoldsRepo) GetByOperation(ctx context.Context, db pg.DBTX, accountID int64, operationID string) (pgdto.HoldRow, bool, error) {
+	row, err := r.q.GetHoldByOperation(ctx, db, gen.GetHoldByOperationParams{
+		AccountID:   accountID,
+		OperationID: operationID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return pgdto.HoldRow{}, false, nil
+		}
+		return pgdto.HoldRow{}, false, err
+	}
+	return mapHold(row), true, nil
+}
+
+func (r *HoldsRepo) Close(ctx context.Context, db pg.DBTX, in pgdto.HoldClose) (pgdto.HoldRow, error) {
+	row, err := r.q.CloseHold(ctx, db, gen.CloseHoldParams{
+		ID:             in.ID,
+		Status:         string(in.Status),
+		CapturedPoints: int4FromPtr(in.CapturedPoints),
+		CaptureEventID: int8FromPtr(in.CaptureEventID),
+		ClosedAt:       timestamptz(in.ClosedAt),
+	})
+	if err != nil {
+		return pgdto.HoldRow{}, err
+	}
+	return mapHold(row), nil
+}
+
+func (r *HoldsRepo) ListExpired(ctx context.Context, db pg.DBTX, at time.Time, limit int) ([]pgdto.HoldRow, error) {
+	rows, err := r.q.ListExpiredHolds(ctx, db, gen.ListExpiredHoldsParams{
+		ExpiresAt: timestamptz(at),
+		Limit:     int32(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+	out := make([]pgdto.HoldRow, 0, len(rows))
+	for _, rw := range rows {
+		out = append(out, mapHold(rw))
+	}
+	return out, nil
+}
+
+// ---------- mapping ----------
+
+func mapHold(rw gen.Hold) pgdto.HoldRow {
+	h := pgdto.HoldRow{
+		ID:             rw.ID,
+		AccountID:      rw.AccountID,
+		OperationID:    rw.OperationID,
+		Points:         int(rw.Points),
+		Status:         pgdto.HoldStatus(rw.Status),
+		ExpiresAt:      rw.ExpiresAt.Time,
+		CaptureEventID: ptrFromInt8(rw.CaptureEventID),
+		ActorUserID:    ptrFromInt8(rw.ActorUserID),
+		CreatedAt:      rw.CreatedAt.Time,
+	}
+	if rw.CapturedPoints.Valid {
+		p := int(rw.CapturedPoints.Int32)
+		h.CapturedPoints = &p
+	}
+	if rw.ClosedAt.Valid {
+		t := rw.ClosedAt.Time
+		h.ClosedAt = &t
+	}
+	return h
+}
+
+func int4FromPtr(v *int) pgtype.Int4 {
+	if v == nil {
+		return pgtype.Int4{}
+	}
+	return pgtype.Int4{Int32: int32(*v), Valid: true}
+}
+
+func ptrFromInt4(v pgtype.Int4) *int {
+	if !v.Valid {
+		return nil
+	}
+	n := int(v.Int32)
+	return &n
+}
@@ -0,0 +1,29 @@
+package repo
+
+import (
+	"context"
+
+	pg "Beanefits/internal/repository/postgres"
+	pgdto "Beanefits/internal/repository/postgres/dto"
+	"Beanefits/internal/repository/postgres/sqlc/gen"
+)
+
+type LevelChangesRepo struct {
+	q *gen.Queries
+}
+
+func NewLevelChangesRepo(q *gen.Queries) *LevelChangesRepo { return &LevelChangesRepo{q: q} }
+
+func (r *LevelChangesRepo) Insert(ctx context.Context, db pg.DBTX, in pgdto.LevelChangeRow) error {
+	return r.q.InsertLevelChange(ctx, db, gen.InsertLevelChangeParams{
+		EventID:      in.EventID,
+		AccountID:    in.AccountID,
+		FromLevel:    in.FromLevel,
+		ToLevel:      in.ToLevel,
+		BonusEventID: int8FromPtr(in.BonusEventID),
+	})
+}
+
+func (r *LevelChangesRepo) HasReached(ctx context.Context, db pg.DBTX, accountID int64, levelCode string) (bool, error) {
+	return r.q.HasReachedLevel(ctx, db, gen.HasReachedLevelParams{AccountID: accountID, ToLevel: levelCode})
+}
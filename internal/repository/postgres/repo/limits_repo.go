@@ -0,0 +1,74 @@
+package repo
+
+import (
+	"context"
+
+	pg "Beanefits/internal/repository/postgres"
+	pgdto "Beanefits/internal/repository/postgres/dto"
+	"Beanefits/internal/repository/postgres/sqlc/gen"
+)
+
+type LimitsRepo struct {
+	q *gen.Queries
+}
+
+func NewLimitsRepo(q *gen.Queries) *LimitsRepo { return &LimitsRepo{q: q} }
+
+func (r *LimitsRepo) List(ctx context.Context, db pg.DBTX) ([]pgdto.VelocityLimitRow, error) {
+	rows, err := r.q.ListVelocityLimits(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+	return mapVelocityLimits(rows), nil
+}
+
+func (r *LimitsRepo) ListByOpType(ctx context.Context, db pg.DBTX, opType pgdto.EventType) ([]pgdto.VelocityLimitRow, error) {
+	rows, err := r.q.ListVelocityLimitsByOpType(ctx, db, gen.EventType(opType))
+	if err != nil {
+		return nil, err
+	}
+	return mapVelocityLimits(rows), nil
+}
+
+func (r *LimitsRepo) Upsert(ctx context.Context, db pg.DBTX, in pgdto.VelocityLimitUpsert) (pgdto.VelocityLimitRow, error) {
+	row, err := r.q.UpsertVelocityLimit(ctx, db, gen.UpsertVelocityLimitParams{
+		Kind:      string(in.Kind),
+		Column2:   gen.EventType(in.OpType),
+		MaxValue:  int32(in.MaxValue),
+		UpdatedBy: int8FromPtr(in.UpdatedBy),
+	})
+	if err != nil {
+		return pgdto.VelocityLimitRow{}, err
+	}
+	return mapVelocityLimit(row), nil
+}
+
+func (r *LimitsRepo) Delete(ctx context.Context, db pg.DBTX, id int64) (bool, error) {
+	n, err := r.q.DeleteVelocityLimit(ctx, db, id)
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
+
+// ---------- mapping ----------
+
+func mapVelocityLimit(rw gen.VelocityLimit) pgdto.VelocityLimitRow {
+	return pgdto.VelocityLimitRow{
+		ID:        rw.ID,
+		Kind:      pgdto.LimitKind(rw.Kind),
+		OpType:    pgdto.EventType(rw.OpType),
+		MaxValue:  int(rw.MaxValue),
+		UpdatedBy: ptrFromInt8(rw.UpdatedBy),
+		CreatedAt: rw.CreatedAt.Time,
+		UpdatedAt: rw.UpdatedAt.Time,
+	}
+}
+
+func mapVelocityLimits(rows []gen.VelocityLimit) []pgdto.VelocityLimitRow {
+	out := make([]pgdto.VelocityLimitRow, 0, len(rows))
+	for _, rw := range rows {
+		out = append(out, mapVelocityLimit(rw))
+	}
+	return out
+}
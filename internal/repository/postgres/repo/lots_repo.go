@@ -0,0 +1,118 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	pg "Beanefits/internal/repository/postgres"
+	pgdto "Beanefits/internal/repository/postgres/dto"
+	"Beanefits/internal/repository/postgres/sqlc/gen"
+
+	"github.com/jackc/pgx/v5"
+)
+
+type LotsRepo struct {
+	q *gen.Queries
+}
+
+func NewLotsRepo(q *gen.Queries) *LotsRepo { return &LotsRepo{q: q} }
+
+func (r *LotsRepo) Insert(ctx context.Context, db pg.DBTX, in pgdto.LotInsert) (pgdto.LotRow, error) {
+	row, err := r.q.InsertPointLot(ctx, db, gen.InsertPointLotParams{
+		AccountID:     in.AccountID,
+		SourceEventID: int8FromPtr(in.SourceEventID),
+		Points:        int32(in.Points),
+		EarnedAt:      timestamptz(in.EarnedAt),
+		ExpiresAt:     timestamptz(in.ExpiresAt),
+	})
+	if err != nil {
+		return pgdto.LotRow{}, err
+	}
+	return mapLot(row), nil
+}
+
+func (r *LotsRepo) ListOpenForUpdate(ctx context.Context, db pg.DBTX, accountID int64) ([]pgdto.LotRow, error) {
+	rows, err := r.q.ListOpenPointLotsForUpdate(ctx, db, accountID)
+	if err != nil {
+		return nil, err
+	}
+	return mapLots(rows), nil
+}
+
+func (r *LotsRepo) ListExpiredForUpdate(ctx context.Context, db pg.DBTX, accountID int64, at time.Time) ([]pgdto.LotRow, error) {
+	rows, err := r.q.ListExpiredPointLotsForUpdate(ctx, db, gen.ListExpiredPointLotsForUpdateParams{
+		AccountID: accountID,
+		ExpiresAt: timestamptz(at),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return mapLots(rows), nil
+}
+
+func (r *LotsRepo) SetRemaining(ctx context.Context, db pg.DBTX, lotID int64, remaining int) error {
+	return r.q.SetPointLotRemaining(ctx, db, gen.SetPointLotRemainingParams{
+		ID:              lotID,
+		RemainingPoints: int32(remaining),
+	})
+}
+
+func (r *LotsRepo) ListLegacyForUpdate(ctx context.Context, db pg.DBTX) ([]pgdto.LotRow, error) {
+	rows, err := r.q.ListLegacyPointLotsForUpdate(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+	return mapLots(rows), nil
+}
+
+func (r *LotsRepo) SetExpiresAt(ctx context.Context, db pg.DBTX, lotID int64, expiresAt time.Time) error {
+	return r.q.SetPointLotExpiresAt(ctx, db, gen.SetPointLotExpiresAtParams{
+		ID:        lotID,
+		ExpiresAt: timestamptz(expiresAt),
+	})
+}
+
+func (r *LotsRepo) ListAccountsWithExpired(ctx context.Context, db pg.DBTX, at time.Time, limit int) ([]int64, error) {
+	return r.q.ListAccountsWithExpiredPointLots(ctx, db, gen.ListAccountsWithExpiredPointLotsParams{
+		ExpiresAt: timestamptz(at),
+		Limit:     int32(limit),
+	})
+}
+
+func (r *LotsRepo) NextExpiring(ctx context.Context, db pg.DBTX, accountID int64) (pgdto.ExpiringPoints, bool, error) {
+	row, err := r.q.GetNextExpiringPoints(ctx, db, accountID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return pgdto.ExpiringPoints{}, false, nil
+		}
+		return pgdto.ExpiringPoints{}, false, err
+	}
+	return pgdto.ExpiringPoints{
+		Points: int(row.Points),
+		On:     row.ExpiresOn.Time,
+	}, true, nil
+}
+
+// ---------- mapping ----------
+
+func mapLots(rows []gen.PointLot) []pgdto.LotRow {
+	out := make([]pgdto.LotRow, 0, len(rows))
+	for _, rw := range rows {
+		out = append(out, mapLot(rw))
+	}
+	return out
+}
+
+func mapLot(rw gen.PointLot) pgdto.LotRow {
+	return pgdto.LotRow{
+		ID:              rw.ID,
+		AccountID:       rw.AccountID,
+		SourceEventID:   ptrFromInt8(rw.SourceEventID),
+		Points:          int(rw.Points),
+		RemainingPoints: int(rw.RemainingPoints),
+		EarnedAt:        rw.EarnedAt.Time,
+		ExpiresAt:       rw.ExpiresAt.Time,
+		CreatedAt:       rw.CreatedAt.Time,
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"time"
 
 	pg "Beanefits/internal/repository/postgres"
 	pgdto "Beanefits/internal/repository/postgres/dto"
@@ -49,8 +50,10 @@ func (r *OperationsRepo) Get(
 		RequestJSON:  json.RawMessage(bytesOrNullJSON(row.RequestJson)),
 		ResponseJSON: nil,
 		HTTPStatus:   nil,
+		EventID:      ptrFromInt8(row.EventID),
 
-		CreatedAt: row.CreatedAt.Time,
+		CreatedAt:        row.CreatedAt.Time,
+		CanonicalRequest: row.CanonicalRequest,
 	}
 
 	// response_json может быть NULL -> sqlc даёт []byte, но pgx возвращает nil для NULL
@@ -92,6 +95,31 @@ func (r *OperationsRepo) Finalize(ctx context.Context, db pg.DBTX, in pgdto.Oper
 		OperationID:  in.OperationID,
 		HttpStatus:   status,
 		ResponseJson: []byte(in.ResponseJSON),
+		EventID:      int8FromPtr(in.EventID),
+	})
+}
+
+func (r *OperationsRepo) ExpireBefore(ctx context.Context, db pg.DBTX, before time.Time, limit int) (int, error) {
+	n, err := r.q.ExpireOperationsBefore(ctx, db, gen.ExpireOperationsBeforeParams{
+		CreatedAt: timestamptz(before),
+		Limit:     int32(limit),
+	})
+	return int(n), err
+}
+
+func (r *OperationsRepo) PurgeExpiredBefore(ctx context.Context, db pg.DBTX, before time.Time, limit int) (int, error) {
+	n, err := r.q.PurgeExpiredOperationsBefore(ctx, db, gen.PurgeExpiredOperationsBeforeParams{
+		ExpiredAt: timestamptz(before),
+		Limit:     int32(limit),
+	})
+	return int(n), err
+}
+
+func (r *OperationsRepo) IsExpired(ctx context.Context, db pg.DBTX, accountID int64, opType pgdto.OperationType, operationID string) (bool, error) {
+	return r.q.IsOperationExpired(ctx, db, gen.IsOperationExpiredParams{
+		AccountID:   accountID,
+		Column2:     gen.EventType(opType),
+		OperationID: operationID,
 	})
 }
 
@@ -0,0 +1,69 @@
+package repo
+
+import (
+	"context"
+	"time"
+
+	pg "Beanefits/internal/repository/postgres"
+	pgdto "Beanefits/internal/repository/postgres/dto"
+	"Beanefits/internal/repository/postgres/sqlc/gen"
+)
+
+type ReceiptsRepo struct {
+	q *gen.Queries
+}
+
+func NewReceiptsRepo(q *gen.Queries) *ReceiptsRepo { return &ReceiptsRepo{q: q} }
+
+func (r *ReceiptsRepo) InsertLines(ctx context.Context, db pg.DBTX, lines []pgdto.ReceiptLineRow) error {
+	for _, l := range lines {
+		err := r.q.InsertReceiptLine(ctx, db, gen.InsertReceiptLineParams{
+			EventID:    l.EventID,
+			LineNo:     int32(l.LineNo),
+			Sku:        l.SKU,
+			Category:   l.Category,
+			Quantity:   int32(l.Quantity),
+			LineTotal:  l.LineTotal,
+			EarnFactor: l.EarnFactor,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *ReceiptsRepo) ListByEvent(ctx context.Context, db pg.DBTX, eventID int64) ([]pgdto.ReceiptLineRow, error) {
+	rows, err := r.q.ListReceiptLinesByEventID(ctx, db, eventID)
+	if err != nil {
+		return nil, err
+	}
+	return receiptLines(rows), nil
+}
+
+func (r *ReceiptsRepo) ListForEarnInRange(ctx context.Context, db pg.DBTX, from, to time.Time) ([]pgdto.ReceiptLineRow, error) {
+	rows, err := r.q.ListReceiptLinesForEarnInRange(ctx, db, gen.ListReceiptLinesForEarnInRangeParams{
+		Ts:   timestamptz(from),
+		Ts_2: timestamptz(to),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return receiptLines(rows), nil
+}
+
+func receiptLines(rows []gen.ReceiptLine) []pgdto.ReceiptLineRow {
+	out := make([]pgdto.ReceiptLineRow, 0, len(rows))
+	for _, l := range rows {
+		out = append(out, pgdto.ReceiptLineRow{
+			EventID:    l.EventID,
+			LineNo:     int(l.LineNo),
+			SKU:        l.Sku,
+			Category:   l.Category,
+			Quantity:   int(l.Quantity),
+			LineTotal:  l.LineTotal,
+			EarnFactor: l.EarnFactor,
+		})
+	}
+	return out
+}
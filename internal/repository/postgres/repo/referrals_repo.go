@@ -0,0 +1,120 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	pg "Beanefits/internal/repository/postgres"
+	pgdto "Beanefits/internal/repository/postgres/dto"
+	"Beanefits/internal/repository/postgres/sqlc/gen"
+)
+
+type ReferralsRepo struct {
+	q *gen.Queries
+}
+
+func NewReferralsRepo(q *gen.Queries) *ReferralsRepo { return &ReferralsRepo{q: q} }
+
+func (r *ReferralsRepo) InsertCode(ctx context.Context, db pg.DBTX, accountID int64, code string) error {
+	return r.q.InsertReferralCode(ctx, db, gen.InsertReferralCodeParams{AccountID: accountID, Code: code})
+}
+
+func (r *ReferralsRepo) GetCode(ctx context.Context, db pg.DBTX, accountID int64) (string, bool, error) {
+	code, err := r.q.GetReferralCodeByAccountID(ctx, db, accountID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", false, nil
+		}
+		return "", false, err
+	}
+	return code, true, nil
+}
+
+func (r *ReferralsRepo) GetAccountIDByCode(ctx context.Context, db pg.DBTX, code string) (int64, bool, error) {
+	id, err := r.q.GetAccountIDByReferralCode(ctx, db, code)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, false, nil
+		}
+		return 0, false, err
+	}
+	return id, true, nil
+}
+
+func (r *ReferralsRepo) Insert(ctx context.Context, db pg.DBTX, refereeAccountID, referrerAccountID int64, at time.Time) error {
+	return r.q.InsertReferral(ctx, db, gen.InsertReferralParams{
+		RefereeAccountID:  refereeAccountID,
+		ReferrerAccountID: referrerAccountID,
+		CreatedAt:         timestamptz(at),
+	})
+}
+
+func (r *ReferralsRepo) CountByReferrerSince(ctx context.Context, db pg.DBTX, referrerAccountID int64, since time.Time) (int, error) {
+	n, err := r.q.CountReferralsByReferrerSince(ctx, db, gen.CountReferralsByReferrerSinceParams{
+		ReferrerAccountID: referrerAccountID,
+		CreatedAt:         timestamptz(since),
+	})
+	return int(n), err
+}
+
+func (r *ReferralsRepo) GetPendingByReferee(ctx context.Context, db pg.DBTX, refereeAccountID int64) (pgdto.ReferralRow, bool, error) {
+	rw, err := r.q.GetPendingReferralByReferee(ctx, db, refereeAccountID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return pgdto.ReferralRow{}, false, nil
+		}
+		return pgdto.ReferralRow{}, false, err
+	}
+	return mapReferral(rw), true, nil
+}
+
+func (r *ReferralsRepo) MarkRewarded(ctx context.Context, db pg.DBTX, in pgdto.ReferralRewardUpdate) (bool, error) {
+	n, err := r.q.MarkReferralRewarded(ctx, db, gen.MarkReferralRewardedParams{
+		RefereeAccountID:  in.RefereeAccountID,
+		RewardedAt:        timestamptz(in.RewardedAt),
+		QualifyingEventID: int8FromPtr(&in.QualifyingEventID),
+		ReferrerEventID:   int8FromPtr(in.ReferrerEventID),
+		RefereeEventID:    int8FromPtr(in.RefereeEventID),
+	})
+	if err != nil {
+		return false, err
+	}
+	return n == 1, nil
+}
+
+func (r *ReferralsRepo) GetStats(ctx context.Context, db pg.DBTX, referrerAccountID int64, since time.Time) (pgdto.ReferralStatsRow, error) {
+	rw, err := r.q.GetReferralStatsByReferrer(ctx, db, gen.GetReferralStatsByReferrerParams{
+		ReferrerAccountID: referrerAccountID,
+		CreatedAt:         timestamptz(since),
+	})
+	if err != nil {
+		return pgdto.ReferralStatsRow{}, err
+	}
+	return pgdto.ReferralStatsRow{
+		Referred:      int(rw.Referred),
+		Rewarded:      int(rw.Rewarded),
+		ReferredSince: int(rw.ReferredSince),
+		BonusPoints:   int(rw.BonusPoints),
+	}, nil
+}
+
+// ---------- mapping ----------
+
+func mapReferral(rw gen.Referral) pgdto.ReferralRow {
+	out := pgdto.ReferralRow{
+		RefereeAccountID:  rw.RefereeAccountID,
+		ReferrerAccountID: rw.ReferrerAccountID,
+		CreatedAt:         rw.CreatedAt.Time,
+		QualifyingEventID: ptrFromInt8(rw.QualifyingEventID),
+		ReferrerEventID:   ptrFromInt8(rw.ReferrerEventID),
+		RefereeEventID:    ptrFromInt8(rw.RefereeEventID),
+	}
+	if rw.RewardedAt.Valid {
+		t := rw.RewardedAt.Time
+		out.RewardedAt = &t
+	}
+	return out
+}
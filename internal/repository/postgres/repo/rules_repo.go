@@ -11,6 +11,7 @@ import (
 	"Beanefits/internal/repository/postgres/sqlc/gen"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 type RulesRepo struct {
@@ -28,58 +29,166 @@ func (r *RulesRepo) GetEffectiveAt(ctx context.Context, db pg.DBTX, at time.Time
 		return pgdto.RulesetWithLevels{}, false, err
 	}
 
-	levels, err := r.q.ListLevelRulesByRulesetID(ctx, db, rs.ID)
+	out, err := r.withLevels(ctx, db, mapRulesetEffective(rs))
 	if err != nil {
 		return pgdto.RulesetWithLevels{}, false, err
 	}
+	return out, true, nil
+}
 
-	return pgdto.RulesetWithLevels{
-		Ruleset: mapRulesetEffective(rs),
-		Levels:  mapLevelRules(levels),
-	}, true, nil
+func (r *RulesRepo) GetByID(ctx context.Context, db pg.DBTX, id int64) (pgdto.RulesetWithLevels, bool, error) {
+	rs, err := r.q.GetRulesetByID(ctx, db, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return pgdto.RulesetWithLevels{}, false, nil
+		}
+		return pgdto.RulesetWithLevels{}, false, err
+	}
+
+	out, err := r.withLevels(ctx, db, mapRulesetByID(rs))
+	if err != nil {
+		return pgdto.RulesetWithLevels{}, false, err
+	}
+	return out, true, nil
 }
 
 func (r *RulesRepo) CreateRuleset(
 	ctx context.Context,
 	db pg.DBTX,
-	effectiveFrom time.Time,
-	baseRubPerPoint pgdto.Money,
+	in pgdto.RulesetInsert,
 	levels []pgdto.LevelRuleRow,
+	rates []pgdto.CategoryEarnRateRow,
 ) (pgdto.RulesetWithLevels, error) {
 	rs, err := r.q.InsertRuleset(ctx, db, gen.InsertRulesetParams{
-		EffectiveFrom:   timestamptz(effectiveFrom),
-		BaseRubPerPoint: baseRubPerPoint,
+		EffectiveFrom:           timestamptz(in.EffectiveFrom),
+		BaseRubPerPoint:         in.BaseRubPerPoint,
+		RedeemRubPerPoint:       in.RedeemRubPerPoint,
+		QualificationWindowDays: int4FromPtr(in.QualificationWindowDays),
+		WelcomeBonusPoints:      int32(in.WelcomeBonusPoints),
+		BirthdayBonusPoints:     int32(in.BirthdayBonusPoints),
+		ReferrerBonusPoints:     int32(in.ReferrerBonusPoints),
+		RefereeBonusPoints:      int32(in.RefereeBonusPoints),
+		RoundingMode:            in.RoundingMode,
+		RoundingStage:           in.RoundingStage,
+		MinEarnAmount:           in.MinEarnAmount,
+		MaxPointsPerPurchase:    int4FromPtr(in.MaxPointsPerPurchase),
+		MaxPointsPerDay:         int4FromPtr(in.MaxPointsPerDay),
+		CreatedBy:               int8FromPtr(in.CreatedBy),
 	})
 	if err != nil {
 		return pgdto.RulesetWithLevels{}, err
 	}
 
-	// вставляем уровни (IDs вернутся, но нам проще потом считать весь snapshot одним запросом)
-	for _, lv := range levels {
-		_, err := r.q.InsertLevelRule(ctx, db, gen.InsertLevelRuleParams{
-			RulesetID:           rs.ID,
-			LevelCode:           lv.LevelCode,
-			ThresholdTotalSpend: lv.ThresholdTotalSpend,
-			PercentEarn:         lv.PercentEarn,
-		})
-		if err != nil {
-			return pgdto.RulesetWithLevels{}, err
-		}
+	if err := r.insertChildren(ctx, db, rs.ID, levels, rates); err != nil {
+		return pgdto.RulesetWithLevels{}, err
 	}
 
 	rs2, err := r.q.GetRulesetByID(ctx, db, rs.ID)
 	if err != nil {
 		return pgdto.RulesetWithLevels{}, err
 	}
-	levels2, err := r.q.ListLevelRulesByRulesetID(ctx, db, rs.ID)
+	return r.withLevels(ctx, db, mapRulesetByID(rs2))
+}
+
+// LockByID locks the ruleset row (without levels, rates and effective_to).
+func (r *RulesRepo) LockByID(ctx context.Context, db pg.DBTX, id int64) (pgdto.RulesetRow, bool, error) {
+	rw, err := r.q.LockRulesetByID(ctx, db, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return pgdto.RulesetRow{}, false, nil
+		}
+		return pgdto.RulesetRow{}, false, err
+	}
+	return pgdto.RulesetRow{
+		ID:                      rw.ID,
+		EffectiveFrom:           rw.EffectiveFrom.Time,
+		BaseRubPerPoint:         rw.BaseRubPerPoint,
+		RedeemRubPerPoint:       rw.RedeemRubPerPoint,
+		QualificationWindowDays: ptrFromInt4(rw.QualificationWindowDays),
+		WelcomeBonusPoints:      int(rw.WelcomeBonusPoints),
+		BirthdayBonusPoints:     int(rw.BirthdayBonusPoints),
+		ReferrerBonusPoints:     int(rw.ReferrerBonusPoints),
+		RefereeBonusPoints:      int(rw.RefereeBonusPoints),
+		RoundingMode:            rw.RoundingMode,
+		RoundingStage:           rw.RoundingStage,
+		MinEarnAmount:           rw.MinEarnAmount,
+		MaxPointsPerPurchase:    ptrFromInt4(rw.MaxPointsPerPurchase),
+		MaxPointsPerDay:         ptrFromInt4(rw.MaxPointsPerDay),
+		CreatedAt:               rw.CreatedAt.Time,
+		Status:                  pgdto.RulesetStatus(rw.Status),
+	}, true, nil
+}
+
+func (r *RulesRepo) SetStatus(ctx context.Context, db pg.DBTX, id int64, status pgdto.RulesetStatus) error {
+	return r.q.SetRulesetStatus(ctx, db, gen.SetRulesetStatusParams{ID: id, Status: string(status)})
+}
+
+func (r *RulesRepo) AddHistory(ctx context.Context, db pg.DBTX, in pgdto.RulesetHistoryInsert) error {
+	comment := pgtype.Text{}
+	if in.Comment != nil {
+		comment = text(*in.Comment)
+	}
+	return r.q.InsertRulesetHistory(ctx, db, gen.InsertRulesetHistoryParams{
+		RulesetID:   in.RulesetID,
+		Action:      string(in.Action),
+		ActorUserID: int8FromPtr(in.ActorUserID),
+		Comment:     comment,
+	})
+}
+
+func (r *RulesRepo) HasEvents(ctx context.Context, db pg.DBTX, id int64) (bool, error) {
+	return r.q.RulesetHasEvents(ctx, db, pgtype.Int8{Int64: id, Valid: true})
+}
+
+// UpdateRuleset replaces the ruleset's values, level rules and category earn rates.
+func (r *RulesRepo) UpdateRuleset(
+	ctx context.Context,
+	db pg.DBTX,
+	id int64,
+	in pgdto.RulesetInsert,
+	levels []pgdto.LevelRuleRow,
+	rates []pgdto.CategoryEarnRateRow,
+) (pgdto.RulesetWithLevels, error) {
+	err := r.q.UpdateRuleset(ctx, db, gen.UpdateRulesetParams{
+		ID:                      id,
+		EffectiveFrom:           timestamptz(in.EffectiveFrom),
+		BaseRubPerPoint:         in.BaseRubPerPoint,
+		RedeemRubPerPoint:       in.RedeemRubPerPoint,
+		QualificationWindowDays: int4FromPtr(in.QualificationWindowDays),
+		WelcomeBonusPoints:      int32(in.WelcomeBonusPoints),
+		BirthdayBonusPoints:     int32(in.BirthdayBonusPoints),
+		ReferrerBonusPoints:     int32(in.ReferrerBonusPoints),
+		RefereeBonusPoints:      int32(in.RefereeBonusPoints),
+		RoundingMode:            in.RoundingMode,
+		RoundingStage:           in.RoundingStage,
+		MinEarnAmount:           in.MinEarnAmount,
+		MaxPointsPerPurchase:    int4FromPtr(in.MaxPointsPerPurchase),
+		MaxPointsPerDay:         int4FromPtr(in.MaxPointsPerDay),
+	})
 	if err != nil {
 		return pgdto.RulesetWithLevels{}, err
 	}
 
-	return pgdto.RulesetWithLevels{
-		Ruleset: mapRulesetByID(rs2),
-		Levels:  mapLevelRules(levels2),
-	}, nil
+	if err := r.q.DeleteLevelRulesByRulesetID(ctx, db, id); err != nil {
+		return pgdto.RulesetWithLevels{}, err
+	}
+	if err := r.q.DeleteCategoryEarnRatesByRulesetID(ctx, db, id); err != nil {
+		return pgdto.RulesetWithLevels{}, err
+	}
+	if err := r.insertChildren(ctx, db, id, levels, rates); err != nil {
+		return pgdto.RulesetWithLevels{}, err
+	}
+
+	rs, err := r.q.GetRulesetByID(ctx, db, id)
+	if err != nil {
+		return pgdto.RulesetWithLevels{}, err
+	}
+	return r.withLevels(ctx, db, mapRulesetByID(rs))
+}
+
+// DeleteRuleset deletes the ruleset with its level rules and category earn rates (ON DELETE CASCADE).
+func (r *RulesRepo) DeleteRuleset(ctx context.Context, db pg.DBTX, id int64) error {
+	return r.q.DeleteRuleset(ctx, db, id)
 }
 
 func (r *RulesRepo) ListRulesets(ctx context.Context, db pg.DBTX, limit, offset int) ([]pgdto.RulesetWithLevels, error) {
@@ -93,45 +202,147 @@ func (r *RulesRepo) ListRulesets(ctx context.Context, db pg.DBTX, limit, offset
 
 	out := make([]pgdto.RulesetWithLevels, 0, len(rows))
 	for _, rs := range rows {
-		levels, err := r.q.ListLevelRulesByRulesetID(ctx, db, rs.ID)
+		item, err := r.withLevels(ctx, db, mapRulesetBase(rs))
 		if err != nil {
 			return nil, err
 		}
-		out = append(out, pgdto.RulesetWithLevels{
-			Ruleset: mapRulesetBase(rs),
-			Levels:  mapLevelRules(levels),
-		})
+		out = append(out, item)
 	}
 	return out, nil
 }
 
 // ---------- helpers ----------
 
+// insertChildren inserts the level rules and category earn rates of a ruleset.
+func (r *RulesRepo) insertChildren(
+	ctx context.Context,
+	db pg.DBTX,
+	rulesetID int64,
+	levels []pgdto.LevelRuleRow,
+	rates []pgdto.CategoryEarnRateRow,
+) error {
+	// вставляем уровни (IDs вернутся, но нам проще потом считать весь snapshot одним запросом)
+	for _, lv := range levels {
+		_, err := r.q.InsertLevelRule(ctx, db, gen.InsertLevelRuleParams{
+			RulesetID:           rulesetID,
+			LevelCode:           lv.LevelCode,
+			ThresholdTotalSpend: lv.ThresholdTotalSpend,
+			PercentEarn:         lv.PercentEarn,
+			ReachedBonusPoints:  int32(lv.ReachedBonusPoints),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, rate := range rates {
+		err := r.q.InsertCategoryEarnRate(ctx, db, gen.InsertCategoryEarnRateParams{
+			RulesetID:  rulesetID,
+			Category:   rate.Category,
+			EarnFactor: rate.EarnFactor,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// withLevels loads the level rules, category earn rates and history of a ruleset.
+func (r *RulesRepo) withLevels(ctx context.Context, db pg.DBTX, rs pgdto.RulesetRow) (pgdto.RulesetWithLevels, error) {
+	levels, err := r.q.ListLevelRulesByRulesetID(ctx, db, rs.ID)
+	if err != nil {
+		return pgdto.RulesetWithLevels{}, err
+	}
+	rates, err := r.q.ListCategoryEarnRatesByRulesetID(ctx, db, rs.ID)
+	if err != nil {
+		return pgdto.RulesetWithLevels{}, err
+	}
+	history, err := r.q.ListRulesetHistoryByRulesetID(ctx, db, rs.ID)
+	if err != nil {
+		return pgdto.RulesetWithLevels{}, err
+	}
+
+	return pgdto.RulesetWithLevels{
+		Ruleset:       rs,
+		Levels:        mapLevelRules(levels),
+		CategoryRates: mapCategoryEarnRates(rates),
+		History:       mapRulesetHistory(history),
+	}, nil
+}
+
 func mapRulesetEffective(rw gen.GetRulesetEffectiveAtRow) pgdto.RulesetRow {
 	return pgdto.RulesetRow{
-		ID:              rw.ID,
-		EffectiveFrom:   rw.EffectiveFrom.Time,
-		BaseRubPerPoint: rw.BaseRubPerPoint,
-		CreatedAt:       rw.CreatedAt.Time,
+		ID:                      rw.ID,
+		EffectiveFrom:           rw.EffectiveFrom.Time,
+		BaseRubPerPoint:         rw.BaseRubPerPoint,
+		RedeemRubPerPoint:       rw.RedeemRubPerPoint,
+		QualificationWindowDays: ptrFromInt4(rw.QualificationWindowDays),
+		WelcomeBonusPoints:      int(rw.WelcomeBonusPoints),
+		BirthdayBonusPoints:     int(rw.BirthdayBonusPoints),
+		ReferrerBonusPoints:     int(rw.ReferrerBonusPoints),
+		RefereeBonusPoints:      int(rw.RefereeBonusPoints),
+		RoundingMode:            rw.RoundingMode,
+		RoundingStage:           rw.RoundingStage,
+		MinEarnAmount:           rw.MinEarnAmount,
+		MaxPointsPerPurchase:    ptrFromInt4(rw.MaxPointsPerPurchase),
+		MaxPointsPerDay:         ptrFromInt4(rw.MaxPointsPerDay),
+		CreatedAt:               rw.CreatedAt.Time,
+		Status:                  pgdto.RulesetStatus(rw.Status),
+		EffectiveTo:             ptrFromTimestamptz(rw.EffectiveTo),
 	}
 }
 
 func mapRulesetByID(rw gen.GetRulesetByIDRow) pgdto.RulesetRow {
 	return pgdto.RulesetRow{
-		ID:              rw.ID,
-		EffectiveFrom:   rw.EffectiveFrom.Time,
-		BaseRubPerPoint: rw.BaseRubPerPoint,
-		CreatedAt:       rw.CreatedAt.Time,
+		ID:                      rw.ID,
+		EffectiveFrom:           rw.EffectiveFrom.Time,
+		BaseRubPerPoint:         rw.BaseRubPerPoint,
+		RedeemRubPerPoint:       rw.RedeemRubPerPoint,
+		QualificationWindowDays: ptrFromInt4(rw.QualificationWindowDays),
+		WelcomeBonusPoints:      int(rw.WelcomeBonusPoints),
+		BirthdayBonusPoints:     int(rw.BirthdayBonusPoints),
+		ReferrerBonusPoints:     int(rw.ReferrerBonusPoints),
+		RefereeBonusPoints:      int(rw.RefereeBonusPoints),
+		RoundingMode:            rw.RoundingMode,
+		RoundingStage:           rw.RoundingStage,
+		MinEarnAmount:           rw.MinEarnAmount,
+		MaxPointsPerPurchase:    ptrFromInt4(rw.MaxPointsPerPurchase),
+		MaxPointsPerDay:         ptrFromInt4(rw.MaxPointsPerDay),
+		CreatedAt:               rw.CreatedAt.Time,
+		Status:                  pgdto.RulesetStatus(rw.Status),
+		EffectiveTo:             ptrFromTimestamptz(rw.EffectiveTo),
 	}
 }
 
 func mapRulesetBase(rw gen.ListRulesetsBaseRow) pgdto.RulesetRow {
 	return pgdto.RulesetRow{
-		ID:              rw.ID,
-		EffectiveFrom:   rw.EffectiveFrom.Time,
-		BaseRubPerPoint: rw.BaseRubPerPoint,
-		CreatedAt:       rw.CreatedAt.Time,
+		ID:                      rw.ID,
+		EffectiveFrom:           rw.EffectiveFrom.Time,
+		BaseRubPerPoint:         rw.BaseRubPerPoint,
+		RedeemRubPerPoint:       rw.RedeemRubPerPoint,
+		QualificationWindowDays: ptrFromInt4(rw.QualificationWindowDays),
+		WelcomeBonusPoints:      int(rw.WelcomeBonusPoints),
+		BirthdayBonusPoints:     int(rw.BirthdayBonusPoints),
+		ReferrerBonusPoints:     int(rw.ReferrerBonusPoints),
+		RefereeBonusPoints:      int(rw.RefereeBonusPoints),
+		RoundingMode:            rw.RoundingMode,
+		RoundingStage:           rw.RoundingStage,
+		MinEarnAmount:           rw.MinEarnAmount,
+		MaxPointsPerPurchase:    ptrFromInt4(rw.MaxPointsPerPurchase),
+		MaxPointsPerDay:         ptrFromInt4(rw.MaxPointsPerDay),
+		CreatedAt:               rw.CreatedAt.Time,
+		Status:                  pgdto.RulesetStatus(rw.Status),
+		EffectiveTo:             ptrFromTimestamptz(rw.EffectiveTo),
+	}
+}
+
+func ptrFromTimestamptz(v pgtype.Timestamptz) *time.Time {
+	if !v.Valid {
+		return nil
 	}
+	t := v.Time
+	return &t
 }
 
 func mapLevelRules(rows []gen.LevelRule) []pgdto.LevelRuleRow {
@@ -143,6 +354,37 @@ func mapLevelRules(rows []gen.LevelRule) []pgdto.LevelRuleRow {
 			LevelCode:           r.LevelCode,
 			ThresholdTotalSpend: r.ThresholdTotalSpend,
 			PercentEarn:         r.PercentEarn,
+			ReachedBonusPoints:  int(r.ReachedBonusPoints),
+		})
+	}
+	return out
+}
+
+func mapRulesetHistory(rows []gen.RulesetHistory) []pgdto.RulesetHistoryRow {
+	out := make([]pgdto.RulesetHistoryRow, 0, len(rows))
+	for _, r := range rows {
+		h := pgdto.RulesetHistoryRow{
+			ID:          r.ID,
+			RulesetID:   r.RulesetID,
+			Action:      pgdto.RulesetAction(r.Action),
+			ActorUserID: ptrFromInt8(r.ActorUserID),
+			CreatedAt:   r.CreatedAt.Time,
+		}
+		if r.Comment.Valid {
+			c := r.Comment.String
+			h.Comment = &c
+		}
+		out = append(out, h)
+	}
+	return out
+}
+
+func mapCategoryEarnRates(rows []gen.CategoryEarnRate) []pgdto.CategoryEarnRateRow {
+	out := make([]pgdto.CategoryEarnRateRow, 0, len(rows))
+	for _, r := range rows {
+		out = append(out, pgdto.CategoryEarnRateRow{
+			Category:   r.Category,
+			EarnFactor: r.EarnFactor,
 		})
 	}
 	return out
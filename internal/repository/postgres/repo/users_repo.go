@@ -9,6 +9,7 @@ import (
 	"Beanefits/internal/repository/postgres/sqlc/gen"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 type UsersRepo struct {
@@ -17,10 +18,11 @@ type UsersRepo struct {
 
 func NewUsersRepo(q *gen.Queries) *UsersRepo { return &UsersRepo{q: q} }
 
-func (r *UsersRepo) Create(ctx context.Context, db pg.DBTX, phone, passwordHash string) (int64, error) {
+func (r *UsersRepo) Create(ctx context.Context, db pg.DBTX, phone, passwordHash string, birthday *pgdto.Ts) (int64, error) {
 	return r.q.CreateUser(ctx, db, gen.CreateUserParams{
 		Phone:        phone,
 		PasswordHash: passwordHash,
+		Birthday:     datePtr(birthday),
 	})
 }
 
@@ -67,6 +69,13 @@ func (r *UsersRepo) Deactivate(ctx context.Context, db pg.DBTX, id int64) error
 	return r.q.DeactivateUser(ctx, db, id)
 }
 
+func (r *UsersRepo) SetBirthday(ctx context.Context, db pg.DBTX, id int64, birthday *pgdto.Ts) error {
+	return r.q.SetUserBirthday(ctx, db, gen.SetUserBirthdayParams{
+		ID:       id,
+		Birthday: datePtr(birthday),
+	})
+}
+
 // --- mapping ---
 
 func mapUserBase(id int64, phone, passwordHash string, isActive bool, createdAt any) pgdto.UserRow {
@@ -95,6 +104,7 @@ func mapUserByID(row gen.GetUserByIDRow) pgdto.UserRow {
 		Phone:        row.Phone,
 		PasswordHash: row.PasswordHash,
 		IsActive:     row.IsActive,
+		Birthday:     ptrFromDate(row.Birthday),
 		CreatedAt:    row.CreatedAt.Time,
 	}
 }
@@ -105,6 +115,7 @@ func mapUserByPhone(row gen.GetUserByPhoneRow) pgdto.UserRow {
 		Phone:        row.Phone,
 		PasswordHash: row.PasswordHash,
 		IsActive:     row.IsActive,
+		Birthday:     ptrFromDate(row.Birthday),
 		CreatedAt:    row.CreatedAt.Time,
 	}
 }
@@ -115,6 +126,15 @@ func mapUserFromList(row gen.ListUsersRow) pgdto.UserRow {
 		Phone:        row.Phone,
 		PasswordHash: row.PasswordHash,
 		IsActive:     row.IsActive,
+		Birthday:     ptrFromDate(row.Birthday),
 		CreatedAt:    row.CreatedAt.Time,
 	}
 }
+
+func ptrFromDate(d pgtype.Date) *pgdto.Ts {
+	if !d.Valid {
+		return nil
+	}
+	t := d.Time
+	return &t
+}
@@ -18,7 +18,8 @@ VALUES ($1, $2, $3)
 RETURNING
     id, user_id, public_code, created_at,
     balance_points, total_spend_money,
-    COALESCE(level_code, '')::text AS level_code
+    COALESCE(level_code, '')::text AS level_code,
+    held_points
 `
 
 type CreateAccountForUserParams struct {
@@ -35,6 +36,7 @@ type CreateAccountForUserRow struct {
 	BalancePoints   int32
 	TotalSpendMoney decimal.Decimal
 	LevelCode       string
+	HeldPoints      int32
 }
 
 func (q *Queries) CreateAccountForUser(ctx context.Context, db DBTX, arg CreateAccountForUserParams) (CreateAccountForUserRow, error) {
@@ -48,6 +50,7 @@ func (q *Queries) CreateAccountForUser(ctx context.Context, db DBTX, arg CreateA
 		&i.BalancePoints,
 		&i.TotalSpendMoney,
 		&i.LevelCode,
+		&i.HeldPoints,
 	)
 	return i, err
 }
@@ -56,7 +59,8 @@ const getAccountByPublicCode = `-- name: GetAccountByPublicCode :one
 SELECT
     id, user_id, public_code, created_at,
     balance_points, total_spend_money,
-    COALESCE(level_code, '')::text AS level_code
+    COALESCE(level_code, '')::text AS level_code,
+    held_points
 FROM accounts
 WHERE public_code = $1
 `
@@ -69,6 +73,7 @@ type GetAccountByPublicCodeRow struct {
 	BalancePoints   int32
 	TotalSpendMoney decimal.Decimal
 	LevelCode       string
+	HeldPoints      int32
 }
 
 func (q *Queries) GetAccountByPublicCode(ctx context.Context, db DBTX, publicCode string) (GetAccountByPublicCodeRow, error) {
@@ -82,6 +87,7 @@ func (q *Queries) GetAccountByPublicCode(ctx context.Context, db DBTX, publicCod
 		&i.BalancePoints,
 		&i.TotalSpendMoney,
 		&i.LevelCode,
+		&i.HeldPoints,
 	)
 	return i, err
 }
@@ -90,7 +96,8 @@ const getAccountByUserID = `-- name: GetAccountByUserID :one
 SELECT
     id, user_id, public_code, created_at,
     balance_points, total_spend_money,
-    COALESCE(level_code, '')::text AS level_code
+    COALESCE(level_code, '')::text AS level_code,
+    held_points
 FROM accounts
 WHERE user_id = $1
 `
@@ -103,6 +110,7 @@ type GetAccountByUserIDRow struct {
 	BalancePoints   int32
 	TotalSpendMoney decimal.Decimal
 	LevelCode       string
+	HeldPoints      int32
 }
 
 func (q *Queries) GetAccountByUserID(ctx context.Context, db DBTX, userID int64) (GetAccountByUserIDRow, error) {
@@ -116,15 +124,50 @@ func (q *Queries) GetAccountByUserID(ctx context.Context, db DBTX, userID int64)
 		&i.BalancePoints,
 		&i.TotalSpendMoney,
 		&i.LevelCode,
+		&i.HeldPoints,
 	)
 	return i, err
 }
 
+const listAccountIDsAfter = `-- name: ListAccountIDsAfter :many
+SELECT id
+FROM accounts
+WHERE id > $1
+ORDER BY id
+LIMIT $2
+`
+
+type ListAccountIDsAfterParams struct {
+	ID    int64
+	Limit int32
+}
+
+func (q *Queries) ListAccountIDsAfter(ctx context.Context, db DBTX, arg ListAccountIDsAfterParams) ([]int64, error) {
+	rows, err := db.Query(ctx, listAccountIDsAfter, arg.ID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const lockAccountByID = `-- name: LockAccountByID :one
 SELECT
     id, user_id, public_code, created_at,
     balance_points, total_spend_money,
-    COALESCE(level_code, '')::text AS level_code
+    COALESCE(level_code, '')::text AS level_code,
+    held_points
 FROM accounts
 WHERE id = $1
     FOR UPDATE
@@ -138,6 +181,7 @@ type LockAccountByIDRow struct {
 	BalancePoints   int32
 	TotalSpendMoney decimal.Decimal
 	LevelCode       string
+	HeldPoints      int32
 }
 
 func (q *Queries) LockAccountByID(ctx context.Context, db DBTX, id int64) (LockAccountByIDRow, error) {
@@ -151,6 +195,93 @@ func (q *Queries) LockAccountByID(ctx context.Context, db DBTX, id int64) (LockA
 		&i.BalancePoints,
 		&i.TotalSpendMoney,
 		&i.LevelCode,
+		&i.HeldPoints,
+	)
+	return i, err
+}
+
+const setAccountHeldPoints = `-- name: SetAccountHeldPoints :one
+UPDATE accounts
+SET held_points = $2
+WHERE id = $1
+RETURNING
+    id, user_id, public_code, created_at,
+    balance_points, total_spend_money,
+    COALESCE(level_code, '')::text AS level_code,
+    held_points
+`
+
+type SetAccountHeldPointsParams struct {
+	ID         int64
+	HeldPoints int32
+}
+
+type SetAccountHeldPointsRow struct {
+	ID              int64
+	UserID          int64
+	PublicCode      string
+	CreatedAt       pgtype.Timestamptz
+	BalancePoints   int32
+	TotalSpendMoney decimal.Decimal
+	LevelCode       string
+	HeldPoints      int32
+}
+
+func (q *Queries) SetAccountHeldPoints(ctx context.Context, db DBTX, arg SetAccountHeldPointsParams) (SetAccountHeldPointsRow, error) {
+	row := db.QueryRow(ctx, setAccountHeldPoints, arg.ID, arg.HeldPoints)
+	var i SetAccountHeldPointsRow
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.PublicCode,
+		&i.CreatedAt,
+		&i.BalancePoints,
+		&i.TotalSpendMoney,
+		&i.LevelCode,
+		&i.HeldPoints,
+	)
+	return i, err
+}
+
+const setAccountLevel = `-- name: SetAccountLevel :one
+UPDATE accounts
+SET level_code = $2
+WHERE id = $1
+RETURNING
+    id, user_id, public_code, created_at,
+    balance_points, total_spend_money,
+    COALESCE(level_code, '')::text AS level_code,
+    held_points
+`
+
+type SetAccountLevelParams struct {
+	ID        int64
+	LevelCode pgtype.Text
+}
+
+type SetAccountLevelRow struct {
+	ID              int64
+	UserID          int64
+	PublicCode      string
+	CreatedAt       pgtype.Timestamptz
+	BalancePoints   int32
+	TotalSpendMoney decimal.Decimal
+	LevelCode       string
+	HeldPoints      int32
+}
+
+func (q *Queries) SetAccountLevel(ctx context.Context, db DBTX, arg SetAccountLevelParams) (SetAccountLevelRow, error) {
+	row := db.QueryRow(ctx, setAccountLevel, arg.ID, arg.LevelCode)
+	var i SetAccountLevelRow
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.PublicCode,
+		&i.CreatedAt,
+		&i.BalancePoints,
+		&i.TotalSpendMoney,
+		&i.LevelCode,
+		&i.HeldPoints,
 	)
 	return i, err
 }
@@ -165,7 +296,8 @@ WHERE id = $1
 RETURNING
     id, user_id, public_code, created_at,
     balance_points, total_spend_money,
-    COALESCE(level_code, '')::text AS level_code
+    COALESCE(level_code, '')::text AS level_code,
+    held_points
 `
 
 type UpdateAccountAfterEarnParams struct {
@@ -183,6 +315,7 @@ type UpdateAccountAfterEarnRow struct {
 	BalancePoints   int32
 	TotalSpendMoney decimal.Decimal
 	LevelCode       string
+	HeldPoints      int32
 }
 
 func (q *Queries) UpdateAccountAfterEarn(ctx context.Context, db DBTX, arg UpdateAccountAfterEarnParams) (UpdateAccountAfterEarnRow, error) {
@@ -201,6 +334,7 @@ func (q *Queries) UpdateAccountAfterEarn(ctx context.Context, db DBTX, arg Updat
 		&i.BalancePoints,
 		&i.TotalSpendMoney,
 		&i.LevelCode,
+		&i.HeldPoints,
 	)
 	return i, err
 }
@@ -212,7 +346,8 @@ WHERE id = $1
 RETURNING
     id, user_id, public_code, created_at,
     balance_points, total_spend_money,
-    COALESCE(level_code, '')::text AS level_code
+    COALESCE(level_code, '')::text AS level_code,
+    held_points
 `
 
 type UpdateAccountAfterSpendParams struct {
@@ -228,6 +363,7 @@ type UpdateAccountAfterSpendRow struct {
 	BalancePoints   int32
 	TotalSpendMoney decimal.Decimal
 	LevelCode       string
+	HeldPoints      int32
 }
 
 func (q *Queries) UpdateAccountAfterSpend(ctx context.Context, db DBTX, arg UpdateAccountAfterSpendParams) (UpdateAccountAfterSpendRow, error) {
@@ -241,6 +377,7 @@ func (q *Queries) UpdateAccountAfterSpend(ctx context.Context, db DBTX, arg Upda
 		&i.BalancePoints,
 		&i.TotalSpendMoney,
 		&i.LevelCode,
+		&i.HeldPoints,
 	)
 	return i, err
 }
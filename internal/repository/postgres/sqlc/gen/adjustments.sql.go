@@ -0,0 +1,38 @@
+// ErrCode generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: adjustments.sql
+
+package gen
+
+import (
+	"context"
+)
+
+const insertAdjustment = `-- name: InsertAdjustment :one
+
+INSERT INTO adjustments (event_id, reason_code, comment)
+VALUES ($1, $2, $3)
+RETURNING
+    event_id,
+    reason_code,
+    comment
+`
+
+type InsertAdjustmentParams struct {
+	EventID    int64
+	ReasonCode string
+	Comment    string
+}
+
+// internal/repository/postgres/sqlc/queries/adjustments.sql
+func (q *Queries) InsertAdjustment(ctx context.Context, db DBTX, arg InsertAdjustmentParams) (Adjustment, error) {
+	row := db.QueryRow(ctx, insertAdjustment, arg.EventID, arg.ReasonCode, arg.Comment)
+	var i Adjustment
+	err := row.Scan(
+		&i.EventID,
+		&i.ReasonCode,
+		&i.Comment,
+	)
+	return i, err
+}
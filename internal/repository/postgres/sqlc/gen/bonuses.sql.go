@@ -0,0 +1,84 @@
+// ErrCode generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: bonuses.sql
+
+package gen
+
+import (
+	"context"
+)
+
+const insertBonusGrant = `-- name: InsertBonusGrant :exec
+
+INSERT INTO bonus_grants (event_id, account_id, kind, year)
+VALUES ($1, $2, $3, $4)
+`
+
+type InsertBonusGrantParams struct {
+	EventID   int64
+	AccountID int64
+	Kind      string
+	Year      int32
+}
+
+// internal/repository/postgres/sqlc/queries/bonuses.sql
+func (q *Queries) InsertBonusGrant(ctx context.Context, db DBTX, arg InsertBonusGrantParams) error {
+	_, err := db.Exec(ctx, insertBonusGrant,
+		arg.EventID,
+		arg.AccountID,
+		arg.Kind,
+		arg.Year,
+	)
+	return err
+}
+
+const listBirthdayAccountIDsAfter = `-- name: ListBirthdayAccountIDsAfter :many
+SELECT a.id
+FROM accounts a
+JOIN users u ON u.id = a.user_id
+WHERE a.id > $1
+  AND u.is_active
+  AND to_char(u.birthday, 'MM-DD') = ANY ($2::text[])
+  AND NOT EXISTS (SELECT 1
+                  FROM bonus_grants g
+                  WHERE g.account_id = a.id
+                    AND g.kind = 'BIRTHDAY'
+                    AND g.year = $3)
+ORDER BY a.id
+LIMIT $4
+`
+
+type ListBirthdayAccountIDsAfterParams struct {
+	ID      int64
+	Column2 []string
+	Year    int32
+	Limit   int32
+}
+
+// Accounts of active clients whose birthday (MM-DD) is one of the given days
+// and who have not got the BIRTHDAY bonus of the year yet.
+func (q *Queries) ListBirthdayAccountIDsAfter(ctx context.Context, db DBTX, arg ListBirthdayAccountIDsAfterParams) ([]int64, error) {
+	rows, err := db.Query(ctx, listBirthdayAccountIDsAfter,
+		arg.ID,
+		arg.Column2,
+		arg.Year,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
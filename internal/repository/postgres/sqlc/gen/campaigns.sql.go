@@ -0,0 +1,267 @@
+// ErrCode generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: campaigns.sql
+
+package gen
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const deleteCampaign = `-- name: DeleteCampaign :execrows
+DELETE FROM campaigns
+WHERE id = $1
+`
+
+func (q *Queries) DeleteCampaign(ctx context.Context, db DBTX, id int64) (int64, error) {
+	result, err := db.Exec(ctx, deleteCampaign, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const getCampaignByID = `-- name: GetCampaignByID :one
+SELECT id, name, starts_on, ends_on, weekdays, time_from, time_to, timezone, multiplier, bonus_points, updated_by, created_at, updated_at
+FROM campaigns
+WHERE id = $1
+`
+
+func (q *Queries) GetCampaignByID(ctx context.Context, db DBTX, id int64) (Campaign, error) {
+	row := db.QueryRow(ctx, getCampaignByID, id)
+	var i Campaign
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.StartsOn,
+		&i.EndsOn,
+		&i.Weekdays,
+		&i.TimeFrom,
+		&i.TimeTo,
+		&i.Timezone,
+		&i.Multiplier,
+		&i.BonusPoints,
+		&i.UpdatedBy,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const insertCampaign = `-- name: InsertCampaign :one
+INSERT INTO campaigns (name, starts_on, ends_on, weekdays, time_from, time_to, timezone, multiplier, bonus_points, updated_by)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+RETURNING id, name, starts_on, ends_on, weekdays, time_from, time_to, timezone, multiplier, bonus_points, updated_by, created_at, updated_at
+`
+
+type InsertCampaignParams struct {
+	Name        string
+	StartsOn    pgtype.Date
+	EndsOn      pgtype.Date
+	Weekdays    []int16
+	TimeFrom    pgtype.Time
+	TimeTo      pgtype.Time
+	Timezone    string
+	Multiplier  pgtype.Numeric
+	BonusPoints pgtype.Int4
+	UpdatedBy   pgtype.Int8
+}
+
+func (q *Queries) InsertCampaign(ctx context.Context, db DBTX, arg InsertCampaignParams) (Campaign, error) {
+	row := db.QueryRow(ctx, insertCampaign,
+		arg.Name,
+		arg.StartsOn,
+		arg.EndsOn,
+		arg.Weekdays,
+		arg.TimeFrom,
+		arg.TimeTo,
+		arg.Timezone,
+		arg.Multiplier,
+		arg.BonusPoints,
+		arg.UpdatedBy,
+	)
+	var i Campaign
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.StartsOn,
+		&i.EndsOn,
+		&i.Weekdays,
+		&i.TimeFrom,
+		&i.TimeTo,
+		&i.Timezone,
+		&i.Multiplier,
+		&i.BonusPoints,
+		&i.UpdatedBy,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const insertEventCampaign = `-- name: InsertEventCampaign :exec
+INSERT INTO event_campaigns (event_id, campaign_id, bonus_points)
+VALUES ($1, $2, $3)
+`
+
+type InsertEventCampaignParams struct {
+	EventID     int64
+	CampaignID  int64
+	BonusPoints int32
+}
+
+func (q *Queries) InsertEventCampaign(ctx context.Context, db DBTX, arg InsertEventCampaignParams) error {
+	_, err := db.Exec(ctx, insertEventCampaign, arg.EventID, arg.CampaignID, arg.BonusPoints)
+	return err
+}
+
+const listCampaigns = `-- name: ListCampaigns :many
+SELECT id, name, starts_on, ends_on, weekdays, time_from, time_to, timezone, multiplier, bonus_points, updated_by, created_at, updated_at
+FROM campaigns
+ORDER BY starts_on DESC, id DESC
+`
+
+func (q *Queries) ListCampaigns(ctx context.Context, db DBTX) ([]Campaign, error) {
+	rows, err := db.Query(ctx, listCampaigns)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Campaign
+	for rows.Next() {
+		var i Campaign
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.StartsOn,
+			&i.EndsOn,
+			&i.Weekdays,
+			&i.TimeFrom,
+			&i.TimeTo,
+			&i.Timezone,
+			&i.Multiplier,
+			&i.BonusPoints,
+			&i.UpdatedBy,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listCampaignsAround = `-- name: ListCampaignsAround :many
+SELECT id, name, starts_on, ends_on, weekdays, time_from, time_to, timezone, multiplier, bonus_points, updated_by, created_at, updated_at
+FROM campaigns
+WHERE starts_on <= $1::date + 1
+  AND (ends_on IS NULL OR ends_on >= $1::date - 1)
+ORDER BY id
+`
+
+// Candidates for a moment on the given UTC date: a day of margin covers any campaign timezone,
+// the exact schedule check is done by the caller.
+func (q *Queries) ListCampaignsAround(ctx context.Context, db DBTX, dollar_1 pgtype.Date) ([]Campaign, error) {
+	rows, err := db.Query(ctx, listCampaignsAround, dollar_1)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Campaign
+	for rows.Next() {
+		var i Campaign
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.StartsOn,
+			&i.EndsOn,
+			&i.Weekdays,
+			&i.TimeFrom,
+			&i.TimeTo,
+			&i.Timezone,
+			&i.Multiplier,
+			&i.BonusPoints,
+			&i.UpdatedBy,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updateCampaign = `-- name: UpdateCampaign :one
+UPDATE campaigns
+SET name = $2,
+    starts_on = $3,
+    ends_on = $4,
+    weekdays = $5,
+    time_from = $6,
+    time_to = $7,
+    timezone = $8,
+    multiplier = $9,
+    bonus_points = $10,
+    updated_by = $11,
+    updated_at = now()
+WHERE id = $1
+RETURNING id, name, starts_on, ends_on, weekdays, time_from, time_to, timezone, multiplier, bonus_points, updated_by, created_at, updated_at
+`
+
+type UpdateCampaignParams struct {
+	ID          int64
+	Name        string
+	StartsOn    pgtype.Date
+	EndsOn      pgtype.Date
+	Weekdays    []int16
+	TimeFrom    pgtype.Time
+	TimeTo      pgtype.Time
+	Timezone    string
+	Multiplier  pgtype.Numeric
+	BonusPoints pgtype.Int4
+	UpdatedBy   pgtype.Int8
+}
+
+func (q *Queries) UpdateCampaign(ctx context.Context, db DBTX, arg UpdateCampaignParams) (Campaign, error) {
+	row := db.QueryRow(ctx, updateCampaign,
+		arg.ID,
+		arg.Name,
+		arg.StartsOn,
+		arg.EndsOn,
+		arg.Weekdays,
+		arg.TimeFrom,
+		arg.TimeTo,
+		arg.Timezone,
+		arg.Multiplier,
+		arg.BonusPoints,
+		arg.UpdatedBy,
+	)
+	var i Campaign
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.StartsOn,
+		&i.EndsOn,
+		&i.Weekdays,
+		&i.TimeFrom,
+		&i.TimeTo,
+		&i.Timezone,
+		&i.Multiplier,
+		&i.BonusPoints,
+		&i.UpdatedBy,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
@@ -9,13 +9,120 @@ import (
 	"context"
 
 	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
 )
 
+const countEventsByActorCreatedSince = `-- name: CountEventsByActorCreatedSince :one
+SELECT COUNT(*)::int AS events_count
+FROM events
+WHERE actor_user_id = $1
+  AND type = $2::event_type
+  AND created_at >= $3
+`
+
+type CountEventsByActorCreatedSinceParams struct {
+	ActorUserID pgtype.Int8
+	Column2     EventType
+	CreatedAt   pgtype.Timestamptz
+}
+
+func (q *Queries) CountEventsByActorCreatedSince(ctx context.Context, db DBTX, arg CountEventsByActorCreatedSinceParams) (int32, error) {
+	row := db.QueryRow(ctx, countEventsByActorCreatedSince, arg.ActorUserID, arg.Column2, arg.CreatedAt)
+	var events_count int32
+	err := row.Scan(&events_count)
+	return events_count, err
+}
+
+const countEventsByRef = `-- name: CountEventsByRef :one
+SELECT COUNT(*)
+FROM events
+WHERE ref_event_id = $1
+  AND type = $2::event_type
+`
+
+type CountEventsByRefParams struct {
+	RefEventID pgtype.Int8
+	Column2    EventType
+}
+
+func (q *Queries) CountEventsByRef(ctx context.Context, db DBTX, arg CountEventsByRefParams) (int64, error) {
+	row := db.QueryRow(ctx, countEventsByRef, arg.RefEventID, arg.Column2)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const getEventByID = `-- name: GetEventByID :one
+SELECT
+    id,
+    account_id,
+    type::text AS type,
+    delta_points,
+    balance_after,
+    amount_money,
+    ruleset_id,
+    actor_user_id,
+    ts,
+    created_at,
+    ref_event_id
+FROM events
+WHERE id = $1
+`
+
+type GetEventByIDRow struct {
+	ID           int64
+	AccountID    int64
+	Type         string
+	DeltaPoints  int32
+	BalanceAfter int32
+	AmountMoney  pgtype.Numeric
+	RulesetID    pgtype.Int8
+	ActorUserID  pgtype.Int8
+	Ts           pgtype.Timestamptz
+	CreatedAt    pgtype.Timestamptz
+	RefEventID   pgtype.Int8
+}
+
+func (q *Queries) GetEventByID(ctx context.Context, db DBTX, id int64) (GetEventByIDRow, error) {
+	row := db.QueryRow(ctx, getEventByID, id)
+	var i GetEventByIDRow
+	err := row.Scan(
+		&i.ID,
+		&i.AccountID,
+		&i.Type,
+		&i.DeltaPoints,
+		&i.BalanceAfter,
+		&i.AmountMoney,
+		&i.RulesetID,
+		&i.ActorUserID,
+		&i.Ts,
+		&i.CreatedAt,
+		&i.RefEventID,
+	)
+	return i, err
+}
+
+const getLastEventHash = `-- name: GetLastEventHash :one
+SELECT hash
+FROM events
+WHERE account_id = $1
+ORDER BY id DESC
+LIMIT 1
+`
+
+func (q *Queries) GetLastEventHash(ctx context.Context, db DBTX, accountID int64) ([]byte, error) {
+	row := db.QueryRow(ctx, getLastEventHash, accountID)
+	var hash []byte
+	err := row.Scan(&hash)
+	return hash, err
+}
+
 const insertEvent = `-- name: InsertEvent :one
 
 INSERT INTO events (
     account_id, type, delta_points, balance_after,
-    amount_money, ruleset_id, actor_user_id, ts
+    amount_money, ruleset_id, actor_user_id, ts,
+    ref_event_id, prev_hash, hash
 )
 VALUES (
            $1,
@@ -25,7 +132,10 @@ VALUES (
            $5,
            $6,
            $7,
-           $8
+           $8,
+           $9,
+           $10,
+           $11
        )
 RETURNING
     id,
@@ -37,7 +147,10 @@ RETURNING
     ruleset_id,
     actor_user_id,
     ts,
-    created_at
+    created_at,
+    ref_event_id,
+    prev_hash,
+    hash
 `
 
 type InsertEventParams struct {
@@ -49,6 +162,9 @@ type InsertEventParams struct {
 	RulesetID    pgtype.Int8
 	ActorUserID  pgtype.Int8
 	Ts           pgtype.Timestamptz
+	RefEventID   pgtype.Int8
+	PrevHash     []byte
+	Hash         []byte
 }
 
 type InsertEventRow struct {
@@ -62,6 +178,9 @@ type InsertEventRow struct {
 	ActorUserID  pgtype.Int8
 	Ts           pgtype.Timestamptz
 	CreatedAt    pgtype.Timestamptz
+	RefEventID   pgtype.Int8
+	PrevHash     []byte
+	Hash         []byte
 }
 
 // internal/repository/postgres/sqlc/queries/events.sql
@@ -75,6 +194,9 @@ func (q *Queries) InsertEvent(ctx context.Context, db DBTX, arg InsertEventParam
 		arg.RulesetID,
 		arg.ActorUserID,
 		arg.Ts,
+		arg.RefEventID,
+		arg.PrevHash,
+		arg.Hash,
 	)
 	var i InsertEventRow
 	err := row.Scan(
@@ -88,10 +210,172 @@ func (q *Queries) InsertEvent(ctx context.Context, db DBTX, arg InsertEventParam
 		&i.ActorUserID,
 		&i.Ts,
 		&i.CreatedAt,
+		&i.RefEventID,
+		&i.PrevHash,
+		&i.Hash,
 	)
 	return i, err
 }
 
+const listAccountsWithUnhashedEvents = `-- name: ListAccountsWithUnhashedEvents :many
+SELECT DISTINCT account_id
+FROM events
+WHERE hash IS NULL
+ORDER BY account_id
+LIMIT $1
+`
+
+func (q *Queries) ListAccountsWithUnhashedEvents(ctx context.Context, db DBTX, limit int32) ([]int64, error) {
+	rows, err := db.Query(ctx, listAccountsWithUnhashedEvents, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []int64
+	for rows.Next() {
+		var account_id int64
+		if err := rows.Scan(&account_id); err != nil {
+			return nil, err
+		}
+		items = append(items, account_id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listAllEventsByAccountAsc = `-- name: ListAllEventsByAccountAsc :many
+SELECT
+    id,
+    account_id,
+    type::text AS type,
+    delta_points,
+    balance_after,
+    amount_money,
+    ruleset_id,
+    actor_user_id,
+    ts,
+    created_at,
+    ref_event_id,
+    prev_hash,
+    hash
+FROM events
+WHERE account_id = $1
+ORDER BY id
+`
+
+type ListAllEventsByAccountAscRow struct {
+	ID           int64
+	AccountID    int64
+	Type         string
+	DeltaPoints  int32
+	BalanceAfter int32
+	AmountMoney  pgtype.Numeric
+	RulesetID    pgtype.Int8
+	ActorUserID  pgtype.Int8
+	Ts           pgtype.Timestamptz
+	CreatedAt    pgtype.Timestamptz
+	RefEventID   pgtype.Int8
+	PrevHash     []byte
+	Hash         []byte
+}
+
+func (q *Queries) ListAllEventsByAccountAsc(ctx context.Context, db DBTX, accountID int64) ([]ListAllEventsByAccountAscRow, error) {
+	rows, err := db.Query(ctx, listAllEventsByAccountAsc, accountID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListAllEventsByAccountAscRow
+	for rows.Next() {
+		var i ListAllEventsByAccountAscRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.AccountID,
+			&i.Type,
+			&i.DeltaPoints,
+			&i.BalanceAfter,
+			&i.AmountMoney,
+			&i.RulesetID,
+			&i.ActorUserID,
+			&i.Ts,
+			&i.CreatedAt,
+			&i.RefEventID,
+			&i.PrevHash,
+			&i.Hash,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listEarnEventsInRange = `-- name: ListEarnEventsInRange :many
+SELECT
+    e.id,
+    e.account_id,
+    a.public_code,
+    e.delta_points,
+    COALESCE(e.amount_money, 0)::numeric AS amount_money,
+    e.ts,
+    COALESCE((SELECT SUM(ec.bonus_points) FROM event_campaigns ec WHERE ec.event_id = e.id), 0)::int AS campaign_points
+FROM events e
+JOIN accounts a ON a.id = e.account_id
+WHERE e.type = 'EARN'
+  AND e.ts >= $1
+  AND e.ts < $2
+ORDER BY e.ts, e.id
+`
+
+type ListEarnEventsInRangeParams struct {
+	Ts   pgtype.Timestamptz
+	Ts_2 pgtype.Timestamptz
+}
+
+type ListEarnEventsInRangeRow struct {
+	ID             int64
+	AccountID      int64
+	PublicCode     string
+	DeltaPoints    int32
+	AmountMoney    decimal.Decimal
+	Ts             pgtype.Timestamptz
+	CampaignPoints int32
+}
+
+// Purchases replayed by the ruleset simulation, oldest first, with the campaign rewards they include.
+func (q *Queries) ListEarnEventsInRange(ctx context.Context, db DBTX, arg ListEarnEventsInRangeParams) ([]ListEarnEventsInRangeRow, error) {
+	rows, err := db.Query(ctx, listEarnEventsInRange, arg.Ts, arg.Ts_2)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListEarnEventsInRangeRow
+	for rows.Next() {
+		var i ListEarnEventsInRangeRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.AccountID,
+			&i.PublicCode,
+			&i.DeltaPoints,
+			&i.AmountMoney,
+			&i.Ts,
+			&i.CampaignPoints,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listEventsByAccount = `-- name: ListEventsByAccount :many
 SELECT
     id,
@@ -103,7 +387,8 @@ SELECT
     ruleset_id,
     actor_user_id,
     ts,
-    created_at
+    created_at,
+    ref_event_id
 FROM events
 WHERE account_id = $1
 ORDER BY ts DESC, id DESC
@@ -126,6 +411,7 @@ type ListEventsByAccountRow struct {
 	ActorUserID  pgtype.Int8
 	Ts           pgtype.Timestamptz
 	CreatedAt    pgtype.Timestamptz
+	RefEventID   pgtype.Int8
 }
 
 func (q *Queries) ListEventsByAccount(ctx context.Context, db DBTX, arg ListEventsByAccountParams) ([]ListEventsByAccountRow, error) {
@@ -148,6 +434,7 @@ func (q *Queries) ListEventsByAccount(ctx context.Context, db DBTX, arg ListEven
 			&i.ActorUserID,
 			&i.Ts,
 			&i.CreatedAt,
+			&i.RefEventID,
 		); err != nil {
 			return nil, err
 		}
@@ -170,7 +457,8 @@ SELECT
     ruleset_id,
     actor_user_id,
     ts,
-    created_at
+    created_at,
+    ref_event_id
 FROM events
 WHERE account_id = $1
   AND ts < $3
@@ -195,6 +483,7 @@ type ListEventsByAccountBeforeRow struct {
 	ActorUserID  pgtype.Int8
 	Ts           pgtype.Timestamptz
 	CreatedAt    pgtype.Timestamptz
+	RefEventID   pgtype.Int8
 }
 
 func (q *Queries) ListEventsByAccountBefore(ctx context.Context, db DBTX, arg ListEventsByAccountBeforeParams) ([]ListEventsByAccountBeforeRow, error) {
@@ -217,6 +506,170 @@ func (q *Queries) ListEventsByAccountBefore(ctx context.Context, db DBTX, arg Li
 			&i.ActorUserID,
 			&i.Ts,
 			&i.CreatedAt,
+			&i.RefEventID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listPurchaseAmountsForEarnAccounts = `-- name: ListPurchaseAmountsForEarnAccounts :many
+SELECT
+    e.account_id,
+    COALESCE(p.ts, e.ts)::timestamptz AS purchase_ts,
+    e.ts,
+    (CASE WHEN e.type = 'EARN' THEN COALESCE(e.amount_money, 0) ELSE -COALESCE(e.amount_money, 0) END)::numeric AS amount_money
+FROM events e
+LEFT JOIN events p ON p.id = e.ref_event_id
+WHERE e.type IN ('EARN', 'REFUND')
+  AND e.ts < $3
+  AND (COALESCE(p.ts, e.ts) >= $1::timestamptz OR e.ts >= $2)
+  AND e.account_id IN (
+      SELECT account_id
+      FROM events
+      WHERE type = 'EARN'
+        AND ts >= $2
+        AND ts < $3
+  )
+ORDER BY e.account_id, e.ts, e.id
+`
+
+type ListPurchaseAmountsForEarnAccountsParams struct {
+	Column1 pgtype.Timestamptz
+	Ts      pgtype.Timestamptz
+	Ts_2    pgtype.Timestamptz
+}
+
+type ListPurchaseAmountsForEarnAccountsRow struct {
+	AccountID   int64
+	PurchaseTs  pgtype.Timestamptz
+	Ts          pgtype.Timestamptz
+	AmountMoney decimal.Decimal
+}
+
+// Purchases (EARN, positive) and refunds (REFUND, negative) with ts < $3 and either a purchase ts >= $1
+// or ts >= $2, of every account that has purchases in [$2, $3); oldest first.
+// A refund carries the ts of the purchase it refunds, so the ruleset simulation can replay both
+// lifetime spend and rolling qualification windows on them.
+func (q *Queries) ListPurchaseAmountsForEarnAccounts(ctx context.Context, db DBTX, arg ListPurchaseAmountsForEarnAccountsParams) ([]ListPurchaseAmountsForEarnAccountsRow, error) {
+	rows, err := db.Query(ctx, listPurchaseAmountsForEarnAccounts, arg.Column1, arg.Ts, arg.Ts_2)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListPurchaseAmountsForEarnAccountsRow
+	for rows.Next() {
+		var i ListPurchaseAmountsForEarnAccountsRow
+		if err := rows.Scan(
+			&i.AccountID,
+			&i.PurchaseTs,
+			&i.Ts,
+			&i.AmountMoney,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const setEventHash = `-- name: SetEventHash :exec
+UPDATE events
+SET prev_hash = $2,
+    hash = $3
+WHERE id = $1
+`
+
+type SetEventHashParams struct {
+	ID       int64
+	PrevHash []byte
+	Hash     []byte
+}
+
+func (q *Queries) SetEventHash(ctx context.Context, db DBTX, arg SetEventHashParams) error {
+	_, err := db.Exec(ctx, setEventHash, arg.ID, arg.PrevHash, arg.Hash)
+	return err
+}
+
+const sumCappedEarnPointsInRange = `-- name: SumCappedEarnPointsInRange :one
+SELECT COALESCE(SUM(GREATEST(x.ruleset_points - x.reverted_points * x.ruleset_points / NULLIF(x.delta_points, 0), 0)), 0)::int AS points
+FROM (
+    SELECT
+        e.delta_points,
+        e.delta_points - COALESCE((SELECT SUM(ec.bonus_points) FROM event_campaigns ec WHERE ec.event_id = e.id), 0) AS ruleset_points,
+        COALESCE((SELECT SUM(-r.delta_points) FROM events r WHERE r.ref_event_id = e.id AND r.type = 'REFUND'), 0) AS reverted_points
+    FROM events e
+    WHERE e.account_id = $1
+      AND e.type = 'EARN'
+      AND e.ts >= $2
+      AND e.ts < $3
+) x
+`
+
+type SumCappedEarnPointsInRangeParams struct {
+	AccountID int64
+	Ts        pgtype.Timestamptz
+	Ts_2      pgtype.Timestamptz
+}
+
+// Ruleset points of the account's purchases with $2 <= ts < $3, as seen by the daily earn cap:
+// campaign rewards come on top of the cap, and refunds take off their share of the ruleset points
+// (a refund reverts the whole EARN, campaign rewards included, in proportion).
+func (q *Queries) SumCappedEarnPointsInRange(ctx context.Context, db DBTX, arg SumCappedEarnPointsInRangeParams) (int32, error) {
+	row := db.QueryRow(ctx, sumCappedEarnPointsInRange, arg.AccountID, arg.Ts, arg.Ts_2)
+	var points int32
+	err := row.Scan(&points)
+	return points, err
+}
+
+const sumEarnSpendBeforeByAccount = `-- name: SumEarnSpendBeforeByAccount :many
+SELECT
+    account_id,
+    COALESCE(SUM(CASE WHEN type = 'EARN' THEN amount_money ELSE -amount_money END), 0)::numeric AS spend_money
+FROM events
+WHERE type IN ('EARN', 'REFUND')
+  AND ts < $1
+  AND account_id IN (
+      SELECT account_id
+      FROM events
+      WHERE type = 'EARN'
+        AND ts >= $1
+        AND ts < $2
+  )
+GROUP BY account_id
+`
+
+type SumEarnSpendBeforeByAccountParams struct {
+	Ts   pgtype.Timestamptz
+	Ts_2 pgtype.Timestamptz
+}
+
+type SumEarnSpendBeforeByAccountRow struct {
+	AccountID  int64
+	SpendMoney decimal.Decimal
+}
+
+// Purchase spend before $1, net of refunds, of every account that has purchases in [$1, $2).
+func (q *Queries) SumEarnSpendBeforeByAccount(ctx context.Context, db DBTX, arg SumEarnSpendBeforeByAccountParams) ([]SumEarnSpendBeforeByAccountRow, error) {
+	rows, err := db.Query(ctx, sumEarnSpendBeforeByAccount, arg.Ts, arg.Ts_2)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SumEarnSpendBeforeByAccountRow
+	for rows.Next() {
+		var i SumEarnSpendBeforeByAccountRow
+		if err := rows.Scan(
+			&i.AccountID,
+			&i.SpendMoney,
 		); err != nil {
 			return nil, err
 		}
@@ -227,3 +680,118 @@ func (q *Queries) ListEventsByAccountBefore(ctx context.Context, db DBTX, arg Li
 	}
 	return items, nil
 }
+
+const sumEventsByTypeCreatedSince = `-- name: SumEventsByTypeCreatedSince :one
+SELECT
+    COUNT(*)::int AS events_count,
+    COALESCE(SUM(ABS(delta_points)), 0)::int AS points
+FROM events
+WHERE account_id = $1
+  AND type = $2::event_type
+  AND created_at >= $3
+`
+
+type SumEventsByTypeCreatedSinceParams struct {
+	AccountID int64
+	Column2   EventType
+	CreatedAt pgtype.Timestamptz
+}
+
+type SumEventsByTypeCreatedSinceRow struct {
+	EventsCount int32
+	Points      int32
+}
+
+func (q *Queries) SumEventsByTypeCreatedSince(ctx context.Context, db DBTX, arg SumEventsByTypeCreatedSinceParams) (SumEventsByTypeCreatedSinceRow, error) {
+	row := db.QueryRow(ctx, sumEventsByTypeCreatedSince, arg.AccountID, arg.Column2, arg.CreatedAt)
+	var i SumEventsByTypeCreatedSinceRow
+	err := row.Scan(
+		&i.EventsCount,
+		&i.Points,
+	)
+	return i, err
+}
+
+const sumEventsByTypeInRange = `-- name: SumEventsByTypeInRange :one
+SELECT
+    COUNT(*)::int AS events_count,
+    COALESCE(SUM(ABS(delta_points)), 0)::int AS points
+FROM events
+WHERE account_id = $1
+  AND type = $2::event_type
+  AND ts >= $3
+  AND ts < $4
+`
+
+type SumEventsByTypeInRangeParams struct {
+	AccountID int64
+	Column2   EventType
+	Ts        pgtype.Timestamptz
+	Ts_2      pgtype.Timestamptz
+}
+
+type SumEventsByTypeInRangeRow struct {
+	EventsCount int32
+	Points      int32
+}
+
+func (q *Queries) SumEventsByTypeInRange(ctx context.Context, db DBTX, arg SumEventsByTypeInRangeParams) (SumEventsByTypeInRangeRow, error) {
+	row := db.QueryRow(ctx, sumEventsByTypeInRange,
+		arg.AccountID,
+		arg.Column2,
+		arg.Ts,
+		arg.Ts_2,
+	)
+	var i SumEventsByTypeInRangeRow
+	err := row.Scan(
+		&i.EventsCount,
+		&i.Points,
+	)
+	return i, err
+}
+
+const sumQualifyingSpendSince = `-- name: SumQualifyingSpendSince :one
+SELECT COALESCE(SUM(CASE WHEN e.type = 'EARN' THEN e.amount_money ELSE -e.amount_money END), 0)::numeric AS spend_money
+FROM events e
+LEFT JOIN events p ON p.id = e.ref_event_id
+WHERE e.account_id = $1
+  AND e.type IN ('EARN', 'REFUND')
+  AND COALESCE(p.ts, e.ts) >= $2::timestamptz
+`
+
+type SumQualifyingSpendSinceParams struct {
+	AccountID int64
+	Column2   pgtype.Timestamptz
+}
+
+// A refund counts against the window of the purchase it refunds.
+func (q *Queries) SumQualifyingSpendSince(ctx context.Context, db DBTX, arg SumQualifyingSpendSinceParams) (decimal.Decimal, error) {
+	row := db.QueryRow(ctx, sumQualifyingSpendSince, arg.AccountID, arg.Column2)
+	var spend_money decimal.Decimal
+	err := row.Scan(&spend_money)
+	return spend_money, err
+}
+
+const sumRefundsByEvent = `-- name: SumRefundsByEvent :one
+SELECT
+    COALESCE(SUM(amount_money), 0)::numeric AS refunded_money,
+    COALESCE(SUM(-delta_points), 0)::int AS reverted_points
+FROM events
+WHERE ref_event_id = $1
+  AND type = 'REFUND'
+`
+
+type SumRefundsByEventRow struct {
+	RefundedMoney  decimal.Decimal
+	RevertedPoints int32
+}
+
+func (q *Queries) SumRefundsByEvent(ctx context.Context, db DBTX, refEventID pgtype.Int8) (SumRefundsByEventRow, error) {
+	row := db.QueryRow(ctx, sumRefundsByEvent, refEventID)
+	var i SumRefundsByEventRow
+	err := row.Scan(
+		&i.RefundedMoney,
+		&i.RevertedPoints,
+	)
+	return i, err
+}
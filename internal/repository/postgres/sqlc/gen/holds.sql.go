@@ -0,0 +1,208 @@
+// ErrCode generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: holds.sql
+
+package gen
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const closeHold = `-- name: CloseHold :one
+UPDATE holds
+SET status = $2,
+    captured_points = $3,
+    capture_event_id = $4,
+    closed_at = $5
+WHERE id = $1
+  AND status = 'ACTIVE'
+RETURNING
+    id, account_id, operation_id, points, status, expires_at,
+    captured_points, capture_event_id, actor_user_id, created_at, closed_at
+`
+
+type CloseHoldParams struct {
+	ID             int64
+	Status         string
+	CapturedPoints pgtype.Int4
+	CaptureEventID pgtype.Int8
+	ClosedAt       pgtype.Timestamptz
+}
+
+func (q *Queries) CloseHold(ctx context.Context, db DBTX, arg CloseHoldParams) (Hold, error) {
+	row := db.QueryRow(ctx, closeHold,
+		arg.ID,
+		arg.Status,
+		arg.CapturedPoints,
+		arg.CaptureEventID,
+		arg.ClosedAt,
+	)
+	var i Hold
+	err := row.Scan(
+		&i.ID,
+		&i.AccountID,
+		&i.OperationID,
+		&i.Points,
+		&i.Status,
+		&i.ExpiresAt,
+		&i.CapturedPoints,
+		&i.CaptureEventID,
+		&i.ActorUserID,
+		&i.CreatedAt,
+		&i.ClosedAt,
+	)
+	return i, err
+}
+
+const getHoldByID = `-- name: GetHoldByID :one
+SELECT
+    id, account_id, operation_id, points, status, expires_at,
+    captured_points, capture_event_id, actor_user_id, created_at, closed_at
+FROM holds
+WHERE id = $1
+`
+
+func (q *Queries) GetHoldByID(ctx context.Context, db DBTX, id int64) (Hold, error) {
+	row := db.QueryRow(ctx, getHoldByID, id)
+	var i Hold
+	err := row.Scan(
+		&i.ID,
+		&i.AccountID,
+		&i.OperationID,
+		&i.Points,
+		&i.Status,
+		&i.ExpiresAt,
+		&i.CapturedPoints,
+		&i.CaptureEventID,
+		&i.ActorUserID,
+		&i.CreatedAt,
+		&i.ClosedAt,
+	)
+	return i, err
+}
+
+const getHoldByOperation = `-- name: GetHoldByOperation :one
+SELECT
+    id, account_id, operation_id, points, status, expires_at,
+    captured_points, capture_event_id, actor_user_id, created_at, closed_at
+FROM holds
+WHERE account_id = $1
+  AND operation_id = $2
+`
+
+type GetHoldByOperationParams struct {
+	AccountID   int64
+	OperationID string
+}
+
+func (q *Queries) GetHoldByOperation(ctx context.Context, db DBTX, arg GetHoldByOperationParams) (Hold, error) {
+	row := db.QueryRow(ctx, getHoldByOperation, arg.AccountID, arg.OperationID)
+	var i Hold
+	err := row.Scan(
+		&i.ID,
+		&i.AccountID,
+		&i.OperationID,
+		&i.Points,
+		&i.Status,
+		&i.ExpiresAt,
+		&i.CapturedPoints,
+		&i.CaptureEventID,
+		&i.ActorUserID,
+		&i.CreatedAt,
+		&i.ClosedAt,
+	)
+	return i, err
+}
+
+const insertHold = `-- name: InsertHold :one
+INSERT INTO holds (account_id, operation_id, points, expires_at, actor_user_id)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (account_id, operation_id) DO NOTHING
+RETURNING
+    id, account_id, operation_id, points, status, expires_at,
+    captured_points, capture_event_id, actor_user_id, created_at, closed_at
+`
+
+type InsertHoldParams struct {
+	AccountID   int64
+	OperationID string
+	Points      int32
+	ExpiresAt   pgtype.Timestamptz
+	ActorUserID pgtype.Int8
+}
+
+func (q *Queries) InsertHold(ctx context.Context, db DBTX, arg InsertHoldParams) (Hold, error) {
+	row := db.QueryRow(ctx, insertHold,
+		arg.AccountID,
+		arg.OperationID,
+		arg.Points,
+		arg.ExpiresAt,
+		arg.ActorUserID,
+	)
+	var i Hold
+	err := row.Scan(
+		&i.ID,
+		&i.AccountID,
+		&i.OperationID,
+		&i.Points,
+		&i.Status,
+		&i.ExpiresAt,
+		&i.CapturedPoints,
+		&i.CaptureEventID,
+		&i.ActorUserID,
+		&i.CreatedAt,
+		&i.ClosedAt,
+	)
+	return i, err
+}
+
+const listExpiredHolds = `-- name: ListExpiredHolds :many
+SELECT
+    id, account_id, operation_id, points, status, expires_at,
+    captured_points, capture_event_id, actor_user_id, created_at, closed_at
+FROM holds
+WHERE status = 'ACTIVE'
+  AND expires_at <= $1
+ORDER BY expires_at, id
+LIMIT $2
+`
+
+type ListExpiredHoldsParams struct {
+	ExpiresAt pgtype.Timestamptz
+	Limit     int32
+}
+
+func (q *Queries) ListExpiredHolds(ctx context.Context, db DBTX, arg ListExpiredHoldsParams) ([]Hold, error) {
+	rows, err := db.Query(ctx, listExpiredHolds, arg.ExpiresAt, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Hold
+	for rows.Next() {
+		var i Hold
+		if err := rows.Scan(
+			&i.ID,
+			&i.AccountID,
+			&i.OperationID,
+			&i.Points,
+			&i.Status,
+			&i.ExpiresAt,
+			&i.CapturedPoints,
+			&i.CaptureEventID,
+			&i.ActorUserID,
+			&i.CreatedAt,
+			&i.ClosedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -0,0 +1,60 @@
+// ErrCode generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: level_changes.sql
+
+package gen
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const hasReachedLevel = `-- name: HasReachedLevel :one
+SELECT EXISTS (
+    SELECT 1
+    FROM level_changes
+    WHERE account_id = $1
+      AND to_level = $2
+) AS reached
+`
+
+type HasReachedLevelParams struct {
+	AccountID int64
+	ToLevel   string
+}
+
+// Whether the account has been moved to the level before, by any ruleset.
+func (q *Queries) HasReachedLevel(ctx context.Context, db DBTX, arg HasReachedLevelParams) (bool, error) {
+	row := db.QueryRow(ctx, hasReachedLevel, arg.AccountID, arg.ToLevel)
+	var reached bool
+	err := row.Scan(&reached)
+	return reached, err
+}
+
+const insertLevelChange = `-- name: InsertLevelChange :exec
+
+INSERT INTO level_changes (event_id, account_id, from_level, to_level, bonus_event_id)
+VALUES ($1, $2, $3, $4, $5)
+`
+
+type InsertLevelChangeParams struct {
+	EventID      int64
+	AccountID    int64
+	FromLevel    string
+	ToLevel      string
+	BonusEventID pgtype.Int8
+}
+
+// internal/repository/postgres/sqlc/queries/level_changes.sql
+func (q *Queries) InsertLevelChange(ctx context.Context, db DBTX, arg InsertLevelChangeParams) error {
+	_, err := db.Exec(ctx, insertLevelChange,
+		arg.EventID,
+		arg.AccountID,
+		arg.FromLevel,
+		arg.ToLevel,
+		arg.BonusEventID,
+	)
+	return err
+}
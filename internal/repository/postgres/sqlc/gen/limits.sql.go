@@ -0,0 +1,131 @@
+// ErrCode generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: limits.sql
+
+package gen
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const deleteVelocityLimit = `-- name: DeleteVelocityLimit :execrows
+DELETE FROM velocity_limits
+WHERE id = $1
+`
+
+func (q *Queries) DeleteVelocityLimit(ctx context.Context, db DBTX, id int64) (int64, error) {
+	result, err := db.Exec(ctx, deleteVelocityLimit, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const listVelocityLimits = `-- name: ListVelocityLimits :many
+SELECT id, kind, op_type, max_value, updated_by, created_at, updated_at
+FROM velocity_limits
+ORDER BY op_type, kind
+`
+
+func (q *Queries) ListVelocityLimits(ctx context.Context, db DBTX) ([]VelocityLimit, error) {
+	rows, err := db.Query(ctx, listVelocityLimits)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []VelocityLimit
+	for rows.Next() {
+		var i VelocityLimit
+		if err := rows.Scan(
+			&i.ID,
+			&i.Kind,
+			&i.OpType,
+			&i.MaxValue,
+			&i.UpdatedBy,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listVelocityLimitsByOpType = `-- name: ListVelocityLimitsByOpType :many
+SELECT id, kind, op_type, max_value, updated_by, created_at, updated_at
+FROM velocity_limits
+WHERE op_type = $1::event_type
+ORDER BY kind
+`
+
+func (q *Queries) ListVelocityLimitsByOpType(ctx context.Context, db DBTX, dollar_1 EventType) ([]VelocityLimit, error) {
+	rows, err := db.Query(ctx, listVelocityLimitsByOpType, dollar_1)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []VelocityLimit
+	for rows.Next() {
+		var i VelocityLimit
+		if err := rows.Scan(
+			&i.ID,
+			&i.Kind,
+			&i.OpType,
+			&i.MaxValue,
+			&i.UpdatedBy,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const upsertVelocityLimit = `-- name: UpsertVelocityLimit :one
+INSERT INTO velocity_limits (kind, op_type, max_value, updated_by)
+VALUES ($1, $2::event_type, $3, $4)
+ON CONFLICT (kind, op_type) DO UPDATE
+SET max_value = EXCLUDED.max_value,
+    updated_by = EXCLUDED.updated_by,
+    updated_at = now()
+RETURNING id, kind, op_type, max_value, updated_by, created_at, updated_at
+`
+
+type UpsertVelocityLimitParams struct {
+	Kind      string
+	Column2   EventType
+	MaxValue  int32
+	UpdatedBy pgtype.Int8
+}
+
+func (q *Queries) UpsertVelocityLimit(ctx context.Context, db DBTX, arg UpsertVelocityLimitParams) (VelocityLimit, error) {
+	row := db.QueryRow(ctx, upsertVelocityLimit,
+		arg.Kind,
+		arg.Column2,
+		arg.MaxValue,
+		arg.UpdatedBy,
+	)
+	var i VelocityLimit
+	err := row.Scan(
+		&i.ID,
+		&i.Kind,
+		&i.OpType,
+		&i.MaxValue,
+		&i.UpdatedBy,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
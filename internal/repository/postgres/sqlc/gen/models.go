@@ -15,8 +15,20 @@ import (
 type EventType string
 
 const (
-	EventTypeEARN  EventType = "EARN"
-	EventTypeSPEND EventType = "SPEND"
+	EventTypeEARN          EventType = "EARN"
+	EventTypeSPEND         EventType = "SPEND"
+	EventTypeREFUND        EventType = "REFUND"
+	EventTypeVOID          EventType = "VOID"
+	EventTypeEXPIRE        EventType = "EXPIRE"
+	EventTypeCHECKOUT      EventType = "CHECKOUT"
+	EventTypeADJUST        EventType = "ADJUST"
+	EventTypeTRANSFEROUT   EventType = "TRANSFER_OUT"
+	EventTypeTRANSFERIN    EventType = "TRANSFER_IN"
+	EventTypeTRANSFER      EventType = "TRANSFER"
+	EventTypeBONUS         EventType = "BONUS"
+	EventTypeLEVELCHANGED  EventType = "LEVEL_CHANGED"
+	EventTypeHOLDAUTHORIZE EventType = "HOLD_AUTHORIZE"
+	EventTypeHOLDCAPTURE   EventType = "HOLD_CAPTURE"
 )
 
 func (e *EventType) Scan(src interface{}) error {
@@ -62,6 +74,47 @@ type Account struct {
 	BalancePoints   int32
 	TotalSpendMoney decimal.Decimal
 	LevelCode       pgtype.Text
+	HeldPoints      int32
+}
+
+type Adjustment struct {
+	EventID    int64
+	ReasonCode string
+	Comment    string
+}
+
+type AdjustmentReason struct {
+	Code string
+}
+
+type BonusGrant struct {
+	EventID   int64
+	AccountID int64
+	Kind      string
+	Year      int32
+}
+
+type Campaign struct {
+	ID          int64
+	Name        string
+	StartsOn    pgtype.Date
+	EndsOn      pgtype.Date
+	Weekdays    []int16
+	TimeFrom    pgtype.Time
+	TimeTo      pgtype.Time
+	Timezone    string
+	Multiplier  pgtype.Numeric
+	BonusPoints pgtype.Int4
+	UpdatedBy   pgtype.Int8
+	CreatedAt   pgtype.Timestamptz
+	UpdatedAt   pgtype.Timestamptz
+}
+
+type CategoryEarnRate struct {
+	ID         int64
+	RulesetID  int64
+	Category   string
+	EarnFactor decimal.Decimal
 }
 
 type Event struct {
@@ -75,6 +128,45 @@ type Event struct {
 	CreatedAt    pgtype.Timestamptz
 	RulesetID    pgtype.Int8
 	ActorUserID  pgtype.Int8
+	RefEventID   pgtype.Int8
+	PrevHash     []byte
+	Hash         []byte
+}
+
+type EventCampaign struct {
+	EventID     int64
+	CampaignID  int64
+	BonusPoints int32
+}
+
+type ExpiredOperation struct {
+	AccountID   int64
+	OpType      EventType
+	OperationID string
+	CreatedAt   pgtype.Timestamptz
+	ExpiredAt   pgtype.Timestamptz
+}
+
+type Hold struct {
+	ID             int64
+	AccountID      int64
+	OperationID    string
+	Points         int32
+	Status         string
+	ExpiresAt      pgtype.Timestamptz
+	CapturedPoints pgtype.Int4
+	CaptureEventID pgtype.Int8
+	ActorUserID    pgtype.Int8
+	CreatedAt      pgtype.Timestamptz
+	ClosedAt       pgtype.Timestamptz
+}
+
+type LevelChange struct {
+	EventID      int64
+	AccountID    int64
+	FromLevel    string
+	ToLevel      string
+	BonusEventID pgtype.Int8
 }
 
 type LevelRule struct {
@@ -83,18 +175,58 @@ type LevelRule struct {
 	LevelCode           string
 	ThresholdTotalSpend decimal.Decimal
 	PercentEarn         decimal.Decimal
+	ReachedBonusPoints  int32
 }
 
 type Operation struct {
-	ID           int64
-	OperationID  string
-	AccountID    int64
-	OpType       EventType
-	RequestJson  []byte
-	ResponseJson []byte
-	HttpStatus   pgtype.Int4
-	EventID      pgtype.Int8
-	CreatedAt    pgtype.Timestamptz
+	ID               int64
+	OperationID      string
+	AccountID        int64
+	OpType           EventType
+	RequestJson      []byte
+	ResponseJson     []byte
+	HttpStatus       pgtype.Int4
+	EventID          pgtype.Int8
+	CreatedAt        pgtype.Timestamptz
+	CanonicalRequest bool
+}
+
+type PointLot struct {
+	ID              int64
+	AccountID       int64
+	SourceEventID   pgtype.Int8
+	Points          int32
+	RemainingPoints int32
+	EarnedAt        pgtype.Timestamptz
+	ExpiresAt       pgtype.Timestamptz
+	CreatedAt       pgtype.Timestamptz
+}
+
+type ReceiptLine struct {
+	ID         int64
+	EventID    int64
+	LineNo     int32
+	Sku        string
+	Category   string
+	Quantity   int32
+	LineTotal  decimal.Decimal
+	EarnFactor decimal.Decimal
+}
+
+type Referral struct {
+	RefereeAccountID  int64
+	ReferrerAccountID int64
+	CreatedAt         pgtype.Timestamptz
+	RewardedAt        pgtype.Timestamptz
+	QualifyingEventID pgtype.Int8
+	ReferrerEventID   pgtype.Int8
+	RefereeEventID    pgtype.Int8
+}
+
+type ReferralCode struct {
+	AccountID int64
+	Code      string
+	CreatedAt pgtype.Timestamptz
 }
 
 type Role struct {
@@ -102,11 +234,32 @@ type Role struct {
 }
 
 type Ruleset struct {
-	ID              int64
-	EffectiveFrom   pgtype.Timestamptz
-	BaseRubPerPoint decimal.Decimal
-	CreatedBy       pgtype.Int8
-	CreatedAt       pgtype.Timestamptz
+	ID                      int64
+	EffectiveFrom           pgtype.Timestamptz
+	BaseRubPerPoint         decimal.Decimal
+	CreatedBy               pgtype.Int8
+	CreatedAt               pgtype.Timestamptz
+	RedeemRubPerPoint       decimal.Decimal
+	QualificationWindowDays pgtype.Int4
+	WelcomeBonusPoints      int32
+	BirthdayBonusPoints     int32
+	ReferrerBonusPoints     int32
+	RefereeBonusPoints      int32
+	RoundingMode            string
+	RoundingStage           string
+	MinEarnAmount           decimal.Decimal
+	MaxPointsPerPurchase    pgtype.Int4
+	MaxPointsPerDay         pgtype.Int4
+	Status                  string
+}
+
+type RulesetHistory struct {
+	ID          int64
+	RulesetID   int64
+	Action      string
+	ActorUserID pgtype.Int8
+	Comment     pgtype.Text
+	CreatedAt   pgtype.Timestamptz
 }
 
 type User struct {
@@ -115,9 +268,20 @@ type User struct {
 	PasswordHash string
 	CreatedAt    pgtype.Timestamptz
 	IsActive     bool
+	Birthday     pgtype.Date
 }
 
 type UserRole struct {
 	UserID   int64
 	RoleCode string
 }
+
+type VelocityLimit struct {
+	ID        int64
+	Kind      string
+	OpType    EventType
+	MaxValue  int32
+	UpdatedBy pgtype.Int8
+	CreatedAt pgtype.Timestamptz
+	UpdatedAt pgtype.Timestamptz
+}
@@ -11,10 +11,47 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const expireOperationsBefore = `-- name: ExpireOperationsBefore :one
+WITH expired AS (
+    DELETE FROM operations
+    WHERE id IN (
+        SELECT id
+        FROM operations
+        WHERE created_at < $1
+        ORDER BY created_at, id
+        LIMIT $2
+        FOR UPDATE SKIP LOCKED
+    )
+    RETURNING account_id, op_type, operation_id, created_at
+),
+tombstones AS (
+    INSERT INTO expired_operations (account_id, op_type, operation_id, created_at)
+    SELECT account_id, op_type, operation_id, created_at
+    FROM expired
+    ON CONFLICT (account_id, op_type, operation_id) DO NOTHING
+)
+SELECT count(*)::bigint AS expired
+FROM expired
+`
+
+type ExpireOperationsBeforeParams struct {
+	CreatedAt pgtype.Timestamptz
+	Limit     int32
+}
+
+// Counts the deleted records, not the tombstones: a key expired before keeps its first tombstone.
+func (q *Queries) ExpireOperationsBefore(ctx context.Context, db DBTX, arg ExpireOperationsBeforeParams) (int64, error) {
+	row := db.QueryRow(ctx, expireOperationsBefore, arg.CreatedAt, arg.Limit)
+	var expired int64
+	err := row.Scan(&expired)
+	return expired, err
+}
+
 const finalizeOperation = `-- name: FinalizeOperation :exec
 UPDATE operations
 SET http_status = $4,
-    response_json = $5
+    response_json = $5,
+    event_id = $6
 WHERE account_id = $1
   AND op_type = $2::event_type
   AND operation_id = $3
@@ -26,6 +63,7 @@ type FinalizeOperationParams struct {
 	OperationID  string
 	HttpStatus   pgtype.Int4
 	ResponseJson []byte
+	EventID      pgtype.Int8
 }
 
 func (q *Queries) FinalizeOperation(ctx context.Context, db DBTX, arg FinalizeOperationParams) error {
@@ -35,6 +73,7 @@ func (q *Queries) FinalizeOperation(ctx context.Context, db DBTX, arg FinalizeOp
 		arg.OperationID,
 		arg.HttpStatus,
 		arg.ResponseJson,
+		arg.EventID,
 	)
 	return err
 }
@@ -47,7 +86,9 @@ SELECT
     request_json,
     response_json,
     http_status,
-    created_at
+    event_id,
+    created_at,
+    canonical_request
 FROM operations
 WHERE account_id = $1
   AND op_type = $2::event_type
@@ -61,13 +102,15 @@ type GetOperationParams struct {
 }
 
 type GetOperationRow struct {
-	AccountID    int64
-	OpType       string
-	OperationID  string
-	RequestJson  []byte
-	ResponseJson []byte
-	HttpStatus   pgtype.Int4
-	CreatedAt    pgtype.Timestamptz
+	AccountID        int64
+	OpType           string
+	OperationID      string
+	RequestJson      []byte
+	ResponseJson     []byte
+	HttpStatus       pgtype.Int4
+	EventID          pgtype.Int8
+	CreatedAt        pgtype.Timestamptz
+	CanonicalRequest bool
 }
 
 func (q *Queries) GetOperation(ctx context.Context, db DBTX, arg GetOperationParams) (GetOperationRow, error) {
@@ -80,7 +123,9 @@ func (q *Queries) GetOperation(ctx context.Context, db DBTX, arg GetOperationPar
 		&i.RequestJson,
 		&i.ResponseJson,
 		&i.HttpStatus,
+		&i.EventID,
 		&i.CreatedAt,
+		&i.CanonicalRequest,
 	)
 	return i, err
 }
@@ -110,3 +155,51 @@ func (q *Queries) InsertOperationPending(ctx context.Context, db DBTX, arg Inser
 	}
 	return result.RowsAffected(), nil
 }
+
+const isOperationExpired = `-- name: IsOperationExpired :one
+SELECT EXISTS (
+    SELECT 1
+    FROM expired_operations
+    WHERE account_id = $1
+      AND op_type = $2::event_type
+      AND operation_id = $3
+) AS expired
+`
+
+type IsOperationExpiredParams struct {
+	AccountID   int64
+	Column2     EventType
+	OperationID string
+}
+
+func (q *Queries) IsOperationExpired(ctx context.Context, db DBTX, arg IsOperationExpiredParams) (bool, error) {
+	row := db.QueryRow(ctx, isOperationExpired, arg.AccountID, arg.Column2, arg.OperationID)
+	var expired bool
+	err := row.Scan(&expired)
+	return expired, err
+}
+
+const purgeExpiredOperationsBefore = `-- name: PurgeExpiredOperationsBefore :execrows
+DELETE FROM expired_operations
+WHERE (account_id, op_type, operation_id) IN (
+    SELECT account_id, op_type, operation_id
+    FROM expired_operations
+    WHERE expired_at < $1
+    ORDER BY expired_at
+    LIMIT $2
+    FOR UPDATE SKIP LOCKED
+)
+`
+
+type PurgeExpiredOperationsBeforeParams struct {
+	ExpiredAt pgtype.Timestamptz
+	Limit     int32
+}
+
+func (q *Queries) PurgeExpiredOperationsBefore(ctx context.Context, db DBTX, arg PurgeExpiredOperationsBeforeParams) (int64, error) {
+	result, err := db.Exec(ctx, purgeExpiredOperationsBefore, arg.ExpiredAt, arg.Limit)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
@@ -0,0 +1,307 @@
+// ErrCode generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: point_lots.sql
+
+package gen
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const getNextExpiringPoints = `-- name: GetNextExpiringPoints :one
+SELECT
+    (expires_at AT TIME ZONE 'UTC')::date AS expires_on,
+    SUM(remaining_points)::int AS points
+FROM point_lots
+WHERE account_id = $1
+  AND remaining_points > 0
+GROUP BY expires_on
+ORDER BY expires_on
+LIMIT 1
+`
+
+type GetNextExpiringPointsRow struct {
+	ExpiresOn pgtype.Date
+	Points    int32
+}
+
+func (q *Queries) GetNextExpiringPoints(ctx context.Context, db DBTX, accountID int64) (GetNextExpiringPointsRow, error) {
+	row := db.QueryRow(ctx, getNextExpiringPoints, accountID)
+	var i GetNextExpiringPointsRow
+	err := row.Scan(
+		&i.ExpiresOn,
+		&i.Points,
+	)
+	return i, err
+}
+
+const insertPointLot = `-- name: InsertPointLot :one
+
+INSERT INTO point_lots (
+    account_id, source_event_id, points, remaining_points,
+    earned_at, expires_at
+)
+VALUES (
+           $1,
+           $2,
+           $3,
+           $3,
+           $4,
+           $5
+       )
+RETURNING
+    id,
+    account_id,
+    source_event_id,
+    points,
+    remaining_points,
+    earned_at,
+    expires_at,
+    created_at
+`
+
+type InsertPointLotParams struct {
+	AccountID     int64
+	SourceEventID pgtype.Int8
+	Points        int32
+	EarnedAt      pgtype.Timestamptz
+	ExpiresAt     pgtype.Timestamptz
+}
+
+// internal/repository/postgres/sqlc/queries/point_lots.sql
+func (q *Queries) InsertPointLot(ctx context.Context, db DBTX, arg InsertPointLotParams) (PointLot, error) {
+	row := db.QueryRow(ctx, insertPointLot,
+		arg.AccountID,
+		arg.SourceEventID,
+		arg.Points,
+		arg.EarnedAt,
+		arg.ExpiresAt,
+	)
+	var i PointLot
+	err := row.Scan(
+		&i.ID,
+		&i.AccountID,
+		&i.SourceEventID,
+		&i.Points,
+		&i.RemainingPoints,
+		&i.EarnedAt,
+		&i.ExpiresAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const listAccountsWithExpiredPointLots = `-- name: ListAccountsWithExpiredPointLots :many
+SELECT DISTINCT account_id
+FROM point_lots
+WHERE remaining_points > 0
+  AND expires_at <= $1
+ORDER BY account_id
+LIMIT $2
+`
+
+type ListAccountsWithExpiredPointLotsParams struct {
+	ExpiresAt pgtype.Timestamptz
+	Limit     int32
+}
+
+func (q *Queries) ListAccountsWithExpiredPointLots(ctx context.Context, db DBTX, arg ListAccountsWithExpiredPointLotsParams) ([]int64, error) {
+	rows, err := db.Query(ctx, listAccountsWithExpiredPointLots, arg.ExpiresAt, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []int64
+	for rows.Next() {
+		var account_id int64
+		if err := rows.Scan(&account_id); err != nil {
+			return nil, err
+		}
+		items = append(items, account_id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listExpiredPointLotsForUpdate = `-- name: ListExpiredPointLotsForUpdate :many
+SELECT
+    id,
+    account_id,
+    source_event_id,
+    points,
+    remaining_points,
+    earned_at,
+    expires_at,
+    created_at
+FROM point_lots
+WHERE account_id = $1
+  AND remaining_points > 0
+  AND expires_at <= $2
+ORDER BY expires_at, id
+FOR UPDATE
+`
+
+type ListExpiredPointLotsForUpdateParams struct {
+	AccountID int64
+	ExpiresAt pgtype.Timestamptz
+}
+
+func (q *Queries) ListExpiredPointLotsForUpdate(ctx context.Context, db DBTX, arg ListExpiredPointLotsForUpdateParams) ([]PointLot, error) {
+	rows, err := db.Query(ctx, listExpiredPointLotsForUpdate, arg.AccountID, arg.ExpiresAt)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []PointLot
+	for rows.Next() {
+		var i PointLot
+		if err := rows.Scan(
+			&i.ID,
+			&i.AccountID,
+			&i.SourceEventID,
+			&i.Points,
+			&i.RemainingPoints,
+			&i.EarnedAt,
+			&i.ExpiresAt,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listLegacyPointLotsForUpdate = `-- name: ListLegacyPointLotsForUpdate :many
+SELECT
+    id,
+    account_id,
+    source_event_id,
+    points,
+    remaining_points,
+    earned_at,
+    expires_at,
+    created_at
+FROM point_lots
+WHERE source_event_id IS NULL
+  AND remaining_points > 0
+  AND expires_at = earned_at + INTERVAL '12 months'
+ORDER BY id
+FOR UPDATE
+`
+
+// Open lots that migration 0014 opened for pre-lot balances and that still carry its 12-month expiry.
+func (q *Queries) ListLegacyPointLotsForUpdate(ctx context.Context, db DBTX) ([]PointLot, error) {
+	rows, err := db.Query(ctx, listLegacyPointLotsForUpdate)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []PointLot
+	for rows.Next() {
+		var i PointLot
+		if err := rows.Scan(
+			&i.ID,
+			&i.AccountID,
+			&i.SourceEventID,
+			&i.Points,
+			&i.RemainingPoints,
+			&i.EarnedAt,
+			&i.ExpiresAt,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listOpenPointLotsForUpdate = `-- name: ListOpenPointLotsForUpdate :many
+SELECT
+    id,
+    account_id,
+    source_event_id,
+    points,
+    remaining_points,
+    earned_at,
+    expires_at,
+    created_at
+FROM point_lots
+WHERE account_id = $1
+  AND remaining_points > 0
+ORDER BY expires_at, id
+FOR UPDATE
+`
+
+func (q *Queries) ListOpenPointLotsForUpdate(ctx context.Context, db DBTX, accountID int64) ([]PointLot, error) {
+	rows, err := db.Query(ctx, listOpenPointLotsForUpdate, accountID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []PointLot
+	for rows.Next() {
+		var i PointLot
+		if err := rows.Scan(
+			&i.ID,
+			&i.AccountID,
+			&i.SourceEventID,
+			&i.Points,
+			&i.RemainingPoints,
+			&i.EarnedAt,
+			&i.ExpiresAt,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const setPointLotExpiresAt = `-- name: SetPointLotExpiresAt :exec
+UPDATE point_lots
+SET expires_at = $2
+WHERE id = $1
+`
+
+type SetPointLotExpiresAtParams struct {
+	ID        int64
+	ExpiresAt pgtype.Timestamptz
+}
+
+func (q *Queries) SetPointLotExpiresAt(ctx context.Context, db DBTX, arg SetPointLotExpiresAtParams) error {
+	_, err := db.Exec(ctx, setPointLotExpiresAt, arg.ID, arg.ExpiresAt)
+	return err
+}
+
+const setPointLotRemaining = `-- name: SetPointLotRemaining :exec
+UPDATE point_lots
+SET remaining_points = $2
+WHERE id = $1
+`
+
+type SetPointLotRemainingParams struct {
+	ID              int64
+	RemainingPoints int32
+}
+
+func (q *Queries) SetPointLotRemaining(ctx context.Context, db DBTX, arg SetPointLotRemainingParams) error {
+	_, err := db.Exec(ctx, setPointLotRemaining, arg.ID, arg.RemainingPoints)
+	return err
+}
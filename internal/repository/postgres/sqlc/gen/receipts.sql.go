@@ -0,0 +1,124 @@
+// ErrCode generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: receipts.sql
+
+package gen
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
+)
+
+const insertReceiptLine = `-- name: InsertReceiptLine :exec
+
+INSERT INTO receipt_lines (event_id, line_no, sku, category, quantity, line_total, earn_factor)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+`
+
+type InsertReceiptLineParams struct {
+	EventID    int64
+	LineNo     int32
+	Sku        string
+	Category   string
+	Quantity   int32
+	LineTotal  decimal.Decimal
+	EarnFactor decimal.Decimal
+}
+
+// internal/repository/postgres/sqlc/queries/receipts.sql
+func (q *Queries) InsertReceiptLine(ctx context.Context, db DBTX, arg InsertReceiptLineParams) error {
+	_, err := db.Exec(ctx, insertReceiptLine,
+		arg.EventID,
+		arg.LineNo,
+		arg.Sku,
+		arg.Category,
+		arg.Quantity,
+		arg.LineTotal,
+		arg.EarnFactor,
+	)
+	return err
+}
+
+const listReceiptLinesByEventID = `-- name: ListReceiptLinesByEventID :many
+SELECT id, event_id, line_no, sku, category, quantity, line_total, earn_factor
+FROM receipt_lines
+WHERE event_id = $1
+ORDER BY line_no ASC
+`
+
+func (q *Queries) ListReceiptLinesByEventID(ctx context.Context, db DBTX, eventID int64) ([]ReceiptLine, error) {
+	rows, err := db.Query(ctx, listReceiptLinesByEventID, eventID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ReceiptLine
+	for rows.Next() {
+		var i ReceiptLine
+		if err := rows.Scan(
+			&i.ID,
+			&i.EventID,
+			&i.LineNo,
+			&i.Sku,
+			&i.Category,
+			&i.Quantity,
+			&i.LineTotal,
+			&i.EarnFactor,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listReceiptLinesForEarnInRange = `-- name: ListReceiptLinesForEarnInRange :many
+SELECT rl.id, rl.event_id, rl.line_no, rl.sku, rl.category, rl.quantity, rl.line_total, rl.earn_factor
+FROM receipt_lines rl
+JOIN events e ON e.id = rl.event_id
+WHERE e.type = 'EARN'
+  AND e.ts >= $1
+  AND e.ts < $2
+ORDER BY rl.event_id, rl.line_no
+`
+
+type ListReceiptLinesForEarnInRangeParams struct {
+	Ts   pgtype.Timestamptz
+	Ts_2 pgtype.Timestamptz
+}
+
+// Lines of the itemized EARN events with $1 <= ts < $2, for the ruleset simulation.
+func (q *Queries) ListReceiptLinesForEarnInRange(ctx context.Context, db DBTX, arg ListReceiptLinesForEarnInRangeParams) ([]ReceiptLine, error) {
+	rows, err := db.Query(ctx, listReceiptLinesForEarnInRange, arg.Ts, arg.Ts_2)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ReceiptLine
+	for rows.Next() {
+		var i ReceiptLine
+		if err := rows.Scan(
+			&i.ID,
+			&i.EventID,
+			&i.LineNo,
+			&i.Sku,
+			&i.Category,
+			&i.Quantity,
+			&i.LineTotal,
+			&i.EarnFactor,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
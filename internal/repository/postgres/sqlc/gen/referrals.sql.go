@@ -0,0 +1,181 @@
+// ErrCode generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: referrals.sql
+
+package gen
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const countReferralsByReferrerSince = `-- name: CountReferralsByReferrerSince :one
+SELECT COUNT(*)::int AS referred
+FROM referrals
+WHERE referrer_account_id = $1
+  AND created_at >= $2
+`
+
+type CountReferralsByReferrerSinceParams struct {
+	ReferrerAccountID int64
+	CreatedAt         pgtype.Timestamptz
+}
+
+func (q *Queries) CountReferralsByReferrerSince(ctx context.Context, db DBTX, arg CountReferralsByReferrerSinceParams) (int32, error) {
+	row := db.QueryRow(ctx, countReferralsByReferrerSince, arg.ReferrerAccountID, arg.CreatedAt)
+	var referred int32
+	err := row.Scan(&referred)
+	return referred, err
+}
+
+const getAccountIDByReferralCode = `-- name: GetAccountIDByReferralCode :one
+SELECT account_id
+FROM referral_codes
+WHERE code = $1
+`
+
+func (q *Queries) GetAccountIDByReferralCode(ctx context.Context, db DBTX, code string) (int64, error) {
+	row := db.QueryRow(ctx, getAccountIDByReferralCode, code)
+	var account_id int64
+	err := row.Scan(&account_id)
+	return account_id, err
+}
+
+const getPendingReferralByReferee = `-- name: GetPendingReferralByReferee :one
+SELECT referee_account_id, referrer_account_id, created_at, rewarded_at, qualifying_event_id, referrer_event_id, referee_event_id
+FROM referrals
+WHERE referee_account_id = $1
+  AND rewarded_at IS NULL
+`
+
+func (q *Queries) GetPendingReferralByReferee(ctx context.Context, db DBTX, refereeAccountID int64) (Referral, error) {
+	row := db.QueryRow(ctx, getPendingReferralByReferee, refereeAccountID)
+	var i Referral
+	err := row.Scan(
+		&i.RefereeAccountID,
+		&i.ReferrerAccountID,
+		&i.CreatedAt,
+		&i.RewardedAt,
+		&i.QualifyingEventID,
+		&i.ReferrerEventID,
+		&i.RefereeEventID,
+	)
+	return i, err
+}
+
+const getReferralCodeByAccountID = `-- name: GetReferralCodeByAccountID :one
+SELECT code
+FROM referral_codes
+WHERE account_id = $1
+`
+
+func (q *Queries) GetReferralCodeByAccountID(ctx context.Context, db DBTX, accountID int64) (string, error) {
+	row := db.QueryRow(ctx, getReferralCodeByAccountID, accountID)
+	var code string
+	err := row.Scan(&code)
+	return code, err
+}
+
+const getReferralStatsByReferrer = `-- name: GetReferralStatsByReferrer :one
+SELECT
+    COUNT(*)::int AS referred,
+    COUNT(r.rewarded_at)::int AS rewarded,
+    (COUNT(*) FILTER (WHERE r.created_at >= $2))::int AS referred_since,
+    COALESCE(SUM(e.delta_points), 0)::int AS bonus_points
+FROM referrals r
+LEFT JOIN events e ON e.id = r.referrer_event_id
+WHERE r.referrer_account_id = $1
+`
+
+type GetReferralStatsByReferrerParams struct {
+	ReferrerAccountID int64
+	CreatedAt         pgtype.Timestamptz
+}
+
+type GetReferralStatsByReferrerRow struct {
+	Referred      int32
+	Rewarded      int32
+	ReferredSince int32
+	BonusPoints   int32
+}
+
+// Totals of a referrer; points are the referrer's side of the rewards.
+func (q *Queries) GetReferralStatsByReferrer(ctx context.Context, db DBTX, arg GetReferralStatsByReferrerParams) (GetReferralStatsByReferrerRow, error) {
+	row := db.QueryRow(ctx, getReferralStatsByReferrer, arg.ReferrerAccountID, arg.CreatedAt)
+	var i GetReferralStatsByReferrerRow
+	err := row.Scan(
+		&i.Referred,
+		&i.Rewarded,
+		&i.ReferredSince,
+		&i.BonusPoints,
+	)
+	return i, err
+}
+
+const insertReferral = `-- name: InsertReferral :exec
+INSERT INTO referrals (referee_account_id, referrer_account_id, created_at)
+VALUES ($1, $2, $3)
+`
+
+type InsertReferralParams struct {
+	RefereeAccountID  int64
+	ReferrerAccountID int64
+	CreatedAt         pgtype.Timestamptz
+}
+
+func (q *Queries) InsertReferral(ctx context.Context, db DBTX, arg InsertReferralParams) error {
+	_, err := db.Exec(ctx, insertReferral, arg.RefereeAccountID, arg.ReferrerAccountID, arg.CreatedAt)
+	return err
+}
+
+const insertReferralCode = `-- name: InsertReferralCode :exec
+
+INSERT INTO referral_codes (account_id, code)
+VALUES ($1, $2)
+`
+
+type InsertReferralCodeParams struct {
+	AccountID int64
+	Code      string
+}
+
+// internal/repository/postgres/sqlc/queries/referrals.sql
+func (q *Queries) InsertReferralCode(ctx context.Context, db DBTX, arg InsertReferralCodeParams) error {
+	_, err := db.Exec(ctx, insertReferralCode, arg.AccountID, arg.Code)
+	return err
+}
+
+const markReferralRewarded = `-- name: MarkReferralRewarded :execrows
+UPDATE referrals
+SET rewarded_at = $2,
+    qualifying_event_id = $3,
+    referrer_event_id = $4,
+    referee_event_id = $5
+WHERE referee_account_id = $1
+  AND rewarded_at IS NULL
+`
+
+type MarkReferralRewardedParams struct {
+	RefereeAccountID  int64
+	RewardedAt        pgtype.Timestamptz
+	QualifyingEventID pgtype.Int8
+	ReferrerEventID   pgtype.Int8
+	RefereeEventID    pgtype.Int8
+}
+
+// Closes a pending referral; 0 rows means it was already rewarded.
+func (q *Queries) MarkReferralRewarded(ctx context.Context, db DBTX, arg MarkReferralRewardedParams) (int64, error) {
+	result, err := db.Exec(ctx, markReferralRewarded,
+		arg.RefereeAccountID,
+		arg.RewardedAt,
+		arg.QualifyingEventID,
+		arg.ReferrerEventID,
+		arg.RefereeEventID,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
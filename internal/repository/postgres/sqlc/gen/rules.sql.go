@@ -12,17 +12,62 @@ import (
 	"github.com/shopspring/decimal"
 )
 
+const deleteCategoryEarnRatesByRulesetID = `-- name: DeleteCategoryEarnRatesByRulesetID :exec
+DELETE FROM category_earn_rates
+WHERE ruleset_id = $1
+`
+
+func (q *Queries) DeleteCategoryEarnRatesByRulesetID(ctx context.Context, db DBTX, rulesetID int64) error {
+	_, err := db.Exec(ctx, deleteCategoryEarnRatesByRulesetID, rulesetID)
+	return err
+}
+
+const deleteLevelRulesByRulesetID = `-- name: DeleteLevelRulesByRulesetID :exec
+DELETE FROM level_rules
+WHERE ruleset_id = $1
+`
+
+func (q *Queries) DeleteLevelRulesByRulesetID(ctx context.Context, db DBTX, rulesetID int64) error {
+	_, err := db.Exec(ctx, deleteLevelRulesByRulesetID, rulesetID)
+	return err
+}
+
+const deleteRuleset = `-- name: DeleteRuleset :exec
+DELETE FROM ruleset
+WHERE id = $1
+`
+
+func (q *Queries) DeleteRuleset(ctx context.Context, db DBTX, id int64) error {
+	_, err := db.Exec(ctx, deleteRuleset, id)
+	return err
+}
+
 const getRulesetByID = `-- name: GetRulesetByID :one
-SELECT id, effective_from, base_rub_per_point, created_at
+SELECT id, effective_from, base_rub_per_point, redeem_rub_per_point, qualification_window_days, welcome_bonus_points, birthday_bonus_points, referrer_bonus_points, referee_bonus_points, rounding_mode, rounding_stage,
+       min_earn_amount, max_points_per_purchase, max_points_per_day, created_at, status,
+       (SELECT MIN(n.effective_from) FROM ruleset n WHERE n.status = 'APPROVED' AND n.effective_from > ruleset.effective_from)::timestamptz AS effective_to
 FROM ruleset
 WHERE id = $1
 `
 
 type GetRulesetByIDRow struct {
-	ID              int64
-	EffectiveFrom   pgtype.Timestamptz
-	BaseRubPerPoint decimal.Decimal
-	CreatedAt       pgtype.Timestamptz
+	ID                      int64
+	EffectiveFrom           pgtype.Timestamptz
+	BaseRubPerPoint         decimal.Decimal
+	RedeemRubPerPoint       decimal.Decimal
+	QualificationWindowDays pgtype.Int4
+	WelcomeBonusPoints      int32
+	BirthdayBonusPoints     int32
+	ReferrerBonusPoints     int32
+	RefereeBonusPoints      int32
+	RoundingMode            string
+	RoundingStage           string
+	MinEarnAmount           decimal.Decimal
+	MaxPointsPerPurchase    pgtype.Int4
+	MaxPointsPerDay         pgtype.Int4
+	CreatedAt               pgtype.Timestamptz
+	Status                  string
+	EffectiveTo             pgtype.Timestamptz
 }
 
 func (q *Queries) GetRulesetByID(ctx context.Context, db DBTX, id int64) (GetRulesetByIDRow, error) {
@@ -32,25 +77,54 @@ func (q *Queries) GetRulesetByID(ctx context.Context, db DBTX, id int64) (GetRul
 		&i.ID,
 		&i.EffectiveFrom,
 		&i.BaseRubPerPoint,
+		&i.RedeemRubPerPoint,
+		&i.QualificationWindowDays,
+		&i.WelcomeBonusPoints,
+		&i.BirthdayBonusPoints,
+		&i.ReferrerBonusPoints,
+		&i.RefereeBonusPoints,
+		&i.RoundingMode,
+		&i.RoundingStage,
+		&i.MinEarnAmount,
+		&i.MaxPointsPerPurchase,
+		&i.MaxPointsPerDay,
 		&i.CreatedAt,
+		&i.Status,
+		&i.EffectiveTo,
 	)
 	return i, err
 }
 
 const getRulesetEffectiveAt = `-- name: GetRulesetEffectiveAt :one
 
-SELECT id, effective_from, base_rub_per_point, created_at
+SELECT id, effective_from, base_rub_per_point, redeem_rub_per_point, qualification_window_days, welcome_bonus_points, birthday_bonus_points, referrer_bonus_points, referee_bonus_points, rounding_mode, rounding_stage,
+       min_earn_amount, max_points_per_purchase, max_points_per_day, created_at, status,
+       (SELECT MIN(n.effective_from) FROM ruleset n WHERE n.status = 'APPROVED' AND n.effective_from > ruleset.effective_from)::timestamptz AS effective_to
 FROM ruleset
-WHERE effective_from <= $1
+WHERE status = 'APPROVED'
+  AND effective_from <= $1
 ORDER BY effective_from DESC
 LIMIT 1
 `
 
 type GetRulesetEffectiveAtRow struct {
-	ID              int64
-	EffectiveFrom   pgtype.Timestamptz
-	BaseRubPerPoint decimal.Decimal
-	CreatedAt       pgtype.Timestamptz
+	ID                      int64
+	EffectiveFrom           pgtype.Timestamptz
+	BaseRubPerPoint         decimal.Decimal
+	RedeemRubPerPoint       decimal.Decimal
+	QualificationWindowDays pgtype.Int4
+	WelcomeBonusPoints      int32
+	BirthdayBonusPoints     int32
+	ReferrerBonusPoints     int32
+	RefereeBonusPoints      int32
+	RoundingMode            string
+	RoundingStage           string
+	MinEarnAmount           decimal.Decimal
+	MaxPointsPerPurchase    pgtype.Int4
+	MaxPointsPerDay         pgtype.Int4
+	CreatedAt               pgtype.Timestamptz
+	Status                  string
+	EffectiveTo             pgtype.Timestamptz
 }
 
 // internal/repository/postgres/sqlc/queries/rules.sql
@@ -61,15 +135,44 @@ func (q *Queries) GetRulesetEffectiveAt(ctx context.Context, db DBTX, effectiveF
 		&i.ID,
 		&i.EffectiveFrom,
 		&i.BaseRubPerPoint,
+		&i.RedeemRubPerPoint,
+		&i.QualificationWindowDays,
+		&i.WelcomeBonusPoints,
+		&i.BirthdayBonusPoints,
+		&i.ReferrerBonusPoints,
+		&i.RefereeBonusPoints,
+		&i.RoundingMode,
+		&i.RoundingStage,
+		&i.MinEarnAmount,
+		&i.MaxPointsPerPurchase,
+		&i.MaxPointsPerDay,
 		&i.CreatedAt,
+		&i.Status,
+		&i.EffectiveTo,
 	)
 	return i, err
 }
 
+const insertCategoryEarnRate = `-- name: InsertCategoryEarnRate :exec
+INSERT INTO category_earn_rates (ruleset_id, category, earn_factor)
+VALUES ($1, $2, $3)
+`
+
+type InsertCategoryEarnRateParams struct {
+	RulesetID  int64
+	Category   string
+	EarnFactor decimal.Decimal
+}
+
+func (q *Queries) InsertCategoryEarnRate(ctx context.Context, db DBTX, arg InsertCategoryEarnRateParams) error {
+	_, err := db.Exec(ctx, insertCategoryEarnRate, arg.RulesetID, arg.Category, arg.EarnFactor)
+	return err
+}
+
 const insertLevelRule = `-- name: InsertLevelRule :one
-INSERT INTO level_rules (ruleset_id, level_code, threshold_total_spend, percent_earn)
-VALUES ($1, $2, $3, $4)
-RETURNING id, ruleset_id, level_code, threshold_total_spend, percent_earn
+INSERT INTO level_rules (ruleset_id, level_code, threshold_total_spend, percent_earn, reached_bonus_points)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING id, ruleset_id, level_code, threshold_total_spend, percent_earn, reached_bonus_points
 `
 
 type InsertLevelRuleParams struct {
@@ -77,6 +180,7 @@ type InsertLevelRuleParams struct {
 	LevelCode           string
 	ThresholdTotalSpend decimal.Decimal
 	PercentEarn         decimal.Decimal
+	ReachedBonusPoints  int32
 }
 
 func (q *Queries) InsertLevelRule(ctx context.Context, db DBTX, arg InsertLevelRuleParams) (LevelRule, error) {
@@ -85,6 +189,7 @@ func (q *Queries) InsertLevelRule(ctx context.Context, db DBTX, arg InsertLevelR
 		arg.LevelCode,
 		arg.ThresholdTotalSpend,
 		arg.PercentEarn,
+		arg.ReachedBonusPoints,
 	)
 	var i LevelRule
 	err := row.Scan(
@@ -93,42 +198,151 @@ func (q *Queries) InsertLevelRule(ctx context.Context, db DBTX, arg InsertLevelR
 		&i.LevelCode,
 		&i.ThresholdTotalSpend,
 		&i.PercentEarn,
+		&i.ReachedBonusPoints,
 	)
 	return i, err
 }
 
 const insertRuleset = `-- name: InsertRuleset :one
-INSERT INTO ruleset (effective_from, base_rub_per_point)
-VALUES ($1, $2)
-RETURNING id, effective_from, base_rub_per_point, created_at
+INSERT INTO ruleset (effective_from, base_rub_per_point, redeem_rub_per_point, qualification_window_days, welcome_bonus_points, birthday_bonus_points,
+                     referrer_bonus_points, referee_bonus_points, rounding_mode, rounding_stage, min_earn_amount, max_points_per_purchase,
+                     max_points_per_day, created_by)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+RETURNING id, effective_from, base_rub_per_point, redeem_rub_per_point, qualification_window_days, welcome_bonus_points, birthday_bonus_points, referrer_bonus_points, referee_bonus_points, rounding_mode, rounding_stage,
+          min_earn_amount, max_points_per_purchase, max_points_per_day, created_at, status
 `
 
 type InsertRulesetParams struct {
-	EffectiveFrom   pgtype.Timestamptz
-	BaseRubPerPoint decimal.Decimal
+	EffectiveFrom           pgtype.Timestamptz
+	BaseRubPerPoint         decimal.Decimal
+	RedeemRubPerPoint       decimal.Decimal
+	QualificationWindowDays pgtype.Int4
+	WelcomeBonusPoints      int32
+	BirthdayBonusPoints     int32
+	ReferrerBonusPoints     int32
+	RefereeBonusPoints      int32
+	RoundingMode            string
+	RoundingStage           string
+	MinEarnAmount           decimal.Decimal
+	MaxPointsPerPurchase    pgtype.Int4
+	MaxPointsPerDay         pgtype.Int4
+	CreatedBy               pgtype.Int8
 }
 
 type InsertRulesetRow struct {
-	ID              int64
-	EffectiveFrom   pgtype.Timestamptz
-	BaseRubPerPoint decimal.Decimal
-	CreatedAt       pgtype.Timestamptz
+	ID                      int64
+	EffectiveFrom           pgtype.Timestamptz
+	BaseRubPerPoint         decimal.Decimal
+	RedeemRubPerPoint       decimal.Decimal
+	QualificationWindowDays pgtype.Int4
+	WelcomeBonusPoints      int32
+	BirthdayBonusPoints     int32
+	ReferrerBonusPoints     int32
+	RefereeBonusPoints      int32
+	RoundingMode            string
+	RoundingStage           string
+	MinEarnAmount           decimal.Decimal
+	MaxPointsPerPurchase    pgtype.Int4
+	MaxPointsPerDay         pgtype.Int4
+	CreatedAt               pgtype.Timestamptz
+	Status                  string
 }
 
 func (q *Queries) InsertRuleset(ctx context.Context, db DBTX, arg InsertRulesetParams) (InsertRulesetRow, error) {
-	row := db.QueryRow(ctx, insertRuleset, arg.EffectiveFrom, arg.BaseRubPerPoint)
+	row := db.QueryRow(ctx, insertRuleset,
+		arg.EffectiveFrom,
+		arg.BaseRubPerPoint,
+		arg.RedeemRubPerPoint,
+		arg.QualificationWindowDays,
+		arg.WelcomeBonusPoints,
+		arg.BirthdayBonusPoints,
+		arg.ReferrerBonusPoints,
+		arg.RefereeBonusPoints,
+		arg.RoundingMode,
+		arg.RoundingStage,
+		arg.MinEarnAmount,
+		arg.MaxPointsPerPurchase,
+		arg.MaxPointsPerDay,
+		arg.CreatedBy,
+	)
 	var i InsertRulesetRow
 	err := row.Scan(
 		&i.ID,
 		&i.EffectiveFrom,
 		&i.BaseRubPerPoint,
+		&i.RedeemRubPerPoint,
+		&i.QualificationWindowDays,
+		&i.WelcomeBonusPoints,
+		&i.BirthdayBonusPoints,
+		&i.ReferrerBonusPoints,
+		&i.RefereeBonusPoints,
+		&i.RoundingMode,
+		&i.RoundingStage,
+		&i.MinEarnAmount,
+		&i.MaxPointsPerPurchase,
+		&i.MaxPointsPerDay,
 		&i.CreatedAt,
+		&i.Status,
 	)
 	return i, err
 }
 
+const insertRulesetHistory = `-- name: InsertRulesetHistory :exec
+INSERT INTO ruleset_history (ruleset_id, action, actor_user_id, comment)
+VALUES ($1, $2, $3, $4)
+`
+
+type InsertRulesetHistoryParams struct {
+	RulesetID   int64
+	Action      string
+	ActorUserID pgtype.Int8
+	Comment     pgtype.Text
+}
+
+func (q *Queries) InsertRulesetHistory(ctx context.Context, db DBTX, arg InsertRulesetHistoryParams) error {
+	_, err := db.Exec(ctx, insertRulesetHistory,
+		arg.RulesetID,
+		arg.Action,
+		arg.ActorUserID,
+		arg.Comment,
+	)
+	return err
+}
+
+const listCategoryEarnRatesByRulesetID = `-- name: ListCategoryEarnRatesByRulesetID :many
+SELECT id, ruleset_id, category, earn_factor
+FROM category_earn_rates
+WHERE ruleset_id = $1
+ORDER BY category ASC
+`
+
+func (q *Queries) ListCategoryEarnRatesByRulesetID(ctx context.Context, db DBTX, rulesetID int64) ([]CategoryEarnRate, error) {
+	rows, err := db.Query(ctx, listCategoryEarnRatesByRulesetID, rulesetID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []CategoryEarnRate
+	for rows.Next() {
+		var i CategoryEarnRate
+		if err := rows.Scan(
+			&i.ID,
+			&i.RulesetID,
+			&i.Category,
+			&i.EarnFactor,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listLevelRulesByRulesetID = `-- name: ListLevelRulesByRulesetID :many
-SELECT id, ruleset_id, level_code, threshold_total_spend, percent_earn
+SELECT id, ruleset_id, level_code, threshold_total_spend, percent_earn, reached_bonus_points
 FROM level_rules
 WHERE ruleset_id = $1
 ORDER BY threshold_total_spend ASC
@@ -149,6 +363,41 @@ func (q *Queries) ListLevelRulesByRulesetID(ctx context.Context, db DBTX, rulese
 			&i.LevelCode,
 			&i.ThresholdTotalSpend,
 			&i.PercentEarn,
+			&i.ReachedBonusPoints,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listRulesetHistoryByRulesetID = `-- name: ListRulesetHistoryByRulesetID :many
+SELECT id, ruleset_id, action, actor_user_id, comment, created_at
+FROM ruleset_history
+WHERE ruleset_id = $1
+ORDER BY id ASC
+`
+
+func (q *Queries) ListRulesetHistoryByRulesetID(ctx context.Context, db DBTX, rulesetID int64) ([]RulesetHistory, error) {
+	rows, err := db.Query(ctx, listRulesetHistoryByRulesetID, rulesetID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []RulesetHistory
+	for rows.Next() {
+		var i RulesetHistory
+		if err := rows.Scan(
+			&i.ID,
+			&i.RulesetID,
+			&i.Action,
+			&i.ActorUserID,
+			&i.Comment,
+			&i.CreatedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -161,9 +410,11 @@ func (q *Queries) ListLevelRulesByRulesetID(ctx context.Context, db DBTX, rulese
 }
 
 const listRulesetsBase = `-- name: ListRulesetsBase :many
-SELECT id, effective_from, base_rub_per_point, created_at
+SELECT id, effective_from, base_rub_per_point, redeem_rub_per_point, qualification_window_days, welcome_bonus_points, birthday_bonus_points, referrer_bonus_points, referee_bonus_points, rounding_mode, rounding_stage,
+       min_earn_amount, max_points_per_purchase, max_points_per_day, created_at, status,
+       (SELECT MIN(n.effective_from) FROM ruleset n WHERE n.status = 'APPROVED' AND n.effective_from > ruleset.effective_from)::timestamptz AS effective_to
 FROM ruleset
-ORDER BY effective_from DESC
+ORDER BY effective_from DESC, id DESC
 LIMIT $1 OFFSET $2
 `
 
@@ -173,10 +424,23 @@ type ListRulesetsBaseParams struct {
 }
 
 type ListRulesetsBaseRow struct {
-	ID              int64
-	EffectiveFrom   pgtype.Timestamptz
-	BaseRubPerPoint decimal.Decimal
-	CreatedAt       pgtype.Timestamptz
+	ID                      int64
+	EffectiveFrom           pgtype.Timestamptz
+	BaseRubPerPoint         decimal.Decimal
+	RedeemRubPerPoint       decimal.Decimal
+	QualificationWindowDays pgtype.Int4
+	WelcomeBonusPoints      int32
+	BirthdayBonusPoints     int32
+	ReferrerBonusPoints     int32
+	RefereeBonusPoints      int32
+	RoundingMode            string
+	RoundingStage           string
+	MinEarnAmount           decimal.Decimal
+	MaxPointsPerPurchase    pgtype.Int4
+	MaxPointsPerDay         pgtype.Int4
+	CreatedAt               pgtype.Timestamptz
+	Status                  string
+	EffectiveTo             pgtype.Timestamptz
 }
 
 func (q *Queries) ListRulesetsBase(ctx context.Context, db DBTX, arg ListRulesetsBaseParams) ([]ListRulesetsBaseRow, error) {
@@ -192,7 +456,20 @@ func (q *Queries) ListRulesetsBase(ctx context.Context, db DBTX, arg ListRuleset
 			&i.ID,
 			&i.EffectiveFrom,
 			&i.BaseRubPerPoint,
+			&i.RedeemRubPerPoint,
+			&i.QualificationWindowDays,
+			&i.WelcomeBonusPoints,
+			&i.BirthdayBonusPoints,
+			&i.ReferrerBonusPoints,
+			&i.RefereeBonusPoints,
+			&i.RoundingMode,
+			&i.RoundingStage,
+			&i.MinEarnAmount,
+			&i.MaxPointsPerPurchase,
+			&i.MaxPointsPerDay,
 			&i.CreatedAt,
+			&i.Status,
+			&i.EffectiveTo,
 		); err != nil {
 			return nil, err
 		}
@@ -203,3 +480,137 @@ func (q *Queries) ListRulesetsBase(ctx context.Context, db DBTX, arg ListRuleset
 	}
 	return items, nil
 }
+
+const lockRulesetByID = `-- name: LockRulesetByID :one
+SELECT id, effective_from, base_rub_per_point, redeem_rub_per_point, qualification_window_days, welcome_bonus_points, birthday_bonus_points, referrer_bonus_points, referee_bonus_points, rounding_mode, rounding_stage,
+       min_earn_amount, max_points_per_purchase, max_points_per_day, created_at, status
+FROM ruleset
+WHERE id = $1
+FOR UPDATE
+`
+
+type LockRulesetByIDRow struct {
+	ID                      int64
+	EffectiveFrom           pgtype.Timestamptz
+	BaseRubPerPoint         decimal.Decimal
+	RedeemRubPerPoint       decimal.Decimal
+	QualificationWindowDays pgtype.Int4
+	WelcomeBonusPoints      int32
+	BirthdayBonusPoints     int32
+	ReferrerBonusPoints     int32
+	RefereeBonusPoints      int32
+	RoundingMode            string
+	RoundingStage           string
+	MinEarnAmount           decimal.Decimal
+	MaxPointsPerPurchase    pgtype.Int4
+	MaxPointsPerDay         pgtype.Int4
+	CreatedAt               pgtype.Timestamptz
+	Status                  string
+}
+
+func (q *Queries) LockRulesetByID(ctx context.Context, db DBTX, id int64) (LockRulesetByIDRow, error) {
+	row := db.QueryRow(ctx, lockRulesetByID, id)
+	var i LockRulesetByIDRow
+	err := row.Scan(
+		&i.ID,
+		&i.EffectiveFrom,
+		&i.BaseRubPerPoint,
+		&i.RedeemRubPerPoint,
+		&i.QualificationWindowDays,
+		&i.WelcomeBonusPoints,
+		&i.BirthdayBonusPoints,
+		&i.ReferrerBonusPoints,
+		&i.RefereeBonusPoints,
+		&i.RoundingMode,
+		&i.RoundingStage,
+		&i.MinEarnAmount,
+		&i.MaxPointsPerPurchase,
+		&i.MaxPointsPerDay,
+		&i.CreatedAt,
+		&i.Status,
+	)
+	return i, err
+}
+
+const rulesetHasEvents = `-- name: RulesetHasEvents :one
+SELECT EXISTS (SELECT 1 FROM events WHERE ruleset_id = $1) AS referenced
+`
+
+func (q *Queries) RulesetHasEvents(ctx context.Context, db DBTX, rulesetID pgtype.Int8) (bool, error) {
+	row := db.QueryRow(ctx, rulesetHasEvents, rulesetID)
+	var referenced bool
+	err := row.Scan(&referenced)
+	return referenced, err
+}
+
+const setRulesetStatus = `-- name: SetRulesetStatus :exec
+UPDATE ruleset
+SET status = $2
+WHERE id = $1
+`
+
+type SetRulesetStatusParams struct {
+	ID     int64
+	Status string
+}
+
+func (q *Queries) SetRulesetStatus(ctx context.Context, db DBTX, arg SetRulesetStatusParams) error {
+	_, err := db.Exec(ctx, setRulesetStatus, arg.ID, arg.Status)
+	return err
+}
+
+const updateRuleset = `-- name: UpdateRuleset :exec
+UPDATE ruleset
+SET effective_from = $2,
+    base_rub_per_point = $3,
+    redeem_rub_per_point = $4,
+    qualification_window_days = $5,
+    welcome_bonus_points = $6,
+    birthday_bonus_points = $7,
+    referrer_bonus_points = $8,
+    referee_bonus_points = $9,
+    rounding_mode = $10,
+    rounding_stage = $11,
+    min_earn_amount = $12,
+    max_points_per_purchase = $13,
+    max_points_per_day = $14,
+    status = 'DRAFT'
+WHERE id = $1
+`
+
+type UpdateRulesetParams struct {
+	ID                      int64
+	EffectiveFrom           pgtype.Timestamptz
+	BaseRubPerPoint         decimal.Decimal
+	RedeemRubPerPoint       decimal.Decimal
+	QualificationWindowDays pgtype.Int4
+	WelcomeBonusPoints      int32
+	BirthdayBonusPoints     int32
+	ReferrerBonusPoints     int32
+	RefereeBonusPoints      int32
+	RoundingMode            string
+	RoundingStage           string
+	MinEarnAmount           decimal.Decimal
+	MaxPointsPerPurchase    pgtype.Int4
+	MaxPointsPerDay         pgtype.Int4
+}
+
+func (q *Queries) UpdateRuleset(ctx context.Context, db DBTX, arg UpdateRulesetParams) error {
+	_, err := db.Exec(ctx, updateRuleset,
+		arg.ID,
+		arg.EffectiveFrom,
+		arg.BaseRubPerPoint,
+		arg.RedeemRubPerPoint,
+		arg.QualificationWindowDays,
+		arg.WelcomeBonusPoints,
+		arg.BirthdayBonusPoints,
+		arg.ReferrerBonusPoints,
+		arg.RefereeBonusPoints,
+		arg.RoundingMode,
+		arg.RoundingStage,
+		arg.MinEarnAmount,
+		arg.MaxPointsPerPurchase,
+		arg.MaxPointsPerDay,
+	)
+	return err
+}
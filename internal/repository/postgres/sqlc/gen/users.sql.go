@@ -12,18 +12,19 @@ import (
 )
 
 const createUser = `-- name: CreateUser :one
-INSERT INTO users (phone, password_hash)
-VALUES ($1, $2)
+INSERT INTO users (phone, password_hash, birthday)
+VALUES ($1, $2, $3)
 RETURNING id
 `
 
 type CreateUserParams struct {
 	Phone        string
 	PasswordHash string
+	Birthday     pgtype.Date
 }
 
 func (q *Queries) CreateUser(ctx context.Context, db DBTX, arg CreateUserParams) (int64, error) {
-	row := db.QueryRow(ctx, createUser, arg.Phone, arg.PasswordHash)
+	row := db.QueryRow(ctx, createUser, arg.Phone, arg.PasswordHash, arg.Birthday)
 	var id int64
 	err := row.Scan(&id)
 	return id, err
@@ -41,7 +42,7 @@ func (q *Queries) DeactivateUser(ctx context.Context, db DBTX, id int64) error {
 }
 
 const getUserByID = `-- name: GetUserByID :one
-SELECT id, phone, password_hash, is_active, created_at
+SELECT id, phone, password_hash, is_active, birthday, created_at
 FROM users
 WHERE id = $1
 `
@@ -51,6 +52,7 @@ type GetUserByIDRow struct {
 	Phone        string
 	PasswordHash string
 	IsActive     bool
+	Birthday     pgtype.Date
 	CreatedAt    pgtype.Timestamptz
 }
 
@@ -62,13 +64,14 @@ func (q *Queries) GetUserByID(ctx context.Context, db DBTX, id int64) (GetUserBy
 		&i.Phone,
 		&i.PasswordHash,
 		&i.IsActive,
+		&i.Birthday,
 		&i.CreatedAt,
 	)
 	return i, err
 }
 
 const getUserByPhone = `-- name: GetUserByPhone :one
-SELECT id, phone, password_hash, is_active, created_at
+SELECT id, phone, password_hash, is_active, birthday, created_at
 FROM users
 WHERE phone = $1
 `
@@ -78,6 +81,7 @@ type GetUserByPhoneRow struct {
 	Phone        string
 	PasswordHash string
 	IsActive     bool
+	Birthday     pgtype.Date
 	CreatedAt    pgtype.Timestamptz
 }
 
@@ -89,13 +93,14 @@ func (q *Queries) GetUserByPhone(ctx context.Context, db DBTX, phone string) (Ge
 		&i.Phone,
 		&i.PasswordHash,
 		&i.IsActive,
+		&i.Birthday,
 		&i.CreatedAt,
 	)
 	return i, err
 }
 
 const listUsers = `-- name: ListUsers :many
-SELECT id, phone, password_hash, is_active, created_at
+SELECT id, phone, password_hash, is_active, birthday, created_at
 FROM users
 WHERE ($1::text = '' OR phone ILIKE '%' || $1 || '%')
 ORDER BY id DESC
@@ -113,6 +118,7 @@ type ListUsersRow struct {
 	Phone        string
 	PasswordHash string
 	IsActive     bool
+	Birthday     pgtype.Date
 	CreatedAt    pgtype.Timestamptz
 }
 
@@ -130,6 +136,7 @@ func (q *Queries) ListUsers(ctx context.Context, db DBTX, arg ListUsersParams) (
 			&i.Phone,
 			&i.PasswordHash,
 			&i.IsActive,
+			&i.Birthday,
 			&i.CreatedAt,
 		); err != nil {
 			return nil, err
@@ -141,3 +148,19 @@ func (q *Queries) ListUsers(ctx context.Context, db DBTX, arg ListUsersParams) (
 	}
 	return items, nil
 }
+
+const setUserBirthday = `-- name: SetUserBirthday :exec
+UPDATE users
+SET birthday = $2
+WHERE id = $1
+`
+
+type SetUserBirthdayParams struct {
+	ID       int64
+	Birthday pgtype.Date
+}
+
+func (q *Queries) SetUserBirthday(ctx context.Context, db DBTX, arg SetUserBirthdayParams) error {
+	_, err := db.Exec(ctx, setUserBirthday, arg.ID, arg.Birthday)
+	return err
+}
@@ -4,13 +4,15 @@ VALUES ($1, $2, $3)
 RETURNING
     id, user_id, public_code, created_at,
     balance_points, total_spend_money,
-    COALESCE(level_code, '')::text AS level_code;
+    COALESCE(level_code, '')::text AS level_code,
+    held_points;
 
 -- name: GetAccountByUserID :one
 SELECT
     id, user_id, public_code, created_at,
     balance_points, total_spend_money,
-    COALESCE(level_code, '')::text AS level_code
+    COALESCE(level_code, '')::text AS level_code,
+    held_points
 FROM accounts
 WHERE user_id = $1;
 
@@ -18,15 +20,24 @@ WHERE user_id = $1;
 SELECT
     id, user_id, public_code, created_at,
     balance_points, total_spend_money,
-    COALESCE(level_code, '')::text AS level_code
+    COALESCE(level_code, '')::text AS level_code,
+    held_points
 FROM accounts
 WHERE public_code = $1;
 
+-- name: ListAccountIDsAfter :many
+SELECT id
+FROM accounts
+WHERE id > $1
+ORDER BY id
+LIMIT $2;
+
 -- name: LockAccountByID :one
 SELECT
     id, user_id, public_code, created_at,
     balance_points, total_spend_money,
-    COALESCE(level_code, '')::text AS level_code
+    COALESCE(level_code, '')::text AS level_code,
+    held_points
 FROM accounts
 WHERE id = $1
     FOR UPDATE;
@@ -41,7 +52,8 @@ WHERE id = $1
 RETURNING
     id, user_id, public_code, created_at,
     balance_points, total_spend_money,
-    COALESCE(level_code, '')::text AS level_code;
+    COALESCE(level_code, '')::text AS level_code,
+    held_points;
 
 -- name: UpdateAccountAfterSpend :one
 UPDATE accounts
@@ -50,4 +62,25 @@ WHERE id = $1
 RETURNING
     id, user_id, public_code, created_at,
     balance_points, total_spend_money,
-    COALESCE(level_code, '')::text AS level_code;
+    COALESCE(level_code, '')::text AS level_code,
+    held_points;
+
+-- name: SetAccountLevel :one
+UPDATE accounts
+SET level_code = $2
+WHERE id = $1
+RETURNING
+    id, user_id, public_code, created_at,
+    balance_points, total_spend_money,
+    COALESCE(level_code, '')::text AS level_code,
+    held_points;
+
+-- name: SetAccountHeldPoints :one
+UPDATE accounts
+SET held_points = $2
+WHERE id = $1
+RETURNING
+    id, user_id, public_code, created_at,
+    balance_points, total_spend_money,
+    COALESCE(level_code, '')::text AS level_code,
+    held_points;
@@ -0,0 +1,9 @@
+-- internal/repository/postgres/sqlc/queries/adjustments.sql
+
+-- name: InsertAdjustment :one
+INSERT INTO adjustments (event_id, reason_code, comment)
+VALUES ($1, $2, $3)
+RETURNING
+    event_id,
+    reason_code,
+    comment;
@@ -0,0 +1,22 @@
+-- internal/repository/postgres/sqlc/queries/bonuses.sql
+
+-- name: InsertBonusGrant :exec
+INSERT INTO bonus_grants (event_id, account_id, kind, year)
+VALUES ($1, $2, $3, $4);
+
+-- name: ListBirthdayAccountIDsAfter :many
+-- Accounts of active clients whose birthday (MM-DD) is one of the given days
+-- and who have not got the BIRTHDAY bonus of the year yet.
+SELECT a.id
+FROM accounts a
+JOIN users u ON u.id = a.user_id
+WHERE a.id > $1
+  AND u.is_active
+  AND to_char(u.birthday, 'MM-DD') = ANY ($2::text[])
+  AND NOT EXISTS (SELECT 1
+                  FROM bonus_grants g
+                  WHERE g.account_id = a.id
+                    AND g.kind = 'BIRTHDAY'
+                    AND g.year = $3)
+ORDER BY a.id
+LIMIT $4;
@@ -0,0 +1,47 @@
+-- name: ListCampaigns :many
+SELECT id, name, starts_on, ends_on, weekdays, time_from, time_to, timezone, multiplier, bonus_points, updated_by, created_at, updated_at
+FROM campaigns
+ORDER BY starts_on DESC, id DESC;
+
+-- name: ListCampaignsAround :many
+-- Candidates for a moment on the given UTC date: a day of margin covers any campaign timezone,
+-- the exact schedule check is done by the caller.
+SELECT id, name, starts_on, ends_on, weekdays, time_from, time_to, timezone, multiplier, bonus_points, updated_by, created_at, updated_at
+FROM campaigns
+WHERE starts_on <= $1::date + 1
+  AND (ends_on IS NULL OR ends_on >= $1::date - 1)
+ORDER BY id;
+
+-- name: GetCampaignByID :one
+SELECT id, name, starts_on, ends_on, weekdays, time_from, time_to, timezone, multiplier, bonus_points, updated_by, created_at, updated_at
+FROM campaigns
+WHERE id = $1;
+
+-- name: InsertCampaign :one
+INSERT INTO campaigns (name, starts_on, ends_on, weekdays, time_from, time_to, timezone, multiplier, bonus_points, updated_by)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+RETURNING id, name, starts_on, ends_on, weekdays, time_from, time_to, timezone, multiplier, bonus_points, updated_by, created_at, updated_at;
+
+-- name: UpdateCampaign :one
+UPDATE campaigns
+SET name = $2,
+    starts_on = $3,
+    ends_on = $4,
+    weekdays = $5,
+    time_from = $6,
+    time_to = $7,
+    timezone = $8,
+    multiplier = $9,
+    bonus_points = $10,
+    updated_by = $11,
+    updated_at = now()
+WHERE id = $1
+RETURNING id, name, starts_on, ends_on, weekdays, time_from, time_to, timezone, multiplier, bonus_points, updated_by, created_at, updated_at;
+
+-- name: DeleteCampaign :execrows
+DELETE FROM campaigns
+WHERE id = $1;
+
+-- name: InsertEventCampaign :exec
+INSERT INTO event_campaigns (event_id, campaign_id, bonus_points)
+VALUES ($1, $2, $3);
@@ -3,7 +3,8 @@
 -- name: InsertEvent :one
 INSERT INTO events (
     account_id, type, delta_points, balance_after,
-    amount_money, ruleset_id, actor_user_id, ts
+    amount_money, ruleset_id, actor_user_id, ts,
+    ref_event_id, prev_hash, hash
 )
 VALUES (
            $1,
@@ -13,7 +14,10 @@ VALUES (
            $5,
            $6,
            $7,
-           $8
+           $8,
+           $9,
+           $10,
+           $11
        )
 RETURNING
     id,
@@ -25,7 +29,10 @@ RETURNING
     ruleset_id,
     actor_user_id,
     ts,
-    created_at;
+    created_at,
+    ref_event_id,
+    prev_hash,
+    hash;
 
 -- name: ListEventsByAccount :many
 SELECT
@@ -38,7 +45,8 @@ SELECT
     ruleset_id,
     actor_user_id,
     ts,
-    created_at
+    created_at,
+    ref_event_id
 FROM events
 WHERE account_id = $1
 ORDER BY ts DESC, id DESC
@@ -55,9 +63,189 @@ SELECT
     ruleset_id,
     actor_user_id,
     ts,
-    created_at
+    created_at,
+    ref_event_id
 FROM events
 WHERE account_id = $1
   AND ts < $3
 ORDER BY ts DESC, id DESC
 LIMIT $2;
+
+-- name: ListAllEventsByAccountAsc :many
+SELECT
+    id,
+    account_id,
+    type::text AS type,
+    delta_points,
+    balance_after,
+    amount_money,
+    ruleset_id,
+    actor_user_id,
+    ts,
+    created_at,
+    ref_event_id,
+    prev_hash,
+    hash
+FROM events
+WHERE account_id = $1
+ORDER BY id;
+
+-- name: GetEventByID :one
+SELECT
+    id,
+    account_id,
+    type::text AS type,
+    delta_points,
+    balance_after,
+    amount_money,
+    ruleset_id,
+    actor_user_id,
+    ts,
+    created_at,
+    ref_event_id
+FROM events
+WHERE id = $1;
+
+-- name: SumRefundsByEvent :one
+SELECT
+    COALESCE(SUM(amount_money), 0)::numeric AS refunded_money,
+    COALESCE(SUM(-delta_points), 0)::int AS reverted_points
+FROM events
+WHERE ref_event_id = $1
+  AND type = 'REFUND';
+
+-- name: CountEventsByRef :one
+SELECT COUNT(*)
+FROM events
+WHERE ref_event_id = $1
+  AND type = $2::event_type;
+
+-- name: SumEventsByTypeInRange :one
+SELECT
+    COUNT(*)::int AS events_count,
+    COALESCE(SUM(ABS(delta_points)), 0)::int AS points
+FROM events
+WHERE account_id = $1
+  AND type = $2::event_type
+  AND ts >= $3
+  AND ts < $4;
+
+-- name: SumCappedEarnPointsInRange :one
+-- Ruleset points of the account's purchases with $2 <= ts < $3, as seen by the daily earn cap:
+-- campaign rewards come on top of the cap, and refunds take off their share of the ruleset points
+-- (a refund reverts the whole EARN, campaign rewards included, in proportion).
+SELECT COALESCE(SUM(GREATEST(x.ruleset_points - x.reverted_points * x.ruleset_points / NULLIF(x.delta_points, 0), 0)), 0)::int AS points
+FROM (
+    SELECT
+        e.delta_points,
+        e.delta_points - COALESCE((SELECT SUM(ec.bonus_points) FROM event_campaigns ec WHERE ec.event_id = e.id), 0) AS ruleset_points,
+        COALESCE((SELECT SUM(-r.delta_points) FROM events r WHERE r.ref_event_id = e.id AND r.type = 'REFUND'), 0) AS reverted_points
+    FROM events e
+    WHERE e.account_id = $1
+      AND e.type = 'EARN'
+      AND e.ts >= $2
+      AND e.ts < $3
+) x;
+
+-- name: SumQualifyingSpendSince :one
+-- A refund counts against the window of the purchase it refunds.
+SELECT COALESCE(SUM(CASE WHEN e.type = 'EARN' THEN e.amount_money ELSE -e.amount_money END), 0)::numeric AS spend_money
+FROM events e
+LEFT JOIN events p ON p.id = e.ref_event_id
+WHERE e.account_id = $1
+  AND e.type IN ('EARN', 'REFUND')
+  AND COALESCE(p.ts, e.ts) >= $2::timestamptz;
+
+-- name: ListEarnEventsInRange :many
+-- Purchases replayed by the ruleset simulation, oldest first, with the campaign rewards they include.
+SELECT
+    e.id,
+    e.account_id,
+    a.public_code,
+    e.delta_points,
+    COALESCE(e.amount_money, 0)::numeric AS amount_money,
+    e.ts,
+    COALESCE((SELECT SUM(ec.bonus_points) FROM event_campaigns ec WHERE ec.event_id = e.id), 0)::int AS campaign_points
+FROM events e
+JOIN accounts a ON a.id = e.account_id
+WHERE e.type = 'EARN'
+  AND e.ts >= $1
+  AND e.ts < $2
+ORDER BY e.ts, e.id;
+
+-- name: ListPurchaseAmountsForEarnAccounts :many
+-- Purchases (EARN, positive) and refunds (REFUND, negative) with ts < $3 and either a purchase ts >= $1
+-- or ts >= $2, of every account that has purchases in [$2, $3); oldest first.
+-- A refund carries the ts of the purchase it refunds, so the ruleset simulation can replay both
+-- lifetime spend and rolling qualification windows on them.
+SELECT
+    e.account_id,
+    COALESCE(p.ts, e.ts)::timestamptz AS purchase_ts,
+    e.ts,
+    (CASE WHEN e.type = 'EARN' THEN COALESCE(e.amount_money, 0) ELSE -COALESCE(e.amount_money, 0) END)::numeric AS amount_money
+FROM events e
+LEFT JOIN events p ON p.id = e.ref_event_id
+WHERE e.type IN ('EARN', 'REFUND')
+  AND e.ts < $3
+  AND (COALESCE(p.ts, e.ts) >= $1::timestamptz OR e.ts >= $2)
+  AND e.account_id IN (
+      SELECT account_id
+      FROM events
+      WHERE type = 'EARN'
+        AND ts >= $2
+        AND ts < $3
+  )
+ORDER BY e.account_id, e.ts, e.id;
+
+-- name: SumEarnSpendBeforeByAccount :many
+-- Purchase spend before $1, net of refunds, of every account that has purchases in [$1, $2).
+SELECT
+    account_id,
+    COALESCE(SUM(CASE WHEN type = 'EARN' THEN amount_money ELSE -amount_money END), 0)::numeric AS spend_money
+FROM events
+WHERE type IN ('EARN', 'REFUND')
+  AND ts < $1
+  AND account_id IN (
+      SELECT account_id
+      FROM events
+      WHERE type = 'EARN'
+        AND ts >= $1
+        AND ts < $2
+  )
+GROUP BY account_id;
+
+-- name: SumEventsByTypeCreatedSince :one
+SELECT
+    COUNT(*)::int AS events_count,
+    COALESCE(SUM(ABS(delta_points)), 0)::int AS points
+FROM events
+WHERE account_id = $1
+  AND type = $2::event_type
+  AND created_at >= $3;
+
+-- name: CountEventsByActorCreatedSince :one
+SELECT COUNT(*)::int AS events_count
+FROM events
+WHERE actor_user_id = $1
+  AND type = $2::event_type
+  AND created_at >= $3;
+
+-- name: GetLastEventHash :one
+SELECT hash
+FROM events
+WHERE account_id = $1
+ORDER BY id DESC
+LIMIT 1;
+
+-- name: ListAccountsWithUnhashedEvents :many
+SELECT DISTINCT account_id
+FROM events
+WHERE hash IS NULL
+ORDER BY account_id
+LIMIT $1;
+
+-- name: SetEventHash :exec
+UPDATE events
+SET prev_hash = $2,
+    hash = $3
+WHERE id = $1;
@@ -0,0 +1,44 @@
+-- name: InsertHold :one
+INSERT INTO holds (account_id, operation_id, points, expires_at, actor_user_id)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (account_id, operation_id) DO NOTHING
+RETURNING
+    id, account_id, operation_id, points, status, expires_at,
+    captured_points, capture_event_id, actor_user_id, created_at, closed_at;
+
+-- name: GetHoldByID :one
+SELECT
+    id, account_id, operation_id, points, status, expires_at,
+    captured_points, capture_event_id, actor_user_id, created_at, closed_at
+FROM holds
+WHERE id = $1;
+
+-- name: GetHoldByOperation :one
+SELECT
+    id, account_id, operation_id, points, status, expires_at,
+    captured_points, capture_event_id, actor_user_id, created_at, closed_at
+FROM holds
+WHERE account_id = $1
+  AND operation_id = $2;
+
+-- name: CloseHold :one
+UPDATE holds
+SET status = $2,
+    captured_points = $3,
+    capture_event_id = $4,
+    closed_at = $5
+WHERE id = $1
+  AND status = 'ACTIVE'
+RETURNING
+    id, account_id, operation_id, points, status, expires_at,
+    captured_points, capture_event_id, actor_user_id, created_at, closed_at;
+
+-- name: ListExpiredHolds :many
+SELECT
+    id, account_id, operation_id, points, status, expires_at,
+    captured_points, capture_event_id, actor_user_id, created_at, closed_at
+FROM holds
+WHERE status = 'ACTIVE'
+  AND expires_at <= $1
+ORDER BY expires_at, id
+LIMIT $2;
@@ -0,0 +1,14 @@
+-- internal/repository/postgres/sqlc/queries/level_changes.sql
+
+-- name: InsertLevelChange :exec
+INSERT INTO level_changes (event_id, account_id, from_level, to_level, bonus_event_id)
+VALUES ($1, $2, $3, $4, $5);
+
+-- name: HasReachedLevel :one
+-- Whether the account has been moved to the level before, by any ruleset.
+SELECT EXISTS (
+    SELECT 1
+    FROM level_changes
+    WHERE account_id = $1
+      AND to_level = $2
+) AS reached;
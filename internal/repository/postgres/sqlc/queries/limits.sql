@@ -0,0 +1,23 @@
+-- name: ListVelocityLimits :many
+SELECT id, kind, op_type, max_value, updated_by, created_at, updated_at
+FROM velocity_limits
+ORDER BY op_type, kind;
+
+-- name: ListVelocityLimitsByOpType :many
+SELECT id, kind, op_type, max_value, updated_by, created_at, updated_at
+FROM velocity_limits
+WHERE op_type = $1::event_type
+ORDER BY kind;
+
+-- name: UpsertVelocityLimit :one
+INSERT INTO velocity_limits (kind, op_type, max_value, updated_by)
+VALUES ($1, $2::event_type, $3, $4)
+ON CONFLICT (kind, op_type) DO UPDATE
+SET max_value = EXCLUDED.max_value,
+    updated_by = EXCLUDED.updated_by,
+    updated_at = now()
+RETURNING id, kind, op_type, max_value, updated_by, created_at, updated_at;
+
+-- name: DeleteVelocityLimit :execrows
+DELETE FROM velocity_limits
+WHERE id = $1;
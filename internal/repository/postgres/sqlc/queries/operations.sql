@@ -6,7 +6,9 @@ SELECT
     request_json,
     response_json,
     http_status,
-    created_at
+    event_id,
+    created_at,
+    canonical_request
 FROM operations
 WHERE account_id = $1
   AND op_type = $2::event_type
@@ -20,7 +22,51 @@ ON CONFLICT (account_id, op_type, operation_id) DO NOTHING;
 -- name: FinalizeOperation :exec
 UPDATE operations
 SET http_status = $4,
-    response_json = $5
+    response_json = $5,
+    event_id = $6
 WHERE account_id = $1
   AND op_type = $2::event_type
   AND operation_id = $3;
+
+-- name: ExpireOperationsBefore :one
+-- Counts the deleted records, not the tombstones: a key expired before keeps its first tombstone.
+WITH expired AS (
+    DELETE FROM operations
+    WHERE id IN (
+        SELECT id
+        FROM operations
+        WHERE created_at < $1
+        ORDER BY created_at, id
+        LIMIT $2
+        FOR UPDATE SKIP LOCKED
+    )
+    RETURNING account_id, op_type, operation_id, created_at
+),
+tombstones AS (
+    INSERT INTO expired_operations (account_id, op_type, operation_id, created_at)
+    SELECT account_id, op_type, operation_id, created_at
+    FROM expired
+    ON CONFLICT (account_id, op_type, operation_id) DO NOTHING
+)
+SELECT count(*)::bigint AS expired
+FROM expired;
+
+-- name: PurgeExpiredOperationsBefore :execrows
+DELETE FROM expired_operations
+WHERE (account_id, op_type, operation_id) IN (
+    SELECT account_id, op_type, operation_id
+    FROM expired_operations
+    WHERE expired_at < $1
+    ORDER BY expired_at
+    LIMIT $2
+    FOR UPDATE SKIP LOCKED
+);
+
+-- name: IsOperationExpired :one
+SELECT EXISTS (
+    SELECT 1
+    FROM expired_operations
+    WHERE account_id = $1
+      AND op_type = $2::event_type
+      AND operation_id = $3
+) AS expired;
@@ -0,0 +1,104 @@
+-- internal/repository/postgres/sqlc/queries/point_lots.sql
+
+-- name: InsertPointLot :one
+INSERT INTO point_lots (
+    account_id, source_event_id, points, remaining_points,
+    earned_at, expires_at
+)
+VALUES (
+           $1,
+           $2,
+           $3,
+           $3,
+           $4,
+           $5
+       )
+RETURNING
+    id,
+    account_id,
+    source_event_id,
+    points,
+    remaining_points,
+    earned_at,
+    expires_at,
+    created_at;
+
+-- name: ListOpenPointLotsForUpdate :many
+SELECT
+    id,
+    account_id,
+    source_event_id,
+    points,
+    remaining_points,
+    earned_at,
+    expires_at,
+    created_at
+FROM point_lots
+WHERE account_id = $1
+  AND remaining_points > 0
+ORDER BY expires_at, id
+FOR UPDATE;
+
+-- name: ListExpiredPointLotsForUpdate :many
+SELECT
+    id,
+    account_id,
+    source_event_id,
+    points,
+    remaining_points,
+    earned_at,
+    expires_at,
+    created_at
+FROM point_lots
+WHERE account_id = $1
+  AND remaining_points > 0
+  AND expires_at <= $2
+ORDER BY expires_at, id
+FOR UPDATE;
+
+-- name: SetPointLotRemaining :exec
+UPDATE point_lots
+SET remaining_points = $2
+WHERE id = $1;
+
+-- name: ListLegacyPointLotsForUpdate :many
+-- Open lots that migration 0014 opened for pre-lot balances and that still carry its 12-month expiry.
+SELECT
+    id,
+    account_id,
+    source_event_id,
+    points,
+    remaining_points,
+    earned_at,
+    expires_at,
+    created_at
+FROM point_lots
+WHERE source_event_id IS NULL
+  AND remaining_points > 0
+  AND expires_at = earned_at + INTERVAL '12 months'
+ORDER BY id
+FOR UPDATE;
+
+-- name: SetPointLotExpiresAt :exec
+UPDATE point_lots
+SET expires_at = $2
+WHERE id = $1;
+
+-- name: ListAccountsWithExpiredPointLots :many
+SELECT DISTINCT account_id
+FROM point_lots
+WHERE remaining_points > 0
+  AND expires_at <= $1
+ORDER BY account_id
+LIMIT $2;
+
+-- name: GetNextExpiringPoints :one
+SELECT
+    (expires_at AT TIME ZONE 'UTC')::date AS expires_on,
+    SUM(remaining_points)::int AS points
+FROM point_lots
+WHERE account_id = $1
+  AND remaining_points > 0
+GROUP BY expires_on
+ORDER BY expires_on
+LIMIT 1;
@@ -0,0 +1,21 @@
+-- internal/repository/postgres/sqlc/queries/receipts.sql
+
+-- name: InsertReceiptLine :exec
+INSERT INTO receipt_lines (event_id, line_no, sku, category, quantity, line_total, earn_factor)
+VALUES ($1, $2, $3, $4, $5, $6, $7);
+
+-- name: ListReceiptLinesByEventID :many
+SELECT id, event_id, line_no, sku, category, quantity, line_total, earn_factor
+FROM receipt_lines
+WHERE event_id = $1
+ORDER BY line_no ASC;
+
+-- name: ListReceiptLinesForEarnInRange :many
+-- Lines of the itemized EARN events with $1 <= ts < $2, for the ruleset simulation.
+SELECT rl.id, rl.event_id, rl.line_no, rl.sku, rl.category, rl.quantity, rl.line_total, rl.earn_factor
+FROM receipt_lines rl
+JOIN events e ON e.id = rl.event_id
+WHERE e.type = 'EARN'
+  AND e.ts >= $1
+  AND e.ts < $2
+ORDER BY rl.event_id, rl.line_no;
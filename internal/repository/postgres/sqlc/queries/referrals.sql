@@ -0,0 +1,52 @@
+-- internal/repository/postgres/sqlc/queries/referrals.sql
+
+-- name: InsertReferralCode :exec
+INSERT INTO referral_codes (account_id, code)
+VALUES ($1, $2);
+
+-- name: GetReferralCodeByAccountID :one
+SELECT code
+FROM referral_codes
+WHERE account_id = $1;
+
+-- name: GetAccountIDByReferralCode :one
+SELECT account_id
+FROM referral_codes
+WHERE code = $1;
+
+-- name: InsertReferral :exec
+INSERT INTO referrals (referee_account_id, referrer_account_id, created_at)
+VALUES ($1, $2, $3);
+
+-- name: CountReferralsByReferrerSince :one
+SELECT COUNT(*)::int AS referred
+FROM referrals
+WHERE referrer_account_id = $1
+  AND created_at >= $2;
+
+-- name: GetPendingReferralByReferee :one
+SELECT referee_account_id, referrer_account_id, created_at, rewarded_at, qualifying_event_id, referrer_event_id, referee_event_id
+FROM referrals
+WHERE referee_account_id = $1
+  AND rewarded_at IS NULL;
+
+-- name: MarkReferralRewarded :execrows
+-- Closes a pending referral; 0 rows means it was already rewarded.
+UPDATE referrals
+SET rewarded_at = $2,
+    qualifying_event_id = $3,
+    referrer_event_id = $4,
+    referee_event_id = $5
+WHERE referee_account_id = $1
+  AND rewarded_at IS NULL;
+
+-- name: GetReferralStatsByReferrer :one
+-- Totals of a referrer; points are the referrer's side of the rewards.
+SELECT
+    COUNT(*)::int AS referred,
+    COUNT(r.rewarded_at)::int AS rewarded,
+    (COUNT(*) FILTER (WHERE r.created_at >= $2))::int AS referred_since,
+    COALESCE(SUM(e.delta_points), 0)::int AS bonus_points
+FROM referrals r
+LEFT JOIN events e ON e.id = r.referrer_event_id
+WHERE r.referrer_account_id = $1;
@@ -1,35 +1,110 @@
 -- internal/repository/postgres/sqlc/queries/rules.sql
 
 -- name: GetRulesetEffectiveAt :one
-SELECT id, effective_from, base_rub_per_point, created_at
+SELECT id, effective_from, base_rub_per_point, redeem_rub_per_point, qualification_window_days, welcome_bonus_points, birthday_bonus_points, referrer_bonus_points, referee_bonus_points, rounding_mode, rounding_stage,
+       min_earn_amount, max_points_per_purchase, max_points_per_day, created_at, status,
+       (SELECT MIN(n.effective_from) FROM ruleset n WHERE n.status = 'APPROVED' AND n.effective_from > ruleset.effective_from)::timestamptz AS effective_to
 FROM ruleset
-WHERE effective_from <= $1
+WHERE status = 'APPROVED'
+  AND effective_from <= $1
 ORDER BY effective_from DESC
 LIMIT 1;
 
 -- name: InsertRuleset :one
-INSERT INTO ruleset (effective_from, base_rub_per_point)
-VALUES ($1, $2)
-RETURNING id, effective_from, base_rub_per_point, created_at;
+INSERT INTO ruleset (effective_from, base_rub_per_point, redeem_rub_per_point, qualification_window_days, welcome_bonus_points, birthday_bonus_points,
+                     referrer_bonus_points, referee_bonus_points, rounding_mode, rounding_stage, min_earn_amount, max_points_per_purchase,
+                     max_points_per_day, created_by)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+RETURNING id, effective_from, base_rub_per_point, redeem_rub_per_point, qualification_window_days, welcome_bonus_points, birthday_bonus_points, referrer_bonus_points, referee_bonus_points, rounding_mode, rounding_stage,
+          min_earn_amount, max_points_per_purchase, max_points_per_day, created_at, status;
 
 -- name: InsertLevelRule :one
-INSERT INTO level_rules (ruleset_id, level_code, threshold_total_spend, percent_earn)
-VALUES ($1, $2, $3, $4)
-RETURNING id, ruleset_id, level_code, threshold_total_spend, percent_earn;
+INSERT INTO level_rules (ruleset_id, level_code, threshold_total_spend, percent_earn, reached_bonus_points)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING id, ruleset_id, level_code, threshold_total_spend, percent_earn, reached_bonus_points;
 
 -- name: GetRulesetByID :one
-SELECT id, effective_from, base_rub_per_point, created_at
+SELECT id, effective_from, base_rub_per_point, redeem_rub_per_point, qualification_window_days, welcome_bonus_points, birthday_bonus_points, referrer_bonus_points, referee_bonus_points, rounding_mode, rounding_stage,
+       min_earn_amount, max_points_per_purchase, max_points_per_day, created_at, status,
+       (SELECT MIN(n.effective_from) FROM ruleset n WHERE n.status = 'APPROVED' AND n.effective_from > ruleset.effective_from)::timestamptz AS effective_to
 FROM ruleset
 WHERE id = $1;
 
+-- name: LockRulesetByID :one
+SELECT id, effective_from, base_rub_per_point, redeem_rub_per_point, qualification_window_days, welcome_bonus_points, birthday_bonus_points, referrer_bonus_points, referee_bonus_points, rounding_mode, rounding_stage,
+       min_earn_amount, max_points_per_purchase, max_points_per_day, created_at, status
+FROM ruleset
+WHERE id = $1
+FOR UPDATE;
+
+-- name: RulesetHasEvents :one
+SELECT EXISTS (SELECT 1 FROM events WHERE ruleset_id = $1) AS referenced;
+
+-- name: UpdateRuleset :exec
+UPDATE ruleset
+SET effective_from = $2,
+    base_rub_per_point = $3,
+    redeem_rub_per_point = $4,
+    qualification_window_days = $5,
+    welcome_bonus_points = $6,
+    birthday_bonus_points = $7,
+    referrer_bonus_points = $8,
+    referee_bonus_points = $9,
+    rounding_mode = $10,
+    rounding_stage = $11,
+    min_earn_amount = $12,
+    max_points_per_purchase = $13,
+    max_points_per_day = $14,
+    status = 'DRAFT'
+WHERE id = $1;
+
+-- name: SetRulesetStatus :exec
+UPDATE ruleset
+SET status = $2
+WHERE id = $1;
+
+-- name: InsertRulesetHistory :exec
+INSERT INTO ruleset_history (ruleset_id, action, actor_user_id, comment)
+VALUES ($1, $2, $3, $4);
+
+-- name: ListRulesetHistoryByRulesetID :many
+SELECT id, ruleset_id, action, actor_user_id, comment, created_at
+FROM ruleset_history
+WHERE ruleset_id = $1
+ORDER BY id ASC;
+
+-- name: DeleteRuleset :exec
+DELETE FROM ruleset
+WHERE id = $1;
+
+-- name: DeleteLevelRulesByRulesetID :exec
+DELETE FROM level_rules
+WHERE ruleset_id = $1;
+
 -- name: ListLevelRulesByRulesetID :many
-SELECT id, ruleset_id, level_code, threshold_total_spend, percent_earn
+SELECT id, ruleset_id, level_code, threshold_total_spend, percent_earn, reached_bonus_points
 FROM level_rules
 WHERE ruleset_id = $1
 ORDER BY threshold_total_spend ASC;
 
 -- name: ListRulesetsBase :many
-SELECT id, effective_from, base_rub_per_point, created_at
+SELECT id, effective_from, base_rub_per_point, redeem_rub_per_point, qualification_window_days, welcome_bonus_points, birthday_bonus_points, referrer_bonus_points, referee_bonus_points, rounding_mode, rounding_stage,
+       min_earn_amount, max_points_per_purchase, max_points_per_day, created_at, status,
+       (SELECT MIN(n.effective_from) FROM ruleset n WHERE n.status = 'APPROVED' AND n.effective_from > ruleset.effective_from)::timestamptz AS effective_to
 FROM ruleset
-ORDER BY effective_from DESC
+ORDER BY effective_from DESC, id DESC
 LIMIT $1 OFFSET $2;
+
+-- name: InsertCategoryEarnRate :exec
+INSERT INTO category_earn_rates (ruleset_id, category, earn_factor)
+VALUES ($1, $2, $3);
+
+-- name: DeleteCategoryEarnRatesByRulesetID :exec
+DELETE FROM category_earn_rates
+WHERE ruleset_id = $1;
+
+-- name: ListCategoryEarnRatesByRulesetID :many
+SELECT id, ruleset_id, category, earn_factor
+FROM category_earn_rates
+WHERE ruleset_id = $1
+ORDER BY category ASC;
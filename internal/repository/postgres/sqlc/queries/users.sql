@@ -1,20 +1,20 @@
 -- name: CreateUser :one
-INSERT INTO users (phone, password_hash)
-VALUES ($1, $2)
+INSERT INTO users (phone, password_hash, birthday)
+VALUES ($1, $2, $3)
 RETURNING id;
 
 -- name: GetUserByPhone :one
-SELECT id, phone, password_hash, is_active, created_at
+SELECT id, phone, password_hash, is_active, birthday, created_at
 FROM users
 WHERE phone = $1;
 
 -- name: GetUserByID :one
-SELECT id, phone, password_hash, is_active, created_at
+SELECT id, phone, password_hash, is_active, birthday, created_at
 FROM users
 WHERE id = $1;
 
 -- name: ListUsers :many
-SELECT id, phone, password_hash, is_active, created_at
+SELECT id, phone, password_hash, is_active, birthday, created_at
 FROM users
 WHERE ($1::text = '' OR phone ILIKE '%' || $1 || '%')
 ORDER BY id DESC
@@ -24,3 +24,8 @@ LIMIT $2 OFFSET $3;
 UPDATE users
 SET is_active = false
 WHERE id = $1;
+
+-- name: SetUserBirthday :exec
+UPDATE users
+SET birthday = $2
+WHERE id = $1;
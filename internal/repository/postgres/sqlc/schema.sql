@@ -22,7 +22,19 @@ SET row_security = off;
 
 CREATE TYPE public.event_type AS ENUM (
     'EARN',
-    'SPEND'
+    'SPEND',
+    'REFUND',
+    'VOID',
+    'EXPIRE',
+    'CHECKOUT',
+    'ADJUST',
+    'TRANSFER_OUT',
+    'TRANSFER_IN',
+    'TRANSFER',
+    'BONUS',
+    'LEVEL_CHANGED',
+    'HOLD_AUTHORIZE',
+    'HOLD_CAPTURE'
 );
 
 
@@ -42,7 +54,9 @@ CREATE TABLE public.accounts (
     balance_points integer DEFAULT 0 NOT NULL,
     total_spend_money numeric(12,2) DEFAULT 0 NOT NULL,
     level_code text,
+    held_points integer DEFAULT 0 NOT NULL,
     CONSTRAINT chk_accounts_balance_nonnegative CHECK ((balance_points >= 0)),
+    CONSTRAINT chk_accounts_held_points_nonnegative CHECK ((held_points >= 0)),
     CONSTRAINT chk_accounts_total_spend_nonnegative CHECK ((total_spend_money >= (0)::numeric))
 );
 
@@ -66,6 +80,132 @@ CREATE SEQUENCE public.accounts_id_seq
 ALTER SEQUENCE public.accounts_id_seq OWNED BY public.accounts.id;
 
 
+--
+-- Name: adjustment_reasons; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.adjustment_reasons (
+    code text NOT NULL
+);
+
+
+--
+-- Name: adjustments; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.adjustments (
+    event_id bigint NOT NULL,
+    reason_code text NOT NULL,
+    comment text NOT NULL,
+    CONSTRAINT chk_adjustments_comment_not_blank CHECK ((length(btrim(comment)) > 0))
+);
+
+
+--
+-- Name: bonus_grants; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.bonus_grants (
+    event_id bigint NOT NULL,
+    account_id bigint NOT NULL,
+    kind text NOT NULL,
+    year integer NOT NULL,
+    CONSTRAINT chk_bonus_grants_kind CHECK ((kind = ANY (ARRAY['WELCOME'::text, 'BIRTHDAY'::text])))
+);
+
+
+--
+-- Name: campaigns; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.campaigns (
+    id bigint NOT NULL,
+    name text NOT NULL,
+    starts_on date NOT NULL,
+    ends_on date,
+    weekdays smallint[] DEFAULT '{}'::smallint[] NOT NULL,
+    time_from time without time zone,
+    time_to time without time zone,
+    timezone text DEFAULT 'UTC'::text NOT NULL,
+    multiplier numeric(6,2),
+    bonus_points integer,
+    updated_by bigint,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    updated_at timestamp with time zone DEFAULT now() NOT NULL,
+    CONSTRAINT chk_campaigns_bonus_points_positive CHECK (((bonus_points IS NULL) OR (bonus_points > 0))),
+    CONSTRAINT chk_campaigns_dates CHECK (((ends_on IS NULL) OR (ends_on >= starts_on))),
+    CONSTRAINT chk_campaigns_multiplier_above_one CHECK (((multiplier IS NULL) OR (multiplier > (1)::numeric))),
+    CONSTRAINT chk_campaigns_name_not_blank CHECK ((length(btrim(name)) > 0)),
+    CONSTRAINT chk_campaigns_reward CHECK ((num_nonnulls(multiplier, bonus_points) = 1)),
+    CONSTRAINT chk_campaigns_time_window CHECK ((((time_from IS NULL) AND (time_to IS NULL)) OR ((time_from IS NOT NULL) AND (time_to IS NOT NULL) AND (time_from <> time_to)))),
+    CONSTRAINT chk_campaigns_weekdays CHECK ((weekdays <@ ARRAY[(1)::smallint, (2)::smallint, (3)::smallint, (4)::smallint, (5)::smallint, (6)::smallint, (7)::smallint]))
+);
+
+
+--
+-- Name: campaigns_id_seq; Type: SEQUENCE; Schema: public; Owner: -
+--
+
+CREATE SEQUENCE public.campaigns_id_seq
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+
+
+--
+-- Name: campaigns_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
+--
+
+ALTER SEQUENCE public.campaigns_id_seq OWNED BY public.campaigns.id;
+
+
+--
+-- Name: category_earn_rates; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.category_earn_rates (
+    id bigint NOT NULL,
+    ruleset_id bigint NOT NULL,
+    category text NOT NULL,
+    earn_factor numeric(4,2) NOT NULL,
+    CONSTRAINT chk_category_earn_rates_category_not_blank CHECK ((length(btrim(category)) > 0)),
+    CONSTRAINT chk_category_earn_rates_factor_range CHECK (((earn_factor >= (0)::numeric) AND (earn_factor <= (10)::numeric)))
+);
+
+
+--
+-- Name: category_earn_rates_id_seq; Type: SEQUENCE; Schema: public; Owner: -
+--
+
+CREATE SEQUENCE public.category_earn_rates_id_seq
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+
+
+--
+-- Name: category_earn_rates_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
+--
+
+ALTER SEQUENCE public.category_earn_rates_id_seq OWNED BY public.category_earn_rates.id;
+
+
+--
+-- Name: event_campaigns; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.event_campaigns (
+    event_id bigint NOT NULL,
+    campaign_id bigint NOT NULL,
+    bonus_points integer NOT NULL,
+    CONSTRAINT chk_event_campaigns_bonus_points_nonnegative CHECK ((bonus_points >= 0))
+);
+
+
 --
 -- Name: events; Type: TABLE; Schema: public; Owner: -
 --
@@ -81,397 +221,1141 @@ CREATE TABLE public.events (
     created_at timestamp with time zone DEFAULT now() NOT NULL,
     ruleset_id bigint,
     actor_user_id bigint,
+    ref_event_id bigint,
+    prev_hash bytea,
+    hash bytea,
     CONSTRAINT chk_events_amount_money_nonnegative CHECK (((amount_money IS NULL) OR (amount_money >= (0)::numeric))),
     CONSTRAINT chk_events_balance_after_nonnegative CHECK ((balance_after >= 0))
 );
 
 
 --
--- Name: events_id_seq; Type: SEQUENCE; Schema: public; Owner: -
+-- Name: events_id_seq; Type: SEQUENCE; Schema: public; Owner: -
+--
+
+CREATE SEQUENCE public.events_id_seq
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+
+
+--
+-- Name: events_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
+--
+
+ALTER SEQUENCE public.events_id_seq OWNED BY public.events.id;
+
+
+--
+-- Name: expired_operations; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.expired_operations (
+    account_id bigint NOT NULL,
+    op_type public.event_type NOT NULL,
+    operation_id text NOT NULL,
+    created_at timestamp with time zone NOT NULL,
+    expired_at timestamp with time zone DEFAULT now() NOT NULL
+);
+
+
+--
+-- Name: holds; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.holds (
+    id bigint NOT NULL,
+    account_id bigint NOT NULL,
+    operation_id text NOT NULL,
+    points integer NOT NULL,
+    status text DEFAULT 'ACTIVE'::text NOT NULL,
+    expires_at timestamp with time zone NOT NULL,
+    captured_points integer,
+    capture_event_id bigint,
+    actor_user_id bigint,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    closed_at timestamp with time zone,
+    CONSTRAINT chk_holds_captured_range CHECK (((captured_points IS NULL) OR ((captured_points > 0) AND (captured_points <= points)))),
+    CONSTRAINT chk_holds_points_positive CHECK ((points > 0)),
+    CONSTRAINT chk_holds_status CHECK ((status = ANY (ARRAY['ACTIVE'::text, 'CAPTURED'::text, 'RELEASED'::text, 'EXPIRED'::text])))
+);
+
+
+--
+-- Name: holds_id_seq; Type: SEQUENCE; Schema: public; Owner: -
+--
+
+CREATE SEQUENCE public.holds_id_seq
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+
+
+--
+-- Name: holds_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
+--
+
+ALTER SEQUENCE public.holds_id_seq OWNED BY public.holds.id;
+
+
+--
+-- Name: level_changes; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.level_changes (
+    event_id bigint NOT NULL,
+    account_id bigint NOT NULL,
+    from_level text NOT NULL,
+    to_level text NOT NULL,
+    bonus_event_id bigint,
+    CONSTRAINT chk_level_changes_levels_differ CHECK ((from_level <> to_level))
+);
+
+
+--
+-- Name: level_rules; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.level_rules (
+    id bigint NOT NULL,
+    ruleset_id bigint NOT NULL,
+    level_code text NOT NULL,
+    threshold_total_spend numeric(12,2) NOT NULL,
+    percent_earn numeric(5,2) NOT NULL,
+    reached_bonus_points integer DEFAULT 0 NOT NULL,
+    CONSTRAINT chk_level_rules_percent_positive CHECK ((percent_earn > (0)::numeric)),
+    CONSTRAINT chk_level_rules_reached_bonus_points_nonnegative CHECK ((reached_bonus_points >= 0)),
+    CONSTRAINT chk_level_rules_threshold_nonnegative CHECK ((threshold_total_spend >= (0)::numeric))
+);
+
+
+--
+-- Name: level_rules_id_seq; Type: SEQUENCE; Schema: public; Owner: -
+--
+
+CREATE SEQUENCE public.level_rules_id_seq
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+
+
+--
+-- Name: level_rules_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
+--
+
+ALTER SEQUENCE public.level_rules_id_seq OWNED BY public.level_rules.id;
+
+
+--
+-- Name: operations; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.operations (
+    id bigint NOT NULL,
+    operation_id text NOT NULL,
+    account_id bigint NOT NULL,
+    op_type public.event_type NOT NULL,
+    request_json jsonb NOT NULL,
+    response_json jsonb,
+    http_status integer,
+    event_id bigint,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    canonical_request boolean DEFAULT true NOT NULL
+);
+
+
+--
+-- Name: operations_id_seq; Type: SEQUENCE; Schema: public; Owner: -
+--
+
+CREATE SEQUENCE public.operations_id_seq
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+
+
+--
+-- Name: operations_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
+--
+
+ALTER SEQUENCE public.operations_id_seq OWNED BY public.operations.id;
+
+
+--
+-- Name: point_lots; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.point_lots (
+    id bigint NOT NULL,
+    account_id bigint NOT NULL,
+    source_event_id bigint,
+    points integer NOT NULL,
+    remaining_points integer NOT NULL,
+    earned_at timestamp with time zone NOT NULL,
+    expires_at timestamp with time zone NOT NULL,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    CONSTRAINT chk_point_lots_points_positive CHECK ((points > 0)),
+    CONSTRAINT chk_point_lots_remaining_range CHECK (((remaining_points >= 0) AND (remaining_points <= points)))
+);
+
+
+--
+-- Name: point_lots_id_seq; Type: SEQUENCE; Schema: public; Owner: -
+--
+
+CREATE SEQUENCE public.point_lots_id_seq
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+
+
+--
+-- Name: point_lots_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
+--
+
+ALTER SEQUENCE public.point_lots_id_seq OWNED BY public.point_lots.id;
+
+
+--
+-- Name: receipt_lines; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.receipt_lines (
+    id bigint NOT NULL,
+    event_id bigint NOT NULL,
+    line_no integer NOT NULL,
+    sku text NOT NULL,
+    category text NOT NULL,
+    quantity integer NOT NULL,
+    line_total numeric(12,2) NOT NULL,
+    earn_factor numeric(4,2) NOT NULL,
+    CONSTRAINT chk_receipt_lines_factor_range CHECK (((earn_factor >= (0)::numeric) AND (earn_factor <= (10)::numeric))),
+    CONSTRAINT chk_receipt_lines_line_no_positive CHECK ((line_no > 0)),
+    CONSTRAINT chk_receipt_lines_line_total_nonnegative CHECK ((line_total >= (0)::numeric)),
+    CONSTRAINT chk_receipt_lines_quantity_positive CHECK ((quantity > 0))
+);
+
+
+--
+-- Name: receipt_lines_id_seq; Type: SEQUENCE; Schema: public; Owner: -
+--
+
+CREATE SEQUENCE public.receipt_lines_id_seq
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+
+
+--
+-- Name: receipt_lines_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
+--
+
+ALTER SEQUENCE public.receipt_lines_id_seq OWNED BY public.receipt_lines.id;
+
+
+--
+-- Name: referral_codes; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.referral_codes (
+    account_id bigint NOT NULL,
+    code text NOT NULL,
+    created_at timestamp with time zone DEFAULT now() NOT NULL
+);
+
+
+--
+-- Name: referrals; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.referrals (
+    referee_account_id bigint NOT NULL,
+    referrer_account_id bigint NOT NULL,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    rewarded_at timestamp with time zone,
+    qualifying_event_id bigint,
+    referrer_event_id bigint,
+    referee_event_id bigint,
+    CONSTRAINT chk_referrals_not_self CHECK ((referrer_account_id <> referee_account_id)),
+    CONSTRAINT chk_referrals_rewarded_has_event CHECK (((rewarded_at IS NULL) OR (qualifying_event_id IS NOT NULL)))
+);
+
+
+--
+-- Name: roles; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.roles (
+    code text NOT NULL
+);
+
+
+--
+-- Name: ruleset; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.ruleset (
+    id bigint NOT NULL,
+    effective_from timestamp with time zone NOT NULL,
+    base_rub_per_point numeric(10,2) NOT NULL,
+    created_by bigint,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    redeem_rub_per_point numeric(10,2) DEFAULT 1.00 NOT NULL,
+    qualification_window_days integer,
+    welcome_bonus_points integer DEFAULT 0 NOT NULL,
+    birthday_bonus_points integer DEFAULT 0 NOT NULL,
+    referrer_bonus_points integer DEFAULT 0 NOT NULL,
+    referee_bonus_points integer DEFAULT 0 NOT NULL,
+    rounding_mode text DEFAULT 'FLOOR'::text NOT NULL,
+    rounding_stage text DEFAULT 'PER_STEP'::text NOT NULL,
+    min_earn_amount numeric(12,2) DEFAULT 0 NOT NULL,
+    max_points_per_purchase integer,
+    max_points_per_day integer,
+    status text DEFAULT 'DRAFT'::text NOT NULL,
+    CONSTRAINT chk_ruleset_base_rub_per_point_positive CHECK ((base_rub_per_point > (0)::numeric)),
+    CONSTRAINT chk_ruleset_birthday_bonus_points_nonnegative CHECK ((birthday_bonus_points >= 0)),
+    CONSTRAINT chk_ruleset_max_points_per_day_positive CHECK (((max_points_per_day IS NULL) OR (max_points_per_day > 0))),
+    CONSTRAINT chk_ruleset_max_points_per_purchase_positive CHECK (((max_points_per_purchase IS NULL) OR (max_points_per_purchase > 0))),
+    CONSTRAINT chk_ruleset_min_earn_amount_nonnegative CHECK ((min_earn_amount >= (0)::numeric)),
+    CONSTRAINT chk_ruleset_qualification_window_days_positive CHECK (((qualification_window_days IS NULL) OR (qualification_window_days > 0))),
+    CONSTRAINT chk_ruleset_redeem_rub_per_point_positive CHECK ((redeem_rub_per_point > (0)::numeric)),
+    CONSTRAINT chk_ruleset_referee_bonus_points_nonnegative CHECK ((referee_bonus_points >= 0)),
+    CONSTRAINT chk_ruleset_referrer_bonus_points_nonnegative CHECK ((referrer_bonus_points >= 0)),
+    CONSTRAINT chk_ruleset_rounding_mode CHECK ((rounding_mode = ANY (ARRAY['FLOOR'::text, 'HALF_UP'::text, 'HALF_EVEN'::text]))),
+    CONSTRAINT chk_ruleset_rounding_stage CHECK ((rounding_stage = ANY (ARRAY['PER_STEP'::text, 'AT_END'::text]))),
+    CONSTRAINT chk_ruleset_status CHECK ((status = ANY (ARRAY['DRAFT'::text, 'PENDING_APPROVAL'::text, 'APPROVED'::text, 'REJECTED'::text]))),
+    CONSTRAINT chk_ruleset_welcome_bonus_points_nonnegative CHECK ((welcome_bonus_points >= 0))
+);
+
+
+--
+-- Name: ruleset_history; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.ruleset_history (
+    id bigint NOT NULL,
+    ruleset_id bigint NOT NULL,
+    action text NOT NULL,
+    actor_user_id bigint,
+    comment text,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    CONSTRAINT chk_ruleset_history_action CHECK ((action = ANY (ARRAY['CREATED'::text, 'UPDATED'::text, 'SUBMITTED'::text, 'APPROVED'::text, 'REJECTED'::text])))
+);
+
+
+--
+-- Name: ruleset_history_id_seq; Type: SEQUENCE; Schema: public; Owner: -
+--
+
+CREATE SEQUENCE public.ruleset_history_id_seq
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+
+
+--
+-- Name: ruleset_history_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
+--
+
+ALTER SEQUENCE public.ruleset_history_id_seq OWNED BY public.ruleset_history.id;
+
+
+--
+-- Name: ruleset_id_seq; Type: SEQUENCE; Schema: public; Owner: -
+--
+
+CREATE SEQUENCE public.ruleset_id_seq
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+
+
+--
+-- Name: ruleset_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
+--
+
+ALTER SEQUENCE public.ruleset_id_seq OWNED BY public.ruleset.id;
+
+
+--
+-- Name: user_roles; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.user_roles (
+    user_id bigint NOT NULL,
+    role_code text NOT NULL
+);
+
+
+--
+-- Name: users; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.users (
+    id bigint NOT NULL,
+    phone text NOT NULL,
+    password_hash text NOT NULL,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    is_active boolean DEFAULT true NOT NULL,
+    birthday date
+);
+
+
+--
+-- Name: users_id_seq; Type: SEQUENCE; Schema: public; Owner: -
+--
+
+CREATE SEQUENCE public.users_id_seq
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+
+
+--
+-- Name: users_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
+--
+
+ALTER SEQUENCE public.users_id_seq OWNED BY public.users.id;
+
+
+--
+-- Name: velocity_limits; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.velocity_limits (
+    id bigint NOT NULL,
+    kind text NOT NULL,
+    op_type public.event_type NOT NULL,
+    max_value integer NOT NULL,
+    updated_by bigint,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    updated_at timestamp with time zone DEFAULT now() NOT NULL,
+    CONSTRAINT chk_velocity_limits_kind CHECK ((kind = ANY (ARRAY['POINTS_PER_OPERATION'::text, 'ACCOUNT_POINTS_PER_DAY'::text, 'CASHIER_OPERATIONS_PER_HOUR'::text]))),
+    CONSTRAINT chk_velocity_limits_max_value_positive CHECK ((max_value > 0)),
+    CONSTRAINT chk_velocity_limits_op_type CHECK ((op_type = ANY (ARRAY['EARN'::public.event_type, 'SPEND'::public.event_type])))
+);
+
+
+--
+-- Name: velocity_limits_id_seq; Type: SEQUENCE; Schema: public; Owner: -
+--
+
+CREATE SEQUENCE public.velocity_limits_id_seq
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+
+
+--
+-- Name: velocity_limits_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
+--
+
+ALTER SEQUENCE public.velocity_limits_id_seq OWNED BY public.velocity_limits.id;
+
+
+--
+-- Name: accounts id; Type: DEFAULT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.accounts ALTER COLUMN id SET DEFAULT nextval('public.accounts_id_seq'::regclass);
+
+
+--
+-- Name: campaigns id; Type: DEFAULT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.campaigns ALTER COLUMN id SET DEFAULT nextval('public.campaigns_id_seq'::regclass);
+
+
+--
+-- Name: category_earn_rates id; Type: DEFAULT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.category_earn_rates ALTER COLUMN id SET DEFAULT nextval('public.category_earn_rates_id_seq'::regclass);
+
+
+--
+-- Name: events id; Type: DEFAULT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.events ALTER COLUMN id SET DEFAULT nextval('public.events_id_seq'::regclass);
+
+
+--
+-- Name: holds id; Type: DEFAULT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.holds ALTER COLUMN id SET DEFAULT nextval('public.holds_id_seq'::regclass);
+
+
+--
+-- Name: level_rules id; Type: DEFAULT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.level_rules ALTER COLUMN id SET DEFAULT nextval('public.level_rules_id_seq'::regclass);
+
+
+--
+-- Name: operations id; Type: DEFAULT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.operations ALTER COLUMN id SET DEFAULT nextval('public.operations_id_seq'::regclass);
+
+
+--
+-- Name: point_lots id; Type: DEFAULT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.point_lots ALTER COLUMN id SET DEFAULT nextval('public.point_lots_id_seq'::regclass);
+
+
+--
+-- Name: receipt_lines id; Type: DEFAULT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.receipt_lines ALTER COLUMN id SET DEFAULT nextval('public.receipt_lines_id_seq'::regclass);
+
+
+--
+-- Name: ruleset id; Type: DEFAULT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.ruleset ALTER COLUMN id SET DEFAULT nextval('public.ruleset_id_seq'::regclass);
+
+
+--
+-- Name: ruleset_history id; Type: DEFAULT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.ruleset_history ALTER COLUMN id SET DEFAULT nextval('public.ruleset_history_id_seq'::regclass);
+
+
+--
+-- Name: users id; Type: DEFAULT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.users ALTER COLUMN id SET DEFAULT nextval('public.users_id_seq'::regclass);
+
+
+--
+-- Name: velocity_limits id; Type: DEFAULT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.velocity_limits ALTER COLUMN id SET DEFAULT nextval('public.velocity_limits_id_seq'::regclass);
+
+
+--
+-- Name: accounts accounts_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.accounts
+    ADD CONSTRAINT accounts_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: adjustment_reasons adjustment_reasons_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.adjustment_reasons
+    ADD CONSTRAINT adjustment_reasons_pkey PRIMARY KEY (code);
+
+
+--
+-- Name: adjustments adjustments_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.adjustments
+    ADD CONSTRAINT adjustments_pkey PRIMARY KEY (event_id);
+
+
+--
+-- Name: bonus_grants bonus_grants_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.bonus_grants
+    ADD CONSTRAINT bonus_grants_pkey PRIMARY KEY (event_id);
+
+
+--
+-- Name: campaigns campaigns_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.campaigns
+    ADD CONSTRAINT campaigns_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: category_earn_rates category_earn_rates_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.category_earn_rates
+    ADD CONSTRAINT category_earn_rates_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: event_campaigns event_campaigns_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.event_campaigns
+    ADD CONSTRAINT event_campaigns_pkey PRIMARY KEY (event_id, campaign_id);
+
+
+--
+-- Name: events events_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.events
+    ADD CONSTRAINT events_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: holds holds_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.holds
+    ADD CONSTRAINT holds_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: level_changes level_changes_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.level_changes
+    ADD CONSTRAINT level_changes_pkey PRIMARY KEY (event_id);
+
+
+--
+-- Name: level_rules level_rules_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.level_rules
+    ADD CONSTRAINT level_rules_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: operations operations_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.operations
+    ADD CONSTRAINT operations_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: point_lots point_lots_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.point_lots
+    ADD CONSTRAINT point_lots_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: expired_operations pk_expired_operations; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.expired_operations
+    ADD CONSTRAINT pk_expired_operations PRIMARY KEY (account_id, op_type, operation_id);
+
+
+--
+-- Name: user_roles pk_user_roles; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.user_roles
+    ADD CONSTRAINT pk_user_roles PRIMARY KEY (user_id, role_code);
+
+
+--
+-- Name: receipt_lines receipt_lines_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.receipt_lines
+    ADD CONSTRAINT receipt_lines_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: referral_codes referral_codes_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.referral_codes
+    ADD CONSTRAINT referral_codes_pkey PRIMARY KEY (account_id);
+
+
+--
+-- Name: referrals referrals_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.referrals
+    ADD CONSTRAINT referrals_pkey PRIMARY KEY (referee_account_id);
+
+
+--
+-- Name: roles roles_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.roles
+    ADD CONSTRAINT roles_pkey PRIMARY KEY (code);
+
+
+--
+-- Name: ruleset_history ruleset_history_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.ruleset_history
+    ADD CONSTRAINT ruleset_history_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: ruleset ruleset_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.ruleset
+    ADD CONSTRAINT ruleset_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: accounts uq_accounts_public_code; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.accounts
+    ADD CONSTRAINT uq_accounts_public_code UNIQUE (public_code);
+
+
+--
+-- Name: accounts uq_accounts_user_id; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.accounts
+    ADD CONSTRAINT uq_accounts_user_id UNIQUE (user_id);
+
+
+--
+-- Name: bonus_grants uq_bonus_grants_account_kind_year; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.bonus_grants
+    ADD CONSTRAINT uq_bonus_grants_account_kind_year UNIQUE (account_id, kind, year);
+
+
+--
+-- Name: category_earn_rates uq_category_earn_rates_ruleset_category; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.category_earn_rates
+    ADD CONSTRAINT uq_category_earn_rates_ruleset_category UNIQUE (ruleset_id, category);
+
+
+--
+-- Name: holds uq_holds_operation; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.holds
+    ADD CONSTRAINT uq_holds_operation UNIQUE (account_id, operation_id);
+
+
+--
+-- Name: level_rules uq_level_rules_ruleset_level; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.level_rules
+    ADD CONSTRAINT uq_level_rules_ruleset_level UNIQUE (ruleset_id, level_code);
+
+
+--
+-- Name: level_rules uq_level_rules_ruleset_threshold; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.level_rules
+    ADD CONSTRAINT uq_level_rules_ruleset_threshold UNIQUE (ruleset_id, threshold_total_spend);
+
+
+--
+-- Name: operations uq_operations_idempotency; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.operations
+    ADD CONSTRAINT uq_operations_idempotency UNIQUE (account_id, op_type, operation_id);
+
+
+--
+-- Name: receipt_lines uq_receipt_lines_event_line; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.receipt_lines
+    ADD CONSTRAINT uq_receipt_lines_event_line UNIQUE (event_id, line_no);
+
+
+--
+-- Name: referral_codes uq_referral_codes_code; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.referral_codes
+    ADD CONSTRAINT uq_referral_codes_code UNIQUE (code);
+
+
+--
+-- Name: users uq_users_phone; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.users
+    ADD CONSTRAINT uq_users_phone UNIQUE (phone);
+
+
+--
+-- Name: velocity_limits uq_velocity_limits_kind_op_type; Type: CONSTRAINT; Schema: public; Owner: -
 --
 
-CREATE SEQUENCE public.events_id_seq
-    START WITH 1
-    INCREMENT BY 1
-    NO MINVALUE
-    NO MAXVALUE
-    CACHE 1;
+ALTER TABLE ONLY public.velocity_limits
+    ADD CONSTRAINT uq_velocity_limits_kind_op_type UNIQUE (kind, op_type);
 
 
 --
--- Name: events_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
+-- Name: users users_pkey; Type: CONSTRAINT; Schema: public; Owner: -
 --
 
-ALTER SEQUENCE public.events_id_seq OWNED BY public.events.id;
+ALTER TABLE ONLY public.users
+    ADD CONSTRAINT users_pkey PRIMARY KEY (id);
 
 
 --
--- Name: level_rules; Type: TABLE; Schema: public; Owner: -
+-- Name: velocity_limits velocity_limits_pkey; Type: CONSTRAINT; Schema: public; Owner: -
 --
 
-CREATE TABLE public.level_rules (
-    id bigint NOT NULL,
-    ruleset_id bigint NOT NULL,
-    level_code text NOT NULL,
-    threshold_total_spend numeric(12,2) NOT NULL,
-    percent_earn numeric(5,2) NOT NULL,
-    CONSTRAINT chk_level_rules_percent_positive CHECK ((percent_earn > (0)::numeric)),
-    CONSTRAINT chk_level_rules_threshold_nonnegative CHECK ((threshold_total_spend >= (0)::numeric))
-);
+ALTER TABLE ONLY public.velocity_limits
+    ADD CONSTRAINT velocity_limits_pkey PRIMARY KEY (id);
 
 
 --
--- Name: level_rules_id_seq; Type: SEQUENCE; Schema: public; Owner: -
+-- Name: idx_campaigns_dates; Type: INDEX; Schema: public; Owner: -
 --
 
-CREATE SEQUENCE public.level_rules_id_seq
-    START WITH 1
-    INCREMENT BY 1
-    NO MINVALUE
-    NO MAXVALUE
-    CACHE 1;
+CREATE INDEX idx_campaigns_dates ON public.campaigns USING btree (starts_on, ends_on);
 
 
 --
--- Name: level_rules_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
+-- Name: idx_event_campaigns_campaign_id; Type: INDEX; Schema: public; Owner: -
 --
 
-ALTER SEQUENCE public.level_rules_id_seq OWNED BY public.level_rules.id;
+CREATE INDEX idx_event_campaigns_campaign_id ON public.event_campaigns USING btree (campaign_id);
 
 
 --
--- Name: operations; Type: TABLE; Schema: public; Owner: -
+-- Name: idx_events_account_created_at; Type: INDEX; Schema: public; Owner: -
 --
 
-CREATE TABLE public.operations (
-    id bigint NOT NULL,
-    operation_id text NOT NULL,
-    account_id bigint NOT NULL,
-    op_type public.event_type NOT NULL,
-    request_json jsonb NOT NULL,
-    response_json jsonb,
-    http_status integer,
-    event_id bigint,
-    created_at timestamp with time zone DEFAULT now() NOT NULL
-);
+CREATE INDEX idx_events_account_created_at ON public.events USING btree (account_id, created_at);
 
 
 --
--- Name: operations_id_seq; Type: SEQUENCE; Schema: public; Owner: -
+-- Name: idx_events_account_id; Type: INDEX; Schema: public; Owner: -
 --
 
-CREATE SEQUENCE public.operations_id_seq
-    START WITH 1
-    INCREMENT BY 1
-    NO MINVALUE
-    NO MAXVALUE
-    CACHE 1;
+CREATE INDEX idx_events_account_id ON public.events USING btree (account_id, id);
 
 
 --
--- Name: operations_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
+-- Name: idx_events_account_ts; Type: INDEX; Schema: public; Owner: -
 --
 
-ALTER SEQUENCE public.operations_id_seq OWNED BY public.operations.id;
+CREATE INDEX idx_events_account_ts ON public.events USING btree (account_id, ts);
 
 
 --
--- Name: roles; Type: TABLE; Schema: public; Owner: -
+-- Name: idx_events_actor_created_at; Type: INDEX; Schema: public; Owner: -
 --
 
-CREATE TABLE public.roles (
-    code text NOT NULL
-);
+CREATE INDEX idx_events_actor_created_at ON public.events USING btree (actor_user_id, created_at);
 
 
 --
--- Name: ruleset; Type: TABLE; Schema: public; Owner: -
+-- Name: idx_events_actor_ts; Type: INDEX; Schema: public; Owner: -
 --
 
-CREATE TABLE public.ruleset (
-    id bigint NOT NULL,
-    effective_from timestamp with time zone NOT NULL,
-    base_rub_per_point numeric(10,2) NOT NULL,
-    created_by bigint,
-    created_at timestamp with time zone DEFAULT now() NOT NULL,
-    CONSTRAINT chk_ruleset_base_rub_per_point_positive CHECK ((base_rub_per_point > (0)::numeric))
-);
+CREATE INDEX idx_events_actor_ts ON public.events USING btree (actor_user_id, ts);
 
 
 --
--- Name: ruleset_id_seq; Type: SEQUENCE; Schema: public; Owner: -
+-- Name: idx_events_earn_ts; Type: INDEX; Schema: public; Owner: -
 --
 
-CREATE SEQUENCE public.ruleset_id_seq
-    START WITH 1
-    INCREMENT BY 1
-    NO MINVALUE
-    NO MAXVALUE
-    CACHE 1;
+CREATE INDEX idx_events_earn_ts ON public.events USING btree (ts) WHERE (type = 'EARN'::public.event_type);
 
 
 --
--- Name: ruleset_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
+-- Name: idx_events_ref_event_id; Type: INDEX; Schema: public; Owner: -
 --
 
-ALTER SEQUENCE public.ruleset_id_seq OWNED BY public.ruleset.id;
+CREATE INDEX idx_events_ref_event_id ON public.events USING btree (ref_event_id) WHERE (ref_event_id IS NOT NULL);
 
 
 --
--- Name: user_roles; Type: TABLE; Schema: public; Owner: -
+-- Name: idx_events_ruleset_id; Type: INDEX; Schema: public; Owner: -
 --
 
-CREATE TABLE public.user_roles (
-    user_id bigint NOT NULL,
-    role_code text NOT NULL
-);
+CREATE INDEX idx_events_ruleset_id ON public.events USING btree (ruleset_id) WHERE (ruleset_id IS NOT NULL);
 
 
 --
--- Name: users; Type: TABLE; Schema: public; Owner: -
+-- Name: idx_events_unhashed; Type: INDEX; Schema: public; Owner: -
 --
 
-CREATE TABLE public.users (
-    id bigint NOT NULL,
-    phone text NOT NULL,
-    password_hash text NOT NULL,
-    created_at timestamp with time zone DEFAULT now() NOT NULL,
-    is_active boolean DEFAULT true NOT NULL
-);
+CREATE INDEX idx_events_unhashed ON public.events USING btree (account_id) WHERE (hash IS NULL);
 
 
 --
--- Name: users_id_seq; Type: SEQUENCE; Schema: public; Owner: -
+-- Name: idx_expired_operations_expired_at; Type: INDEX; Schema: public; Owner: -
 --
 
-CREATE SEQUENCE public.users_id_seq
-    START WITH 1
-    INCREMENT BY 1
-    NO MINVALUE
-    NO MAXVALUE
-    CACHE 1;
+CREATE INDEX idx_expired_operations_expired_at ON public.expired_operations USING btree (expired_at);
 
 
 --
--- Name: users_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
+-- Name: idx_holds_active_expires; Type: INDEX; Schema: public; Owner: -
 --
 
-ALTER SEQUENCE public.users_id_seq OWNED BY public.users.id;
+CREATE INDEX idx_holds_active_expires ON public.holds USING btree (expires_at) WHERE (status = 'ACTIVE'::text);
 
 
 --
--- Name: accounts id; Type: DEFAULT; Schema: public; Owner: -
+-- Name: idx_operations_account_created_at; Type: INDEX; Schema: public; Owner: -
 --
 
-ALTER TABLE ONLY public.accounts ALTER COLUMN id SET DEFAULT nextval('public.accounts_id_seq'::regclass);
+CREATE INDEX idx_operations_account_created_at ON public.operations USING btree (account_id, created_at);
 
 
 --
--- Name: events id; Type: DEFAULT; Schema: public; Owner: -
+-- Name: idx_operations_created_at; Type: INDEX; Schema: public; Owner: -
 --
 
-ALTER TABLE ONLY public.events ALTER COLUMN id SET DEFAULT nextval('public.events_id_seq'::regclass);
+CREATE INDEX idx_operations_created_at ON public.operations USING btree (created_at, id);
 
 
 --
--- Name: level_rules id; Type: DEFAULT; Schema: public; Owner: -
+-- Name: idx_point_lots_account_open; Type: INDEX; Schema: public; Owner: -
 --
 
-ALTER TABLE ONLY public.level_rules ALTER COLUMN id SET DEFAULT nextval('public.level_rules_id_seq'::regclass);
+CREATE INDEX idx_point_lots_account_open ON public.point_lots USING btree (account_id, expires_at, id) WHERE (remaining_points > 0);
 
 
 --
--- Name: operations id; Type: DEFAULT; Schema: public; Owner: -
+-- Name: idx_point_lots_expires_open; Type: INDEX; Schema: public; Owner: -
 --
 
-ALTER TABLE ONLY public.operations ALTER COLUMN id SET DEFAULT nextval('public.operations_id_seq'::regclass);
+CREATE INDEX idx_point_lots_expires_open ON public.point_lots USING btree (expires_at) WHERE (remaining_points > 0);
 
 
 --
--- Name: ruleset id; Type: DEFAULT; Schema: public; Owner: -
+-- Name: idx_referrals_referrer_created; Type: INDEX; Schema: public; Owner: -
 --
 
-ALTER TABLE ONLY public.ruleset ALTER COLUMN id SET DEFAULT nextval('public.ruleset_id_seq'::regclass);
+CREATE INDEX idx_referrals_referrer_created ON public.referrals USING btree (referrer_account_id, created_at);
 
 
 --
--- Name: users id; Type: DEFAULT; Schema: public; Owner: -
+-- Name: idx_ruleset_history_ruleset_id; Type: INDEX; Schema: public; Owner: -
 --
 
-ALTER TABLE ONLY public.users ALTER COLUMN id SET DEFAULT nextval('public.users_id_seq'::regclass);
+CREATE INDEX idx_ruleset_history_ruleset_id ON public.ruleset_history USING btree (ruleset_id, id);
 
 
 --
--- Name: accounts accounts_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+-- Name: uq_events_void_ref_event; Type: INDEX; Schema: public; Owner: -
 --
 
-ALTER TABLE ONLY public.accounts
-    ADD CONSTRAINT accounts_pkey PRIMARY KEY (id);
+CREATE UNIQUE INDEX uq_events_void_ref_event ON public.events USING btree (ref_event_id) WHERE (type = 'VOID'::public.event_type);
 
 
 --
--- Name: events events_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+-- Name: uq_level_changes_account_bonus_level; Type: INDEX; Schema: public; Owner: -
 --
 
-ALTER TABLE ONLY public.events
-    ADD CONSTRAINT events_pkey PRIMARY KEY (id);
+CREATE UNIQUE INDEX uq_level_changes_account_bonus_level ON public.level_changes USING btree (account_id, to_level) WHERE (bonus_event_id IS NOT NULL);
 
 
 --
--- Name: level_rules level_rules_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+-- Name: uq_ruleset_effective_from; Type: INDEX; Schema: public; Owner: -
 --
 
-ALTER TABLE ONLY public.level_rules
-    ADD CONSTRAINT level_rules_pkey PRIMARY KEY (id);
+CREATE UNIQUE INDEX uq_ruleset_effective_from ON public.ruleset USING btree (effective_from) WHERE (status = 'APPROVED'::text);
 
 
 --
--- Name: operations operations_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+-- Name: accounts fk_accounts_user; Type: FK CONSTRAINT; Schema: public; Owner: -
 --
 
-ALTER TABLE ONLY public.operations
-    ADD CONSTRAINT operations_pkey PRIMARY KEY (id);
+ALTER TABLE ONLY public.accounts
+    ADD CONSTRAINT fk_accounts_user FOREIGN KEY (user_id) REFERENCES public.users(id) ON DELETE RESTRICT;
 
 
 --
--- Name: user_roles pk_user_roles; Type: CONSTRAINT; Schema: public; Owner: -
+-- Name: adjustments fk_adjustments_event; Type: FK CONSTRAINT; Schema: public; Owner: -
 --
 
-ALTER TABLE ONLY public.user_roles
-    ADD CONSTRAINT pk_user_roles PRIMARY KEY (user_id, role_code);
+ALTER TABLE ONLY public.adjustments
+    ADD CONSTRAINT fk_adjustments_event FOREIGN KEY (event_id) REFERENCES public.events(id) ON DELETE RESTRICT;
 
 
 --
--- Name: roles roles_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+-- Name: adjustments fk_adjustments_reason; Type: FK CONSTRAINT; Schema: public; Owner: -
 --
 
-ALTER TABLE ONLY public.roles
-    ADD CONSTRAINT roles_pkey PRIMARY KEY (code);
+ALTER TABLE ONLY public.adjustments
+    ADD CONSTRAINT fk_adjustments_reason FOREIGN KEY (reason_code) REFERENCES public.adjustment_reasons(code) ON DELETE RESTRICT;
 
 
 --
--- Name: ruleset ruleset_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+-- Name: bonus_grants fk_bonus_grants_account; Type: FK CONSTRAINT; Schema: public; Owner: -
 --
 
-ALTER TABLE ONLY public.ruleset
-    ADD CONSTRAINT ruleset_pkey PRIMARY KEY (id);
+ALTER TABLE ONLY public.bonus_grants
+    ADD CONSTRAINT fk_bonus_grants_account FOREIGN KEY (account_id) REFERENCES public.accounts(id) ON DELETE RESTRICT;
 
 
 --
--- Name: accounts uq_accounts_public_code; Type: CONSTRAINT; Schema: public; Owner: -
+-- Name: bonus_grants fk_bonus_grants_event; Type: FK CONSTRAINT; Schema: public; Owner: -
 --
 
-ALTER TABLE ONLY public.accounts
-    ADD CONSTRAINT uq_accounts_public_code UNIQUE (public_code);
+ALTER TABLE ONLY public.bonus_grants
+    ADD CONSTRAINT fk_bonus_grants_event FOREIGN KEY (event_id) REFERENCES public.events(id) ON DELETE RESTRICT;
 
 
 --
--- Name: accounts uq_accounts_user_id; Type: CONSTRAINT; Schema: public; Owner: -
+-- Name: campaigns fk_campaigns_updated_by; Type: FK CONSTRAINT; Schema: public; Owner: -
 --
 
-ALTER TABLE ONLY public.accounts
-    ADD CONSTRAINT uq_accounts_user_id UNIQUE (user_id);
+ALTER TABLE ONLY public.campaigns
+    ADD CONSTRAINT fk_campaigns_updated_by FOREIGN KEY (updated_by) REFERENCES public.users(id) ON DELETE SET NULL;
 
 
 --
--- Name: level_rules uq_level_rules_ruleset_level; Type: CONSTRAINT; Schema: public; Owner: -
+-- Name: category_earn_rates fk_category_earn_rates_ruleset; Type: FK CONSTRAINT; Schema: public; Owner: -
 --
 
-ALTER TABLE ONLY public.level_rules
-    ADD CONSTRAINT uq_level_rules_ruleset_level UNIQUE (ruleset_id, level_code);
+ALTER TABLE ONLY public.category_earn_rates
+    ADD CONSTRAINT fk_category_earn_rates_ruleset FOREIGN KEY (ruleset_id) REFERENCES public.ruleset(id) ON DELETE CASCADE;
 
 
 --
--- Name: level_rules uq_level_rules_ruleset_threshold; Type: CONSTRAINT; Schema: public; Owner: -
+-- Name: event_campaigns fk_event_campaigns_campaign; Type: FK CONSTRAINT; Schema: public; Owner: -
 --
 
-ALTER TABLE ONLY public.level_rules
-    ADD CONSTRAINT uq_level_rules_ruleset_threshold UNIQUE (ruleset_id, threshold_total_spend);
+ALTER TABLE ONLY public.event_campaigns
+    ADD CONSTRAINT fk_event_campaigns_campaign FOREIGN KEY (campaign_id) REFERENCES public.campaigns(id) ON DELETE RESTRICT;
 
 
 --
--- Name: operations uq_operations_idempotency; Type: CONSTRAINT; Schema: public; Owner: -
+-- Name: event_campaigns fk_event_campaigns_event; Type: FK CONSTRAINT; Schema: public; Owner: -
 --
 
-ALTER TABLE ONLY public.operations
-    ADD CONSTRAINT uq_operations_idempotency UNIQUE (account_id, op_type, operation_id);
+ALTER TABLE ONLY public.event_campaigns
+    ADD CONSTRAINT fk_event_campaigns_event FOREIGN KEY (event_id) REFERENCES public.events(id) ON DELETE RESTRICT;
 
 
 --
--- Name: ruleset uq_ruleset_effective_from; Type: CONSTRAINT; Schema: public; Owner: -
+-- Name: events fk_events_account; Type: FK CONSTRAINT; Schema: public; Owner: -
 --
 
-ALTER TABLE ONLY public.ruleset
-    ADD CONSTRAINT uq_ruleset_effective_from UNIQUE (effective_from);
+ALTER TABLE ONLY public.events
+    ADD CONSTRAINT fk_events_account FOREIGN KEY (account_id) REFERENCES public.accounts(id) ON DELETE RESTRICT;
 
 
 --
--- Name: users uq_users_phone; Type: CONSTRAINT; Schema: public; Owner: -
+-- Name: events fk_events_actor; Type: FK CONSTRAINT; Schema: public; Owner: -
 --
 
-ALTER TABLE ONLY public.users
-    ADD CONSTRAINT uq_users_phone UNIQUE (phone);
+ALTER TABLE ONLY public.events
+    ADD CONSTRAINT fk_events_actor FOREIGN KEY (actor_user_id) REFERENCES public.users(id) ON DELETE SET NULL;
 
 
 --
--- Name: users users_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+-- Name: events fk_events_ref_event; Type: FK CONSTRAINT; Schema: public; Owner: -
 --
 
-ALTER TABLE ONLY public.users
-    ADD CONSTRAINT users_pkey PRIMARY KEY (id);
+ALTER TABLE ONLY public.events
+    ADD CONSTRAINT fk_events_ref_event FOREIGN KEY (ref_event_id) REFERENCES public.events(id) ON DELETE RESTRICT;
 
 
 --
--- Name: idx_events_account_ts; Type: INDEX; Schema: public; Owner: -
+-- Name: events fk_events_ruleset; Type: FK CONSTRAINT; Schema: public; Owner: -
 --
 
-CREATE INDEX idx_events_account_ts ON public.events USING btree (account_id, ts);
+ALTER TABLE ONLY public.events
+    ADD CONSTRAINT fk_events_ruleset FOREIGN KEY (ruleset_id) REFERENCES public.ruleset(id) ON DELETE SET NULL;
 
 
 --
--- Name: idx_events_actor_ts; Type: INDEX; Schema: public; Owner: -
+-- Name: expired_operations fk_expired_operations_account; Type: FK CONSTRAINT; Schema: public; Owner: -
 --
 
-CREATE INDEX idx_events_actor_ts ON public.events USING btree (actor_user_id, ts);
+ALTER TABLE ONLY public.expired_operations
+    ADD CONSTRAINT fk_expired_operations_account FOREIGN KEY (account_id) REFERENCES public.accounts(id) ON DELETE RESTRICT;
 
 
 --
--- Name: idx_operations_account_created_at; Type: INDEX; Schema: public; Owner: -
+-- Name: holds fk_holds_account; Type: FK CONSTRAINT; Schema: public; Owner: -
 --
 
-CREATE INDEX idx_operations_account_created_at ON public.operations USING btree (account_id, created_at);
+ALTER TABLE ONLY public.holds
+    ADD CONSTRAINT fk_holds_account FOREIGN KEY (account_id) REFERENCES public.accounts(id) ON DELETE RESTRICT;
 
 
 --
--- Name: accounts fk_accounts_user; Type: FK CONSTRAINT; Schema: public; Owner: -
+-- Name: holds fk_holds_actor; Type: FK CONSTRAINT; Schema: public; Owner: -
 --
 
-ALTER TABLE ONLY public.accounts
-    ADD CONSTRAINT fk_accounts_user FOREIGN KEY (user_id) REFERENCES public.users(id) ON DELETE RESTRICT;
+ALTER TABLE ONLY public.holds
+    ADD CONSTRAINT fk_holds_actor FOREIGN KEY (actor_user_id) REFERENCES public.users(id) ON DELETE SET NULL;
 
 
 --
--- Name: events fk_events_account; Type: FK CONSTRAINT; Schema: public; Owner: -
+-- Name: holds fk_holds_capture_event; Type: FK CONSTRAINT; Schema: public; Owner: -
 --
 
-ALTER TABLE ONLY public.events
-    ADD CONSTRAINT fk_events_account FOREIGN KEY (account_id) REFERENCES public.accounts(id) ON DELETE RESTRICT;
+ALTER TABLE ONLY public.holds
+    ADD CONSTRAINT fk_holds_capture_event FOREIGN KEY (capture_event_id) REFERENCES public.events(id) ON DELETE RESTRICT;
 
 
 --
--- Name: events fk_events_actor; Type: FK CONSTRAINT; Schema: public; Owner: -
+-- Name: level_changes fk_level_changes_account; Type: FK CONSTRAINT; Schema: public; Owner: -
 --
 
-ALTER TABLE ONLY public.events
-    ADD CONSTRAINT fk_events_actor FOREIGN KEY (actor_user_id) REFERENCES public.users(id) ON DELETE SET NULL;
+ALTER TABLE ONLY public.level_changes
+    ADD CONSTRAINT fk_level_changes_account FOREIGN KEY (account_id) REFERENCES public.accounts(id) ON DELETE RESTRICT;
 
 
 --
--- Name: events fk_events_ruleset; Type: FK CONSTRAINT; Schema: public; Owner: -
+-- Name: level_changes fk_level_changes_bonus_event; Type: FK CONSTRAINT; Schema: public; Owner: -
 --
 
-ALTER TABLE ONLY public.events
-    ADD CONSTRAINT fk_events_ruleset FOREIGN KEY (ruleset_id) REFERENCES public.ruleset(id) ON DELETE SET NULL;
+ALTER TABLE ONLY public.level_changes
+    ADD CONSTRAINT fk_level_changes_bonus_event FOREIGN KEY (bonus_event_id) REFERENCES public.events(id) ON DELETE RESTRICT;
+
+
+--
+-- Name: level_changes fk_level_changes_event; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.level_changes
+    ADD CONSTRAINT fk_level_changes_event FOREIGN KEY (event_id) REFERENCES public.events(id) ON DELETE RESTRICT;
 
 
 --
@@ -498,6 +1382,78 @@ ALTER TABLE ONLY public.operations
     ADD CONSTRAINT fk_operations_event FOREIGN KEY (event_id) REFERENCES public.events(id) ON DELETE SET NULL;
 
 
+--
+-- Name: point_lots fk_point_lots_account; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.point_lots
+    ADD CONSTRAINT fk_point_lots_account FOREIGN KEY (account_id) REFERENCES public.accounts(id) ON DELETE RESTRICT;
+
+
+--
+-- Name: point_lots fk_point_lots_source_event; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.point_lots
+    ADD CONSTRAINT fk_point_lots_source_event FOREIGN KEY (source_event_id) REFERENCES public.events(id) ON DELETE RESTRICT;
+
+
+--
+-- Name: receipt_lines fk_receipt_lines_event; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.receipt_lines
+    ADD CONSTRAINT fk_receipt_lines_event FOREIGN KEY (event_id) REFERENCES public.events(id) ON DELETE RESTRICT;
+
+
+--
+-- Name: referral_codes fk_referral_codes_account; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.referral_codes
+    ADD CONSTRAINT fk_referral_codes_account FOREIGN KEY (account_id) REFERENCES public.accounts(id) ON DELETE CASCADE;
+
+
+--
+-- Name: referrals fk_referrals_qualifying_event; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.referrals
+    ADD CONSTRAINT fk_referrals_qualifying_event FOREIGN KEY (qualifying_event_id) REFERENCES public.events(id) ON DELETE RESTRICT;
+
+
+--
+-- Name: referrals fk_referrals_referee; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.referrals
+    ADD CONSTRAINT fk_referrals_referee FOREIGN KEY (referee_account_id) REFERENCES public.accounts(id) ON DELETE RESTRICT;
+
+
+--
+-- Name: referrals fk_referrals_referee_event; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.referrals
+    ADD CONSTRAINT fk_referrals_referee_event FOREIGN KEY (referee_event_id) REFERENCES public.events(id) ON DELETE RESTRICT;
+
+
+--
+-- Name: referrals fk_referrals_referrer; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.referrals
+    ADD CONSTRAINT fk_referrals_referrer FOREIGN KEY (referrer_account_id) REFERENCES public.accounts(id) ON DELETE RESTRICT;
+
+
+--
+-- Name: referrals fk_referrals_referrer_event; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.referrals
+    ADD CONSTRAINT fk_referrals_referrer_event FOREIGN KEY (referrer_event_id) REFERENCES public.events(id) ON DELETE RESTRICT;
+
+
 --
 -- Name: ruleset fk_ruleset_created_by; Type: FK CONSTRAINT; Schema: public; Owner: -
 --
@@ -506,6 +1462,22 @@ ALTER TABLE ONLY public.ruleset
     ADD CONSTRAINT fk_ruleset_created_by FOREIGN KEY (created_by) REFERENCES public.users(id) ON DELETE SET NULL;
 
 
+--
+-- Name: ruleset_history fk_ruleset_history_actor; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.ruleset_history
+    ADD CONSTRAINT fk_ruleset_history_actor FOREIGN KEY (actor_user_id) REFERENCES public.users(id) ON DELETE SET NULL;
+
+
+--
+-- Name: ruleset_history fk_ruleset_history_ruleset; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.ruleset_history
+    ADD CONSTRAINT fk_ruleset_history_ruleset FOREIGN KEY (ruleset_id) REFERENCES public.ruleset(id) ON DELETE CASCADE;
+
+
 --
 -- Name: user_roles fk_user_roles_role; Type: FK CONSTRAINT; Schema: public; Owner: -
 --
@@ -522,6 +1494,14 @@ ALTER TABLE ONLY public.user_roles
     ADD CONSTRAINT fk_user_roles_user FOREIGN KEY (user_id) REFERENCES public.users(id) ON DELETE CASCADE;
 
 
+--
+-- Name: velocity_limits fk_velocity_limits_updated_by; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.velocity_limits
+    ADD CONSTRAINT fk_velocity_limits_updated_by FOREIGN KEY (updated_by) REFERENCES public.users(id) ON DELETE SET NULL;
+
+
 --
 -- PostgreSQL database dump complete
 --
@@ -0,0 +1,135 @@
+package admin
+
+import (
+	"context"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"Beanefits/internal/domain/errs"
+	"Beanefits/internal/domain/ledger"
+	pg "Beanefits/internal/repository/postgres"
+	pgdto "Beanefits/internal/repository/postgres/dto"
+	"Beanefits/internal/service/dto"
+	"Beanefits/internal/service/mapper"
+)
+
+const maxAdjustCommentLen = 500
+
+// AdjustBalance credits or debits an account by hand (goodwill, fixing mistakes).
+// The admin is recorded as actor of the ADJUST event; totalSpend and level stay as they are.
+func (s *Service) AdjustBalance(ctx context.Context, actorUserID int64, in dto.AdjustBalanceIn) (dto.AdjustmentOut, error) {
+	start := time.Now()
+	s.log.InfoContext(ctx, "admin.adjust_balance start", "actorUserID", actorUserID, "userID", in.UserID, "deltaPoints", in.DeltaPoints, "reasonCode", in.ReasonCode)
+
+	reason, comment, err := validateAdjustment(in)
+	if err != nil {
+		s.log.ErrorContext(ctx, "admin.adjust_balance failed", "ms", time.Since(start).Milliseconds(), "err", err)
+		return dto.AdjustmentOut{}, err
+	}
+
+	opTs := s.now()
+	var result dto.AdjustmentOut
+
+	err = s.txm.WithinTx(ctx, func(ctx context.Context, tx pg.DBTX) error {
+		accRow, ok, err := s.accounts.GetByUserID(ctx, tx, in.UserID)
+		if err != nil {
+			return errs.Wrap(errs.CodeInternal, "accounts.get_by_user_id", err)
+		}
+		if !ok {
+			return errs.New(errs.CodeAccountNotFound, "account not found")
+		}
+
+		// concurrency gate: same as cashier operations
+		lockedRow, err := s.accounts.LockByID(ctx, tx, accRow.ID)
+		if err != nil {
+			return errs.Wrap(errs.CodeInternal, "accounts.lock_by_id", err)
+		}
+
+		agg, err := mapper.Account(lockedRow)
+		if err != nil {
+			return errs.Wrap(errs.CodeInternal, "account aggregate build failed", err)
+		}
+
+		actor := actorUserID
+		delta := ledger.Points(in.DeltaPoints)
+
+		updatedAgg, evDraft, err := agg.ApplyAdjust(delta, &actor, opTs)
+		if err != nil {
+			return err
+		}
+
+		evRow, err := s.events.Insert(ctx, tx, mapper.EventInsert(evDraft))
+		if err != nil {
+			return errs.Wrap(errs.CodeInternal, "events.insert", err)
+		}
+
+		adj, err := s.adjustments.Insert(ctx, tx, pgdto.AdjustmentRow{
+			EventID:    evRow.ID,
+			ReasonCode: string(reason),
+			Comment:    comment,
+		})
+		if err != nil {
+			return errs.Wrap(errs.CodeInternal, "adjustments.insert", err)
+		}
+
+		// credited points age like earned ones, debits take the oldest lots first
+		if delta > 0 {
+			err = s.lots.Credit(ctx, tx, updatedAgg.ID, evRow.ID, delta, opTs)
+		} else {
+			err = s.lots.Debit(ctx, tx, updatedAgg.ID, -delta, nil)
+		}
+		if err != nil {
+			return err
+		}
+
+		// only balance changes, same as after SPEND
+		updatedRow, err := s.accounts.UpdateAfterSpend(ctx, tx, updatedAgg.ID, updatedAgg.Balance.Int())
+		if err != nil {
+			return errs.Wrap(errs.CodeInternal, "accounts.update_after_spend", err)
+		}
+
+		result = dto.AdjustmentOut{
+			Event:      mapper.EventOut(evRow),
+			ReasonCode: adj.ReasonCode,
+			Comment:    adj.Comment,
+			Balance:    mapper.BalanceOut(updatedRow, s.now()),
+		}
+		return nil
+	})
+
+	if err != nil {
+		s.log.ErrorContext(ctx, "admin.adjust_balance failed", "ms", time.Since(start).Milliseconds(), "err", err)
+		return dto.AdjustmentOut{}, err
+	}
+
+	s.log.InfoContext(ctx, "admin.adjust_balance ok",
+		"ms", time.Since(start).Milliseconds(),
+		"userID", in.UserID,
+		"eventID", result.Event.ID,
+		"balance", result.Balance.BalancePoints,
+	)
+
+	return result, nil
+}
+
+func validateAdjustment(in dto.AdjustBalanceIn) (ledger.AdjustReason, string, error) {
+	if in.DeltaPoints == 0 {
+		return "", "", errs.New(errs.CodeInvalidAdjustment, "deltaPoints must not be 0")
+	}
+
+	reason, err := ledger.ParseAdjustReason(in.ReasonCode)
+	if err != nil {
+		return "", "", err
+	}
+
+	comment := strings.TrimSpace(in.Comment)
+	if comment == "" {
+		return "", "", errs.New(errs.CodeInvalidAdjustment, "comment is required")
+	}
+	if utf8.RuneCountInString(comment) > maxAdjustCommentLen {
+		return "", "", errs.New(errs.CodeInvalidAdjustment, "comment is too long")
+	}
+
+	return reason, comment, nil
+}
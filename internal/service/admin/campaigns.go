@@ -0,0 +1,231 @@
+package admin
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"Beanefits/internal/domain/errs"
+	"Beanefits/internal/domain/ledger"
+	"Beanefits/internal/domain/rules"
+	pg "Beanefits/internal/repository/postgres"
+	pgdto "Beanefits/internal/repository/postgres/dto"
+	"Beanefits/internal/service/dto"
+	"Beanefits/internal/service/mapper"
+	svcvalidation "Beanefits/internal/service/validation"
+)
+
+const (
+	defaultCampaignTimezone = "UTC"
+	maxCampaignMultiplier   = 100
+)
+
+// ListCampaigns returns every campaign, latest start first.
+func (s *Service) ListCampaigns(ctx context.Context) ([]dto.CampaignOut, error) {
+	start := time.Now()
+	s.log.InfoContext(ctx, "admin.list_campaigns start")
+
+	rows, err := s.campaigns.List(ctx, s.db)
+	if err != nil {
+		wrapped := errs.Wrap(errs.CodeInternal, "campaigns.list", err)
+		s.log.ErrorContext(ctx, "admin.list_campaigns failed", "ms", time.Since(start).Milliseconds(), "err", wrapped)
+		return nil, wrapped
+	}
+
+	out := make([]dto.CampaignOut, 0, len(rows))
+	for _, r := range rows {
+		out = append(out, mapper.CampaignOut(r))
+	}
+
+	s.log.InfoContext(ctx, "admin.list_campaigns ok", "ms", time.Since(start).Milliseconds(), "items", len(out))
+	return out, nil
+}
+
+func (s *Service) GetCampaign(ctx context.Context, campaignID int64) (dto.CampaignOut, error) {
+	start := time.Now()
+	s.log.InfoContext(ctx, "admin.get_campaign start", "campaignID", campaignID)
+
+	row, ok, err := s.campaigns.GetByID(ctx, s.db, campaignID)
+	if err != nil {
+		wrapped := errs.Wrap(errs.CodeInternal, "campaigns.get_by_id", err)
+		s.log.ErrorContext(ctx, "admin.get_campaign failed", "ms", time.Since(start).Milliseconds(), "err", wrapped)
+		return dto.CampaignOut{}, wrapped
+	}
+	if !ok {
+		e := errs.New(errs.CodeCampaignNotFound, "campaign not found")
+		s.log.ErrorContext(ctx, "admin.get_campaign failed", "ms", time.Since(start).Milliseconds(), "err", e)
+		return dto.CampaignOut{}, e
+	}
+
+	s.log.InfoContext(ctx, "admin.get_campaign ok", "ms", time.Since(start).Milliseconds())
+	return mapper.CampaignOut(row), nil
+}
+
+// CreateCampaign stores a campaign; it applies to EARN operations from the next one on.
+func (s *Service) CreateCampaign(ctx context.Context, actorUserID int64, in dto.CampaignIn) (dto.CampaignOut, error) {
+	start := time.Now()
+	s.log.InfoContext(ctx, "admin.create_campaign start", "actorUserID", actorUserID, "name", in.Name)
+
+	w, err := campaignWrite(in)
+	if err != nil {
+		s.log.ErrorContext(ctx, "admin.create_campaign failed", "ms", time.Since(start).Milliseconds(), "err", err)
+		return dto.CampaignOut{}, err
+	}
+	actor := actorUserID
+	w.UpdatedBy = &actor
+
+	row, err := s.campaigns.Insert(ctx, s.db, w)
+	if err != nil {
+		wrapped := errs.Wrap(errs.CodeInternal, "campaigns.insert", err)
+		s.log.ErrorContext(ctx, "admin.create_campaign failed", "ms", time.Since(start).Milliseconds(), "err", wrapped)
+		return dto.CampaignOut{}, wrapped
+	}
+
+	s.log.InfoContext(ctx, "admin.create_campaign ok", "ms", time.Since(start).Milliseconds(), "campaignID", row.ID)
+	return mapper.CampaignOut(row), nil
+}
+
+// UpdateCampaign replaces a campaign definition. Events already earned keep the points they got.
+func (s *Service) UpdateCampaign(ctx context.Context, actorUserID, campaignID int64, in dto.CampaignIn) (dto.CampaignOut, error) {
+	start := time.Now()
+	s.log.InfoContext(ctx, "admin.update_campaign start", "actorUserID", actorUserID, "campaignID", campaignID)
+
+	w, err := campaignWrite(in)
+	if err != nil {
+		s.log.ErrorContext(ctx, "admin.update_campaign failed", "ms", time.Since(start).Milliseconds(), "err", err)
+		return dto.CampaignOut{}, err
+	}
+	actor := actorUserID
+	w.UpdatedBy = &actor
+
+	row, ok, err := s.campaigns.Update(ctx, s.db, campaignID, w)
+	if err != nil {
+		wrapped := errs.Wrap(errs.CodeInternal, "campaigns.update", err)
+		s.log.ErrorContext(ctx, "admin.update_campaign failed", "ms", time.Since(start).Milliseconds(), "err", wrapped)
+		return dto.CampaignOut{}, wrapped
+	}
+	if !ok {
+		e := errs.New(errs.CodeCampaignNotFound, "campaign not found")
+		s.log.ErrorContext(ctx, "admin.update_campaign failed", "ms", time.Since(start).Milliseconds(), "err", e)
+		return dto.CampaignOut{}, e
+	}
+
+	s.log.InfoContext(ctx, "admin.update_campaign ok", "ms", time.Since(start).Milliseconds())
+	return mapper.CampaignOut(row), nil
+}
+
+// DeleteCampaign removes a campaign that was never applied; one recorded on events can only be ended.
+func (s *Service) DeleteCampaign(ctx context.Context, campaignID int64) error {
+	start := time.Now()
+	s.log.InfoContext(ctx, "admin.delete_campaign start", "campaignID", campaignID)
+
+	ok, err := s.campaigns.Delete(ctx, s.db, campaignID)
+	if err != nil {
+		var wrapped error
+		if pg.IsForeignKeyViolation(err, "fk_event_campaigns_campaign") {
+			wrapped = errs.New(errs.CodeCampaignInUse, "campaign was applied to events; set endsOn to end it instead")
+		} else {
+			wrapped = errs.Wrap(errs.CodeInternal, "campaigns.delete", err)
+		}
+		s.log.ErrorContext(ctx, "admin.delete_campaign failed", "ms", time.Since(start).Milliseconds(), "err", wrapped)
+		return wrapped
+	}
+	if !ok {
+		e := errs.New(errs.CodeCampaignNotFound, "campaign not found")
+		s.log.ErrorContext(ctx, "admin.delete_campaign failed", "ms", time.Since(start).Milliseconds(), "err", e)
+		return e
+	}
+
+	s.log.InfoContext(ctx, "admin.delete_campaign ok", "ms", time.Since(start).Milliseconds())
+	return nil
+}
+
+// campaignWrite parses and validates the input against the domain invariants.
+func campaignWrite(in dto.CampaignIn) (pgdto.CampaignWrite, error) {
+	name := strings.TrimSpace(in.Name)
+	if name == "" {
+		return pgdto.CampaignWrite{}, errs.New(errs.CodeInvalidCampaign, "name is required")
+	}
+
+	tz := in.Timezone
+	if tz == "" {
+		tz = defaultCampaignTimezone
+	}
+	loc, err := time.LoadLocation(tz)
+	if err != nil {
+		return pgdto.CampaignWrite{}, errs.Wrap(errs.CodeInvalidCampaign, "unknown timezone", err)
+	}
+
+	c := rules.Campaign{Schedule: rules.Schedule{StartsOn: in.StartsOn, EndsOn: in.EndsOn, Location: loc}}
+
+	for _, wd := range in.Weekdays {
+		d, ok := rules.ParseWeekday(wd)
+		if !ok {
+			return pgdto.CampaignWrite{}, errs.New(errs.CodeInvalidCampaign, fmt.Sprintf("unknown weekday %q", wd))
+		}
+		c.Schedule.Weekdays = append(c.Schedule.Weekdays, d)
+	}
+
+	if c.Schedule.TimeFrom, err = parseTimeOfDay(in.TimeFrom); err != nil {
+		return pgdto.CampaignWrite{}, errs.Wrap(errs.CodeInvalidCampaign, "invalid timeFrom", err)
+	}
+	if c.Schedule.TimeTo, err = parseTimeOfDay(in.TimeTo); err != nil {
+		return pgdto.CampaignWrite{}, errs.Wrap(errs.CodeInvalidCampaign, "invalid timeTo", err)
+	}
+
+	if in.Multiplier != nil {
+		m, err := svcvalidation.ParseDecimal2(*in.Multiplier)
+		if err != nil {
+			return pgdto.CampaignWrite{}, errs.Wrap(errs.CodeInvalidCampaign, "invalid multiplier", err)
+		}
+		if m.GreaterThan(decimal.NewFromInt(maxCampaignMultiplier)) {
+			return pgdto.CampaignWrite{}, errs.New(errs.CodeInvalidCampaign, "multiplier must be <= 100")
+		}
+		c.Multiplier = &m
+	}
+	if in.BonusPoints != nil {
+		if *in.BonusPoints <= 0 {
+			return pgdto.CampaignWrite{}, errs.New(errs.CodeInvalidCampaign, "bonusPoints must be > 0")
+		}
+		c.BonusPoints = ledger.Points(*in.BonusPoints)
+	}
+
+	if err := c.Validate(); err != nil {
+		return pgdto.CampaignWrite{}, err
+	}
+
+	w := pgdto.CampaignWrite{
+		Name:        name,
+		StartsOn:    in.StartsOn,
+		EndsOn:      in.EndsOn,
+		Weekdays:    make([]int, 0, len(c.Schedule.Weekdays)),
+		TimeFrom:    c.Schedule.TimeFrom,
+		TimeTo:      c.Schedule.TimeTo,
+		Timezone:    tz,
+		BonusPoints: in.BonusPoints,
+	}
+	for _, d := range c.Schedule.Weekdays {
+		w.Weekdays = append(w.Weekdays, rules.ISOWeekday(d))
+	}
+	if c.Multiplier != nil {
+		m := pgdto.Money(*c.Multiplier)
+		w.Multiplier = &m
+	}
+	return w, nil
+}
+
+// parseTimeOfDay parses "HH:MM" into the offset from midnight.
+func parseTimeOfDay(s *string) (*time.Duration, error) {
+	if s == nil {
+		return nil, nil
+	}
+	t, err := time.Parse("15:04", *s)
+	if err != nil {
+		return nil, err
+	}
+	d := time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute
+	return &d, nil
+}
@@ -0,0 +1,320 @@
+package admin
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"Beanefits/internal/domain/errs"
+	"Beanefits/internal/domain/ledger"
+	"Beanefits/internal/domain/rules"
+	pg "Beanefits/internal/repository/postgres"
+	pgdto "Beanefits/internal/repository/postgres/dto"
+	"Beanefits/internal/service/dto"
+	"Beanefits/internal/service/mapper"
+)
+
+const (
+	verifyLedgerBatch   = 200
+	backfillHashesBatch = 100
+)
+
+// levelsByRuleset caches sorted level rules (and the qualification window) of rulesets referenced by replayed events.
+type levelsByRuleset map[int64]replayRuleset
+
+type replayRuleset struct {
+	levels     []rules.LevelRule
+	windowDays *int
+}
+
+// VerifyLedger replays the events of one user's account (or of every account) and reports
+// where balance_after, balance, totalSpend, level or the hash chain differ from the stored state.
+// Events are replayed by id rather than by ts: balance_after was computed in insertion order
+// under the account lock, while ts can be backdated by a cashier.
+// Read-only; each account is locked while it is replayed, so running operations cannot cause false mismatches.
+func (s *Service) VerifyLedger(ctx context.Context, in dto.VerifyLedgerIn) (dto.LedgerReportOut, error) {
+	start := time.Now()
+	s.log.InfoContext(ctx, "admin.verify_ledger start", "userID", in.UserID)
+
+	out := dto.LedgerReportOut{
+		Mismatches: []dto.LedgerMismatchOut{},
+		CheckedAt:  s.now(),
+	}
+	cache := levelsByRuleset{}
+
+	var err error
+	if in.UserID != nil {
+		err = s.txm.WithinTx(ctx, func(ctx context.Context, tx pg.DBTX) error {
+			accRow, ok, err := s.accounts.GetByUserID(ctx, tx, *in.UserID)
+			if err != nil {
+				return errs.Wrap(errs.CodeInternal, "accounts.get_by_user_id", err)
+			}
+			if !ok {
+				return errs.New(errs.CodeAccountNotFound, "account not found")
+			}
+			return s.verifyAccount(ctx, tx, accRow.ID, cache, &out)
+		})
+	} else {
+		err = s.verifyAllAccounts(ctx, cache, &out)
+	}
+
+	if err != nil {
+		s.log.ErrorContext(ctx, "admin.verify_ledger failed", "ms", time.Since(start).Milliseconds(), "err", err)
+		return dto.LedgerReportOut{}, err
+	}
+
+	lvl := s.log.InfoContext
+	if len(out.Mismatches) > 0 {
+		lvl = s.log.WarnContext
+	}
+	lvl(ctx, "admin.verify_ledger ok",
+		"ms", time.Since(start).Milliseconds(),
+		"accounts", out.AccountsChecked,
+		"events", out.EventsChecked,
+		"mismatches", len(out.Mismatches),
+	)
+
+	return out, nil
+}
+
+// verifyAllAccounts pages through accounts by id; every account is replayed in its own transaction.
+func (s *Service) verifyAllAccounts(ctx context.Context, cache levelsByRuleset, out *dto.LedgerReportOut) error {
+	var afterID int64
+	for {
+		var ids []int64
+		err := s.txm.WithinTx(ctx, func(ctx context.Context, tx pg.DBTX) error {
+			var err error
+			ids, err = s.accounts.ListIDsAfter(ctx, tx, afterID, verifyLedgerBatch)
+			if err != nil {
+				return errs.Wrap(errs.CodeInternal, "accounts.list_ids_after", err)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, id := range ids {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			err := s.txm.WithinTx(ctx, func(ctx context.Context, tx pg.DBTX) error {
+				return s.verifyAccount(ctx, tx, id, cache, out)
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		if len(ids) < verifyLedgerBatch {
+			return nil
+		}
+		afterID = ids[len(ids)-1]
+	}
+}
+
+func (s *Service) verifyAccount(ctx context.Context, tx pg.DBTX, accountID int64, cache levelsByRuleset, out *dto.LedgerReportOut) error {
+	// same gate as cashier operations: nothing is written while the history is read
+	acc, err := s.accounts.LockByID(ctx, tx, accountID)
+	if err != nil {
+		return errs.Wrap(errs.CodeInternal, "accounts.lock_by_id", err)
+	}
+
+	events, err := s.events.ListAllByAccount(ctx, tx, accountID)
+	if err != nil {
+		return errs.Wrap(errs.CodeInternal, "events.list_all_by_account", err)
+	}
+
+	mismatch := func(check dto.LedgerCheck, eventID *int64, expected, actual string) {
+		out.Mismatches = append(out.Mismatches, dto.LedgerMismatchOut{
+			AccountID: acc.ID,
+			UserID:    acc.UserID,
+			EventID:   eventID,
+			Check:     check,
+			Expected:  expected,
+			Actual:    actual,
+		})
+	}
+
+	var (
+		balance       int
+		prevAfter     int
+		totalSpend    = ledger.ZeroMoney()
+		lastRulesetID *int64
+	)
+
+	for _, ev := range events {
+		// compared with the previous stored value, so one broken row is reported once
+		if want := prevAfter + ev.DeltaPoints; ev.BalanceAfter != want {
+			id := ev.ID
+			mismatch(dto.LedgerCheckBalanceAfter, &id, strconv.Itoa(want), strconv.Itoa(ev.BalanceAfter))
+		}
+		prevAfter = ev.BalanceAfter
+		balance += ev.DeltaPoints
+
+		// only EARN and REFUND move totalSpend (and so the level)
+		switch ev.Type {
+		case pgdto.EventEarn, pgdto.EventRefund:
+			if ev.AmountMoney == nil {
+				return errs.New(errs.CodeInternal, fmt.Sprintf("event %d has no amountMoney", ev.ID))
+			}
+			amount, err := ledger.ParseMoney(ev.AmountMoney.String())
+			if err != nil {
+				return errs.Wrap(errs.CodeInternal, "amountMoney parse failed", err)
+			}
+			if ev.Type == pgdto.EventEarn {
+				totalSpend = totalSpend.Add(amount)
+			} else {
+				totalSpend = totalSpend.Sub(amount)
+			}
+			lastRulesetID = ev.RulesetID
+		}
+	}
+
+	if balance != acc.BalancePoints {
+		mismatch(dto.LedgerCheckBalance, nil, strconv.Itoa(balance), strconv.Itoa(acc.BalancePoints))
+	}
+
+	stored := mapper.MoneyFixed2(acc.TotalSpendMoney)
+	if replayed := totalSpend.Decimal().StringFixed(2); replayed != stored {
+		mismatch(dto.LedgerCheckTotalSpend, nil, replayed, stored)
+	}
+
+	// without EARN/REFUND the account keeps the level it was created with
+	if lastRulesetID != nil {
+		level, replayable, err := s.replayedLevel(ctx, tx, *lastRulesetID, totalSpend, cache)
+		if err != nil {
+			return err
+		}
+		if replayable && string(level) != acc.LevelCode {
+			mismatch(dto.LedgerCheckLevel, nil, string(level), acc.LevelCode)
+		}
+	}
+
+	// only the first broken link is reported: everything after it is unverifiable anyway
+	if brk, ok := pg.VerifyChain(events); !ok {
+		id := brk.EventID
+		mismatch(dto.LedgerCheckHashChain, &id, brk.Expected, brk.Actual)
+	}
+
+	out.AccountsChecked++
+	out.EventsChecked += len(events)
+	return nil
+}
+
+// BackfillEventHashes links events written before the hash chain existed, one account per transaction.
+// An account's chain is rebuilt from its first unhashed event on; earlier links are kept as they are.
+// It returns the number of accounts processed.
+func (s *Service) BackfillEventHashes(ctx context.Context) (int, error) {
+	start := time.Now()
+	processed := 0
+
+	for {
+		var ids []int64
+		err := s.txm.WithinTx(ctx, func(ctx context.Context, tx pg.DBTX) error {
+			var err error
+			ids, err = s.events.ListUnhashedAccounts(ctx, tx, backfillHashesBatch)
+			if err != nil {
+				return errs.Wrap(errs.CodeInternal, "events.list_unhashed_accounts", err)
+			}
+			return nil
+		})
+		if err != nil {
+			s.log.ErrorContext(ctx, "admin.backfill_event_hashes failed", "ms", time.Since(start).Milliseconds(), "err", err)
+			return processed, err
+		}
+		if len(ids) == 0 {
+			break
+		}
+
+		for _, id := range ids {
+			err := s.txm.WithinTx(ctx, func(ctx context.Context, tx pg.DBTX) error {
+				return s.backfillAccountHashes(ctx, tx, id)
+			})
+			if err != nil {
+				s.log.ErrorContext(ctx, "admin.backfill_event_hashes failed", "accountID", id, "ms", time.Since(start).Milliseconds(), "err", err)
+				return processed, err
+			}
+			processed++
+		}
+	}
+
+	if processed > 0 {
+		s.log.InfoContext(ctx, "admin.backfill_event_hashes ok", "ms", time.Since(start).Milliseconds(), "accounts", processed)
+	}
+	return processed, nil
+}
+
+func (s *Service) backfillAccountHashes(ctx context.Context, tx pg.DBTX, accountID int64) error {
+	// same gate as cashier operations: no event is appended while the chain is rebuilt
+	if _, err := s.accounts.LockByID(ctx, tx, accountID); err != nil {
+		return errs.Wrap(errs.CodeInternal, "accounts.lock_by_id", err)
+	}
+
+	events, err := s.events.ListAllByAccount(ctx, tx, accountID)
+	if err != nil {
+		return errs.Wrap(errs.CodeInternal, "events.list_all_by_account", err)
+	}
+
+	from := len(events)
+	for i, ev := range events {
+		if ev.Hash == nil {
+			from = i
+			break
+		}
+	}
+
+	var prev []byte
+	if from > 0 {
+		prev = events[from-1].Hash
+	}
+	for _, ev := range events[from:] {
+		hash := pg.EventHash(prev, ev)
+		if !bytes.Equal(ev.PrevHash, prev) || !bytes.Equal(ev.Hash, hash) {
+			if err := s.events.SetHash(ctx, tx, ev.ID, prev, hash); err != nil {
+				return errs.Wrap(errs.CodeInternal, "events.set_hash", err)
+			}
+		}
+		prev = hash
+	}
+	return nil
+}
+
+// replayedLevel resolves the level for totalSpend with the ruleset of the last EARN/REFUND,
+// the same way those operations did.
+// A ruleset with a rolling qualification window is not replayable (false): its level depends on when
+// it was resolved and on nightly requalification, not on the lifetime totals.
+func (s *Service) replayedLevel(ctx context.Context, tx pg.DBTX, rulesetID int64, totalSpend ledger.Money, cache levelsByRuleset) (rules.LevelCode, bool, error) {
+	rr, ok := cache[rulesetID]
+	if !ok {
+		rs, found, err := s.rules.GetByID(ctx, tx, rulesetID)
+		if err != nil {
+			return "", false, errs.Wrap(errs.CodeInternal, "rules.get_by_id", err)
+		}
+		if !found {
+			return "", false, errs.New(errs.CodeInternal, fmt.Sprintf("ruleset %d not found", rulesetID))
+		}
+
+		levels, err := mapper.LevelRules(rs.Levels)
+		if err != nil {
+			return "", false, err
+		}
+		if err := rules.ValidateLevels(levels); err != nil {
+			return "", false, err
+		}
+		rules.SortLevels(levels)
+		rr = replayRuleset{levels: levels, windowDays: rs.Ruleset.QualificationWindowDays}
+		cache[rulesetID] = rr
+	}
+	if rr.windowDays != nil {
+		return "", false, nil
+	}
+
+	lr, err := rules.ResolveLevel(totalSpend, rr.levels)
+	if err != nil {
+		return "", false, err
+	}
+	return lr.LevelCode, true, nil
+}
@@ -0,0 +1,95 @@
+package admin
+
+import (
+	"context"
+	"time"
+
+	"Beanefits/internal/domain/errs"
+	pgdto "Beanefits/internal/repository/postgres/dto"
+	"Beanefits/internal/service/dto"
+	"Beanefits/internal/service/mapper"
+)
+
+// ListLimits returns the velocity limits checked by cashier EARN/SPEND.
+func (s *Service) ListLimits(ctx context.Context) ([]dto.LimitOut, error) {
+	start := time.Now()
+	s.log.InfoContext(ctx, "admin.list_limits start")
+
+	rows, err := s.limits.List(ctx, s.db)
+	if err != nil {
+		wrapped := errs.Wrap(errs.CodeInternal, "limits.list", err)
+		s.log.ErrorContext(ctx, "admin.list_limits failed", "ms", time.Since(start).Milliseconds(), "err", wrapped)
+		return nil, wrapped
+	}
+
+	out := make([]dto.LimitOut, 0, len(rows))
+	for _, l := range rows {
+		out = append(out, mapper.LimitOut(l))
+	}
+
+	s.log.InfoContext(ctx, "admin.list_limits ok", "ms", time.Since(start).Milliseconds(), "items", len(out))
+	return out, nil
+}
+
+// SetLimit creates the (kind, opType) limit or replaces its max value; it applies to the next operation.
+func (s *Service) SetLimit(ctx context.Context, actorUserID int64, in dto.SetLimitIn) (dto.LimitOut, error) {
+	start := time.Now()
+	s.log.InfoContext(ctx, "admin.set_limit start", "actorUserID", actorUserID, "kind", in.Kind, "opType", in.OpType, "maxValue", in.MaxValue)
+
+	if err := validateLimit(in); err != nil {
+		s.log.ErrorContext(ctx, "admin.set_limit failed", "ms", time.Since(start).Milliseconds(), "err", err)
+		return dto.LimitOut{}, err
+	}
+
+	actor := actorUserID
+	row, err := s.limits.Upsert(ctx, s.db, pgdto.VelocityLimitUpsert{
+		Kind:      pgdto.LimitKind(in.Kind),
+		OpType:    pgdto.EventType(in.OpType),
+		MaxValue:  in.MaxValue,
+		UpdatedBy: &actor,
+	})
+	if err != nil {
+		wrapped := errs.Wrap(errs.CodeInternal, "limits.upsert", err)
+		s.log.ErrorContext(ctx, "admin.set_limit failed", "ms", time.Since(start).Milliseconds(), "err", wrapped)
+		return dto.LimitOut{}, wrapped
+	}
+
+	s.log.InfoContext(ctx, "admin.set_limit ok", "ms", time.Since(start).Milliseconds(), "limitID", row.ID)
+	return mapper.LimitOut(row), nil
+}
+
+// DeleteLimit removes a limit, lifting it for the next operation.
+func (s *Service) DeleteLimit(ctx context.Context, limitID int64) error {
+	start := time.Now()
+	s.log.InfoContext(ctx, "admin.delete_limit start", "limitID", limitID)
+
+	ok, err := s.limits.Delete(ctx, s.db, limitID)
+	if err != nil {
+		wrapped := errs.Wrap(errs.CodeInternal, "limits.delete", err)
+		s.log.ErrorContext(ctx, "admin.delete_limit failed", "ms", time.Since(start).Milliseconds(), "err", wrapped)
+		return wrapped
+	}
+	if !ok {
+		e := errs.New(errs.CodeLimitNotFound, "limit not found")
+		s.log.ErrorContext(ctx, "admin.delete_limit failed", "ms", time.Since(start).Milliseconds(), "err", e)
+		return e
+	}
+
+	s.log.InfoContext(ctx, "admin.delete_limit ok", "ms", time.Since(start).Milliseconds())
+	return nil
+}
+
+func validateLimit(in dto.SetLimitIn) error {
+	switch in.Kind {
+	case dto.LimitPointsPerOperation, dto.LimitAccountPointsPerDay, dto.LimitCashierOperationsPerHour:
+	default:
+		return errs.New(errs.CodeInvalidLimit, "unknown limit kind")
+	}
+	if in.OpType != dto.OpEarn && in.OpType != dto.OpSpend {
+		return errs.New(errs.CodeInvalidLimit, "opType must be EARN or SPEND")
+	}
+	if in.MaxValue <= 0 {
+		return errs.New(errs.CodeInvalidLimit, "maxValue must be > 0")
+	}
+	return nil
+}
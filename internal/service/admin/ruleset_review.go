@@ -0,0 +1,161 @@
+package admin
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"Beanefits/internal/domain/errs"
+	pg "Beanefits/internal/repository/postgres"
+	pgdto "Beanefits/internal/repository/postgres/dto"
+	"Beanefits/internal/service/dto"
+	"Beanefits/internal/service/mapper"
+)
+
+const maxReviewCommentLen = 500
+
+// SubmitRuleset sends a DRAFT or REJECTED ruleset for approval.
+func (s *Service) SubmitRuleset(ctx context.Context, actorUserID, rulesetID int64) (dto.RulesetOut, error) {
+	start := time.Now()
+	s.log.InfoContext(ctx, "admin.submit_ruleset start", "actorUserID", actorUserID, "rulesetID", rulesetID)
+
+	r, err := s.reviewRuleset(ctx, actorUserID, rulesetID, pgdto.RulesetActionSubmitted, nil)
+	if err != nil {
+		s.log.ErrorContext(ctx, "admin.submit_ruleset failed", "ms", time.Since(start).Milliseconds(), "err", err)
+		return dto.RulesetOut{}, err
+	}
+
+	s.log.InfoContext(ctx, "admin.submit_ruleset ok", "ms", time.Since(start).Milliseconds())
+	return mapper.RulesetOut(r), nil
+}
+
+// ApproveRuleset publishes a ruleset pending approval. The approver must be another admin than
+// the ones who created or edited it, and effectiveFrom must still be in the future.
+func (s *Service) ApproveRuleset(ctx context.Context, actorUserID, rulesetID int64) (dto.RulesetOut, error) {
+	start := time.Now()
+	s.log.InfoContext(ctx, "admin.approve_ruleset start", "actorUserID", actorUserID, "rulesetID", rulesetID)
+
+	r, err := s.reviewRuleset(ctx, actorUserID, rulesetID, pgdto.RulesetActionApproved, nil)
+	if err != nil {
+		s.log.ErrorContext(ctx, "admin.approve_ruleset failed", "ms", time.Since(start).Milliseconds(), "err", err)
+		return dto.RulesetOut{}, err
+	}
+
+	s.log.InfoContext(ctx, "admin.approve_ruleset ok", "ms", time.Since(start).Milliseconds())
+	return mapper.RulesetOut(r), nil
+}
+
+// RejectRuleset sends a ruleset pending approval back to its authors with a comment;
+// it can be edited and submitted again.
+func (s *Service) RejectRuleset(ctx context.Context, actorUserID, rulesetID int64, in dto.RejectRulesetIn) (dto.RulesetOut, error) {
+	start := time.Now()
+	s.log.InfoContext(ctx, "admin.reject_ruleset start", "actorUserID", actorUserID, "rulesetID", rulesetID)
+
+	comment := strings.TrimSpace(in.Comment)
+	if comment == "" || len(comment) > maxReviewCommentLen {
+		e := errs.New(errs.CodeInvalidRuleset, "comment is required, up to 500 characters")
+		s.log.ErrorContext(ctx, "admin.reject_ruleset failed", "ms", time.Since(start).Milliseconds(), "err", e)
+		return dto.RulesetOut{}, e
+	}
+
+	r, err := s.reviewRuleset(ctx, actorUserID, rulesetID, pgdto.RulesetActionRejected, &comment)
+	if err != nil {
+		s.log.ErrorContext(ctx, "admin.reject_ruleset failed", "ms", time.Since(start).Milliseconds(), "err", err)
+		return dto.RulesetOut{}, err
+	}
+
+	s.log.InfoContext(ctx, "admin.reject_ruleset ok", "ms", time.Since(start).Milliseconds())
+	return mapper.RulesetOut(r), nil
+}
+
+// reviewRuleset moves a ruleset through DRAFT/REJECTED -> PENDING_APPROVAL -> APPROVED/REJECTED
+// under a row lock and records the step in its history.
+func (s *Service) reviewRuleset(
+	ctx context.Context,
+	actorUserID, rulesetID int64,
+	action pgdto.RulesetAction,
+	comment *string,
+) (pgdto.RulesetWithLevels, error) {
+	var out pgdto.RulesetWithLevels
+
+	err := s.txm.WithinTx(ctx, func(ctx context.Context, tx pg.DBTX) error {
+		rs, ok, err := s.rules.LockByID(ctx, tx, rulesetID)
+		if err != nil {
+			return errs.Wrap(errs.CodeInternal, "rules.lock_by_id", err)
+		}
+		if !ok {
+			return errs.New(errs.CodeRulesetNotFound, "ruleset not found")
+		}
+
+		next, err := nextRulesetStatus(rs.Status, action)
+		if err != nil {
+			return err
+		}
+
+		// a ruleset must never start in the past: that would change the rules of operations already made
+		if action != pgdto.RulesetActionRejected && !rs.EffectiveFrom.After(s.now()) {
+			return errs.New(errs.CodeInvalidRuleset, "effectiveFrom has passed; edit the ruleset first")
+		}
+
+		if action == pgdto.RulesetActionApproved {
+			full, ok, err := s.rules.GetByID(ctx, tx, rulesetID)
+			if err != nil {
+				return errs.Wrap(errs.CodeInternal, "rules.get_by_id", err)
+			}
+			if !ok {
+				return errs.New(errs.CodeRulesetNotFound, "ruleset not found")
+			}
+			if authoredBy(full.History, actorUserID) {
+				return errs.New(errs.CodeSelfApproval, "a ruleset must be approved by an admin who did not create or edit it")
+			}
+		}
+
+		if err := s.rules.SetStatus(ctx, tx, rulesetID, next); err != nil {
+			return rulesetWriteError(err, "rules.set_status")
+		}
+
+		r, err := s.recordHistory(ctx, tx, rulesetID, action, actorUserID, comment)
+		if err != nil {
+			return err
+		}
+		out = r
+		return nil
+	})
+
+	return out, err
+}
+
+// nextRulesetStatus is the status a review action moves a ruleset to from status.
+func nextRulesetStatus(status pgdto.RulesetStatus, action pgdto.RulesetAction) (pgdto.RulesetStatus, error) {
+	switch action {
+	case pgdto.RulesetActionSubmitted:
+		if status != pgdto.RulesetDraft && status != pgdto.RulesetRejected {
+			return "", errs.New(errs.CodeRulesetStatusConflict, "only a DRAFT or REJECTED ruleset can be submitted")
+		}
+		return pgdto.RulesetPendingApproval, nil
+	case pgdto.RulesetActionApproved:
+		if status != pgdto.RulesetPendingApproval {
+			return "", errs.New(errs.CodeRulesetStatusConflict, "ruleset is not pending approval")
+		}
+		return pgdto.RulesetApproved, nil
+	case pgdto.RulesetActionRejected:
+		if status != pgdto.RulesetPendingApproval {
+			return "", errs.New(errs.CodeRulesetStatusConflict, "ruleset is not pending approval")
+		}
+		return pgdto.RulesetRejected, nil
+	}
+	return "", errs.New(errs.CodeInternal, "unknown review action "+string(action))
+}
+
+// authoredBy reports whether the user created or edited the ruleset.
+func authoredBy(history []pgdto.RulesetHistoryRow, userID int64) bool {
+	for _, h := range history {
+		if h.Action != pgdto.RulesetActionCreated && h.Action != pgdto.RulesetActionUpdated {
+			continue
+		}
+		if h.ActorUserID != nil && *h.ActorUserID == userID {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,88 @@
+package admin
+
+import (
+	"testing"
+
+	"Beanefits/internal/domain/errs"
+	pgdto "Beanefits/internal/repository/postgres/dto"
+)
+
+func TestNextRulesetStatus(t *testing.T) {
+	tests := []struct {
+		status pgdto.RulesetStatus
+		action pgdto.RulesetAction
+		want   pgdto.RulesetStatus // empty: RULESET_STATUS_CONFLICT
+	}{
+		{status: pgdto.RulesetDraft, action: pgdto.RulesetActionSubmitted, want: pgdto.RulesetPendingApproval},
+		{status: pgdto.RulesetRejected, action: pgdto.RulesetActionSubmitted, want: pgdto.RulesetPendingApproval},
+		{status: pgdto.RulesetPendingApproval, action: pgdto.RulesetActionSubmitted},
+		{status: pgdto.RulesetApproved, action: pgdto.RulesetActionSubmitted},
+
+		{status: pgdto.RulesetPendingApproval, action: pgdto.RulesetActionApproved, want: pgdto.RulesetApproved},
+		{status: pgdto.RulesetDraft, action: pgdto.RulesetActionApproved},
+		{status: pgdto.RulesetRejected, action: pgdto.RulesetActionApproved},
+		{status: pgdto.RulesetApproved, action: pgdto.RulesetActionApproved},
+
+		{status: pgdto.RulesetPendingApproval, action: pgdto.RulesetActionRejected, want: pgdto.RulesetRejected},
+		{status: pgdto.RulesetDraft, action: pgdto.RulesetActionRejected},
+		{status: pgdto.RulesetRejected, action: pgdto.RulesetActionRejected},
+		{status: pgdto.RulesetApproved, action: pgdto.RulesetActionRejected},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.status)+" "+string(tt.action), func(t *testing.T) {
+			got, err := nextRulesetStatus(tt.status, tt.action)
+			if tt.want == "" {
+				if code, _ := errs.CodeOf(err); code != errs.CodeRulesetStatusConflict {
+					t.Fatalf("nextRulesetStatus error = %v, want %s", err, errs.CodeRulesetStatusConflict)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("nextRulesetStatus: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("nextRulesetStatus = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNextRulesetStatusNotAReview(t *testing.T) {
+	for _, action := range []pgdto.RulesetAction{pgdto.RulesetActionCreated, pgdto.RulesetActionUpdated} {
+		if _, err := nextRulesetStatus(pgdto.RulesetDraft, action); err == nil {
+			t.Errorf("nextRulesetStatus(%s): want error", action)
+		}
+	}
+}
+
+func TestAuthoredBy(t *testing.T) {
+	user := func(id int64) *int64 { return &id }
+	history := []pgdto.RulesetHistoryRow{
+		{Action: pgdto.RulesetActionCreated, ActorUserID: user(1)},
+		{Action: pgdto.RulesetActionUpdated, ActorUserID: user(2)},
+		{Action: pgdto.RulesetActionSubmitted, ActorUserID: user(3)},
+		{Action: pgdto.RulesetActionRejected, ActorUserID: user(4)},
+		{Action: pgdto.RulesetActionCreated},
+	}
+
+	tests := []struct {
+		name   string
+		userID int64
+		want   bool
+	}{
+		{name: "creator", userID: 1, want: true},
+		{name: "editor", userID: 2, want: true},
+		{name: "submitter only", userID: 3, want: false},
+		{name: "earlier reviewer", userID: 4, want: false},
+		{name: "stranger", userID: 5, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := authoredBy(history, tt.userID); got != tt.want {
+				t.Errorf("authoredBy(%d) = %v, want %v", tt.userID, got, tt.want)
+			}
+		})
+	}
+}
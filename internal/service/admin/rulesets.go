@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"Beanefits/internal/domain/errs"
+	"Beanefits/internal/domain/rules"
 	pg "Beanefits/internal/repository/postgres"
 	pgdto "Beanefits/internal/repository/postgres/dto"
 	"Beanefits/internal/service/dto"
@@ -15,50 +16,39 @@ import (
 )
 
 const (
-	constraintRulesetEffectiveFrom = "ruleset_effective_from_key"
+	constraintRulesetEffectiveFrom = "uq_ruleset_effective_from"
 
-	constraintLevelRulesetLevelCode = "level_rules_ruleset_id_level_code_key"
-	constraintLevelRulesetThreshold = "level_rules_ruleset_id_threshold_total_spend_key"
+	constraintLevelRulesetLevelCode = "uq_level_rules_ruleset_level"
+	constraintLevelRulesetThreshold = "uq_level_rules_ruleset_threshold"
 	defaultRulesetsLimit            = 20
 	maxRulesetsLimit                = 100
+
+	maxQualificationWindowDays = 3650
 )
 
+// CreateRuleset stores a ruleset as a DRAFT; it takes effect only once submitted and approved by another admin.
 func (s *Service) CreateRuleset(ctx context.Context, actorUserID int64, in dto.CreateRulesetIn) (dto.RulesetOut, error) {
 	start := time.Now()
 	s.log.InfoContext(ctx, "admin.create_ruleset start", "actorUserID", actorUserID, "effectiveFrom", in.EffectiveFrom)
 
-	base, err := svcvalidation.ParseDecimal2(in.BaseRubPerPoint)
-	if err != nil {
-		e := errs.Wrap(errs.CodeInvalidMoney, "invalid baseRubPerPoint", err)
-		s.log.ErrorContext(ctx, "admin.create_ruleset failed", "ms", time.Since(start).Milliseconds(), "err", e)
-		return dto.RulesetOut{}, e
-	}
-	if base.Cmp(decimal.Zero) <= 0 {
-		e := errs.New(errs.CodeInvalidMoney, "baseRubPerPoint must be > 0")
-		s.log.ErrorContext(ctx, "admin.create_ruleset failed", "ms", time.Since(start).Milliseconds(), "err", e)
-		return dto.RulesetOut{}, e
-	}
-
-	levelRows, err := svcvalidation.ValidateAndMapLevelRules(in.Levels)
+	draft, levelRows, rateRows, err := draftRuleset(in)
 	if err != nil {
 		s.log.ErrorContext(ctx, "admin.create_ruleset failed", "ms", time.Since(start).Milliseconds(), "err", err)
 		return dto.RulesetOut{}, err
 	}
+	actor := actorUserID
+	draft.CreatedBy = &actor
 
 	var created pgdto.RulesetWithLevels
 
 	err = s.txm.WithinTx(ctx, func(ctx context.Context, tx pg.DBTX) error {
-		r, err := s.rules.CreateRuleset(ctx, tx, in.EffectiveFrom, pgdto.Money(base), levelRows)
+		r, err := s.rules.CreateRuleset(ctx, tx, draft, levelRows, rateRows)
+		if err != nil {
+			return rulesetWriteError(err, "rules.create_ruleset")
+		}
+		r, err = s.recordHistory(ctx, tx, r.Ruleset.ID, pgdto.RulesetActionCreated, actorUserID, nil)
 		if err != nil {
-			// human-friendly ошибки на уникальные ограничения.
-			if pg.IsUniqueViolation(err, constraintRulesetEffectiveFrom) {
-				return errs.New(errs.CodeInvalidRuleset, "ruleset.effectiveFrom already exists")
-			}
-			if pg.IsUniqueViolation(err, constraintLevelRulesetLevelCode) ||
-				pg.IsUniqueViolation(err, constraintLevelRulesetThreshold) {
-				return errs.New(errs.CodeInvalidLevels, "duplicate level definitions")
-			}
-			return errs.Wrap(errs.CodeInternal, "rules.create_ruleset", err)
+			return err
 		}
 		created = r
 		return nil
@@ -78,6 +68,228 @@ func (s *Service) CreateRuleset(ctx context.Context, actorUserID int64, in dto.C
 	return out, nil
 }
 
+// GetRuleset returns a ruleset by id, in any status.
+func (s *Service) GetRuleset(ctx context.Context, rulesetID int64) (dto.RulesetOut, error) {
+	start := time.Now()
+	s.log.InfoContext(ctx, "admin.get_ruleset start", "rulesetID", rulesetID)
+
+	r, ok, err := s.rules.GetByID(ctx, s.db, rulesetID)
+	if err != nil {
+		wrapped := errs.Wrap(errs.CodeInternal, "rules.get_by_id", err)
+		s.log.ErrorContext(ctx, "admin.get_ruleset failed", "ms", time.Since(start).Milliseconds(), "err", wrapped)
+		return dto.RulesetOut{}, wrapped
+	}
+	if !ok {
+		e := errs.New(errs.CodeRulesetNotFound, "ruleset not found")
+		s.log.ErrorContext(ctx, "admin.get_ruleset failed", "ms", time.Since(start).Milliseconds(), "err", e)
+		return dto.RulesetOut{}, e
+	}
+
+	s.log.InfoContext(ctx, "admin.get_ruleset ok", "ms", time.Since(start).Milliseconds())
+	return mapper.RulesetOut(r), nil
+}
+
+// UpdateRuleset replaces a ruleset that has not taken effect (levels and category rates included);
+// the new effectiveFrom must be in the future. The ruleset goes back to DRAFT and needs a new approval.
+func (s *Service) UpdateRuleset(ctx context.Context, actorUserID, rulesetID int64, in dto.CreateRulesetIn) (dto.RulesetOut, error) {
+	start := time.Now()
+	s.log.InfoContext(ctx, "admin.update_ruleset start", "actorUserID", actorUserID, "rulesetID", rulesetID, "effectiveFrom", in.EffectiveFrom)
+
+	draft, levelRows, rateRows, err := draftRuleset(in)
+	if err != nil {
+		s.log.ErrorContext(ctx, "admin.update_ruleset failed", "ms", time.Since(start).Milliseconds(), "err", err)
+		return dto.RulesetOut{}, err
+	}
+
+	var updated pgdto.RulesetWithLevels
+
+	err = s.txm.WithinTx(ctx, func(ctx context.Context, tx pg.DBTX) error {
+		if _, err := s.lockMutable(ctx, tx, rulesetID); err != nil {
+			return err
+		}
+		if !draft.EffectiveFrom.After(s.now()) {
+			return errs.New(errs.CodeInvalidRuleset, "effectiveFrom of a scheduled ruleset must be in the future")
+		}
+
+		if _, err := s.rules.UpdateRuleset(ctx, tx, rulesetID, draft, levelRows, rateRows); err != nil {
+			return rulesetWriteError(err, "rules.update_ruleset")
+		}
+		r, err := s.recordHistory(ctx, tx, rulesetID, pgdto.RulesetActionUpdated, actorUserID, nil)
+		if err != nil {
+			return err
+		}
+		updated = r
+		return nil
+	})
+
+	if err != nil {
+		s.log.ErrorContext(ctx, "admin.update_ruleset failed", "ms", time.Since(start).Milliseconds(), "err", err)
+		return dto.RulesetOut{}, err
+	}
+
+	s.log.InfoContext(ctx, "admin.update_ruleset ok", "ms", time.Since(start).Milliseconds())
+	return mapper.RulesetOut(updated), nil
+}
+
+// DeleteRuleset deletes a ruleset that has not taken effect, history included.
+func (s *Service) DeleteRuleset(ctx context.Context, rulesetID int64) error {
+	start := time.Now()
+	s.log.InfoContext(ctx, "admin.delete_ruleset start", "rulesetID", rulesetID)
+
+	err := s.txm.WithinTx(ctx, func(ctx context.Context, tx pg.DBTX) error {
+		if _, err := s.lockMutable(ctx, tx, rulesetID); err != nil {
+			return err
+		}
+		if err := s.rules.DeleteRuleset(ctx, tx, rulesetID); err != nil {
+			return errs.Wrap(errs.CodeInternal, "rules.delete_ruleset", err)
+		}
+		return nil
+	})
+
+	if err != nil {
+		s.log.ErrorContext(ctx, "admin.delete_ruleset failed", "ms", time.Since(start).Milliseconds(), "err", err)
+		return err
+	}
+
+	s.log.InfoContext(ctx, "admin.delete_ruleset ok", "ms", time.Since(start).Milliseconds())
+	return nil
+}
+
+// lockMutable locks a ruleset row and checks that it has not taken effect:
+// an approved ruleset past its effectiveFrom, or one that any event references, is immutable.
+// Rulesets that were never approved can always be changed. The row lock also makes a concurrent
+// event insert referencing the ruleset wait for the transaction.
+func (s *Service) lockMutable(ctx context.Context, tx pg.DBTX, rulesetID int64) (pgdto.RulesetRow, error) {
+	rs, ok, err := s.rules.LockByID(ctx, tx, rulesetID)
+	if err != nil {
+		return pgdto.RulesetRow{}, errs.Wrap(errs.CodeInternal, "rules.lock_by_id", err)
+	}
+	if !ok {
+		return pgdto.RulesetRow{}, errs.New(errs.CodeRulesetNotFound, "ruleset not found")
+	}
+	if rs.Status == pgdto.RulesetApproved && !rs.EffectiveFrom.After(s.now()) {
+		return pgdto.RulesetRow{}, errs.New(errs.CodeRulesetImmutable, "ruleset has already taken effect")
+	}
+
+	used, err := s.rules.HasEvents(ctx, tx, rulesetID)
+	if err != nil {
+		return pgdto.RulesetRow{}, errs.Wrap(errs.CodeInternal, "rules.has_events", err)
+	}
+	if used {
+		return pgdto.RulesetRow{}, errs.New(errs.CodeRulesetImmutable, "ruleset is referenced by events")
+	}
+	return rs, nil
+}
+
+// recordHistory appends a history entry and returns the ruleset as it now stands.
+func (s *Service) recordHistory(
+	ctx context.Context,
+	tx pg.DBTX,
+	rulesetID int64,
+	action pgdto.RulesetAction,
+	actorUserID int64,
+	comment *string,
+) (pgdto.RulesetWithLevels, error) {
+	actor := actorUserID
+	err := s.rules.AddHistory(ctx, tx, pgdto.RulesetHistoryInsert{
+		RulesetID:   rulesetID,
+		Action:      action,
+		ActorUserID: &actor,
+		Comment:     comment,
+	})
+	if err != nil {
+		return pgdto.RulesetWithLevels{}, errs.Wrap(errs.CodeInternal, "rules.add_history", err)
+	}
+
+	rs, ok, err := s.rules.GetByID(ctx, tx, rulesetID)
+	if err != nil {
+		return pgdto.RulesetWithLevels{}, errs.Wrap(errs.CodeInternal, "rules.get_by_id", err)
+	}
+	if !ok {
+		return pgdto.RulesetWithLevels{}, errs.New(errs.CodeRulesetNotFound, "ruleset not found")
+	}
+	return rs, nil
+}
+
+// rulesetWriteError maps constraint violations of a ruleset insert/update to human-friendly errors.
+func rulesetWriteError(err error, op string) error {
+	if pg.IsUniqueViolation(err, constraintRulesetEffectiveFrom) {
+		return errs.New(errs.CodeInvalidRuleset, "an approved ruleset already starts at effectiveFrom")
+	}
+	if pg.IsUniqueViolation(err, constraintLevelRulesetLevelCode) ||
+		pg.IsUniqueViolation(err, constraintLevelRulesetThreshold) {
+		return errs.New(errs.CodeInvalidLevels, "duplicate level definitions")
+	}
+	return errs.Wrap(errs.CodeInternal, op, err)
+}
+
+// draftRuleset validates a ruleset as submitted by an admin and maps it to rows;
+// shared by CreateRuleset and SimulateRuleset so a draft is simulated only if it could be published.
+func draftRuleset(in dto.CreateRulesetIn) (pgdto.RulesetInsert, []pgdto.LevelRuleRow, []pgdto.CategoryEarnRateRow, error) {
+	base, err := svcvalidation.ParseDecimal2(in.BaseRubPerPoint)
+	if err != nil {
+		return pgdto.RulesetInsert{}, nil, nil, errs.Wrap(errs.CodeInvalidMoney, "invalid baseRubPerPoint", err)
+	}
+	if base.Cmp(decimal.Zero) <= 0 {
+		return pgdto.RulesetInsert{}, nil, nil, errs.New(errs.CodeInvalidMoney, "baseRubPerPoint must be > 0")
+	}
+
+	redeem := decimal.NewFromInt(1)
+	if in.RedeemRubPerPoint != nil {
+		redeem, err = svcvalidation.ParseDecimal2(*in.RedeemRubPerPoint)
+		if err != nil {
+			return pgdto.RulesetInsert{}, nil, nil, errs.Wrap(errs.CodeInvalidMoney, "invalid redeemRubPerPoint", err)
+		}
+		if redeem.Cmp(decimal.Zero) <= 0 {
+			return pgdto.RulesetInsert{}, nil, nil, errs.New(errs.CodeInvalidMoney, "redeemRubPerPoint must be > 0")
+		}
+	}
+
+	if w := in.QualificationWindowDays; w != nil && (*w < 1 || *w > maxQualificationWindowDays) {
+		return pgdto.RulesetInsert{}, nil, nil, errs.New(errs.CodeInvalidRuleset, "qualificationWindowDays must be 1..3650")
+	}
+
+	if in.WelcomeBonusPoints < 0 || in.BirthdayBonusPoints < 0 || in.ReferrerBonusPoints < 0 || in.RefereeBonusPoints < 0 {
+		return pgdto.RulesetInsert{}, nil, nil, errs.New(errs.CodeInvalidRuleset, "bonus points must be >= 0")
+	}
+
+	rounding, err := rules.ParseRounding(in.RoundingMode, in.RoundingStage)
+	if err != nil {
+		return pgdto.RulesetInsert{}, nil, nil, err
+	}
+
+	minEarn, err := svcvalidation.ValidateAndMapEarnCaps(in)
+	if err != nil {
+		return pgdto.RulesetInsert{}, nil, nil, err
+	}
+
+	levelRows, err := svcvalidation.ValidateAndMapLevelRules(in.Levels)
+	if err != nil {
+		return pgdto.RulesetInsert{}, nil, nil, err
+	}
+
+	rateRows, err := svcvalidation.ValidateAndMapCategoryRates(in.CategoryRates)
+	if err != nil {
+		return pgdto.RulesetInsert{}, nil, nil, err
+	}
+
+	return pgdto.RulesetInsert{
+		EffectiveFrom:           in.EffectiveFrom,
+		BaseRubPerPoint:         pgdto.Money(base),
+		RedeemRubPerPoint:       pgdto.Money(redeem),
+		QualificationWindowDays: in.QualificationWindowDays,
+		WelcomeBonusPoints:      in.WelcomeBonusPoints,
+		BirthdayBonusPoints:     in.BirthdayBonusPoints,
+		ReferrerBonusPoints:     in.ReferrerBonusPoints,
+		RefereeBonusPoints:      in.RefereeBonusPoints,
+		RoundingMode:            string(rounding.Mode),
+		RoundingStage:           string(rounding.Stage),
+		MinEarnAmount:           minEarn,
+		MaxPointsPerPurchase:    in.MaxPointsPerPurchase,
+		MaxPointsPerDay:         in.MaxPointsPerDay,
+	}, levelRows, rateRows, nil
+}
+
 func (s *Service) ListRulesets(ctx context.Context, in dto.ListRulesetsIn) (dto.RulesetsOut, error) {
 	limit, offset := svcvalidation.NormalizeListParams(in.Limit, in.Offset, defaultRulesetsLimit, maxRulesetsLimit)
 
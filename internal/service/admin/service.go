@@ -5,15 +5,23 @@ import (
 	"time"
 
 	pg "Beanefits/internal/repository/postgres"
+	"Beanefits/internal/service/lots"
 )
 
 type Service struct {
 	db  pg.DBTX
 	txm pg.TxManager
 
-	users pg.UsersRepo
-	roles pg.RolesRepo
-	rules pg.RulesRepo
+	users       pg.UsersRepo
+	roles       pg.RolesRepo
+	rules       pg.RulesRepo
+	accounts    pg.AccountsRepo
+	events      pg.EventsRepo
+	adjustments pg.AdjustmentsRepo
+	limits      pg.LimitsRepo
+	campaigns   pg.CampaignsRepo
+	receipts    pg.ReceiptsRepo
+	lots        *lots.Book
 
 	now func() time.Time
 	log *slog.Logger
@@ -27,6 +35,21 @@ type Deps struct {
 	Roles pg.RolesRepo
 	Rules pg.RulesRepo
 
+	// balance adjustments
+	Accounts    pg.AccountsRepo
+	Events      pg.EventsRepo
+	Adjustments pg.AdjustmentsRepo
+	Lots        *lots.Book
+
+	// velocity limits of cashier operations
+	Limits pg.LimitsRepo
+
+	// promotional earn campaigns
+	Campaigns pg.CampaignsRepo
+
+	// receipt lines replayed by the ruleset simulation
+	Receipts pg.ReceiptsRepo
+
 	Now func() time.Time
 	Log *slog.Logger
 }
@@ -50,7 +73,16 @@ func New(deps Deps) *Service {
 		users: deps.Users,
 		roles: deps.Roles,
 		rules: deps.Rules,
-		now:   n,
-		log:   l,
+
+		accounts:    deps.Accounts,
+		events:      deps.Events,
+		adjustments: deps.Adjustments,
+		limits:      deps.Limits,
+		campaigns:   deps.Campaigns,
+		receipts:    deps.Receipts,
+		lots:        deps.Lots,
+
+		now: n,
+		log: l,
 	}
 }
@@ -0,0 +1,333 @@
+package admin
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"Beanefits/internal/domain/errs"
+	"Beanefits/internal/domain/ledger"
+	"Beanefits/internal/domain/rules"
+	pgdto "Beanefits/internal/repository/postgres/dto"
+	"Beanefits/internal/service/dto"
+	"Beanefits/internal/service/mapper"
+)
+
+const (
+	maxSimulationRange   = 366 * 24 * time.Hour
+	defaultSimulationTop = 10
+	maxSimulationTop     = 100
+)
+
+// simAccount accumulates one account's purchases during a replay.
+type simAccount struct {
+	id         int64
+	publicCode string
+
+	spendBefore ledger.Money // lifetime spend before the range, net of refunds
+	history     []simPurchase
+
+	actual ledger.Points
+	draft  ledger.Points
+
+	day        time.Time     // UTC day of draftToday
+	draftToday ledger.Points // draft points of that day: what the draft's daily cap sees
+}
+
+// simPurchase is a purchase (positive) or a refund (negative) of a replayed account.
+type simPurchase struct {
+	purchaseTs time.Time // for a refund, ts of the purchase it refunds
+	ts         time.Time
+	amount     ledger.Money
+}
+
+// spendAt is the spend levels are resolved on at at, counting what happened before at only:
+// lifetime spend, or with a qualification window the purchases made within it, net of their refunds.
+func (a *simAccount) spendAt(from, at time.Time, windowDays *int) ledger.Money {
+	spend := ledger.ZeroMoney()
+	if windowDays == nil {
+		spend = a.spendBefore
+	}
+	for _, h := range a.history {
+		if !h.ts.Before(at) {
+			break
+		}
+		if windowDays == nil {
+			if h.ts.Before(from) {
+				continue
+			}
+		} else if !rules.Qualifies(h.purchaseTs, at, windowDays) {
+			continue
+		}
+		spend = spend.Add(h.amount)
+	}
+	return spend
+}
+
+// SimulateRuleset replays the real EARN events of [from, to) through the draft ruleset and
+// compares it with what was actually issued. Each purchase earns at the level the draft resolves
+// on the account's spend before it (lifetime, or the draft's qualification window; net of refunds),
+// on its receipt lines weighted by the draft's category rates, with the draft's rounding and earn caps.
+// Campaign rewards are left out of both sides, including the draft's daily cap. Nothing is written.
+func (s *Service) SimulateRuleset(ctx context.Context, in dto.SimulateRulesetIn) (dto.SimulateRulesetOut, error) {
+	start := time.Now()
+	s.log.InfoContext(ctx, "admin.simulate_ruleset start", "from", in.From, "to", in.To)
+
+	if !in.From.Before(in.To) {
+		e := errs.New(errs.CodeInvalidSimulation, "from must be before to")
+		s.log.ErrorContext(ctx, "admin.simulate_ruleset failed", "ms", time.Since(start).Milliseconds(), "err", e)
+		return dto.SimulateRulesetOut{}, e
+	}
+	if in.To.Sub(in.From) > maxSimulationRange {
+		e := errs.New(errs.CodeInvalidSimulation, "range must not exceed 366 days")
+		s.log.ErrorContext(ctx, "admin.simulate_ruleset failed", "ms", time.Since(start).Milliseconds(), "err", e)
+		return dto.SimulateRulesetOut{}, e
+	}
+	top := in.TopLimit
+	if top == 0 {
+		top = defaultSimulationTop
+	}
+	if top < 1 || top > maxSimulationTop {
+		e := errs.New(errs.CodeInvalidSimulation, "topLimit must be 1..100")
+		s.log.ErrorContext(ctx, "admin.simulate_ruleset failed", "ms", time.Since(start).Milliseconds(), "err", e)
+		return dto.SimulateRulesetOut{}, e
+	}
+
+	draftRow, levelRows, rateRows, err := draftRuleset(in.Draft)
+	if err != nil {
+		s.log.ErrorContext(ctx, "admin.simulate_ruleset failed", "ms", time.Since(start).Milliseconds(), "err", err)
+		return dto.SimulateRulesetOut{}, err
+	}
+
+	out, err := s.simulate(ctx, draftRow, levelRows, rateRows, in.From, in.To, top)
+	if err != nil {
+		s.log.ErrorContext(ctx, "admin.simulate_ruleset failed", "ms", time.Since(start).Milliseconds(), "err", err)
+		return dto.SimulateRulesetOut{}, err
+	}
+
+	s.log.InfoContext(ctx, "admin.simulate_ruleset ok",
+		"ms", time.Since(start).Milliseconds(),
+		"purchases", out.Purchases,
+		"accounts", out.Accounts,
+		"actualPoints", out.ActualPoints,
+		"draftPoints", out.DraftPoints,
+	)
+	return out, nil
+}
+
+func (s *Service) simulate(
+	ctx context.Context,
+	draftRow pgdto.RulesetInsert,
+	levelRows []pgdto.LevelRuleRow,
+	rateRows []pgdto.CategoryEarnRateRow,
+	from, to time.Time,
+	top int,
+) (dto.SimulateRulesetOut, error) {
+	draft, err := mapper.Ruleset(pgdto.RulesetWithLevels{
+		Ruleset: pgdto.RulesetRow{
+			BaseRubPerPoint:         draftRow.BaseRubPerPoint,
+			QualificationWindowDays: draftRow.QualificationWindowDays,
+			RoundingMode:            draftRow.RoundingMode,
+			RoundingStage:           draftRow.RoundingStage,
+			MinEarnAmount:           draftRow.MinEarnAmount,
+			MaxPointsPerPurchase:    draftRow.MaxPointsPerPurchase,
+			MaxPointsPerDay:         draftRow.MaxPointsPerDay,
+		},
+		Levels: levelRows,
+	})
+	if err != nil {
+		return dto.SimulateRulesetOut{}, err
+	}
+	draftWindow := draftRow.QualificationWindowDays
+	draftRates := mapper.CategoryRates(rateRows)
+
+	// levels are compared with the ruleset in force at the end of the range (or now, for a range ending later)
+	at := to
+	if now := s.now(); now.Before(at) {
+		at = now
+	}
+	actualRS, ok, err := s.rules.GetEffectiveAt(ctx, s.db, at)
+	if err != nil {
+		return dto.SimulateRulesetOut{}, errs.Wrap(errs.CodeInternal, "rules.get_effective_at", err)
+	}
+	if !ok {
+		return dto.SimulateRulesetOut{}, errs.New(errs.CodeInvalidSimulation, "no ruleset effective at the end of the range")
+	}
+	actual, err := mapper.Ruleset(actualRS)
+	if err != nil {
+		return dto.SimulateRulesetOut{}, err
+	}
+	actualWindow := actualRS.Ruleset.QualificationWindowDays
+
+	accounts := make(map[int64]*simAccount)
+	account := func(id int64) *simAccount {
+		acc, ok := accounts[id]
+		if !ok {
+			acc = &simAccount{id: id, spendBefore: ledger.ZeroMoney()}
+			accounts[id] = acc
+		}
+		return acc
+	}
+
+	spendRows, err := s.events.SumEarnSpendBefore(ctx, s.db, from, to)
+	if err != nil {
+		return dto.SimulateRulesetOut{}, errs.Wrap(errs.CodeInternal, "events.sum_earn_spend_before", err)
+	}
+	for _, r := range spendRows {
+		m, err := ledger.ParseMoney(r.SpendMoney.String())
+		if err != nil {
+			return dto.SimulateRulesetOut{}, errs.Wrap(errs.CodeInternal, "spend parse failed", err)
+		}
+		account(r.AccountID).spendBefore = m
+	}
+
+	// purchases are loaded back to the start of the widest qualification window in play
+	since := from
+	for _, w := range []*int{draftWindow, actualWindow} {
+		if w != nil {
+			if ws := rules.QualificationSince(from, *w); ws.Before(since) {
+				since = ws
+			}
+		}
+	}
+	historyRows, err := s.events.ListPurchaseAmounts(ctx, s.db, since, from, to)
+	if err != nil {
+		return dto.SimulateRulesetOut{}, errs.Wrap(errs.CodeInternal, "events.list_purchase_amounts", err)
+	}
+	for _, r := range historyRows {
+		m, err := ledger.ParseMoney(r.AmountMoney.String())
+		if err != nil {
+			return dto.SimulateRulesetOut{}, errs.Wrap(errs.CodeInternal, "amountMoney parse failed", err)
+		}
+		acc := account(r.AccountID)
+		acc.history = append(acc.history, simPurchase{purchaseTs: r.PurchaseTs, ts: r.Ts, amount: m})
+	}
+
+	lineRows, err := s.receipts.ListForEarnInRange(ctx, s.db, from, to)
+	if err != nil {
+		return dto.SimulateRulesetOut{}, errs.Wrap(errs.CodeInternal, "receipts.list_for_earn_in_range", err)
+	}
+	receipts := make(map[int64][]rules.ReceiptLine)
+	for _, l := range lineRows {
+		total, err := ledger.ParseMoney(l.LineTotal.String())
+		if err != nil {
+			return dto.SimulateRulesetOut{}, errs.Wrap(errs.CodeInternal, "lineTotal parse failed", err)
+		}
+		receipts[l.EventID] = append(receipts[l.EventID], rules.ReceiptLine{
+			SKU:       l.SKU,
+			Category:  l.Category,
+			Quantity:  l.Quantity,
+			LineTotal: total,
+		})
+	}
+
+	rows, err := s.events.ListEarnInRange(ctx, s.db, from, to)
+	if err != nil {
+		return dto.SimulateRulesetOut{}, errs.Wrap(errs.CodeInternal, "events.list_earn_in_range", err)
+	}
+
+	order := make([]*simAccount, 0)
+	out := dto.SimulateRulesetOut{From: from, To: to, ActualRulesetID: actual.ID, Purchases: len(rows)}
+
+	for _, r := range rows {
+		acc := account(r.AccountID)
+		if acc.publicCode == "" {
+			acc.publicCode = r.PublicCode
+			order = append(order, acc)
+		}
+
+		purchase, err := ledger.ParseMoney(r.AmountMoney.String())
+		if err != nil {
+			return dto.SimulateRulesetOut{}, errs.Wrap(errs.CodeInternal, "amountMoney parse failed", err)
+		}
+		earnable := purchase
+		if lines := receipts[r.EventID]; len(lines) > 0 {
+			earnable, err = rules.EarnableAmount(lines, draftRates)
+			if err != nil {
+				return dto.SimulateRulesetOut{}, errs.Wrap(errs.CodeInternal, "earnable amount failed", err)
+			}
+		}
+
+		if day := r.Ts.UTC().Truncate(24 * time.Hour); !day.Equal(acc.day) {
+			acc.day = day
+			acc.draftToday = 0
+		}
+
+		lv, err := rules.ResolveLevel(acc.spendAt(from, r.Ts, draftWindow), draft.Levels)
+		if err != nil {
+			return dto.SimulateRulesetOut{}, err
+		}
+		pts, err := draft.EarnPoints(lv, purchase, earnable, acc.draftToday)
+		if err != nil {
+			return dto.SimulateRulesetOut{}, err
+		}
+
+		acc.draft += pts
+		acc.draftToday += pts
+		acc.actual += ledger.Points(r.DeltaPoints - r.CampaignPoints)
+	}
+
+	actualCounts := make(map[rules.LevelCode]int, len(actual.Levels))
+	draftCounts := make(map[rules.LevelCode]int, len(draft.Levels))
+	changed := make([]dto.SimulatedAccountOut, 0)
+
+	for _, acc := range order {
+		actualLv, err := rules.ResolveLevel(acc.spendAt(from, at, actualWindow), actual.Levels)
+		if err != nil {
+			return dto.SimulateRulesetOut{}, err
+		}
+		draftLv, err := rules.ResolveLevel(acc.spendAt(from, at, draftWindow), draft.Levels)
+		if err != nil {
+			return dto.SimulateRulesetOut{}, err
+		}
+		actualCounts[actualLv.LevelCode]++
+		draftCounts[draftLv.LevelCode]++
+
+		out.ActualPoints += acc.actual.Int()
+		out.DraftPoints += acc.draft.Int()
+
+		if acc.actual != acc.draft || actualLv.LevelCode != draftLv.LevelCode {
+			changed = append(changed, dto.SimulatedAccountOut{
+				AccountID:    acc.id,
+				PublicCode:   acc.publicCode,
+				ActualPoints: acc.actual.Int(),
+				DraftPoints:  acc.draft.Int(),
+				ActualLevel:  string(actualLv.LevelCode),
+				DraftLevel:   string(draftLv.LevelCode),
+			})
+		}
+	}
+	out.Accounts = len(order)
+	out.ActualLevels = levelCounts(actual.Levels, actualCounts)
+	out.DraftLevels = levelCounts(draft.Levels, draftCounts)
+
+	sort.SliceStable(changed, func(i, j int) bool {
+		di, dj := absInt(changed[i].DraftPoints-changed[i].ActualPoints), absInt(changed[j].DraftPoints-changed[j].ActualPoints)
+		if di != dj {
+			return di > dj
+		}
+		return changed[i].AccountID < changed[j].AccountID
+	})
+	if len(changed) > top {
+		changed = changed[:top]
+	}
+	out.TopAccounts = changed
+
+	return out, nil
+}
+
+// levelCounts lists every level of a ruleset in threshold order, including empty ones.
+func levelCounts(levels []rules.LevelRule, counts map[rules.LevelCode]int) []dto.LevelCountOut {
+	out := make([]dto.LevelCountOut, 0, len(levels))
+	for _, lv := range levels {
+		out = append(out, dto.LevelCountOut{LevelCode: string(lv.LevelCode), Accounts: counts[lv.LevelCode]})
+	}
+	return out
+}
+
+func absInt(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"Beanefits/internal/domain/errs"
+	"Beanefits/internal/domain/ledger"
 	"Beanefits/internal/domain/user"
 	"Beanefits/internal/repository/postgres"
 	pg "Beanefits/internal/repository/postgres"
@@ -23,6 +24,18 @@ func (s *Service) RegisterClient(ctx context.Context, in sdto.RegisterIn) (sdto.
 		return sdto.AuthOut{}, err
 	}
 
+	opTs := s.now()
+
+	var birthday *pgdto.Ts
+	if in.Birthday != nil {
+		b, err := user.ParseBirthday(*in.Birthday, opTs)
+		if err != nil {
+			s.log.ErrorContext(ctx, "auth.register_client failed", "ms", time.Since(start).Milliseconds(), "err", err)
+			return sdto.AuthOut{}, err
+		}
+		birthday = &b
+	}
+
 	var (
 		created pgdto.UserWithRoles
 		acc     pgdto.AccountRow
@@ -42,7 +55,7 @@ func (s *Service) RegisterClient(ctx context.Context, in sdto.RegisterIn) (sdto.
 			return errs.Wrap(errs.CodeInternal, "hasher.hash", err)
 		}
 
-		userID, err := s.users.Create(ctx, tx, in.Phone, hash)
+		userID, err := s.users.Create(ctx, tx, in.Phone, hash, birthday)
 		if err != nil {
 			if postgres.IsUniqueViolation(err, "users_phone_key") {
 				return errs.New(errs.CodePhoneAlreadyExists, "phone already exists")
@@ -80,6 +93,28 @@ func (s *Service) RegisterClient(ctx context.Context, in sdto.RegisterIn) (sdto.
 			return errs.Wrap(errs.CodePublicCodeCollision, "public code collision retries exhausted", lastCollision)
 		}
 
+		if err := s.referrals.AssignCode(ctx, tx, acc.ID); err != nil {
+			return err
+		}
+		if in.ReferralCode != nil {
+			if err := s.referrals.Attach(ctx, tx, acc.ID, *in.ReferralCode, opTs); err != nil {
+				return err
+			}
+		}
+
+		// welcome bonus of the ruleset effective now, in the same transaction as the account
+		ruleset, found, err := s.rules.GetEffectiveAt(ctx, tx, opTs)
+		if err != nil {
+			return errs.Wrap(errs.CodeInternal, "rules.get_effective_at", err)
+		}
+		if found && ruleset.Ruleset.WelcomeBonusPoints > 0 {
+			points := ledger.Points(ruleset.Ruleset.WelcomeBonusPoints)
+			acc, err = s.bonuses.Grant(ctx, tx, acc, pgdto.BonusWelcome, points, ruleset.Ruleset.ID, opTs)
+			if err != nil {
+				return err
+			}
+		}
+
 		u, ok, err := s.users.GetByID(ctx, tx, userID)
 		if err != nil {
 			return errs.Wrap(errs.CodeInternal, "users.get_by_id", err)
@@ -8,6 +8,8 @@ import (
 	"Beanefits/internal/domain/errs"
 	pg "Beanefits/internal/repository/postgres"
 	pgdto "Beanefits/internal/repository/postgres/dto"
+	"Beanefits/internal/service/bonuses"
+	"Beanefits/internal/service/referrals"
 )
 
 type PasswordHasher interface {
@@ -32,6 +34,10 @@ type Service struct {
 	users    pg.UsersRepo
 	roles    pg.RolesRepo
 	accounts pg.AccountsRepo
+	rules    pg.RulesRepo
+
+	bonuses   *bonuses.Granter
+	referrals *referrals.Program
 
 	hasher  PasswordHasher
 	issuer  TokenIssuer
@@ -51,6 +57,13 @@ type Deps struct {
 	Users    pg.UsersRepo
 	Roles    pg.RolesRepo
 	Accounts pg.AccountsRepo
+	Rules    pg.RulesRepo
+
+	// Bonuses credits the welcome bonus of the effective ruleset on registration.
+	Bonuses *bonuses.Granter
+
+	// Referrals gives every new account a referral code and links it to the referrer whose code it registered with.
+	Referrals *referrals.Program
 
 	Hasher  PasswordHasher
 	Issuer  TokenIssuer
@@ -97,9 +110,15 @@ func New(deps Deps) *Service {
 	if deps.TXM == nil {
 		panic("auth.New: deps.TXM is nil")
 	}
-	if deps.Users == nil || deps.Roles == nil || deps.Accounts == nil {
+	if deps.Users == nil || deps.Roles == nil || deps.Accounts == nil || deps.Rules == nil {
 		panic("auth.New: repos are nil")
 	}
+	if deps.Bonuses == nil {
+		panic("auth.New: deps.Bonuses is nil")
+	}
+	if deps.Referrals == nil {
+		panic("auth.New: deps.Referrals is nil")
+	}
 	if deps.Hasher == nil {
 		panic("auth.New: deps.Hasher is nil")
 	}
@@ -116,6 +135,9 @@ func New(deps Deps) *Service {
 		users:             deps.Users,
 		roles:             deps.Roles,
 		accounts:          deps.Accounts,
+		rules:             deps.Rules,
+		bonuses:           deps.Bonuses,
+		referrals:         deps.Referrals,
 		hasher:            deps.Hasher,
 		issuer:            deps.Issuer,
 		codeGen:           deps.CodeGen,
@@ -0,0 +1,86 @@
+// Package bonuses credits ruleset bonuses as BONUS events: the welcome bonus on registration,
+// the yearly birthday bonus paid by a daily job and the referral bonuses.
+package bonuses
+
+import (
+	"context"
+	"time"
+
+	"Beanefits/internal/domain/errs"
+	"Beanefits/internal/domain/ledger"
+	pg "Beanefits/internal/repository/postgres"
+	pgdto "Beanefits/internal/repository/postgres/dto"
+	"Beanefits/internal/service/lots"
+	"Beanefits/internal/service/mapper"
+)
+
+// Granter writes a bonus into the ledger. Every call must run inside a transaction,
+// after the account row is locked (or created in the same transaction).
+type Granter struct {
+	accounts pg.AccountsRepo
+	events   pg.EventsRepo
+	grants   pg.BonusesRepo
+	lots     *lots.Book
+}
+
+func NewGranter(accounts pg.AccountsRepo, events pg.EventsRepo, grants pg.BonusesRepo, book *lots.Book) *Granter {
+	return &Granter{accounts: accounts, events: events, grants: grants, lots: book}
+}
+
+// errAlreadyGranted: the bonus of this kind was paid to the account this year.
+var errAlreadyGranted = errs.New(errs.CodeInternal, "bonus already granted this year")
+
+// Grant credits p points of the ruleset bonus kind to acc and returns the updated account.
+// A kind is paid at most once a year per account.
+func (g *Granter) Grant(ctx context.Context, tx pg.DBTX, acc pgdto.AccountRow, kind pgdto.BonusKind, p ledger.Points, rulesetID int64, ts time.Time) (pgdto.AccountRow, error) {
+	updated, evRow, err := g.Credit(ctx, tx, acc, p, rulesetID, ts)
+	if err != nil {
+		return pgdto.AccountRow{}, err
+	}
+
+	err = g.grants.InsertGrant(ctx, tx, pgdto.BonusGrantRow{
+		EventID:   evRow.ID,
+		AccountID: acc.ID,
+		Kind:      kind,
+		Year:      ts.UTC().Year(),
+	})
+	if err != nil {
+		if pg.IsUniqueViolation(err, "uq_bonus_grants_account_kind_year") {
+			return pgdto.AccountRow{}, errAlreadyGranted
+		}
+		return pgdto.AccountRow{}, errs.Wrap(errs.CodeInternal, "bonuses.insert_grant", err)
+	}
+	return updated, nil
+}
+
+// Credit writes a BONUS event of p points for acc and returns the updated account and the event;
+// the caller records what the bonus was paid for.
+// Bonus points age like earned ones; totalSpend and level stay as they are.
+func (g *Granter) Credit(ctx context.Context, tx pg.DBTX, acc pgdto.AccountRow, p ledger.Points, rulesetID int64, ts time.Time) (pgdto.AccountRow, pgdto.EventRow, error) {
+	agg, err := mapper.Account(acc)
+	if err != nil {
+		return pgdto.AccountRow{}, pgdto.EventRow{}, errs.Wrap(errs.CodeInternal, "account aggregate build failed", err)
+	}
+
+	rsID := rulesetID
+	updatedAgg, evDraft, err := agg.ApplyBonus(p, &rsID, ts)
+	if err != nil {
+		return pgdto.AccountRow{}, pgdto.EventRow{}, err
+	}
+
+	evRow, err := g.events.Insert(ctx, tx, mapper.EventInsert(evDraft))
+	if err != nil {
+		return pgdto.AccountRow{}, pgdto.EventRow{}, errs.Wrap(errs.CodeInternal, "events.insert", err)
+	}
+
+	if err := g.lots.Credit(ctx, tx, acc.ID, evRow.ID, p, ts); err != nil {
+		return pgdto.AccountRow{}, pgdto.EventRow{}, err
+	}
+
+	// only balance changes, same as after ADJUST
+	updated, err := g.accounts.UpdateAfterSpend(ctx, tx, acc.ID, updatedAgg.Balance.Int())
+	if err != nil {
+		return pgdto.AccountRow{}, pgdto.EventRow{}, errs.Wrap(errs.CodeInternal, "accounts.update_after_spend", err)
+	}
+	return updated, evRow, nil
+}
@@ -0,0 +1,68 @@
+package bonuses
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type RunConfig struct {
+	At    time.Duration // time of day (UTC) to run at, as an offset from midnight
+	Batch int
+}
+
+// Start runs GrantBirthdays once a day at At (UTC) until stop is called.
+func Start(parent context.Context, s *Service, cfg RunConfig) (stop func()) {
+	if s == nil {
+		return func() {}
+	}
+
+	at := cfg.At
+	if at < 0 || at >= 24*time.Hour {
+		at = time.Hour
+	}
+	batch := cfg.Batch
+	if batch <= 0 {
+		batch = 200
+	}
+
+	ctx, cancel := context.WithCancel(parent)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+
+	s.log.InfoContext(ctx, "bonuses runner started", "at", at, "batch", batch)
+
+	go func() {
+		defer wg.Done()
+
+		t := time.NewTimer(time.Until(nextRun(s.now(), at)))
+		defer t.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				s.log.Info("bonuses runner stopped")
+				return
+
+			case <-t.C:
+				_, _ = s.GrantBirthdays(ctx, batch)
+				t.Reset(time.Until(nextRun(s.now(), at)))
+			}
+		}
+	}()
+
+	return func() {
+		cancel()
+		wg.Wait()
+	}
+}
+
+// nextRun is the first moment after now that is at past a UTC midnight.
+func nextRun(now time.Time, at time.Duration) time.Time {
+	next := now.UTC().Truncate(24 * time.Hour).Add(at)
+	if !next.After(now) {
+		next = next.Add(24 * time.Hour)
+	}
+	return next
+}
@@ -0,0 +1,151 @@
+package bonuses
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"Beanefits/internal/domain/errs"
+	"Beanefits/internal/domain/ledger"
+	"Beanefits/internal/domain/user"
+	pg "Beanefits/internal/repository/postgres"
+	pgdto "Beanefits/internal/repository/postgres/dto"
+)
+
+type Clock func() time.Time
+
+// Service pays the birthday bonus of the effective ruleset to clients whose birthday is today (UTC).
+// A grant is recorded per calendar year, so a rerun on the same day pays nobody twice.
+type Service struct {
+	txm pg.TxManager
+
+	rules    pg.RulesRepo
+	accounts pg.AccountsRepo
+	bonuses  pg.BonusesRepo
+	granter  *Granter
+
+	now Clock
+	log *slog.Logger
+}
+
+type Deps struct {
+	TXM pg.TxManager
+
+	Rules    pg.RulesRepo
+	Accounts pg.AccountsRepo
+	Bonuses  pg.BonusesRepo
+	Granter  *Granter
+
+	Now Clock
+	Log *slog.Logger
+}
+
+func New(deps Deps) *Service {
+	n := deps.Now
+	if n == nil {
+		n = time.Now
+	}
+
+	l := deps.Log
+	if l == nil {
+		l = slog.Default()
+	}
+	l = l.With("layer", "service", "svc", "bonuses")
+
+	return &Service{
+		txm:      deps.TXM,
+		rules:    deps.Rules,
+		accounts: deps.Accounts,
+		bonuses:  deps.Bonuses,
+		granter:  deps.Granter,
+		now:      n,
+		log:      l,
+	}
+}
+
+// GrantBirthdays pays the birthday bonus to every eligible account, batch accounts per page
+// and one transaction per account. It is a no-op when the effective ruleset has no birthday bonus.
+// It returns the number of bonuses paid.
+func (s *Service) GrantBirthdays(ctx context.Context, batch int) (int, error) {
+	start := time.Now()
+	at := s.now()
+
+	var rs pgdto.RulesetWithLevels
+	found := false
+	err := s.txm.WithinTx(ctx, func(ctx context.Context, tx pg.DBTX) error {
+		var err error
+		rs, found, err = s.rules.GetEffectiveAt(ctx, tx, at)
+		if err != nil {
+			return errs.Wrap(errs.CodeInternal, "rules.get_effective_at", err)
+		}
+		return nil
+	})
+	if err != nil {
+		s.log.ErrorContext(ctx, "bonuses.grant_birthdays failed", "ms", time.Since(start).Milliseconds(), "err", err)
+		return 0, err
+	}
+	if !found || rs.Ruleset.BirthdayBonusPoints <= 0 {
+		return 0, nil
+	}
+
+	points := ledger.Points(rs.Ruleset.BirthdayBonusPoints)
+	days := user.BirthdayDays(at)
+	year := at.UTC().Year()
+
+	var (
+		afterID int64
+		granted int
+	)
+	for {
+		var ids []int64
+		err := s.txm.WithinTx(ctx, func(ctx context.Context, tx pg.DBTX) error {
+			var err error
+			ids, err = s.bonuses.ListBirthdayAccountIDsAfter(ctx, tx, afterID, days, year, batch)
+			if err != nil {
+				return errs.Wrap(errs.CodeInternal, "bonuses.list_birthday_account_ids_after", err)
+			}
+			return nil
+		})
+		if err != nil {
+			s.log.ErrorContext(ctx, "bonuses.grant_birthdays failed", "ms", time.Since(start).Milliseconds(), "err", err)
+			return granted, err
+		}
+
+		for _, id := range ids {
+			if err := ctx.Err(); err != nil {
+				return granted, err
+			}
+			err := s.txm.WithinTx(ctx, func(ctx context.Context, tx pg.DBTX) error {
+				acc, err := s.accounts.LockByID(ctx, tx, id)
+				if err != nil {
+					return errs.Wrap(errs.CodeInternal, "accounts.lock_by_id", err)
+				}
+				_, err = s.granter.Grant(ctx, tx, acc, pgdto.BonusBirthday, points, rs.Ruleset.ID, at)
+				return err
+			})
+			if errors.Is(err, errAlreadyGranted) {
+				// paid by a concurrent run after the page was listed
+				continue
+			}
+			if err != nil {
+				s.log.ErrorContext(ctx, "bonuses.grant_birthday failed", "accountID", id, "err", err)
+				continue
+			}
+			granted++
+		}
+
+		if len(ids) < batch {
+			break
+		}
+		afterID = ids[len(ids)-1]
+	}
+
+	s.log.InfoContext(ctx, "bonuses.grant_birthdays ok",
+		"ms", time.Since(start).Milliseconds(),
+		"rulesetID", rs.Ruleset.ID,
+		"points", points.Int(),
+		"granted", granted,
+	)
+	return granted, nil
+}
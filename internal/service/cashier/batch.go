@@ -0,0 +1,81 @@
+package cashier
+
+import (
+	"context"
+	"time"
+
+	"Beanefits/internal/domain/errs"
+	"Beanefits/internal/service/dto"
+)
+
+const maxBatchItems = 100
+
+// Batch replays operations queued by an offline till through the regular Earn/Spend usecases.
+// Items run one by one in input order (so order per account is kept), each in its own transaction
+// and idempotent by its operationId; a failed item is reported in its slot and does not stop the rest.
+func (s *Service) Batch(ctx context.Context, actorUserID int64, in dto.BatchIn) (dto.BatchOut, error) {
+	start := time.Now()
+	s.log.InfoContext(ctx, "cashier.batch start", "actorUserID", actorUserID, "items", len(in.Items))
+
+	if len(in.Items) == 0 || len(in.Items) > maxBatchItems {
+		err := errs.New(errs.CodeInvalidBatch, "batch must contain 1..100 items")
+		s.log.ErrorContext(ctx, "cashier.batch failed", "ms", time.Since(start).Milliseconds(), "err", err)
+		return dto.BatchOut{}, err
+	}
+
+	out := dto.BatchOut{Items: make([]dto.BatchItemOut, 0, len(in.Items))}
+	failed := 0
+
+	for i, it := range in.Items {
+		res, err := s.batchItem(ctx, actorUserID, it)
+
+		item := dto.BatchItemOut{
+			Index:       i,
+			Type:        it.Type,
+			OperationID: it.OperationID,
+		}
+		if err != nil {
+			item.Err = err
+			failed++
+		} else {
+			item.Result = &res
+		}
+		out.Items = append(out.Items, item)
+	}
+
+	s.log.InfoContext(ctx, "cashier.batch ok",
+		"ms", time.Since(start).Milliseconds(),
+		"items", len(out.Items),
+		"failed", failed,
+	)
+
+	return out, nil
+}
+
+func (s *Service) batchItem(ctx context.Context, actorUserID int64, it dto.BatchItemIn) (dto.OperationOut, error) {
+	switch it.Type {
+	case dto.OpEarn:
+		if it.AmountMoney == nil {
+			return dto.OperationOut{}, errs.New(errs.CodeInvalidPurchaseAmount, "amountMoney is required for EARN")
+		}
+		return s.Earn(ctx, actorUserID, dto.EarnIn{
+			OperationID: it.OperationID,
+			PublicCode:  it.PublicCode,
+			AmountMoney: *it.AmountMoney,
+			Ts:          it.Ts,
+		})
+
+	case dto.OpSpend:
+		if it.AmountPoints == nil {
+			return dto.OperationOut{}, errs.New(errs.CodeInvalidPoints, "amountPoints is required for SPEND")
+		}
+		return s.Spend(ctx, actorUserID, dto.SpendIn{
+			OperationID:  it.OperationID,
+			PublicCode:   it.PublicCode,
+			AmountPoints: *it.AmountPoints,
+			Ts:           it.Ts,
+		})
+	}
+
+	return dto.OperationOut{}, errs.New(errs.CodeInvalidBatch, "item type must be EARN or SPEND")
+}
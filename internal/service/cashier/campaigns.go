@@ -0,0 +1,54 @@
+package cashier
+
+import (
+	"context"
+	"time"
+
+	"Beanefits/internal/domain/errs"
+	"Beanefits/internal/domain/ledger"
+	"Beanefits/internal/domain/rules"
+	pg "Beanefits/internal/repository/postgres"
+	pgdto "Beanefits/internal/repository/postgres/dto"
+	"Beanefits/internal/service/mapper"
+)
+
+// applyCampaigns adds the rewards of every campaign active at opTs to the points earned under the ruleset.
+func (s *Service) applyCampaigns(ctx context.Context, tx pg.DBTX, earned ledger.Points, purchase ledger.Money, opTs time.Time) (ledger.Points, []rules.AppliedCampaign, error) {
+	if s.campaigns == nil {
+		return earned, nil, nil
+	}
+
+	rows, err := s.campaigns.ListAround(ctx, tx, opTs)
+	if err != nil {
+		return 0, nil, errs.Wrap(errs.CodeInternal, "campaigns.list_around", err)
+	}
+
+	active := make([]rules.Campaign, 0, len(rows))
+	for _, r := range rows {
+		c, err := mapper.Campaign(r)
+		if err != nil {
+			return 0, nil, errs.Wrap(errs.CodeInternal, "campaign build failed", err)
+		}
+		if c.Schedule.ActiveAt(opTs) {
+			active = append(active, c)
+		}
+	}
+
+	total, applied := rules.ApplyCampaigns(earned, purchase, active)
+	return total, applied, nil
+}
+
+// recordCampaigns links the applied campaigns to the EARN event.
+func (s *Service) recordCampaigns(ctx context.Context, tx pg.DBTX, eventID int64, applied []rules.AppliedCampaign) error {
+	for _, a := range applied {
+		err := s.campaigns.InsertApplied(ctx, tx, pgdto.EventCampaignRow{
+			EventID:     eventID,
+			CampaignID:  a.CampaignID,
+			BonusPoints: a.BonusPoints.Int(),
+		})
+		if err != nil {
+			return errs.Wrap(errs.CodeInternal, "campaigns.insert_applied", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,285 @@
+package cashier
+
+import (
+	"context"
+	"time"
+
+	"Beanefits/internal/domain/account"
+	"Beanefits/internal/domain/errs"
+	"Beanefits/internal/domain/ledger"
+	"Beanefits/internal/domain/rules"
+	pg "Beanefits/internal/repository/postgres"
+	pgdto "Beanefits/internal/repository/postgres/dto"
+	"Beanefits/internal/service/dto"
+	"Beanefits/internal/service/idempotency"
+	"Beanefits/internal/service/mapper"
+)
+
+// Checkout pays part of the bill with points and earns points on the money actually paid.
+// The points discount uses the redemption rate of the ruleset effective at the checkout ts.
+// SPEND and EARN are written in one transaction under one operationId.
+func (s *Service) Checkout(ctx context.Context, actorUserID int64, in dto.CheckoutIn) (dto.CheckoutOut, error) {
+	start := time.Now()
+	s.log.InfoContext(ctx, "cashier.checkout start", "actorUserID", actorUserID, "operationID", in.OperationID, "publicCode", in.PublicCode, "redeemPoints", in.RedeemPoints)
+
+	if _, err := account.ParsePublicCode(in.PublicCode); err != nil {
+		s.log.ErrorContext(ctx, "cashier.checkout failed", "ms", time.Since(start).Milliseconds(), "err", err)
+		return dto.CheckoutOut{}, err
+	}
+
+	opTs := s.now()
+	if in.Ts != nil {
+		opTs = *in.Ts
+	}
+
+	bill, err := parseMoney2(in.BillMoney)
+	if err != nil {
+		wrapped := errs.Wrap(errs.CodeInvalidMoney, "invalid billMoney", err)
+		s.log.ErrorContext(ctx, "cashier.checkout failed", "ms", time.Since(start).Milliseconds(), "err", wrapped)
+		return dto.CheckoutOut{}, wrapped
+	}
+	if bill.IsNegative() || bill.IsZero() {
+		e := errs.New(errs.CodeInvalidPurchaseAmount, "billMoney must be > 0")
+		s.log.ErrorContext(ctx, "cashier.checkout failed", "ms", time.Since(start).Milliseconds(), "err", e)
+		return dto.CheckoutOut{}, e
+	}
+	if in.RedeemPoints < 0 {
+		e := errs.New(errs.CodeInvalidPoints, "redeemPoints must be >= 0")
+		s.log.ErrorContext(ctx, "cashier.checkout failed", "ms", time.Since(start).Milliseconds(), "err", e)
+		return dto.CheckoutOut{}, e
+	}
+
+	var result dto.CheckoutOut
+
+	err = idempotency.WithinTx(ctx, s.txm, func(ctx context.Context, tx pg.DBTX) error {
+		accRow, ok, err := s.accounts.GetByPublicCode(ctx, tx, in.PublicCode)
+		if err != nil {
+			return errs.Wrap(errs.CodeInternal, "accounts.get_by_public_code", err)
+		}
+		if !ok {
+			return errs.New(errs.CodeAccountNotFound, "account not found")
+		}
+
+		reqJSON, err := marshalCheckoutRequest(in)
+		if err != nil {
+			return errs.Wrap(errs.CodeInternal, "marshal checkout request", err)
+		}
+
+		inserted, err := s.operations.InsertPending(ctx, tx, pgdto.OperationPendingInsert{
+			AccountID:   accRow.ID,
+			OpType:      pgdto.OpCheckout,
+			OperationID: in.OperationID,
+			RequestJSON: reqJSON,
+		})
+		if err != nil {
+			return errs.Wrap(errs.CodeInternal, "operations.insert_pending", err)
+		}
+		if !inserted {
+			if err := s.replayCached(ctx, tx, accRow.ID, pgdto.OpCheckout, in.OperationID, reqJSON, &result); err != nil {
+				return err
+			}
+			result.IdempotentReplay = true
+			return nil
+		}
+		if err := s.rejectExpired(ctx, tx, accRow.ID, pgdto.OpCheckout, in.OperationID); err != nil {
+			return err
+		}
+
+		// a pending referral may pay the referrer too; its account goes first
+		if err := s.referrals.LockReferrer(ctx, tx, accRow.ID); err != nil {
+			return err
+		}
+
+		// concurrency gate
+		lockedRow, err := s.accounts.LockByID(ctx, tx, accRow.ID)
+		if err != nil {
+			return errs.Wrap(errs.CodeInternal, "accounts.lock_by_id", err)
+		}
+
+		rs, ok, err := s.rules.GetEffectiveAt(ctx, tx, opTs)
+		if err != nil {
+			return errs.Wrap(errs.CodeInternal, "rules.get_effective_at", err)
+		}
+		if !ok {
+			return errs.New(errs.CodeInvalidRuleset, "no ruleset effective at provided ts")
+		}
+
+		redeemRate, err := ledger.ParseMoney(rs.Ruleset.RedeemRubPerPoint.String())
+		if err != nil {
+			return errs.Wrap(errs.CodeInvalidRuleset, "redeemRubPerPoint parse failed", err)
+		}
+
+		redeem := ledger.Points(in.RedeemPoints)
+		discount, err := rules.ComputeRedeemDiscount(redeem, redeemRate)
+		if err != nil {
+			return err
+		}
+		if discount.GT(bill) {
+			return s.businessErr(ctx, tx, lockedRow.ID, pgdto.OpCheckout, in.OperationID, errs.CodeRedeemExceedsBill, "points discount exceeds billMoney")
+		}
+		paid := bill.Sub(discount)
+
+		agg, err := mapper.Account(lockedRow)
+		if err != nil {
+			return errs.Wrap(errs.CodeInternal, "account aggregate build failed", err)
+		}
+
+		actor := actorUserID
+		rulesetID := rs.Ruleset.ID
+
+		// the EARN leg is priced before anything is written: spending points changes neither totalSpend nor today's earn
+		earns := paid.GT(ledger.ZeroMoney())
+		var (
+			earned     ledger.Points
+			levelAfter rules.LevelCode
+			applied    []rules.AppliedCampaign
+		)
+		if earns {
+			// earn only on the money actually paid
+			qualifying, err := s.qualifyingSpend(ctx, tx, rs, agg, opTs)
+			if err != nil {
+				return err
+			}
+
+			earnedToday, err := s.earnedToday(ctx, tx, rs, agg.ID, opTs)
+			if err != nil {
+				return err
+			}
+
+			earned, _, levelAfter, _, err = computeEarnDomain(rs, qualifying, paid, paid, earnedToday)
+			if err != nil {
+				return err
+			}
+
+			earned, applied, err = s.applyCampaigns(ctx, tx, earned, paid, opTs)
+			if err != nil {
+				return err
+			}
+		}
+
+		// both legs count against the same velocity limits as a plain SPEND and EARN;
+		// they are checked up front so a cached rejection never follows a partial write
+		if redeem > 0 {
+			if err := s.checkLimits(ctx, tx, pgdto.OpCheckout, pgdto.EventSpend, lockedRow.ID, actorUserID, redeem.Int(), in.OperationID); err != nil {
+				return err
+			}
+		}
+		if earns {
+			if err := s.checkLimits(ctx, tx, pgdto.OpCheckout, pgdto.EventEarn, lockedRow.ID, actorUserID, earned.Int(), in.OperationID); err != nil {
+				return err
+			}
+		}
+
+		var spendEv, earnEv *dto.EventOut
+
+		if redeem > 0 {
+			spentAgg, evDraft, err := agg.ApplySpend(redeem, &actor, opTs)
+			if err != nil {
+				if code, ok := errs.CodeOf(err); ok && code == errs.CodeNotEnoughBalance {
+					return s.businessErr(ctx, tx, agg.ID, pgdto.OpCheckout, in.OperationID, errs.CodeNotEnoughBalance, "not enough balance")
+				}
+				return err
+			}
+
+			evRow, err := s.events.Insert(ctx, tx, mapper.EventInsert(evDraft))
+			if err != nil {
+				return errs.Wrap(errs.CodeInternal, "events.insert", err)
+			}
+
+			// oldest points go first
+			if err := s.lots.Debit(ctx, tx, agg.ID, redeem, nil); err != nil {
+				return err
+			}
+
+			ev := mapper.EventOut(evRow)
+			spendEv = &ev
+			agg = spentAgg
+		}
+
+		if earns {
+			earnedAgg, evDraft, err := agg.ApplyEarn(earned, paid, levelAfter, &rulesetID, &actor, opTs)
+			if err != nil {
+				return err
+			}
+
+			evRow, err := s.events.Insert(ctx, tx, mapper.EventInsert(evDraft))
+			if err != nil {
+				return errs.Wrap(errs.CodeInternal, "events.insert", err)
+			}
+			if err := s.recordCampaigns(ctx, tx, evRow.ID, applied); err != nil {
+				return err
+			}
+
+			if err := s.lots.Credit(ctx, tx, agg.ID, evRow.ID, earned, opTs); err != nil {
+				return err
+			}
+
+			ev := mapper.EventOut(evRow)
+			ev.Campaigns = mapper.AppliedCampaigns(applied)
+			earnEv = &ev
+			agg = earnedAgg
+		}
+
+		// balance, totalSpend and level in one update for both legs
+		updatedRow, err := s.accounts.UpdateAfterEarn(
+			ctx, tx,
+			agg.ID,
+			agg.Balance.Int(),
+			pgdto.Money(agg.TotalSpend.Decimal()),
+			string(agg.LevelCode),
+		)
+		if err != nil {
+			return errs.Wrap(errs.CodeInternal, "accounts.update_after_earn", err)
+		}
+
+		var levelChange *dto.LevelChangeOut
+		if earnEv != nil {
+			updatedRow, levelChange, err = s.recordLevelChange(ctx, tx, updatedRow, lockedRow.LevelCode, rs, actorUserID, earnEv.ID, opTs)
+			if err != nil {
+				return err
+			}
+			updatedRow, err = s.referrals.Reward(ctx, tx, updatedRow, rs.Ruleset, earnEv.ID, paid, opTs)
+			if err != nil {
+				return err
+			}
+		}
+
+		result = dto.CheckoutOut{
+			OperationID:      in.OperationID,
+			OpType:           dto.OpCheckout,
+			BillMoney:        bill.Decimal().StringFixed(2),
+			DiscountMoney:    discount.Decimal().StringFixed(2),
+			PaidMoney:        paid.Decimal().StringFixed(2),
+			SpendEvent:       spendEv,
+			EarnEvent:        earnEv,
+			Balance:          mapper.BalanceOut(updatedRow, s.now()),
+			IdempotentReplay: false,
+			LevelChange:      levelChange,
+		}
+
+		// the EARN (purchase) is the main event; refunds reference it
+		var eventID *int64
+		if earnEv != nil {
+			eventID = &earnEv.ID
+		} else if spendEv != nil {
+			eventID = &spendEv.ID
+		}
+		return s.finalizeCached(ctx, tx, updatedRow.ID, pgdto.OpCheckout, in.OperationID, result, eventID)
+	})
+
+	if err != nil {
+		s.log.ErrorContext(ctx, "cashier.checkout failed", "ms", time.Since(start).Milliseconds(), "err", err)
+		return dto.CheckoutOut{}, err
+	}
+
+	s.log.InfoContext(ctx, "cashier.checkout ok",
+		"ms", time.Since(start).Milliseconds(),
+		"operationID", in.OperationID,
+		"publicCode", in.PublicCode,
+		"discountMoney", result.DiscountMoney,
+		"paidMoney", result.PaidMoney,
+		"replay", result.IdempotentReplay,
+	)
+
+	return result, nil
+}
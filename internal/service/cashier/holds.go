@@ -0,0 +1,408 @@
+package cashier
+
+import (
+	"context"
+	"time"
+
+	"Beanefits/internal/domain/account"
+	"Beanefits/internal/domain/errs"
+	"Beanefits/internal/domain/ledger"
+	pg "Beanefits/internal/repository/postgres"
+	pgdto "Beanefits/internal/repository/postgres/dto"
+	"Beanefits/internal/service/dto"
+	"Beanefits/internal/service/mapper"
+)
+
+// AuthorizeHold reserves points until CaptureHold/ReleaseHold or until the hold TTL passes.
+// Held points stay in the ledger balance but are no longer available for spending.
+// Idempotent by (account + operationId): a retry returns the same hold, a different request fails with IDEMPOTENCY_KEY_REUSED.
+func (s *Service) AuthorizeHold(ctx context.Context, actorUserID int64, in dto.HoldAuthorizeIn) (dto.HoldResultOut, error) {
+	start := time.Now()
+	s.log.InfoContext(ctx, "cashier.authorize_hold start", "actorUserID", actorUserID, "operationID", in.OperationID, "points", in.Points)
+
+	if _, err := account.ParsePublicCode(in.PublicCode); err != nil {
+		s.log.ErrorContext(ctx, "cashier.authorize_hold failed", "ms", time.Since(start).Milliseconds(), "err", err)
+		return dto.HoldResultOut{}, err
+	}
+
+	if in.Points <= 0 {
+		e := errs.New(errs.CodeInvalidPoints, "points must be > 0")
+		s.log.ErrorContext(ctx, "cashier.authorize_hold failed", "ms", time.Since(start).Milliseconds(), "err", e)
+		return dto.HoldResultOut{}, e
+	}
+
+	now := s.now()
+	var result dto.HoldResultOut
+
+	err := s.txm.WithinTx(ctx, func(ctx context.Context, tx pg.DBTX) error {
+		accRow, ok, err := s.accounts.GetByPublicCode(ctx, tx, in.PublicCode)
+		if err != nil {
+			return errs.Wrap(errs.CodeInternal, "accounts.get_by_public_code", err)
+		}
+		if !ok {
+			return errs.New(errs.CodeAccountNotFound, "account not found")
+		}
+
+		reqJSON, err := marshalHoldAuthorizeRequest(in)
+		if err != nil {
+			return errs.Wrap(errs.CodeInternal, "marshal hold authorize request", err)
+		}
+
+		inserted, err := s.operations.InsertPending(ctx, tx, pgdto.OperationPendingInsert{
+			AccountID:   accRow.ID,
+			OpType:      pgdto.OpHoldAuthorize,
+			OperationID: in.OperationID,
+			RequestJSON: reqJSON,
+		})
+		if err != nil {
+			return errs.Wrap(errs.CodeInternal, "operations.insert_pending", err)
+		}
+		if !inserted {
+			if err := s.replayCached(ctx, tx, accRow.ID, pgdto.OpHoldAuthorize, in.OperationID, reqJSON, &result); err != nil {
+				return err
+			}
+			result.IdempotentReplay = true
+			return nil
+		}
+		if err := s.rejectExpired(ctx, tx, accRow.ID, pgdto.OpHoldAuthorize, in.OperationID); err != nil {
+			return err
+		}
+
+		// concurrency gate: held points change only under the account lock
+		lockedRow, err := s.accounts.LockByID(ctx, tx, accRow.ID)
+		if err != nil {
+			return errs.Wrap(errs.CodeInternal, "accounts.lock_by_id", err)
+		}
+
+		// a hold authorized before operations kept hold requests has no record: match it on the hold itself
+		existing, found, err := s.holds.GetByOperation(ctx, tx, lockedRow.ID, in.OperationID)
+		if err != nil {
+			return errs.Wrap(errs.CodeInternal, "holds.get_by_operation", err)
+		}
+		if found {
+			if existing.Points != in.Points {
+				return errs.New(errs.CodeIdempotencyKeyReused, "operationId was already used with a different request")
+			}
+			result = dto.HoldResultOut{
+				Hold:    mapper.HoldOut(existing),
+				Balance: mapper.BalanceOut(lockedRow, now),
+			}
+			if err := s.finalizeCached(ctx, tx, lockedRow.ID, pgdto.OpHoldAuthorize, in.OperationID, result, nil); err != nil {
+				return err
+			}
+			result.IdempotentReplay = true
+			return nil
+		}
+
+		agg, err := mapper.Account(lockedRow)
+		if err != nil {
+			return errs.Wrap(errs.CodeInternal, "account aggregate build failed", err)
+		}
+
+		// a hold is captured as a SPEND: refuse to reserve points the SPEND velocity limits would not let it capture
+		msg, exceeded, err := s.velocityExceeded(ctx, tx, pgdto.EventSpend, lockedRow.ID, actorUserID, in.Points)
+		if err != nil {
+			return err
+		}
+		if exceeded {
+			return errs.New(errs.CodeVelocityLimitExceeded, msg)
+		}
+
+		updatedAgg, err := agg.ApplyAuthorizeHold(ledger.Points(in.Points))
+		if err != nil {
+			return err
+		}
+
+		actor := actorUserID
+		hold, inserted, err := s.holds.Insert(ctx, tx, pgdto.HoldInsert{
+			AccountID:   lockedRow.ID,
+			OperationID: in.OperationID,
+			Points:      in.Points,
+			ExpiresAt:   now.Add(s.holdTTL),
+			ActorUserID: &actor,
+		})
+		if err != nil {
+			return errs.Wrap(errs.CodeInternal, "holds.insert", err)
+		}
+		if !inserted {
+			// cannot happen under the account lock after GetByOperation
+			return errs.New(errs.CodeInternal, "hold already exists")
+		}
+
+		updatedRow, err := s.accounts.SetHeld(ctx, tx, updatedAgg.ID, updatedAgg.Held.Int())
+		if err != nil {
+			return errs.Wrap(errs.CodeInternal, "accounts.set_held", err)
+		}
+
+		result = dto.HoldResultOut{
+			Hold:    mapper.HoldOut(hold),
+			Balance: mapper.BalanceOut(updatedRow, now),
+		}
+		return s.finalizeCached(ctx, tx, updatedRow.ID, pgdto.OpHoldAuthorize, in.OperationID, result, nil)
+	})
+
+	if err != nil {
+		s.log.ErrorContext(ctx, "cashier.authorize_hold failed", "ms", time.Since(start).Milliseconds(), "err", err)
+		return dto.HoldResultOut{}, err
+	}
+
+	s.log.InfoContext(ctx, "cashier.authorize_hold ok",
+		"ms", time.Since(start).Milliseconds(),
+		"holdID", result.Hold.ID,
+		"replay", result.IdempotentReplay,
+	)
+
+	return result, nil
+}
+
+// CaptureHold spends the held points (or part of them, the rest is released) with a SPEND event.
+// Idempotent by hold: capturing an already captured hold with the same points returns it again,
+// other points fail with IDEMPOTENCY_KEY_REUSED. The capture is keyed by the hold's operationId.
+func (s *Service) CaptureHold(ctx context.Context, actorUserID int64, in dto.HoldCaptureIn) (dto.HoldResultOut, error) {
+	start := time.Now()
+	s.log.InfoContext(ctx, "cashier.capture_hold start", "actorUserID", actorUserID, "holdID", in.HoldID, "points", in.Points)
+
+	if in.Points != nil && *in.Points <= 0 {
+		e := errs.New(errs.CodeInvalidPoints, "points must be > 0")
+		s.log.ErrorContext(ctx, "cashier.capture_hold failed", "ms", time.Since(start).Milliseconds(), "err", e)
+		return dto.HoldResultOut{}, e
+	}
+
+	now := s.now()
+	var result dto.HoldResultOut
+
+	err := s.txm.WithinTx(ctx, func(ctx context.Context, tx pg.DBTX) error {
+		hold, lockedRow, err := s.lockHold(ctx, tx, in.HoldID)
+		if err != nil {
+			return err
+		}
+
+		captured := hold.Points
+		if in.Points != nil {
+			captured = *in.Points
+		}
+
+		reqJSON, err := marshalHoldCaptureRequest(hold.ID, captured)
+		if err != nil {
+			return errs.Wrap(errs.CodeInternal, "marshal hold capture request", err)
+		}
+
+		inserted, err := s.operations.InsertPending(ctx, tx, pgdto.OperationPendingInsert{
+			AccountID:   lockedRow.ID,
+			OpType:      pgdto.OpHoldCapture,
+			OperationID: hold.OperationID,
+			RequestJSON: reqJSON,
+		})
+		if err != nil {
+			return errs.Wrap(errs.CodeInternal, "operations.insert_pending", err)
+		}
+		if !inserted {
+			if err := s.replayCached(ctx, tx, lockedRow.ID, pgdto.OpHoldCapture, hold.OperationID, reqJSON, &result); err != nil {
+				return err
+			}
+			result.IdempotentReplay = true
+			return nil
+		}
+		if err := s.rejectExpired(ctx, tx, lockedRow.ID, pgdto.OpHoldCapture, hold.OperationID); err != nil {
+			return err
+		}
+
+		switch hold.Status {
+		case pgdto.HoldCaptured:
+			// captured before operations kept capture requests: match it on the hold itself
+			if hold.CaptureEventID == nil {
+				return errs.New(errs.CodeInternal, "captured hold has no event")
+			}
+			if hold.CapturedPoints == nil || *hold.CapturedPoints != captured {
+				return errs.New(errs.CodeIdempotencyKeyReused, "hold was already captured with different points")
+			}
+			evRow, ok, err := s.events.GetByID(ctx, tx, *hold.CaptureEventID)
+			if err != nil {
+				return errs.Wrap(errs.CodeInternal, "events.get_by_id", err)
+			}
+			if !ok {
+				return errs.New(errs.CodeInternal, "capture event not found")
+			}
+			ev := mapper.EventOut(evRow)
+			result = dto.HoldResultOut{
+				Hold:    mapper.HoldOut(hold),
+				Event:   &ev,
+				Balance: mapper.BalanceOut(lockedRow, now),
+			}
+			if err := s.finalizeCached(ctx, tx, lockedRow.ID, pgdto.OpHoldCapture, hold.OperationID, result, &evRow.ID); err != nil {
+				return err
+			}
+			result.IdempotentReplay = true
+			return nil
+		case pgdto.HoldActive:
+		default:
+			return errs.New(errs.CodeHoldNotActive, "hold is "+string(hold.Status))
+		}
+		if !now.Before(hold.ExpiresAt) {
+			return errs.New(errs.CodeHoldExpired, "hold has expired")
+		}
+
+		agg, err := mapper.Account(lockedRow)
+		if err != nil {
+			return errs.Wrap(errs.CodeInternal, "account aggregate build failed", err)
+		}
+
+		// a capture is a SPEND and counts against the SPEND velocity limits
+		msg, exceeded, err := s.velocityExceeded(ctx, tx, pgdto.EventSpend, lockedRow.ID, actorUserID, captured)
+		if err != nil {
+			return err
+		}
+		if exceeded {
+			return errs.New(errs.CodeVelocityLimitExceeded, msg)
+		}
+
+		actor := actorUserID
+		updatedAgg, evDraft, err := agg.ApplyCaptureHold(ledger.Points(hold.Points), ledger.Points(captured), &actor, now)
+		if err != nil {
+			return err
+		}
+
+		evRow, err := s.events.Insert(ctx, tx, mapper.EventInsert(evDraft))
+		if err != nil {
+			return errs.Wrap(errs.CodeInternal, "events.insert", err)
+		}
+
+		// oldest points go first, as for a regular SPEND
+		if err := s.lots.Debit(ctx, tx, updatedAgg.ID, ledger.Points(captured), nil); err != nil {
+			return err
+		}
+
+		if _, err := s.accounts.UpdateAfterSpend(ctx, tx, updatedAgg.ID, updatedAgg.Balance.Int()); err != nil {
+			return errs.Wrap(errs.CodeInternal, "accounts.update_after_spend", err)
+		}
+		updatedRow, err := s.accounts.SetHeld(ctx, tx, updatedAgg.ID, updatedAgg.Held.Int())
+		if err != nil {
+			return errs.Wrap(errs.CodeInternal, "accounts.set_held", err)
+		}
+
+		eventID := evRow.ID
+		closed, err := s.holds.Close(ctx, tx, pgdto.HoldClose{
+			ID:             hold.ID,
+			Status:         pgdto.HoldCaptured,
+			CapturedPoints: &captured,
+			CaptureEventID: &eventID,
+			ClosedAt:       now,
+		})
+		if err != nil {
+			return errs.Wrap(errs.CodeInternal, "holds.close", err)
+		}
+
+		ev := mapper.EventOut(evRow)
+		result = dto.HoldResultOut{
+			Hold:    mapper.HoldOut(closed),
+			Event:   &ev,
+			Balance: mapper.BalanceOut(updatedRow, now),
+		}
+		return s.finalizeCached(ctx, tx, updatedRow.ID, pgdto.OpHoldCapture, hold.OperationID, result, &eventID)
+	})
+
+	if err != nil {
+		s.log.ErrorContext(ctx, "cashier.capture_hold failed", "ms", time.Since(start).Milliseconds(), "err", err)
+		return dto.HoldResultOut{}, err
+	}
+
+	s.log.InfoContext(ctx, "cashier.capture_hold ok",
+		"ms", time.Since(start).Milliseconds(),
+		"holdID", result.Hold.ID,
+		"replay", result.IdempotentReplay,
+	)
+
+	return result, nil
+}
+
+// ReleaseHold cancels a hold and makes its points available again. Releasing twice returns the hold again.
+func (s *Service) ReleaseHold(ctx context.Context, actorUserID int64, holdID int64) (dto.HoldResultOut, error) {
+	start := time.Now()
+	s.log.InfoContext(ctx, "cashier.release_hold start", "actorUserID", actorUserID, "holdID", holdID)
+
+	now := s.now()
+	var result dto.HoldResultOut
+
+	err := s.txm.WithinTx(ctx, func(ctx context.Context, tx pg.DBTX) error {
+		hold, lockedRow, err := s.lockHold(ctx, tx, holdID)
+		if err != nil {
+			return err
+		}
+
+		switch hold.Status {
+		case pgdto.HoldReleased:
+			result = dto.HoldResultOut{
+				Hold:             mapper.HoldOut(hold),
+				Balance:          mapper.BalanceOut(lockedRow, now),
+				IdempotentReplay: true,
+			}
+			return nil
+		case pgdto.HoldExpired:
+			return errs.New(errs.CodeHoldExpired, "hold has expired")
+		case pgdto.HoldActive:
+		default:
+			return errs.New(errs.CodeHoldNotActive, "hold is "+string(hold.Status))
+		}
+
+		agg, err := mapper.Account(lockedRow)
+		if err != nil {
+			return errs.Wrap(errs.CodeInternal, "account aggregate build failed", err)
+		}
+		updatedAgg := agg.ApplyReleaseHold(ledger.Points(hold.Points))
+
+		updatedRow, err := s.accounts.SetHeld(ctx, tx, updatedAgg.ID, updatedAgg.Held.Int())
+		if err != nil {
+			return errs.Wrap(errs.CodeInternal, "accounts.set_held", err)
+		}
+
+		closed, err := s.holds.Close(ctx, tx, pgdto.HoldClose{
+			ID:       hold.ID,
+			Status:   pgdto.HoldReleased,
+			ClosedAt: now,
+		})
+		if err != nil {
+			return errs.Wrap(errs.CodeInternal, "holds.close", err)
+		}
+
+		result = dto.HoldResultOut{
+			Hold:    mapper.HoldOut(closed),
+			Balance: mapper.BalanceOut(updatedRow, now),
+		}
+		return nil
+	})
+
+	if err != nil {
+		s.log.ErrorContext(ctx, "cashier.release_hold failed", "ms", time.Since(start).Milliseconds(), "err", err)
+		return dto.HoldResultOut{}, err
+	}
+
+	s.log.InfoContext(ctx, "cashier.release_hold ok",
+		"ms", time.Since(start).Milliseconds(),
+		"holdID", result.Hold.ID,
+		"replay", result.IdempotentReplay,
+	)
+
+	return result, nil
+}
+
+// lockHold locks the hold's account and re-reads the hold, so its status cannot change underneath.
+func (s *Service) lockHold(ctx context.Context, tx pg.DBTX, holdID int64) (pgdto.HoldRow, pgdto.AccountRow, error) {
+	hold, ok, err := s.holds.GetByID(ctx, tx, holdID)
+	if err != nil {
+		return pgdto.HoldRow{}, pgdto.AccountRow{}, errs.Wrap(errs.CodeInternal, "holds.get_by_id", err)
+	}
+	if !ok {
+		return pgdto.HoldRow{}, pgdto.AccountRow{}, errs.New(errs.CodeHoldNotFound, "hold not found")
+	}
+
+	lockedRow, err := s.accounts.LockByID(ctx, tx, hold.AccountID)
+	if err != nil {
+		return pgdto.HoldRow{}, pgdto.AccountRow{}, errs.Wrap(errs.CodeInternal, "accounts.lock_by_id", err)
+	}
+
+	hold, _, err = s.holds.GetByID(ctx, tx, holdID)
+	if err != nil {
+		return pgdto.HoldRow{}, pgdto.AccountRow{}, errs.Wrap(errs.CodeInternal, "holds.get_by_id", err)
+	}
+	return hold, lockedRow, nil
+}
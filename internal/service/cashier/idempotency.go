@@ -3,110 +3,198 @@ package cashier
 import (
 	"context"
 	"encoding/json"
-	"errors"
 	"time"
 
 	"Beanefits/internal/domain/errs"
 	pg "Beanefits/internal/repository/postgres"
 	pgdto "Beanefits/internal/repository/postgres/dto"
 	"Beanefits/internal/service/dto"
+	"Beanefits/internal/service/idempotency"
 )
 
-type opErrCache struct {
-	Code errs.Code `json:"code"`
-	Msg  string    `json:"msg"`
-}
-
-func (s *Service) replayOperation(ctx context.Context, tx pg.DBTX, accountID int64, opType pgdto.OperationType, operationID string, out *dto.OperationOut) error {
-	rec, ok, err := s.operations.Get(ctx, tx, accountID, opType, operationID)
-	if err != nil {
-		return errs.Wrap(errs.CodeInternal, "operations.get", err)
-	}
-	if !ok || rec.HTTPStatus == nil || rec.ResponseJSON == nil {
-		return errs.Wrap(errs.CodeInternal, "idempotency record incomplete", errors.New("missing cached response"))
-	}
-
-	if *rec.HTTPStatus != 200 {
-		var cached opErrCache
-		if err := json.Unmarshal(*rec.ResponseJSON, &cached); err != nil {
-			return errs.Wrap(errs.CodeInternal, "unmarshal cached error", err)
-		}
-		if cached.Code == "" {
-			return errs.Wrap(errs.CodeInternal, "cached error code missing", errors.New("invalid cache"))
-		}
-		return errs.New(cached.Code, cached.Msg)
-	}
-
-	if err := json.Unmarshal(*rec.ResponseJSON, out); err != nil {
-		return errs.Wrap(errs.CodeInternal, "unmarshal cached success", err)
+func (s *Service) replayOperation(ctx context.Context, tx pg.DBTX, accountID int64, opType pgdto.OperationType, operationID string, request pgdto.JSON, out *dto.OperationOut) error {
+	if err := s.replayCached(ctx, tx, accountID, opType, operationID, request, out); err != nil {
+		return err
 	}
 	out.IdempotentReplay = true
 	return nil
 }
 
+// replayCached returns the cached business error or decodes the cached success response into out.
+// A retry whose request differs from the stored one fails with IDEMPOTENCY_KEY_REUSED.
+func (s *Service) replayCached(ctx context.Context, tx pg.DBTX, accountID int64, opType pgdto.OperationType, operationID string, request pgdto.JSON, out any) error {
+	return idempotency.Replay(ctx, s.operations, tx, accountID, opType, operationID, request, out)
+}
+
+func (s *Service) rejectExpired(ctx context.Context, tx pg.DBTX, accountID int64, opType pgdto.OperationType, operationID string) error {
+	return idempotency.RejectExpired(ctx, s.operations, tx, accountID, opType, operationID)
+}
+
 func (s *Service) finalizeOK(ctx context.Context, tx pg.DBTX, accountID int64, opType pgdto.OperationType, operationID string, out dto.OperationOut) error {
 	// Canonical persisted payload should not include per-response replay flag.
 	out.IdempotentReplay = false
 
-	b, err := json.Marshal(out)
-	if err != nil {
-		return errs.Wrap(errs.CodeInternal, "marshal operation out", err)
-	}
+	eventID := out.Event.ID
+	return s.finalizeCached(ctx, tx, accountID, opType, operationID, out, &eventID)
+}
 
-	return s.operations.Finalize(ctx, tx, pgdto.OperationFinalize{
-		AccountID:    accountID,
-		OpType:       opType,
-		OperationID:  operationID,
-		HTTPStatus:   200,
-		ResponseJSON: json.RawMessage(b),
-	})
+// finalizeCached stores a success response for replay; eventID is the main event written by the operation.
+func (s *Service) finalizeCached(ctx context.Context, tx pg.DBTX, accountID int64, opType pgdto.OperationType, operationID string, out any, eventID *int64) error {
+	return idempotency.Finalize(ctx, s.operations, tx, accountID, opType, operationID, out, eventID)
 }
 
-func (s *Service) finalizeErr(ctx context.Context, tx pg.DBTX, accountID int64, opType pgdto.OperationType, operationID string, code errs.Code, msg string) error {
-	b, err := json.Marshal(opErrCache{Code: code, Msg: msg})
-	if err != nil {
-		return errs.Wrap(errs.CodeInternal, "marshal cached error", err)
+// businessErr caches a business error for replay and returns it; see idempotency.BusinessErr.
+func (s *Service) businessErr(ctx context.Context, tx pg.DBTX, accountID int64, opType pgdto.OperationType, operationID string, code errs.Code, msg string) error {
+	return idempotency.BusinessErr(ctx, s.operations, tx, accountID, opType, operationID, code, msg)
+}
+
+// The marshal*Request helpers build the canonical request compared on replay:
+// money is normalized to 2 fraction digits and ts is kept only when the client sent it,
+// so a retry without ts matches although the server clock has moved on.
+
+func marshalEarnRequest(in dto.EarnIn) (pgdto.JSON, error) {
+	type line struct {
+		SKU       string `json:"sku"`
+		Category  string `json:"category"`
+		Quantity  int    `json:"quantity"`
+		LineTotal string `json:"lineTotal"`
+	}
+	type req struct {
+		OperationID string     `json:"operationId"`
+		PublicCode  string     `json:"publicCode"`
+		AmountMoney string     `json:"amountMoney"`
+		Ts          *time.Time `json:"ts,omitempty"`
+		Lines       []line     `json:"lines,omitempty"`
+	}
+	r := req{
+		OperationID: in.OperationID,
+		PublicCode:  in.PublicCode,
+		AmountMoney: canonicalMoney(in.AmountMoney),
+		Ts:          canonicalTs(in.Ts),
 	}
+	for _, l := range in.Lines {
+		r.Lines = append(r.Lines, line{
+			SKU:       l.SKU,
+			Category:  l.Category,
+			Quantity:  l.Quantity,
+			LineTotal: canonicalMoney(l.LineTotal),
+		})
+	}
+	b, err := json.Marshal(r)
+	return pgdto.JSON(b), err
+}
 
-	// For MVP: store “transport-ish” status approximation for replay logic.
-	status := 409
-	return s.operations.Finalize(ctx, tx, pgdto.OperationFinalize{
-		AccountID:    accountID,
-		OpType:       opType,
-		OperationID:  operationID,
-		HTTPStatus:   status,
-		ResponseJSON: json.RawMessage(b),
+func marshalSpendRequest(in dto.SpendIn) (pgdto.JSON, error) {
+	type req struct {
+		OperationID  string     `json:"operationId"`
+		PublicCode   string     `json:"publicCode"`
+		AmountPoints int        `json:"amountPoints"`
+		Ts           *time.Time `json:"ts,omitempty"`
+	}
+	b, err := json.Marshal(req{
+		OperationID:  in.OperationID,
+		PublicCode:   in.PublicCode,
+		AmountPoints: in.AmountPoints,
+		Ts:           canonicalTs(in.Ts),
 	})
+	return pgdto.JSON(b), err
 }
 
-func marshalEarnRequest(in dto.EarnIn, ts time.Time) (pgdto.JSON, error) {
+func marshalRefundRequest(in dto.RefundIn) (pgdto.JSON, error) {
 	type req struct {
-		OperationID string    `json:"operationId"`
-		PublicCode  string    `json:"publicCode"`
-		AmountMoney string    `json:"amountMoney"`
-		Ts          time.Time `json:"ts"`
+		OperationID string     `json:"operationId"`
+		PublicCode  string     `json:"publicCode"`
+		EventID     int64      `json:"eventId"`
+		AmountMoney *string    `json:"amountMoney,omitempty"`
+		Ts          *time.Time `json:"ts,omitempty"`
+	}
+	var amount *string
+	if in.AmountMoney != nil {
+		a := canonicalMoney(*in.AmountMoney)
+		amount = &a
 	}
 	b, err := json.Marshal(req{
 		OperationID: in.OperationID,
 		PublicCode:  in.PublicCode,
-		AmountMoney: in.AmountMoney,
-		Ts:          ts,
+		EventID:     in.EventID,
+		AmountMoney: amount,
+		Ts:          canonicalTs(in.Ts),
+	})
+	return pgdto.JSON(b), err
+}
+
+func marshalVoidRequest(in dto.VoidIn) (pgdto.JSON, error) {
+	type req struct {
+		OperationID      string     `json:"operationId"`
+		PublicCode       string     `json:"publicCode"`
+		SpendOperationID string     `json:"spendOperationId"`
+		Ts               *time.Time `json:"ts,omitempty"`
+	}
+	b, err := json.Marshal(req{
+		OperationID:      in.OperationID,
+		PublicCode:       in.PublicCode,
+		SpendOperationID: in.SpendOperationID,
+		Ts:               canonicalTs(in.Ts),
 	})
 	return pgdto.JSON(b), err
 }
 
-func marshalSpendRequest(in dto.SpendIn, ts time.Time) (pgdto.JSON, error) {
+func marshalCheckoutRequest(in dto.CheckoutIn) (pgdto.JSON, error) {
 	type req struct {
-		OperationID  string    `json:"operationId"`
-		PublicCode   string    `json:"publicCode"`
-		AmountPoints int       `json:"amountPoints"`
-		Ts           time.Time `json:"ts"`
+		OperationID  string     `json:"operationId"`
+		PublicCode   string     `json:"publicCode"`
+		BillMoney    string     `json:"billMoney"`
+		RedeemPoints int        `json:"redeemPoints"`
+		Ts           *time.Time `json:"ts,omitempty"`
 	}
 	b, err := json.Marshal(req{
 		OperationID:  in.OperationID,
 		PublicCode:   in.PublicCode,
-		AmountPoints: in.AmountPoints,
-		Ts:           ts,
+		BillMoney:    canonicalMoney(in.BillMoney),
+		RedeemPoints: in.RedeemPoints,
+		Ts:           canonicalTs(in.Ts),
+	})
+	return pgdto.JSON(b), err
+}
+
+func marshalHoldAuthorizeRequest(in dto.HoldAuthorizeIn) (pgdto.JSON, error) {
+	type req struct {
+		OperationID string `json:"operationId"`
+		PublicCode  string `json:"publicCode"`
+		Points      int    `json:"points"`
+	}
+	b, err := json.Marshal(req{
+		OperationID: in.OperationID,
+		PublicCode:  in.PublicCode,
+		Points:      in.Points,
 	})
 	return pgdto.JSON(b), err
 }
+
+// marshalHoldCaptureRequest takes the resolved points, so a capture without points matches one of the whole hold.
+func marshalHoldCaptureRequest(holdID int64, points int) (pgdto.JSON, error) {
+	type req struct {
+		HoldID int64 `json:"holdId"`
+		Points int   `json:"points"`
+	}
+	b, err := json.Marshal(req{HoldID: holdID, Points: points})
+	return pgdto.JSON(b), err
+}
+
+// canonicalMoney makes "10", "10.0" and "10.00" the same request; unparsable input is kept as is.
+func canonicalMoney(s string) string {
+	m, err := parseMoney2(s)
+	if err != nil {
+		return s
+	}
+	return m.Decimal().StringFixed(2)
+}
+
+// canonicalTs makes the same instant in different offsets the same request.
+func canonicalTs(ts *time.Time) *time.Time {
+	if ts == nil {
+		return nil
+	}
+	t := ts.UTC()
+	return &t
+}
@@ -0,0 +1,37 @@
+package cashier
+
+import (
+	"context"
+	"time"
+
+	pg "Beanefits/internal/repository/postgres"
+	pgdto "Beanefits/internal/repository/postgres/dto"
+	"Beanefits/internal/service/dto"
+)
+
+// recordLevelChange writes the LEVEL_CHANGED event of an operation that moved the account off level from;
+// updated is the account after the operation, refEventID the operation's event.
+func (s *Service) recordLevelChange(
+	ctx context.Context,
+	tx pg.DBTX,
+	updated pgdto.AccountRow,
+	from string,
+	rs pgdto.RulesetWithLevels,
+	actorUserID int64,
+	refEventID int64,
+	ts time.Time,
+) (pgdto.AccountRow, *dto.LevelChangeOut, error) {
+	actor := actorUserID
+	ref := refEventID
+	acc, ch, err := s.levels.Record(ctx, tx, updated, from, rs, &actor, &ref, ts)
+	if err != nil || ch == nil {
+		return acc, nil, err
+	}
+	return acc, &dto.LevelChangeOut{
+		EventID:      ch.EventID,
+		FromLevel:    ch.FromLevel,
+		ToLevel:      ch.ToLevel,
+		BonusPoints:  ch.BonusPoints,
+		BonusEventID: ch.BonusEventID,
+	}, nil
+}
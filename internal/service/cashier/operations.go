@@ -12,6 +12,8 @@ import (
 	pg "Beanefits/internal/repository/postgres"
 	pgdto "Beanefits/internal/repository/postgres/dto"
 	"Beanefits/internal/service/dto"
+	"Beanefits/internal/service/idempotency"
+	"Beanefits/internal/service/limits"
 	"Beanefits/internal/service/mapper"
 )
 
@@ -41,9 +43,15 @@ func (s *Service) Earn(ctx context.Context, actorUserID int64, in dto.EarnIn) (d
 		return dto.OperationOut{}, e
 	}
 
+	lines, err := parseReceipt(in.Lines, purchase)
+	if err != nil {
+		s.log.ErrorContext(ctx, "cashier.earn failed", "ms", time.Since(start).Milliseconds(), "err", err)
+		return dto.OperationOut{}, err
+	}
+
 	var result dto.OperationOut
 
-	err = s.txm.WithinTx(ctx, func(ctx context.Context, tx pg.DBTX) error {
+	err = idempotency.WithinTx(ctx, s.txm, func(ctx context.Context, tx pg.DBTX) error {
 		accRow, ok, err := s.accounts.GetByPublicCode(ctx, tx, in.PublicCode)
 		if err != nil {
 			return errs.Wrap(errs.CodeInternal, "accounts.get_by_public_code", err)
@@ -52,7 +60,7 @@ func (s *Service) Earn(ctx context.Context, actorUserID int64, in dto.EarnIn) (d
 			return errs.New(errs.CodeAccountNotFound, "account not found")
 		}
 
-		reqJSON, err := marshalEarnRequest(in, opTs)
+		reqJSON, err := marshalEarnRequest(in)
 		if err != nil {
 			return errs.Wrap(errs.CodeInternal, "marshal earn request", err)
 		}
@@ -68,7 +76,15 @@ func (s *Service) Earn(ctx context.Context, actorUserID int64, in dto.EarnIn) (d
 		}
 		if !inserted {
 			// idempotent replay
-			return s.replayOperation(ctx, tx, accRow.ID, pgdto.OpEarn, in.OperationID, &result)
+			return s.replayOperation(ctx, tx, accRow.ID, pgdto.OpEarn, in.OperationID, reqJSON, &result)
+		}
+		if err := s.rejectExpired(ctx, tx, accRow.ID, pgdto.OpEarn, in.OperationID); err != nil {
+			return err
+		}
+
+		// a pending referral may pay the referrer too; its account goes first
+		if err := s.referrals.LockReferrer(ctx, tx, accRow.ID); err != nil {
+			return err
 		}
 
 		// concurrency gate
@@ -85,16 +101,40 @@ func (s *Service) Earn(ctx context.Context, actorUserID int64, in dto.EarnIn) (d
 			return errs.New(errs.CodeInvalidRuleset, "no ruleset effective at provided ts")
 		}
 
-		agg, err := accountFromRow(lockedRow)
+		agg, err := mapper.Account(lockedRow)
 		if err != nil {
 			return errs.Wrap(errs.CodeInternal, "account aggregate build failed", err)
 		}
 
-		earned, levelBefore, levelAfter, baseRubPerPoint, err := computeEarnDomain(rs, agg.TotalSpend, purchase)
+		qualifying, err := s.qualifyingSpend(ctx, tx, rs, agg, opTs)
 		if err != nil {
 			return err
 		}
 
+		earnable, err := earnableAmount(rs, lines, purchase)
+		if err != nil {
+			return err
+		}
+
+		earnedToday, err := s.earnedToday(ctx, tx, rs, lockedRow.ID, opTs)
+		if err != nil {
+			return err
+		}
+
+		earned, _, levelAfter, _, err := computeEarnDomain(rs, qualifying, purchase, earnable, earnedToday)
+		if err != nil {
+			return err
+		}
+
+		earned, applied, err := s.applyCampaigns(ctx, tx, earned, purchase, opTs)
+		if err != nil {
+			return err
+		}
+
+		if err := s.checkLimits(ctx, tx, pgdto.OpEarn, pgdto.EventEarn, lockedRow.ID, actorUserID, earned.Int(), in.OperationID); err != nil {
+			return err
+		}
+
 		actor := actorUserID
 		rulesetID := rs.Ruleset.ID
 
@@ -110,10 +150,20 @@ func (s *Service) Earn(ctx context.Context, actorUserID int64, in dto.EarnIn) (d
 			return err
 		}
 
-		evRow, err := s.events.Insert(ctx, tx, eventInsertFromDraft(evDraft))
+		evRow, err := s.events.Insert(ctx, tx, mapper.EventInsert(evDraft))
 		if err != nil {
 			return errs.Wrap(errs.CodeInternal, "events.insert", err)
 		}
+		if err := s.recordCampaigns(ctx, tx, evRow.ID, applied); err != nil {
+			return err
+		}
+		if err := s.recordReceipt(ctx, tx, rs, evRow.ID, lines); err != nil {
+			return err
+		}
+
+		if err := s.lots.Credit(ctx, tx, updatedAgg.ID, evRow.ID, earned, opTs); err != nil {
+			return err
+		}
 
 		updatedRow, err := s.accounts.UpdateAfterEarn(
 			ctx, tx,
@@ -126,21 +176,27 @@ func (s *Service) Earn(ctx context.Context, actorUserID int64, in dto.EarnIn) (d
 			return errs.Wrap(errs.CodeInternal, "accounts.update_after_earn", err)
 		}
 
+		updatedRow, levelChange, err := s.recordLevelChange(ctx, tx, updatedRow, lockedRow.LevelCode, rs, actorUserID, evRow.ID, opTs)
+		if err != nil {
+			return err
+		}
+
+		updatedRow, err = s.referrals.Reward(ctx, tx, updatedRow, rs.Ruleset, evRow.ID, purchase, opTs)
+		if err != nil {
+			return err
+		}
+
 		result = dto.OperationOut{
 			OperationID:      in.OperationID,
 			OpType:           dto.OpEarn,
 			Event:            mapper.EventOut(evRow),
 			Balance:          mapper.BalanceOut(updatedRow, s.now()),
 			IdempotentReplay: false,
+			LevelChange:      levelChange,
 		}
+		result.Event.Campaigns = mapper.AppliedCampaigns(applied)
 
-		if err := s.finalizeOK(ctx, tx, updatedRow.ID, pgdto.OpEarn, in.OperationID, result); err != nil {
-			return err
-		}
-
-		_ = levelBefore
-		_ = baseRubPerPoint
-		return nil
+		return s.finalizeOK(ctx, tx, updatedRow.ID, pgdto.OpEarn, in.OperationID, result)
 	})
 
 	if err != nil {
@@ -180,7 +236,7 @@ func (s *Service) Spend(ctx context.Context, actorUserID int64, in dto.SpendIn)
 
 	var result dto.OperationOut
 
-	err := s.txm.WithinTx(ctx, func(ctx context.Context, tx pg.DBTX) error {
+	err := idempotency.WithinTx(ctx, s.txm, func(ctx context.Context, tx pg.DBTX) error {
 		accRow, ok, err := s.accounts.GetByPublicCode(ctx, tx, in.PublicCode)
 		if err != nil {
 			return errs.Wrap(errs.CodeInternal, "accounts.get_by_public_code", err)
@@ -189,7 +245,7 @@ func (s *Service) Spend(ctx context.Context, actorUserID int64, in dto.SpendIn)
 			return errs.New(errs.CodeAccountNotFound, "account not found")
 		}
 
-		reqJSON, err := marshalSpendRequest(in, opTs)
+		reqJSON, err := marshalSpendRequest(in)
 		if err != nil {
 			return errs.Wrap(errs.CodeInternal, "marshal spend request", err)
 		}
@@ -204,7 +260,10 @@ func (s *Service) Spend(ctx context.Context, actorUserID int64, in dto.SpendIn)
 			return errs.Wrap(errs.CodeInternal, "operations.insert_pending", err)
 		}
 		if !inserted {
-			return s.replayOperation(ctx, tx, accRow.ID, pgdto.OpSpend, in.OperationID, &result)
+			return s.replayOperation(ctx, tx, accRow.ID, pgdto.OpSpend, in.OperationID, reqJSON, &result)
+		}
+		if err := s.rejectExpired(ctx, tx, accRow.ID, pgdto.OpSpend, in.OperationID); err != nil {
+			return err
 		}
 
 		lockedRow, err := s.accounts.LockByID(ctx, tx, accRow.ID)
@@ -212,7 +271,11 @@ func (s *Service) Spend(ctx context.Context, actorUserID int64, in dto.SpendIn)
 			return errs.Wrap(errs.CodeInternal, "accounts.lock_by_id", err)
 		}
 
-		agg, err := accountFromRow(lockedRow)
+		if err := s.checkLimits(ctx, tx, pgdto.OpSpend, pgdto.EventSpend, lockedRow.ID, actorUserID, in.AmountPoints, in.OperationID); err != nil {
+			return err
+		}
+
+		agg, err := mapper.Account(lockedRow)
 		if err != nil {
 			return errs.Wrap(errs.CodeInternal, "account aggregate build failed", err)
 		}
@@ -222,18 +285,21 @@ func (s *Service) Spend(ctx context.Context, actorUserID int64, in dto.SpendIn)
 		if err != nil {
 			// важно: кешируем “бизнес-ошибку” для replay
 			if code, ok := errs.CodeOf(err); ok && code == errs.CodeNotEnoughBalance {
-				if ferr := s.finalizeErr(ctx, tx, agg.ID, pgdto.OpSpend, in.OperationID, errs.CodeNotEnoughBalance, "not enough balance"); ferr != nil {
-					return ferr
-				}
+				return s.businessErr(ctx, tx, agg.ID, pgdto.OpSpend, in.OperationID, errs.CodeNotEnoughBalance, "not enough balance")
 			}
 			return err
 		}
 
-		evRow, err := s.events.Insert(ctx, tx, eventInsertFromDraft(evDraft))
+		evRow, err := s.events.Insert(ctx, tx, mapper.EventInsert(evDraft))
 		if err != nil {
 			return errs.Wrap(errs.CodeInternal, "events.insert", err)
 		}
 
+		// oldest points go first
+		if err := s.lots.Debit(ctx, tx, updatedAgg.ID, ledger.Points(in.AmountPoints), nil); err != nil {
+			return err
+		}
+
 		updatedRow, err := s.accounts.UpdateAfterSpend(ctx, tx, updatedAgg.ID, updatedAgg.Balance.Int())
 		if err != nil {
 			return errs.Wrap(errs.CodeInternal, "accounts.update_after_spend", err)
@@ -269,107 +335,118 @@ func (s *Service) Spend(ctx context.Context, actorUserID int64, in dto.SpendIn)
 	return result, nil
 }
 
+// checkLimits rejects the operation with VELOCITY_LIMIT_EXCEEDED (cached for replay under opType) if it breaks
+// a velocity limit of limitType, the EARN or SPEND event it is about to write. The account must already be locked.
+func (s *Service) checkLimits(ctx context.Context, tx pg.DBTX, opType pgdto.OperationType, limitType pgdto.EventType, accountID, actorUserID int64, points int, operationID string) error {
+	msg, exceeded, err := s.velocityExceeded(ctx, tx, limitType, accountID, actorUserID, points)
+	if err != nil || !exceeded {
+		return err
+	}
+	return s.businessErr(ctx, tx, accountID, opType, operationID, errs.CodeVelocityLimitExceeded, msg)
+}
+
+// velocityExceeded reports the first velocity limit of limitType that writing points would break.
+func (s *Service) velocityExceeded(ctx context.Context, tx pg.DBTX, limitType pgdto.EventType, accountID, actorUserID int64, points int) (string, bool, error) {
+	msg, exceeded, err := s.limits.Exceeded(ctx, tx, limits.Usage{
+		OpType:      limitType,
+		AccountID:   accountID,
+		ActorUserID: actorUserID,
+		Points:      points,
+		At:          s.now(),
+	})
+	if err != nil || !exceeded {
+		return "", false, err
+	}
+
+	s.log.WarnContext(ctx, "cashier.velocity_limit exceeded", "opType", limitType, "accountID", accountID, "actorUserID", actorUserID, "points", points, "reason", msg)
+	return msg, true, nil
+}
+
 // computeEarnDomain uses domain rules to compute points and resolve levels.
+// Points are computed on earnable (the purchase weighted by category earn factors), levels on the whole purchase.
+// The earn caps of rs are applied last; earnedToday is what the account has already earned today.
 func computeEarnDomain(
 	rs pgdto.RulesetWithLevels,
 	totalSpendBefore ledger.Money,
 	purchase ledger.Money,
+	earnable ledger.Money,
+	earnedToday ledger.Points,
 ) (earned ledger.Points, levelBefore rules.LevelCode, levelAfter rules.LevelCode, base ledger.Money, _ error) {
-	base, err := ledger.ParseMoney(rs.Ruleset.BaseRubPerPoint.String())
-	if err != nil {
-		return 0, "", "", ledger.Money{}, errs.Wrap(errs.CodeInvalidRuleset, "baseRubPerPoint parse failed", err)
-	}
-
-	levels, err := levelRulesFromRepo(rs.Levels)
+	dr, err := mapper.Ruleset(rs)
 	if err != nil {
 		return 0, "", "", ledger.Money{}, err
 	}
-	if err := rules.ValidateLevels(levels); err != nil {
-		return 0, "", "", ledger.Money{}, err
-	}
-	rules.SortLevels(levels)
 
-	beforeRule, err := rules.ResolveLevel(totalSpendBefore, levels)
+	beforeRule, err := rules.ResolveLevel(totalSpendBefore, dr.Levels)
 	if err != nil {
 		return 0, "", "", ledger.Money{}, err
 	}
 
 	afterSpend := totalSpendBefore.Add(purchase)
-	afterRule, err := rules.ResolveLevel(afterSpend, levels)
+	afterRule, err := rules.ResolveLevel(afterSpend, dr.Levels)
 	if err != nil {
 		return 0, "", "", ledger.Money{}, err
 	}
 
-	pts, err := rules.ComputeEarnPoints(purchase, base, beforeRule.PercentEarn)
+	pts, err := dr.EarnPoints(beforeRule, purchase, earnable, earnedToday)
 	if err != nil {
 		return 0, "", "", ledger.Money{}, err
 	}
 
-	return pts, beforeRule.LevelCode, afterRule.LevelCode, base, nil
+	return pts, beforeRule.LevelCode, afterRule.LevelCode, dr.BaseRubPerPoint, nil
 }
 
-func levelRulesFromRepo(in []pgdto.LevelRuleRow) ([]rules.LevelRule, error) {
-	out := make([]rules.LevelRule, 0, len(in))
-	for _, lv := range in {
-		thr, err := ledger.ParseMoney(lv.ThresholdTotalSpend.String())
-		if err != nil {
-			return nil, errs.Wrap(errs.CodeInvalidLevels, fmt.Sprintf("thresholdTotalSpend parse failed: %s", lv.ThresholdTotalSpend.String()), err)
-		}
-		perc, err := rules.ParsePercent(lv.PercentEarn.String())
-		if err != nil {
-			return nil, errs.Wrap(errs.CodeInvalidLevels, fmt.Sprintf("percentEarn parse failed: %s", lv.PercentEarn.String()), err)
-		}
-		out = append(out, rules.LevelRule{
-			ID:                  lv.ID,
-			LevelCode:           rules.LevelCode(lv.LevelCode),
-			ThresholdTotalSpend: thr,
-			PercentEarn:         perc,
-		})
+// earnedToday is what the daily cap has already counted on the UTC day of at, the ts the EARN is written with:
+// ruleset points of the day's EARN events, without campaign rewards and net of refunds.
+// It is only read when rs caps the points per day.
+func (s *Service) earnedToday(ctx context.Context, tx pg.DBTX, rs pgdto.RulesetWithLevels, accountID int64, at time.Time) (ledger.Points, error) {
+	if rs.Ruleset.MaxPointsPerDay == nil {
+		return 0, nil
 	}
-	return out, nil
-}
 
-func accountFromRow(r pgdto.AccountRow) (account.Account, error) {
-	ts, err := ledger.ParseMoney(r.TotalSpendMoney.String())
+	dayStart := at.UTC().Truncate(24 * time.Hour)
+	used, err := s.events.SumCappedEarnPointsInRange(ctx, tx, accountID, dayStart, dayStart.Add(24*time.Hour))
 	if err != nil {
-		return account.Account{}, err
+		return 0, errs.Wrap(errs.CodeInternal, "events.sum_capped_earn_points_in_range", err)
 	}
-	return account.Account{
-		ID:         r.ID,
-		PublicCode: account.PublicCode(r.PublicCode),
-		Balance:    ledger.Points(r.BalancePoints),
-		TotalSpend: ts,
-		LevelCode:  rules.LevelCode(r.LevelCode),
-	}, nil
+	return ledger.Points(used), nil
 }
 
-func eventInsertFromDraft(d ledger.EventDraft) pgdto.EventInsert {
-	var typ pgdto.EventType
-	switch d.Type {
-	case ledger.EventEarn:
-		typ = pgdto.EventEarn
-	case ledger.EventSpend:
-		typ = pgdto.EventSpend
-	default:
-		typ = pgdto.EventType(d.Type)
+// qualifyingSpend is the spend the levels of rs are resolved on: lifetime totalSpend,
+// or, when rs qualifies on a rolling window, purchases of the window ending at at (net of their refunds).
+func (s *Service) qualifyingSpend(ctx context.Context, tx pg.DBTX, rs pgdto.RulesetWithLevels, agg account.Account, at time.Time) (ledger.Money, error) {
+	days := rs.Ruleset.QualificationWindowDays
+	if days == nil {
+		return agg.TotalSpend, nil
+	}
+
+	spend, err := s.events.SumQualifyingSpendSince(ctx, tx, agg.ID, rules.QualificationSince(at, *days))
+	if err != nil {
+		return ledger.Money{}, errs.Wrap(errs.CodeInternal, "events.sum_qualifying_spend_since", err)
+	}
+	m, err := ledger.ParseMoney(spend.String())
+	if err != nil {
+		return ledger.Money{}, errs.Wrap(errs.CodeInternal, "qualifying spend parse failed", err)
 	}
+	return m, nil
+}
 
-	var amt *pgdto.Money
-	if d.AmountMoney != nil {
-		m := pgdto.Money(d.AmountMoney.Decimal())
-		amt = &m
+// resolveLevelDomain resolves the level for the given totalSpend using the ruleset levels.
+func resolveLevelDomain(rs pgdto.RulesetWithLevels, totalSpend ledger.Money) (rules.LevelCode, error) {
+	levels, err := mapper.LevelRules(rs.Levels)
+	if err != nil {
+		return "", err
+	}
+	if err := rules.ValidateLevels(levels); err != nil {
+		return "", err
 	}
+	rules.SortLevels(levels)
 
-	return pgdto.EventInsert{
-		AccountID:    d.AccountID,
-		Type:         typ,
-		DeltaPoints:  int(d.DeltaPoints),
-		BalanceAfter: int(d.BalanceAfter),
-		AmountMoney:  amt,
-		RulesetID:    d.RulesetID,
-		ActorUserID:  d.ActorUserID,
-		Ts:           d.Ts,
+	lr, err := rules.ResolveLevel(totalSpend, levels)
+	if err != nil {
+		return "", err
 	}
+	return lr.LevelCode, nil
 }
 
 // Money parsing helper: enforces <=2 fraction digits.
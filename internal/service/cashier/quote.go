@@ -0,0 +1,116 @@
+package cashier
+
+import (
+	"context"
+	"time"
+
+	"Beanefits/internal/domain/account"
+	"Beanefits/internal/domain/errs"
+	"Beanefits/internal/domain/ledger"
+	pgdto "Beanefits/internal/repository/postgres/dto"
+	"Beanefits/internal/service/dto"
+	"Beanefits/internal/service/mapper"
+)
+
+// QuoteEarn previews an EARN of in.AmountMoney at server time: the same computation as Earn
+// (levels, rounding, earn caps, campaigns) against the account's current totals.
+// It is read-only: no operation, event or lock is taken, so a concurrent purchase may change the result.
+func (s *Service) QuoteEarn(ctx context.Context, in dto.EarnQuoteIn) (dto.EarnQuoteOut, error) {
+	start := time.Now()
+	s.log.InfoContext(ctx, "cashier.quote_earn start", "publicCode", in.PublicCode)
+
+	if _, err := account.ParsePublicCode(in.PublicCode); err != nil {
+		s.log.ErrorContext(ctx, "cashier.quote_earn failed", "ms", time.Since(start).Milliseconds(), "err", err)
+		return dto.EarnQuoteOut{}, err
+	}
+
+	purchase, err := parseMoney2(in.AmountMoney)
+	if err != nil {
+		wrapped := errs.Wrap(errs.CodeInvalidMoney, "invalid amountMoney", err)
+		s.log.ErrorContext(ctx, "cashier.quote_earn failed", "ms", time.Since(start).Milliseconds(), "err", wrapped)
+		return dto.EarnQuoteOut{}, wrapped
+	}
+	if purchase.IsNegative() {
+		e := errs.New(errs.CodeInvalidPurchaseAmount, "purchase amount must be >= 0")
+		s.log.ErrorContext(ctx, "cashier.quote_earn failed", "ms", time.Since(start).Milliseconds(), "err", e)
+		return dto.EarnQuoteOut{}, e
+	}
+
+	out, err := s.quoteEarn(ctx, in.PublicCode, purchase, s.now())
+	if err != nil {
+		s.log.ErrorContext(ctx, "cashier.quote_earn failed", "ms", time.Since(start).Milliseconds(), "err", err)
+		return dto.EarnQuoteOut{}, err
+	}
+
+	s.log.InfoContext(ctx, "cashier.quote_earn ok",
+		"ms", time.Since(start).Milliseconds(),
+		"rulesetID", out.RulesetID,
+		"points", out.Points,
+	)
+	return out, nil
+}
+
+func (s *Service) quoteEarn(ctx context.Context, publicCode string, purchase ledger.Money, at time.Time) (dto.EarnQuoteOut, error) {
+	accRow, ok, err := s.accounts.GetByPublicCode(ctx, s.db, publicCode)
+	if err != nil {
+		return dto.EarnQuoteOut{}, errs.Wrap(errs.CodeInternal, "accounts.get_by_public_code", err)
+	}
+	if !ok {
+		return dto.EarnQuoteOut{}, errs.New(errs.CodeAccountNotFound, "account not found")
+	}
+
+	rs, ok, err := s.rules.GetEffectiveAt(ctx, s.db, at)
+	if err != nil {
+		return dto.EarnQuoteOut{}, errs.Wrap(errs.CodeInternal, "rules.get_effective_at", err)
+	}
+	if !ok {
+		return dto.EarnQuoteOut{}, errs.New(errs.CodeInvalidRuleset, "no ruleset effective now")
+	}
+
+	agg, err := mapper.Account(accRow)
+	if err != nil {
+		return dto.EarnQuoteOut{}, errs.Wrap(errs.CodeInternal, "account aggregate build failed", err)
+	}
+
+	qualifying, err := s.qualifyingSpend(ctx, s.db, rs, agg, at)
+	if err != nil {
+		return dto.EarnQuoteOut{}, err
+	}
+
+	earnedToday, err := s.earnedToday(ctx, s.db, rs, accRow.ID, at)
+	if err != nil {
+		return dto.EarnQuoteOut{}, err
+	}
+
+	earned, levelBefore, levelAfter, _, err := computeEarnDomain(rs, qualifying, purchase, purchase, earnedToday)
+	if err != nil {
+		return dto.EarnQuoteOut{}, err
+	}
+
+	total, applied, err := s.applyCampaigns(ctx, s.db, earned, purchase, at)
+	if err != nil {
+		return dto.EarnQuoteOut{}, err
+	}
+
+	percent := ""
+	for _, lv := range rs.Levels {
+		if lv.LevelCode == string(levelBefore) {
+			percent = mapper.MoneyFixed2(lv.PercentEarn)
+			break
+		}
+	}
+
+	return dto.EarnQuoteOut{
+		PublicCode:      accRow.PublicCode,
+		AmountMoney:     mapper.MoneyFixed2(pgdto.Money(purchase.Decimal())),
+		RulesetID:       rs.Ruleset.ID,
+		BaseRubPerPoint: mapper.MoneyFixed2(rs.Ruleset.BaseRubPerPoint),
+		PercentEarn:     percent,
+		LevelBefore:     string(levelBefore),
+		LevelAfter:      string(levelAfter),
+		RulesetPoints:   earned.Int(),
+		Campaigns:       mapper.AppliedCampaigns(applied),
+		Points:          total.Int(),
+		Ts:              at,
+	}, nil
+}
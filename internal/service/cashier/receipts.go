@@ -0,0 +1,80 @@
+package cashier
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"Beanefits/internal/domain/errs"
+	"Beanefits/internal/domain/ledger"
+	"Beanefits/internal/domain/rules"
+	pg "Beanefits/internal/repository/postgres"
+	pgdto "Beanefits/internal/repository/postgres/dto"
+	"Beanefits/internal/service/dto"
+	"Beanefits/internal/service/mapper"
+)
+
+// parseReceipt parses itemized lines and checks they add up to the purchase; nil when the receipt is not itemized.
+func parseReceipt(in []dto.ReceiptLineIn, purchase ledger.Money) ([]rules.ReceiptLine, error) {
+	if len(in) == 0 {
+		return nil, nil
+	}
+
+	lines := make([]rules.ReceiptLine, 0, len(in))
+	for i, l := range in {
+		total, err := parseMoney2(l.LineTotal)
+		if err != nil {
+			return nil, errs.Wrap(errs.CodeInvalidReceipt, fmt.Sprintf("lines[%d].lineTotal invalid", i), err)
+		}
+		lines = append(lines, rules.ReceiptLine{
+			SKU:       strings.TrimSpace(l.SKU),
+			Category:  strings.TrimSpace(l.Category),
+			Quantity:  l.Quantity,
+			LineTotal: total,
+		})
+	}
+
+	if err := rules.ValidateReceipt(lines, purchase); err != nil {
+		return nil, err
+	}
+	return lines, nil
+}
+
+// earnableAmount is the amount points are computed on: the purchase itself,
+// or for an itemized receipt the line totals weighted by the category earn factors of rs.
+func earnableAmount(rs pgdto.RulesetWithLevels, lines []rules.ReceiptLine, purchase ledger.Money) (ledger.Money, error) {
+	if len(lines) == 0 {
+		return purchase, nil
+	}
+	m, err := rules.EarnableAmount(lines, mapper.CategoryRates(rs.CategoryRates))
+	if err != nil {
+		return ledger.Money{}, errs.Wrap(errs.CodeInternal, "earnable amount failed", err)
+	}
+	return m, nil
+}
+
+// recordReceipt stores the lines of an EARN event with the factors they earned at.
+func (s *Service) recordReceipt(ctx context.Context, tx pg.DBTX, rs pgdto.RulesetWithLevels, eventID int64, lines []rules.ReceiptLine) error {
+	if len(lines) == 0 {
+		return nil
+	}
+
+	rates := mapper.CategoryRates(rs.CategoryRates)
+	rows := make([]pgdto.ReceiptLineRow, 0, len(lines))
+	for i, l := range lines {
+		rows = append(rows, pgdto.ReceiptLineRow{
+			EventID:    eventID,
+			LineNo:     i + 1,
+			SKU:        l.SKU,
+			Category:   l.Category,
+			Quantity:   l.Quantity,
+			LineTotal:  pgdto.Money(l.LineTotal.Decimal()),
+			EarnFactor: pgdto.Money(rates.Factor(l.Category)),
+		})
+	}
+
+	if err := s.receipts.InsertLines(ctx, tx, rows); err != nil {
+		return errs.Wrap(errs.CodeInternal, "receipts.insert_lines", err)
+	}
+	return nil
+}
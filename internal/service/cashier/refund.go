@@ -0,0 +1,230 @@
+package cashier
+
+import (
+	"context"
+	"time"
+
+	"Beanefits/internal/domain/account"
+	"Beanefits/internal/domain/errs"
+	"Beanefits/internal/domain/ledger"
+	"Beanefits/internal/domain/rules"
+	pg "Beanefits/internal/repository/postgres"
+	pgdto "Beanefits/internal/repository/postgres/dto"
+	"Beanefits/internal/service/dto"
+	"Beanefits/internal/service/idempotency"
+	"Beanefits/internal/service/mapper"
+)
+
+// Refund reverts (fully or partially) a purchase that earned points.
+// Points are taken back proportionally to the refunded amount, totalSpendMoney is decreased
+// and the level is re-resolved against the ruleset effective at the refund ts.
+func (s *Service) Refund(ctx context.Context, actorUserID int64, in dto.RefundIn) (dto.OperationOut, error) {
+	start := time.Now()
+	s.log.InfoContext(ctx, "cashier.refund start", "actorUserID", actorUserID, "operationID", in.OperationID, "publicCode", in.PublicCode, "eventID", in.EventID)
+
+	if _, err := account.ParsePublicCode(in.PublicCode); err != nil {
+		s.log.ErrorContext(ctx, "cashier.refund failed", "ms", time.Since(start).Milliseconds(), "err", err)
+		return dto.OperationOut{}, err
+	}
+
+	opTs := s.now()
+	if in.Ts != nil {
+		opTs = *in.Ts
+	}
+
+	var requested *ledger.Money
+	if in.AmountMoney != nil {
+		m, err := parseMoney2(*in.AmountMoney)
+		if err != nil {
+			wrapped := errs.Wrap(errs.CodeInvalidMoney, "invalid amountMoney", err)
+			s.log.ErrorContext(ctx, "cashier.refund failed", "ms", time.Since(start).Milliseconds(), "err", wrapped)
+			return dto.OperationOut{}, wrapped
+		}
+		if m.IsNegative() || m.IsZero() {
+			e := errs.New(errs.CodeInvalidRefundAmount, "refund amount must be > 0")
+			s.log.ErrorContext(ctx, "cashier.refund failed", "ms", time.Since(start).Milliseconds(), "err", e)
+			return dto.OperationOut{}, e
+		}
+		requested = &m
+	}
+
+	var result dto.OperationOut
+
+	err := idempotency.WithinTx(ctx, s.txm, func(ctx context.Context, tx pg.DBTX) error {
+		accRow, ok, err := s.accounts.GetByPublicCode(ctx, tx, in.PublicCode)
+		if err != nil {
+			return errs.Wrap(errs.CodeInternal, "accounts.get_by_public_code", err)
+		}
+		if !ok {
+			return errs.New(errs.CodeAccountNotFound, "account not found")
+		}
+
+		reqJSON, err := marshalRefundRequest(in)
+		if err != nil {
+			return errs.Wrap(errs.CodeInternal, "marshal refund request", err)
+		}
+
+		inserted, err := s.operations.InsertPending(ctx, tx, pgdto.OperationPendingInsert{
+			AccountID:   accRow.ID,
+			OpType:      pgdto.OpRefund,
+			OperationID: in.OperationID,
+			RequestJSON: reqJSON,
+		})
+		if err != nil {
+			return errs.Wrap(errs.CodeInternal, "operations.insert_pending", err)
+		}
+		if !inserted {
+			return s.replayOperation(ctx, tx, accRow.ID, pgdto.OpRefund, in.OperationID, reqJSON, &result)
+		}
+		if err := s.rejectExpired(ctx, tx, accRow.ID, pgdto.OpRefund, in.OperationID); err != nil {
+			return err
+		}
+
+		// concurrency gate: also serializes refunds of the same EARN
+		lockedRow, err := s.accounts.LockByID(ctx, tx, accRow.ID)
+		if err != nil {
+			return errs.Wrap(errs.CodeInternal, "accounts.lock_by_id", err)
+		}
+
+		orig, ok, err := s.events.GetByID(ctx, tx, in.EventID)
+		if err != nil {
+			return errs.Wrap(errs.CodeInternal, "events.get_by_id", err)
+		}
+		if !ok || orig.AccountID != lockedRow.ID {
+			return errs.New(errs.CodeEventNotFound, "event not found")
+		}
+		if orig.Type != pgdto.EventEarn || orig.AmountMoney == nil {
+			return s.businessErr(ctx, tx, lockedRow.ID, pgdto.OpRefund, in.OperationID, errs.CodeRefundNotAllowed, "only EARN events can be refunded")
+		}
+
+		totals, err := s.events.SumRefunds(ctx, tx, orig.ID)
+		if err != nil {
+			return errs.Wrap(errs.CodeInternal, "events.sum_refunds", err)
+		}
+
+		purchase, err := ledger.ParseMoney(orig.AmountMoney.String())
+		if err != nil {
+			return errs.Wrap(errs.CodeInternal, "original amountMoney parse failed", err)
+		}
+		refundedBefore, err := ledger.ParseMoney(totals.RefundedMoney.String())
+		if err != nil {
+			return errs.Wrap(errs.CodeInternal, "refunded amount parse failed", err)
+		}
+
+		remaining := purchase.Sub(refundedBefore)
+		if !remaining.GT(ledger.ZeroMoney()) {
+			return s.businessErr(ctx, tx, lockedRow.ID, pgdto.OpRefund, in.OperationID, errs.CodeRefundNotAllowed, "purchase already fully refunded")
+		}
+
+		amount := remaining
+		if requested != nil {
+			amount = *requested
+		}
+		if amount.GT(remaining) {
+			return s.businessErr(ctx, tx, lockedRow.ID, pgdto.OpRefund, in.OperationID, errs.CodeInvalidRefundAmount, "refund amount exceeds remaining purchase amount")
+		}
+
+		reverted, err := rules.ComputeRefundPoints(
+			ledger.Points(orig.DeltaPoints),
+			purchase,
+			refundedBefore,
+			ledger.Points(totals.RevertedPoints),
+			amount,
+		)
+		if err != nil {
+			return err
+		}
+
+		rs, ok, err := s.rules.GetEffectiveAt(ctx, tx, opTs)
+		if err != nil {
+			return errs.Wrap(errs.CodeInternal, "rules.get_effective_at", err)
+		}
+		if !ok {
+			return errs.New(errs.CodeInvalidRuleset, "no ruleset effective at provided ts")
+		}
+
+		agg, err := mapper.Account(lockedRow)
+		if err != nil {
+			return errs.Wrap(errs.CodeInternal, "account aggregate build failed", err)
+		}
+
+		qualifying, err := s.qualifyingSpend(ctx, tx, rs, agg, opTs)
+		if err != nil {
+			return err
+		}
+		// a purchase outside a rolling window no longer counts, so neither does its refund
+		if rules.Qualifies(orig.Ts, opTs, rs.Ruleset.QualificationWindowDays) {
+			qualifying = qualifying.Sub(amount)
+		}
+
+		levelAfter, err := resolveLevelDomain(rs, qualifying)
+		if err != nil {
+			return err
+		}
+
+		actor := actorUserID
+		rulesetID := rs.Ruleset.ID
+
+		updatedAgg, evDraft, err := agg.ApplyRefund(reverted, amount, levelAfter, orig.ID, &rulesetID, &actor, opTs)
+		if err != nil {
+			if code, ok := errs.CodeOf(err); ok && code == errs.CodeNotEnoughBalance {
+				return s.businessErr(ctx, tx, agg.ID, pgdto.OpRefund, in.OperationID, errs.CodeNotEnoughBalance, "not enough balance to revert earned points")
+			}
+			return err
+		}
+
+		evRow, err := s.events.Insert(ctx, tx, mapper.EventInsert(evDraft))
+		if err != nil {
+			return errs.Wrap(errs.CodeInternal, "events.insert", err)
+		}
+
+		// points of the refunded purchase go first, the rest is taken oldest-first
+		if err := s.lots.Debit(ctx, tx, updatedAgg.ID, reverted, &orig.ID); err != nil {
+			return err
+		}
+
+		// balance, totalSpend and level change together, same as after EARN
+		updatedRow, err := s.accounts.UpdateAfterEarn(
+			ctx, tx,
+			updatedAgg.ID,
+			updatedAgg.Balance.Int(),
+			pgdto.Money(updatedAgg.TotalSpend.Decimal()),
+			string(updatedAgg.LevelCode),
+		)
+		if err != nil {
+			return errs.Wrap(errs.CodeInternal, "accounts.update_after_earn", err)
+		}
+
+		// a refund can only move the account down, so no level bonus is paid here
+		updatedRow, levelChange, err := s.recordLevelChange(ctx, tx, updatedRow, lockedRow.LevelCode, rs, actorUserID, evRow.ID, opTs)
+		if err != nil {
+			return err
+		}
+
+		result = dto.OperationOut{
+			OperationID:      in.OperationID,
+			OpType:           dto.OpRefund,
+			Event:            mapper.EventOut(evRow),
+			Balance:          mapper.BalanceOut(updatedRow, s.now()),
+			IdempotentReplay: false,
+			LevelChange:      levelChange,
+		}
+
+		return s.finalizeOK(ctx, tx, updatedRow.ID, pgdto.OpRefund, in.OperationID, result)
+	})
+
+	if err != nil {
+		s.log.ErrorContext(ctx, "cashier.refund failed", "ms", time.Since(start).Milliseconds(), "err", err)
+		return dto.OperationOut{}, err
+	}
+
+	s.log.InfoContext(ctx, "cashier.refund ok",
+		"ms", time.Since(start).Milliseconds(),
+		"operationID", in.OperationID,
+		"publicCode", in.PublicCode,
+		"eventID", in.EventID,
+		"replay", result.IdempotentReplay,
+	)
+
+	return result, nil
+}
@@ -5,6 +5,10 @@ import (
 	"time"
 
 	pg "Beanefits/internal/repository/postgres"
+	"Beanefits/internal/service/limits"
+	"Beanefits/internal/service/lots"
+	"Beanefits/internal/service/referrals"
+	"Beanefits/internal/service/tiers"
 )
 
 type Clock func() time.Time
@@ -17,6 +21,16 @@ type Service struct {
 	events     pg.EventsRepo
 	operations pg.OperationsRepo
 	rules      pg.RulesRepo
+	holds      pg.HoldsRepo
+	campaigns  pg.CampaignsRepo
+	receipts   pg.ReceiptsRepo
+	lots       *lots.Book
+	limits     *limits.Guard
+	referrals  *referrals.Program
+	levels     *tiers.Recorder
+
+	voidWindow time.Duration
+	holdTTL    time.Duration
 
 	now Clock
 	log *slog.Logger
@@ -30,6 +44,31 @@ type Deps struct {
 	Events     pg.EventsRepo
 	Operations pg.OperationsRepo
 	Rules      pg.RulesRepo
+	Holds      pg.HoldsRepo
+
+	// Receipts keeps the itemized lines of EARN operations.
+	Receipts pg.ReceiptsRepo
+
+	// Campaigns adds time-boxed earn promotions on top of the ruleset; nil applies none.
+	Campaigns pg.CampaignsRepo
+
+	// Lots tracks earned points by age; every balance change goes through it.
+	Lots *lots.Book
+
+	// Limits enforces velocity limits of EARN/SPEND; nil disables them.
+	Limits *limits.Guard
+
+	// Referrals pays the referral bonuses on a referee's first EARN; nil pays none.
+	Referrals *referrals.Program
+
+	// Levels records level transitions as LEVEL_CHANGED events and pays level bonuses; nil records none.
+	Levels *tiers.Recorder
+
+	// VoidWindow limits how old a SPEND can be to still be voided (default 24h).
+	VoidWindow time.Duration
+
+	// HoldTTL is how long authorized points stay reserved without a capture (default 24h).
+	HoldTTL time.Duration
 
 	Now Clock
 	Log *slog.Logger
@@ -47,6 +86,16 @@ func New(deps Deps) *Service {
 	}
 	l = l.With("layer", "service", "svc", "cashier")
 
+	vw := deps.VoidWindow
+	if vw <= 0 {
+		vw = 24 * time.Hour
+	}
+
+	ttl := deps.HoldTTL
+	if ttl <= 0 {
+		ttl = 24 * time.Hour
+	}
+
 	return &Service{
 		db:         deps.DB,
 		txm:        deps.TXM,
@@ -54,6 +103,15 @@ func New(deps Deps) *Service {
 		events:     deps.Events,
 		operations: deps.Operations,
 		rules:      deps.Rules,
+		holds:      deps.Holds,
+		campaigns:  deps.Campaigns,
+		receipts:   deps.Receipts,
+		lots:       deps.Lots,
+		limits:     deps.Limits,
+		referrals:  deps.Referrals,
+		levels:     deps.Levels,
+		voidWindow: vw,
+		holdTTL:    ttl,
 		now:        n,
 		log:        l,
 	}
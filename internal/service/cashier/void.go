@@ -0,0 +1,176 @@
+package cashier
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"Beanefits/internal/domain/account"
+	"Beanefits/internal/domain/errs"
+	"Beanefits/internal/domain/ledger"
+	pg "Beanefits/internal/repository/postgres"
+	pgdto "Beanefits/internal/repository/postgres/dto"
+	"Beanefits/internal/service/dto"
+	"Beanefits/internal/service/idempotency"
+	"Beanefits/internal/service/mapper"
+)
+
+// Void cancels a SPEND by its operationId and gives the points back.
+// A SPEND can be voided once and only within the configured void window after the SPEND was written, on server time.
+func (s *Service) Void(ctx context.Context, actorUserID int64, in dto.VoidIn) (dto.OperationOut, error) {
+	start := time.Now()
+	s.log.InfoContext(ctx, "cashier.void start", "actorUserID", actorUserID, "operationID", in.OperationID, "publicCode", in.PublicCode, "spendOperationID", in.SpendOperationID)
+
+	if _, err := account.ParsePublicCode(in.PublicCode); err != nil {
+		s.log.ErrorContext(ctx, "cashier.void failed", "ms", time.Since(start).Milliseconds(), "err", err)
+		return dto.OperationOut{}, err
+	}
+
+	opTs := s.now()
+	if in.Ts != nil {
+		opTs = *in.Ts
+	}
+
+	var result dto.OperationOut
+
+	err := idempotency.WithinTx(ctx, s.txm, func(ctx context.Context, tx pg.DBTX) error {
+		accRow, ok, err := s.accounts.GetByPublicCode(ctx, tx, in.PublicCode)
+		if err != nil {
+			return errs.Wrap(errs.CodeInternal, "accounts.get_by_public_code", err)
+		}
+		if !ok {
+			return errs.New(errs.CodeAccountNotFound, "account not found")
+		}
+
+		reqJSON, err := marshalVoidRequest(in)
+		if err != nil {
+			return errs.Wrap(errs.CodeInternal, "marshal void request", err)
+		}
+
+		inserted, err := s.operations.InsertPending(ctx, tx, pgdto.OperationPendingInsert{
+			AccountID:   accRow.ID,
+			OpType:      pgdto.OpVoid,
+			OperationID: in.OperationID,
+			RequestJSON: reqJSON,
+		})
+		if err != nil {
+			return errs.Wrap(errs.CodeInternal, "operations.insert_pending", err)
+		}
+		if !inserted {
+			return s.replayOperation(ctx, tx, accRow.ID, pgdto.OpVoid, in.OperationID, reqJSON, &result)
+		}
+		if err := s.rejectExpired(ctx, tx, accRow.ID, pgdto.OpVoid, in.OperationID); err != nil {
+			return err
+		}
+
+		// concurrency gate: also serializes voids of the same SPEND
+		lockedRow, err := s.accounts.LockByID(ctx, tx, accRow.ID)
+		if err != nil {
+			return errs.Wrap(errs.CodeInternal, "accounts.lock_by_id", err)
+		}
+
+		spendEventID, ok, err := s.spendEventID(ctx, tx, lockedRow.ID, in.SpendOperationID)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return errs.New(errs.CodeOperationNotFound, "spend operation not found")
+		}
+
+		orig, ok, err := s.events.GetByID(ctx, tx, spendEventID)
+		if err != nil {
+			return errs.Wrap(errs.CodeInternal, "events.get_by_id", err)
+		}
+		if !ok || orig.AccountID != lockedRow.ID || orig.Type != pgdto.EventSpend {
+			return errs.New(errs.CodeOperationNotFound, "spend operation not found")
+		}
+
+		voided, err := s.events.CountByRef(ctx, tx, orig.ID, pgdto.EventVoid)
+		if err != nil {
+			return errs.Wrap(errs.CodeInternal, "events.count_by_ref", err)
+		}
+		if voided > 0 {
+			return s.businessErr(ctx, tx, lockedRow.ID, pgdto.OpVoid, in.OperationID, errs.CodeAlreadyVoided, "spend operation already voided")
+		}
+		// the window runs on server time from when the SPEND was written; the client ts of either event plays no part
+		if s.now().Sub(orig.CreatedAt) > s.voidWindow {
+			return s.businessErr(ctx, tx, lockedRow.ID, pgdto.OpVoid, in.OperationID, errs.CodeVoidWindowExpired, "spend operation is older than void window "+s.voidWindow.String())
+		}
+
+		agg, err := mapper.Account(lockedRow)
+		if err != nil {
+			return errs.Wrap(errs.CodeInternal, "account aggregate build failed", err)
+		}
+
+		actor := actorUserID
+		updatedAgg, evDraft, err := agg.ApplyVoid(ledger.Points(-orig.DeltaPoints), orig.ID, &actor, opTs)
+		if err != nil {
+			return err
+		}
+
+		evRow, err := s.events.Insert(ctx, tx, mapper.EventInsert(evDraft))
+		if err != nil {
+			return errs.Wrap(errs.CodeInternal, "events.insert", err)
+		}
+
+		// restored points age from the voided SPEND
+		if err := s.lots.Credit(ctx, tx, updatedAgg.ID, evRow.ID, ledger.Points(-orig.DeltaPoints), orig.Ts); err != nil {
+			return err
+		}
+
+		// only balance changes, same as after SPEND
+		updatedRow, err := s.accounts.UpdateAfterSpend(ctx, tx, updatedAgg.ID, updatedAgg.Balance.Int())
+		if err != nil {
+			return errs.Wrap(errs.CodeInternal, "accounts.update_after_spend", err)
+		}
+
+		result = dto.OperationOut{
+			OperationID:      in.OperationID,
+			OpType:           dto.OpVoid,
+			Event:            mapper.EventOut(evRow),
+			Balance:          mapper.BalanceOut(updatedRow, s.now()),
+			IdempotentReplay: false,
+		}
+
+		return s.finalizeOK(ctx, tx, updatedRow.ID, pgdto.OpVoid, in.OperationID, result)
+	})
+
+	if err != nil {
+		s.log.ErrorContext(ctx, "cashier.void failed", "ms", time.Since(start).Milliseconds(), "err", err)
+		return dto.OperationOut{}, err
+	}
+
+	s.log.InfoContext(ctx, "cashier.void ok",
+		"ms", time.Since(start).Milliseconds(),
+		"operationID", in.OperationID,
+		"publicCode", in.PublicCode,
+		"spendOperationID", in.SpendOperationID,
+		"replay", result.IdempotentReplay,
+	)
+
+	return result, nil
+}
+
+// spendEventID resolves the SPEND event written by a successful spend operation.
+// Older operations have no event_id stored, so it falls back to the cached response.
+func (s *Service) spendEventID(ctx context.Context, tx pg.DBTX, accountID int64, spendOperationID string) (int64, bool, error) {
+	rec, ok, err := s.operations.Get(ctx, tx, accountID, pgdto.OpSpend, spendOperationID)
+	if err != nil {
+		return 0, false, errs.Wrap(errs.CodeInternal, "operations.get", err)
+	}
+	if !ok || rec.HTTPStatus == nil || *rec.HTTPStatus != 200 {
+		return 0, false, nil
+	}
+	if rec.EventID != nil {
+		return *rec.EventID, true, nil
+	}
+	if rec.ResponseJSON == nil {
+		return 0, false, nil
+	}
+
+	var cached dto.OperationOut
+	if err := json.Unmarshal(*rec.ResponseJSON, &cached); err != nil {
+		return 0, false, errs.Wrap(errs.CodeInternal, "unmarshal cached success", err)
+	}
+	return cached.Event.ID, cached.Event.ID > 0, nil
+}
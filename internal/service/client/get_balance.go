@@ -4,6 +4,7 @@ import (
 	"context"
 	"time"
 
+	"Beanefits/internal/domain/errs"
 	sdto "Beanefits/internal/service/dto"
 	"Beanefits/internal/service/mapper"
 )
@@ -24,6 +25,17 @@ func (s *Service) GetBalance(ctx context.Context, userID int64) (sdto.BalanceOut
 	}
 
 	out := mapper.BalanceOut(acc, s.now())
+
+	exp, ok, err := s.lots.NextExpiring(ctx, s.db, acc.ID)
+	if err != nil {
+		wrapped := errs.Wrap(errs.CodeInternal, "lots.next_expiring", err)
+		s.log.ErrorContext(ctx, "client.get_balance failed", "ms", time.Since(start).Milliseconds(), "err", wrapped)
+		return sdto.BalanceOut{}, wrapped
+	}
+	if ok {
+		out.ExpiringPoints = &exp.Points
+		out.ExpiringOn = &exp.On
+	}
 	s.log.InfoContext(ctx, "client.get_balance ok", "ms", time.Since(start).Milliseconds(), "userID", userID, "accountID", acc.ID)
 	return out, nil
 }
@@ -0,0 +1,46 @@
+package client
+
+import (
+	"context"
+	"time"
+
+	sdto "Beanefits/internal/service/dto"
+)
+
+// GetReferrals returns the caller's referral code and how the referrals made with it went.
+func (s *Service) GetReferrals(ctx context.Context, userID int64) (sdto.ReferralStatsOut, error) {
+	start := time.Now()
+	s.log.InfoContext(ctx, "client.get_referrals start", "userID", userID)
+
+	if _, err := s.requireActiveUser(ctx, userID); err != nil {
+		s.log.ErrorContext(ctx, "client.get_referrals failed", "ms", time.Since(start).Milliseconds(), "err", err)
+		return sdto.ReferralStatsOut{}, err
+	}
+
+	acc, err := s.requireAccount(ctx, userID)
+	if err != nil {
+		s.log.ErrorContext(ctx, "client.get_referrals failed", "ms", time.Since(start).Milliseconds(), "err", err)
+		return sdto.ReferralStatsOut{}, err
+	}
+
+	code, st, err := s.referrals.Stats(ctx, s.db, acc.ID, s.now())
+	if err != nil {
+		s.log.ErrorContext(ctx, "client.get_referrals failed", "ms", time.Since(start).Milliseconds(), "err", err)
+		return sdto.ReferralStatsOut{}, err
+	}
+
+	out := sdto.ReferralStatsOut{
+		ReferralCode:      code,
+		ReferredCount:     st.Referred,
+		RewardedCount:     st.Rewarded,
+		PendingCount:      st.Referred - st.Rewarded,
+		ReferredThisMonth: st.ReferredSince,
+		BonusPointsEarned: st.BonusPoints,
+	}
+	if limit := s.referrals.MonthlyLimit(); limit > 0 {
+		out.MonthlyLimit = &limit
+	}
+
+	s.log.InfoContext(ctx, "client.get_referrals ok", "ms", time.Since(start).Milliseconds(), "userID", userID, "referred", st.Referred)
+	return out, nil
+}
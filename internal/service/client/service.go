@@ -9,29 +9,55 @@ import (
 	"Beanefits/internal/domain/errs"
 	pg "Beanefits/internal/repository/postgres"
 	pgdto "Beanefits/internal/repository/postgres/dto"
+	"Beanefits/internal/service/lots"
+	"Beanefits/internal/service/referrals"
 )
 
 type Clock func() time.Time
 
 type Service struct {
-	db pg.DBTX
+	db  pg.DBTX
+	txm pg.TxManager
 
-	users    pg.UsersRepo
-	roles    pg.RolesRepo
-	accounts pg.AccountsRepo
-	events   pg.EventsRepo
+	users      pg.UsersRepo
+	roles      pg.RolesRepo
+	accounts   pg.AccountsRepo
+	events     pg.EventsRepo
+	lots       pg.LotsRepo
+	operations pg.OperationsRepo
+	lotBook    *lots.Book
+	referrals  *referrals.Program
+
+	transferLimits TransferLimits
 
 	now Clock
 	log *slog.Logger
 }
 
+// TransferLimits caps what one account can send per UTC day; 0 means no limit.
+type TransferLimits struct {
+	DailyPoints int
+	DailyCount  int
+}
+
 type Deps struct {
-	DB pg.DBTX
+	DB  pg.DBTX
+	TXM pg.TxManager
+
+	Users      pg.UsersRepo
+	Roles      pg.RolesRepo
+	Accounts   pg.AccountsRepo
+	Events     pg.EventsRepo
+	Lots       pg.LotsRepo
+	Operations pg.OperationsRepo
 
-	Users    pg.UsersRepo
-	Roles    pg.RolesRepo
-	Accounts pg.AccountsRepo
-	Events   pg.EventsRepo
+	// LotBook moves lots between accounts on transfers.
+	LotBook *lots.Book
+
+	// Referrals reads the caller's referral code and stats.
+	Referrals *referrals.Program
+
+	TransferLimits TransferLimits
 
 	Now Clock
 	Log *slog.Logger
@@ -49,21 +75,33 @@ func New(deps Deps) *Service {
 	}
 	l = l.With("layer", "service", "svc", "client")
 
-	if deps.DB == nil {
-		panic("client.New: deps.DB is nil")
+	if deps.DB == nil || deps.TXM == nil {
+		panic("client.New: deps.DB or deps.TXM is nil")
 	}
-	if deps.Users == nil || deps.Roles == nil || deps.Accounts == nil || deps.Events == nil {
+	if deps.Users == nil || deps.Roles == nil || deps.Accounts == nil || deps.Events == nil || deps.Lots == nil || deps.Operations == nil {
 		panic("client.New: repos are nil")
 	}
+	if deps.LotBook == nil {
+		panic("client.New: deps.LotBook is nil")
+	}
+	if deps.Referrals == nil {
+		panic("client.New: deps.Referrals is nil")
+	}
 
 	return &Service{
-		db:       deps.DB,
-		users:    deps.Users,
-		roles:    deps.Roles,
-		accounts: deps.Accounts,
-		events:   deps.Events,
-		now:      n,
-		log:      l,
+		db:             deps.DB,
+		txm:            deps.TXM,
+		users:          deps.Users,
+		roles:          deps.Roles,
+		accounts:       deps.Accounts,
+		events:         deps.Events,
+		lots:           deps.Lots,
+		operations:     deps.Operations,
+		lotBook:        deps.LotBook,
+		referrals:      deps.Referrals,
+		transferLimits: deps.TransferLimits,
+		now:            n,
+		log:            l,
 	}
 }
 
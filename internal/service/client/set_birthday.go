@@ -0,0 +1,42 @@
+package client
+
+import (
+	"context"
+	"time"
+
+	"Beanefits/internal/domain/errs"
+	"Beanefits/internal/domain/user"
+	pgdto "Beanefits/internal/repository/postgres/dto"
+	sdto "Beanefits/internal/service/dto"
+)
+
+// SetBirthday sets or clears the client's birthday and returns the updated profile.
+// Changing it does not pay the birthday bonus a second time in a year: grants are recorded per account.
+func (s *Service) SetBirthday(ctx context.Context, userID int64, in sdto.SetBirthdayIn) (sdto.ClientProfileOut, error) {
+	start := time.Now()
+	s.log.InfoContext(ctx, "client.set_birthday start", "userID", userID)
+
+	var birthday *pgdto.Ts
+	if in.Birthday != nil {
+		b, err := user.ParseBirthday(*in.Birthday, s.now())
+		if err != nil {
+			s.log.ErrorContext(ctx, "client.set_birthday failed", "ms", time.Since(start).Milliseconds(), "err", err)
+			return sdto.ClientProfileOut{}, err
+		}
+		birthday = &b
+	}
+
+	if _, err := s.requireActiveUser(ctx, userID); err != nil {
+		s.log.ErrorContext(ctx, "client.set_birthday failed", "ms", time.Since(start).Milliseconds(), "err", err)
+		return sdto.ClientProfileOut{}, err
+	}
+
+	if err := s.users.SetBirthday(ctx, s.db, userID, birthday); err != nil {
+		wrapped := errs.Wrap(errs.CodeInternal, "users.set_birthday", err)
+		s.log.ErrorContext(ctx, "client.set_birthday failed", "ms", time.Since(start).Milliseconds(), "err", wrapped)
+		return sdto.ClientProfileOut{}, wrapped
+	}
+
+	s.log.InfoContext(ctx, "client.set_birthday ok", "ms", time.Since(start).Milliseconds(), "userID", userID, "cleared", birthday == nil)
+	return s.GetMe(ctx, userID)
+}
@@ -0,0 +1,288 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"Beanefits/internal/domain/account"
+	"Beanefits/internal/domain/errs"
+	"Beanefits/internal/domain/ledger"
+	"Beanefits/internal/domain/user"
+	pg "Beanefits/internal/repository/postgres"
+	pgdto "Beanefits/internal/repository/postgres/dto"
+	sdto "Beanefits/internal/service/dto"
+	"Beanefits/internal/service/idempotency"
+	"Beanefits/internal/service/mapper"
+)
+
+// Transfer moves points from the caller's account to another customer found by public code or phone.
+// Both accounts are locked in id order, so opposite transfers cannot deadlock.
+// Transferred points keep their expiry dates. Idempotent by (sender account + operationId).
+func (s *Service) Transfer(ctx context.Context, userID int64, in sdto.TransferIn) (sdto.OperationOut, error) {
+	start := time.Now()
+	s.log.InfoContext(ctx, "client.transfer start", "userID", userID, "operationID", in.OperationID, "points", in.Points)
+
+	if err := validateTransfer(in); err != nil {
+		s.log.ErrorContext(ctx, "client.transfer failed", "ms", time.Since(start).Milliseconds(), "err", err)
+		return sdto.OperationOut{}, err
+	}
+
+	if _, err := s.requireActiveUser(ctx, userID); err != nil {
+		s.log.ErrorContext(ctx, "client.transfer failed", "ms", time.Since(start).Milliseconds(), "err", err)
+		return sdto.OperationOut{}, err
+	}
+
+	opTs := s.now()
+	var result sdto.OperationOut
+
+	err := idempotency.WithinTx(ctx, s.txm, func(ctx context.Context, tx pg.DBTX) error {
+		fromRow, ok, err := s.accounts.GetByUserID(ctx, tx, userID)
+		if err != nil {
+			return errs.Wrap(errs.CodeInternal, "accounts.get_by_user_id", err)
+		}
+		if !ok {
+			return errs.New(errs.CodeAccountNotFound, "account not found")
+		}
+
+		reqJSON, err := marshalTransferRequest(in)
+		if err != nil {
+			return errs.Wrap(errs.CodeInternal, "marshal transfer request", err)
+		}
+
+		inserted, err := s.operations.InsertPending(ctx, tx, pgdto.OperationPendingInsert{
+			AccountID:   fromRow.ID,
+			OpType:      pgdto.OpTransfer,
+			OperationID: in.OperationID,
+			RequestJSON: reqJSON,
+		})
+		if err != nil {
+			return errs.Wrap(errs.CodeInternal, "operations.insert_pending", err)
+		}
+		if !inserted {
+			if err := idempotency.Replay(ctx, s.operations, tx, fromRow.ID, pgdto.OpTransfer, in.OperationID, reqJSON, &result); err != nil {
+				return err
+			}
+			result.IdempotentReplay = true
+			return nil
+		}
+		if err := idempotency.RejectExpired(ctx, s.operations, tx, fromRow.ID, pgdto.OpTransfer, in.OperationID); err != nil {
+			return err
+		}
+
+		toRow, err := s.recipientAccount(ctx, tx, in)
+		if err != nil {
+			return err
+		}
+		if toRow.ID == fromRow.ID {
+			return errs.New(errs.CodeInvalidTransfer, "cannot transfer points to own account")
+		}
+
+		// concurrency gate: lower account id first, whichever side it is
+		lockedFrom, lockedTo, err := s.lockPair(ctx, tx, fromRow.ID, toRow.ID)
+		if err != nil {
+			return err
+		}
+
+		if msg, exceeded, err := s.transferLimitExceeded(ctx, tx, lockedFrom.ID, in.Points, opTs); err != nil {
+			return err
+		} else if exceeded {
+			return idempotency.BusinessErr(ctx, s.operations, tx, lockedFrom.ID, pgdto.OpTransfer, in.OperationID, errs.CodeTransferLimitExceeded, msg)
+		}
+
+		from, err := mapper.Account(lockedFrom)
+		if err != nil {
+			return errs.Wrap(errs.CodeInternal, "account aggregate build failed", err)
+		}
+		to, err := mapper.Account(lockedTo)
+		if err != nil {
+			return errs.Wrap(errs.CodeInternal, "account aggregate build failed", err)
+		}
+
+		actor := userID
+		p := ledger.Points(in.Points)
+
+		updatedFrom, outDraft, err := from.ApplyTransferOut(p, &actor, opTs)
+		if err != nil {
+			if code, ok := errs.CodeOf(err); ok && code == errs.CodeNotEnoughBalance {
+				return idempotency.BusinessErr(ctx, s.operations, tx, from.ID, pgdto.OpTransfer, in.OperationID, errs.CodeNotEnoughBalance, "not enough balance")
+			}
+			return err
+		}
+
+		outRow, err := s.events.Insert(ctx, tx, mapper.EventInsert(outDraft))
+		if err != nil {
+			return errs.Wrap(errs.CodeInternal, "events.insert", err)
+		}
+
+		updatedTo, inDraft, err := to.ApplyTransferIn(p, outRow.ID, &actor, opTs)
+		if err != nil {
+			return err
+		}
+
+		inRow, err := s.events.Insert(ctx, tx, mapper.EventInsert(inDraft))
+		if err != nil {
+			return errs.Wrap(errs.CodeInternal, "events.insert", err)
+		}
+
+		if err := s.lotBook.Move(ctx, tx, updatedFrom.ID, updatedTo.ID, inRow.ID, p); err != nil {
+			return err
+		}
+
+		fromAfter, err := s.accounts.UpdateAfterSpend(ctx, tx, updatedFrom.ID, updatedFrom.Balance.Int())
+		if err != nil {
+			return errs.Wrap(errs.CodeInternal, "accounts.update_after_spend", err)
+		}
+		if _, err := s.accounts.UpdateAfterSpend(ctx, tx, updatedTo.ID, updatedTo.Balance.Int()); err != nil {
+			return errs.Wrap(errs.CodeInternal, "accounts.update_after_spend", err)
+		}
+
+		result = sdto.OperationOut{
+			OperationID:      in.OperationID,
+			OpType:           sdto.OpTransfer,
+			Event:            mapper.EventOut(outRow),
+			Balance:          mapper.BalanceOut(fromAfter, s.now()),
+			IdempotentReplay: false,
+		}
+
+		eventID := outRow.ID
+		return idempotency.Finalize(ctx, s.operations, tx, fromAfter.ID, pgdto.OpTransfer, in.OperationID, result, &eventID)
+	})
+
+	if err != nil {
+		s.log.ErrorContext(ctx, "client.transfer failed", "ms", time.Since(start).Milliseconds(), "err", err)
+		return sdto.OperationOut{}, err
+	}
+
+	s.log.InfoContext(ctx, "client.transfer ok",
+		"ms", time.Since(start).Milliseconds(),
+		"userID", userID,
+		"operationID", in.OperationID,
+		"points", in.Points,
+		"replay", result.IdempotentReplay,
+	)
+
+	return result, nil
+}
+
+func validateTransfer(in sdto.TransferIn) error {
+	if in.Points <= 0 {
+		return errs.New(errs.CodeInvalidPoints, "points must be > 0")
+	}
+	if (in.RecipientPublicCode == nil) == (in.RecipientPhone == nil) {
+		return errs.New(errs.CodeInvalidTransfer, "exactly one of recipientPublicCode and recipientPhone is required")
+	}
+	if in.RecipientPublicCode != nil {
+		if _, err := account.ParsePublicCode(*in.RecipientPublicCode); err != nil {
+			return err
+		}
+	}
+	if in.RecipientPhone != nil {
+		if _, err := user.ParsePhone(*in.RecipientPhone); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// recipientAccount resolves the recipient; accounts of inactive users are treated as missing.
+func (s *Service) recipientAccount(ctx context.Context, tx pg.DBTX, in sdto.TransferIn) (pgdto.AccountRow, error) {
+	var (
+		acc pgdto.AccountRow
+		ok  bool
+		err error
+	)
+
+	if in.RecipientPublicCode != nil {
+		acc, ok, err = s.accounts.GetByPublicCode(ctx, tx, *in.RecipientPublicCode)
+		if err != nil {
+			return pgdto.AccountRow{}, errs.Wrap(errs.CodeInternal, "accounts.get_by_public_code", err)
+		}
+	} else {
+		u, found, err := s.users.GetByPhone(ctx, tx, *in.RecipientPhone)
+		if err != nil {
+			return pgdto.AccountRow{}, errs.Wrap(errs.CodeInternal, "users.get_by_phone", err)
+		}
+		if found {
+			acc, ok, err = s.accounts.GetByUserID(ctx, tx, u.ID)
+			if err != nil {
+				return pgdto.AccountRow{}, errs.Wrap(errs.CodeInternal, "accounts.get_by_user_id", err)
+			}
+		}
+	}
+	if !ok {
+		return pgdto.AccountRow{}, errs.New(errs.CodeAccountNotFound, "recipient account not found")
+	}
+
+	u, found, err := s.users.GetByID(ctx, tx, acc.UserID)
+	if err != nil {
+		return pgdto.AccountRow{}, errs.Wrap(errs.CodeInternal, "users.get_by_id", err)
+	}
+	if !found || !u.IsActive {
+		return pgdto.AccountRow{}, errs.New(errs.CodeAccountNotFound, "recipient account not found")
+	}
+	return acc, nil
+}
+
+// lockPair locks both accounts in ascending id order and returns them as (from, to).
+func (s *Service) lockPair(ctx context.Context, tx pg.DBTX, fromID, toID int64) (pgdto.AccountRow, pgdto.AccountRow, error) {
+	firstID, secondID := fromID, toID
+	if secondID < firstID {
+		firstID, secondID = secondID, firstID
+	}
+
+	first, err := s.accounts.LockByID(ctx, tx, firstID)
+	if err != nil {
+		return pgdto.AccountRow{}, pgdto.AccountRow{}, errs.Wrap(errs.CodeInternal, "accounts.lock_by_id", err)
+	}
+	second, err := s.accounts.LockByID(ctx, tx, secondID)
+	if err != nil {
+		return pgdto.AccountRow{}, pgdto.AccountRow{}, errs.Wrap(errs.CodeInternal, "accounts.lock_by_id", err)
+	}
+
+	if first.ID == fromID {
+		return first, second, nil
+	}
+	return second, first, nil
+}
+
+// transferLimitExceeded checks the sender's TRANSFER_OUT totals for the UTC day of ts.
+// Must be called with the sender locked so concurrent transfers see each other.
+func (s *Service) transferLimitExceeded(ctx context.Context, tx pg.DBTX, accountID int64, points int, ts time.Time) (string, bool, error) {
+	lim := s.transferLimits
+	if lim.DailyPoints <= 0 && lim.DailyCount <= 0 {
+		return "", false, nil
+	}
+
+	dayStart := ts.UTC().Truncate(24 * time.Hour)
+	sent, err := s.events.SumByTypeInRange(ctx, tx, accountID, pgdto.EventTransferOut, dayStart, dayStart.Add(24*time.Hour))
+	if err != nil {
+		return "", false, errs.Wrap(errs.CodeInternal, "events.sum_by_type_in_range", err)
+	}
+
+	if lim.DailyCount > 0 && sent.Count+1 > lim.DailyCount {
+		return "daily transfer count limit reached", true, nil
+	}
+	if lim.DailyPoints > 0 && sent.Points+points > lim.DailyPoints {
+		return "daily transfer points limit exceeded", true, nil
+	}
+	return "", false, nil
+}
+
+// marshalTransferRequest builds the canonical request compared on replay.
+// The transfer time is always the server's, so it is not part of the request.
+func marshalTransferRequest(in sdto.TransferIn) (pgdto.JSON, error) {
+	type req struct {
+		OperationID         string  `json:"operationId"`
+		RecipientPublicCode *string `json:"recipientPublicCode,omitempty"`
+		RecipientPhone      *string `json:"recipientPhone,omitempty"`
+		Points              int     `json:"points"`
+	}
+	b, err := json.Marshal(req{
+		OperationID:         in.OperationID,
+		RecipientPublicCode: in.RecipientPublicCode,
+		RecipientPhone:      in.RecipientPhone,
+		Points:              in.Points,
+	})
+	return pgdto.JSON(b), err
+}
@@ -17,9 +17,39 @@ type UsersOut struct {
 
 // CreateRulesetIn is the usecase input for creating a new ruleset (not retroactive).
 type CreateRulesetIn struct {
-	EffectiveFrom   time.Time     `validate:"required"`
-	BaseRubPerPoint string        `validate:"required,decimal2,gtzero_decimal"`
-	Levels          []LevelRuleIn `validate:"required,min=1,dive"`
+	EffectiveFrom     time.Time     `validate:"required"`
+	BaseRubPerPoint   string        `validate:"required,decimal2,gtzero_decimal"`
+	RedeemRubPerPoint *string       `validate:"omitempty,decimal2,gtzero_decimal"` // default 1.00
+	Levels            []LevelRuleIn `validate:"required,min=1,dive"`
+
+	// QualificationWindowDays switches levels to rolling-window qualification; nil keeps lifetime spend.
+	QualificationWindowDays *int `validate:"omitempty,gte=1,lte=3650"`
+
+	// CategoryRates set the earn factor of receipt line categories; others earn at factor 1.
+	CategoryRates []CategoryRateIn `validate:"omitempty,dive"`
+
+	// Bonus amounts credited as BONUS events; 0 disables the bonus.
+	WelcomeBonusPoints  int `validate:"gte=0"`
+	BirthdayBonusPoints int `validate:"gte=0"`
+
+	// Referral bonuses paid on the referee's first EARN, to the referrer and to the referee.
+	ReferrerBonusPoints int `validate:"gte=0"`
+	RefereeBonusPoints  int `validate:"gte=0"`
+
+	// Rounding policy of earn points; empty keeps the default (FLOOR, PER_STEP).
+	RoundingMode  string `validate:"omitempty,oneof=FLOOR HALF_UP HALF_EVEN"`
+	RoundingStage string `validate:"omitempty,oneof=PER_STEP AT_END"`
+
+	// Earn caps: purchases below MinEarnAmount earn nothing (nil: 0.00); nil caps mean no cap.
+	MinEarnAmount        *string `validate:"omitempty,decimal2"`
+	MaxPointsPerPurchase *int    `validate:"omitempty,gte=1"`
+	MaxPointsPerDay      *int    `validate:"omitempty,gte=1"`
+}
+
+// CategoryRateIn is the earn factor of a receipt line category (1.00 = the level percent, 0 = nothing).
+type CategoryRateIn struct {
+	Category   string `validate:"required,min=1,max=64"`
+	EarnFactor string `validate:"required,decimal2"`
 }
 
 // LevelRuleIn is a single level definition inside a ruleset.
@@ -27,6 +57,53 @@ type LevelRuleIn struct {
 	LevelCode           string `validate:"required,min=1,max=64"`
 	ThresholdTotalSpend string `validate:"required,decimal2"`
 	PercentEarn         string `validate:"required,decimal2,gtzero_decimal"`
+
+	// ReachedBonusPoints are credited once per account the first time it is moved up to this level.
+	ReachedBonusPoints int `validate:"gte=0"`
+}
+
+// SimulateRulesetIn replays the EARN events with From <= ts < To under the Draft ruleset.
+// Draft is validated like a new ruleset; its EffectiveFrom is not used.
+type SimulateRulesetIn struct {
+	Draft    CreateRulesetIn `validate:"required"`
+	From     time.Time       `validate:"required"`
+	To       time.Time       `validate:"required"`
+	TopLimit int             `validate:"omitempty,gte=1,lte=100"` // 0: default
+}
+
+// SimulateRulesetOut compares the draft with what was actually issued.
+// Points exclude campaign rewards; levels are resolved on each account's purchase spend at the end of the range.
+type SimulateRulesetOut struct {
+	From            time.Time `validate:"required"`
+	To              time.Time `validate:"required"`
+	ActualRulesetID int64     `validate:"required,gt=0"` // ruleset the actual levels are resolved with
+	Purchases       int       `validate:"gte=0"`
+	Accounts        int       `validate:"gte=0"`
+
+	ActualPoints int `validate:"gte=0"`
+	DraftPoints  int `validate:"gte=0"`
+
+	ActualLevels []LevelCountOut `validate:"required"`
+	DraftLevels  []LevelCountOut `validate:"required"`
+
+	// TopAccounts changed the most: largest points difference first.
+	TopAccounts []SimulatedAccountOut `validate:"required"`
+}
+
+// LevelCountOut is the number of simulated accounts at a level.
+type LevelCountOut struct {
+	LevelCode string `validate:"required"`
+	Accounts  int    `validate:"gte=0"`
+}
+
+// SimulatedAccountOut is the result of one account under both rulesets.
+type SimulatedAccountOut struct {
+	AccountID    int64  `validate:"required,gt=0"`
+	PublicCode   string `validate:"required"`
+	ActualPoints int    `validate:"gte=0"`
+	DraftPoints  int    `validate:"gte=0"`
+	ActualLevel  string `validate:"required"`
+	DraftLevel   string `validate:"required"`
 }
 
 // ListRulesetsIn is the usecase input for listing rulesets with pagination.
@@ -43,11 +120,51 @@ type RulesetsOut struct {
 
 // RulesetOut is the ruleset representation returned to admin.
 type RulesetOut struct {
-	ID              int64          `validate:"required,gt=0"`
-	EffectiveFrom   time.Time      `validate:"required"`
-	BaseRubPerPoint string         `validate:"required,decimal2"`
-	Levels          []LevelRuleOut `validate:"required"`
-	CreatedAt       time.Time      `validate:"required"`
+	ID                int64          `validate:"required,gt=0"`
+	EffectiveFrom     time.Time      `validate:"required"`
+	EffectiveTo       *time.Time     // effectiveFrom of the next approved ruleset; nil: open-ended
+	BaseRubPerPoint   string         `validate:"required,decimal2"`
+	RedeemRubPerPoint string         `validate:"required,decimal2"`
+	Levels            []LevelRuleOut `validate:"required"`
+	CreatedAt         time.Time      `validate:"required"`
+
+	QualificationWindowDays *int `validate:"omitempty,gte=1"` // nil: lifetime qualification
+
+	CategoryRates []CategoryRateOut `validate:"required"`
+
+	WelcomeBonusPoints  int `validate:"gte=0"`
+	BirthdayBonusPoints int `validate:"gte=0"`
+	ReferrerBonusPoints int `validate:"gte=0"`
+	RefereeBonusPoints  int `validate:"gte=0"`
+
+	RoundingMode  string `validate:"required,oneof=FLOOR HALF_UP HALF_EVEN"`
+	RoundingStage string `validate:"required,oneof=PER_STEP AT_END"`
+
+	MinEarnAmount        string `validate:"required,decimal2"`
+	MaxPointsPerPurchase *int   `validate:"omitempty,gte=1"` // nil: no cap
+	MaxPointsPerDay      *int   `validate:"omitempty,gte=1"` // nil: no cap
+
+	Status  string              `validate:"required,oneof=DRAFT PENDING_APPROVAL APPROVED REJECTED"`
+	History []RulesetHistoryOut `validate:"required"` // oldest first
+}
+
+// RulesetHistoryOut is one step of a ruleset's life: who did what, and when.
+type RulesetHistoryOut struct {
+	Action      string    `validate:"required,oneof=CREATED UPDATED SUBMITTED APPROVED REJECTED"`
+	ActorUserID *int64    `validate:"omitempty,gt=0"` // nil: actor deleted
+	Comment     *string   `validate:"omitempty,max=500"`
+	At          time.Time `validate:"required"`
+}
+
+// RejectRulesetIn is the usecase input for rejecting a ruleset pending approval.
+type RejectRulesetIn struct {
+	Comment string `validate:"required,min=1,max=500"`
+}
+
+// CategoryRateOut is a stored category earn factor.
+type CategoryRateOut struct {
+	Category   string `validate:"required,min=1,max=64"`
+	EarnFactor string `validate:"required,decimal2"`
 }
 
 // LevelRuleOut is the stored level rule representation returned to admin.
@@ -56,4 +173,111 @@ type LevelRuleOut struct {
 	LevelCode           string `validate:"required,min=1,max=64"`
 	ThresholdTotalSpend string `validate:"required,decimal2"`
 	PercentEarn         string `validate:"required,decimal2"`
+	ReachedBonusPoints  int    `validate:"gte=0"`
+}
+
+// AdjustBalanceIn is the usecase input for a manual balance change by an admin.
+type AdjustBalanceIn struct {
+	UserID      int64  `validate:"required,gt=0"`
+	DeltaPoints int    `validate:"required,ne=0"` // signed: + credits, - debits
+	ReasonCode  string `validate:"required,oneof=GOODWILL COMPENSATION CORRECTION OTHER"`
+	Comment     string `validate:"required,min=1,max=500"`
+}
+
+// AdjustmentOut is the ADJUST event with its reason and the balance after it.
+type AdjustmentOut struct {
+	Event      EventOut   `validate:"required"`
+	ReasonCode string     `validate:"required"`
+	Comment    string     `validate:"required"`
+	Balance    BalanceOut `validate:"required"`
+}
+
+// VerifyLedgerIn selects the accounts to verify; nil UserID means all accounts.
+type VerifyLedgerIn struct {
+	UserID *int64 `validate:"omitempty,gt=0"`
+}
+
+// LedgerCheck names the invariant a mismatch was found in.
+type LedgerCheck string
+
+const (
+	LedgerCheckBalanceAfter LedgerCheck = "BALANCE_AFTER" // event.balance_after != previous balance_after + delta
+	LedgerCheckBalance      LedgerCheck = "BALANCE"       // accounts.balance_points != sum of deltas
+	LedgerCheckTotalSpend   LedgerCheck = "TOTAL_SPEND"   // accounts.total_spend_money != EARN minus REFUND amounts
+	LedgerCheckLevel        LedgerCheck = "LEVEL"         // accounts.level_code != level resolved from replayed total spend
+	LedgerCheckHashChain    LedgerCheck = "HASH_CHAIN"    // first event whose prev_hash/hash does not match the chain
+)
+
+// LedgerMismatchOut is a single difference between the stored state and the replayed history.
+type LedgerMismatchOut struct {
+	AccountID int64       `validate:"required,gt=0"`
+	UserID    int64       `validate:"required,gt=0"`
+	EventID   *int64      `validate:"omitempty,gt=0"` // set for BALANCE_AFTER and HASH_CHAIN
+	Check     LedgerCheck `validate:"required,oneof=BALANCE_AFTER BALANCE TOTAL_SPEND LEVEL HASH_CHAIN"`
+	Expected  string      // replayed value
+	Actual    string      // stored value
+}
+
+// LedgerReportOut is the result of replaying events against stored account state.
+type LedgerReportOut struct {
+	AccountsChecked int                 `validate:"gte=0"`
+	EventsChecked   int                 `validate:"gte=0"`
+	Mismatches      []LedgerMismatchOut `validate:"required"`
+	CheckedAt       time.Time           `validate:"required"`
+}
+
+// LimitKind names what a velocity limit caps.
+type LimitKind string
+
+const (
+	LimitPointsPerOperation       LimitKind = "POINTS_PER_OPERATION"        // points of one operation
+	LimitAccountPointsPerDay      LimitKind = "ACCOUNT_POINTS_PER_DAY"      // points per account per UTC day
+	LimitCashierOperationsPerHour LimitKind = "CASHIER_OPERATIONS_PER_HOUR" // operations per cashier in the last hour
+)
+
+// SetLimitIn creates the (Kind, OpType) velocity limit or replaces its MaxValue.
+type SetLimitIn struct {
+	Kind     LimitKind     `validate:"required,oneof=POINTS_PER_OPERATION ACCOUNT_POINTS_PER_DAY CASHIER_OPERATIONS_PER_HOUR"`
+	OpType   OperationType `validate:"required,oneof=EARN SPEND"`
+	MaxValue int           `validate:"required,gt=0"`
+}
+
+// LimitOut is a stored velocity limit.
+type LimitOut struct {
+	ID        int64         `validate:"required,gt=0"`
+	Kind      LimitKind     `validate:"required"`
+	OpType    OperationType `validate:"required"`
+	MaxValue  int           `validate:"required,gt=0"`
+	UpdatedBy *int64        `validate:"omitempty,gt=0"`
+	UpdatedAt time.Time     `validate:"required"`
+}
+
+// CampaignIn defines a time-boxed earn promotion (create and full replace).
+// Exactly one of Multiplier and BonusPoints must be set.
+type CampaignIn struct {
+	Name        string     `validate:"required,min=1,max=128"`
+	StartsOn    time.Time  `validate:"required"`                                         // date
+	EndsOn      *time.Time `validate:"omitempty"`                                        // date, inclusive
+	Weekdays    []string   `validate:"omitempty,dive,oneof=MON TUE WED THU FRI SAT SUN"` // empty: every day
+	TimeFrom    *string    `validate:"omitempty"`                                        // "HH:MM", with TimeTo
+	TimeTo      *string    `validate:"omitempty"`
+	Timezone    string     `validate:"omitempty"` // IANA name, default UTC
+	Multiplier  *string    `validate:"omitempty,decimal2"`
+	BonusPoints *int       `validate:"omitempty,gt=0"`
+}
+
+// CampaignOut is a stored campaign.
+type CampaignOut struct {
+	ID          int64      `validate:"required,gt=0"`
+	Name        string     `validate:"required"`
+	StartsOn    time.Time  `validate:"required"`
+	EndsOn      *time.Time `validate:"omitempty"`
+	Weekdays    []string   `validate:"required"`
+	TimeFrom    *string    `validate:"omitempty"`
+	TimeTo      *string    `validate:"omitempty"`
+	Timezone    string     `validate:"required"`
+	Multiplier  *string    `validate:"omitempty,decimal2"`
+	BonusPoints *int       `validate:"omitempty,gt=0"`
+	UpdatedBy   *int64     `validate:"omitempty,gt=0"`
+	UpdatedAt   time.Time  `validate:"required"`
 }
@@ -2,8 +2,10 @@ package dto
 
 // RegisterIn — вход в usecase Auth.RegisterClient.
 type RegisterIn struct {
-	Phone    string `validate:"required,phone"`
-	Password string `validate:"required,min=6,max=128"`
+	Phone        string  `validate:"required,phone"`
+	Password     string  `validate:"required,min=6,max=128"`
+	Birthday     *string `validate:"omitempty"` // YYYY-MM-DD
+	ReferralCode *string `validate:"omitempty"` // code of the referring account
 }
 
 // LoginIn — вход в usecase Auth.Login.
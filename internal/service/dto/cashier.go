@@ -13,6 +13,18 @@ type EarnIn struct {
 	PublicCode  string     `validate:"required,min=6,max=64"`
 	AmountMoney string     `validate:"required,decimal2"` // decimal-as-string, up to 2 fractional digits
 	Ts          *time.Time `validate:"omitempty"`
+
+	// Lines optionally itemize the receipt; their totals must add up to AmountMoney.
+	// Each line earns at the ruleset's earn factor of its category.
+	Lines []ReceiptLineIn `validate:"omitempty,max=200,dive"`
+}
+
+// ReceiptLineIn is a single receipt line of an EARN.
+type ReceiptLineIn struct {
+	SKU       string `validate:"required,min=1,max=64"`
+	Category  string `validate:"required,min=1,max=64"`
+	Quantity  int    `validate:"required,gt=0"`
+	LineTotal string `validate:"required,decimal2"`
 }
 
 // SpendIn is the usecase input for spending points (idempotent, concurrency-safe).
@@ -23,19 +35,174 @@ type SpendIn struct {
 	Ts           *time.Time `validate:"omitempty"`
 }
 
+// RefundIn is the usecase input for refunding a purchase that earned points (idempotent).
+// AmountMoney is optional: when omitted, the whole remaining (not yet refunded) amount is refunded.
+type RefundIn struct {
+	OperationID string     `validate:"required,uuid"`
+	PublicCode  string     `validate:"required,min=6,max=64"`
+	EventID     int64      `validate:"required,gt=0"`      // original EARN event
+	AmountMoney *string    `validate:"omitempty,decimal2"` // decimal-as-string, up to 2 fractional digits
+	Ts          *time.Time `validate:"omitempty"`
+}
+
+// VoidIn is the usecase input for voiding a SPEND operation (idempotent).
+type VoidIn struct {
+	OperationID      string     `validate:"required,uuid"`
+	PublicCode       string     `validate:"required,min=6,max=64"`
+	SpendOperationID string     `validate:"required,uuid"` // operationId of the original SPEND
+	Ts               *time.Time `validate:"omitempty"`
+}
+
+// CheckoutIn is the usecase input for a combined checkout (idempotent):
+// RedeemPoints pay for part of BillMoney, points are earned on the rest.
+type CheckoutIn struct {
+	OperationID  string     `validate:"required,uuid"`
+	PublicCode   string     `validate:"required,min=6,max=64"`
+	BillMoney    string     `validate:"required,decimal2"` // decimal-as-string, up to 2 fractional digits
+	RedeemPoints int        `validate:"gte=0"`
+	Ts           *time.Time `validate:"omitempty"`
+}
+
+// EarnQuoteIn asks what an EARN of AmountMoney would give the account right now; nothing is written.
+type EarnQuoteIn struct {
+	PublicCode  string `validate:"required,min=6,max=64"`
+	AmountMoney string `validate:"required,decimal2"` // decimal-as-string, up to 2 fractional digits
+}
+
+// BatchIn is a queue of EARN/SPEND operations replayed by a till after it was offline.
+type BatchIn struct {
+	Items []BatchItemIn `validate:"required,min=1,max=100,dive"`
+}
+
+// BatchItemIn is one queued operation: AmountMoney is used for EARN, AmountPoints for SPEND.
+type BatchItemIn struct {
+	Type         OperationType `validate:"required,oneof=EARN SPEND"`
+	OperationID  string        `validate:"required,uuid"`
+	PublicCode   string        `validate:"required,min=6,max=64"`
+	AmountMoney  *string       `validate:"omitempty,decimal2"`
+	AmountPoints *int          `validate:"omitempty,gt=0"`
+	Ts           *time.Time    `validate:"omitempty"`
+}
+
+// HoldAuthorizeIn reserves points for a later capture (idempotent by account + operationId).
+type HoldAuthorizeIn struct {
+	OperationID string `validate:"required,uuid"`
+	PublicCode  string `validate:"required,min=6,max=64"`
+	Points      int    `validate:"required,gt=0"`
+}
+
+// HoldCaptureIn spends held points; Points defaults to the whole hold, the rest is released.
+type HoldCaptureIn struct {
+	HoldID int64 `validate:"required,gt=0"`
+	Points *int  `validate:"omitempty,gt=0"`
+}
+
 // OperationType is a stable operation kind for idempotency.
 type OperationType string
 
 const (
-	OpEarn  OperationType = "EARN"
-	OpSpend OperationType = "SPEND"
+	OpEarn     OperationType = "EARN"
+	OpSpend    OperationType = "SPEND"
+	OpRefund   OperationType = "REFUND"
+	OpVoid     OperationType = "VOID"
+	OpCheckout OperationType = "CHECKOUT"
+	OpTransfer OperationType = "TRANSFER"
 )
 
-// OperationOut is returned by Earn/Spend usecases.
+// OperationOut is returned by Earn/Spend/Refund/Void usecases and by a client Transfer.
 type OperationOut struct {
 	OperationID      string        `validate:"required,uuid"`
-	OpType           OperationType `validate:"required,oneof=EARN SPEND"`
+	OpType           OperationType `validate:"required,oneof=EARN SPEND REFUND VOID TRANSFER"`
 	Event            EventOut      `validate:"required"`
 	Balance          BalanceOut    `validate:"required"`
 	IdempotentReplay bool          `validate:"-"`
+
+	// LevelChange is set when the operation moved the account to another level.
+	LevelChange *LevelChangeOut `validate:"omitempty"`
+}
+
+// LevelChangeOut is a level transition recorded as a LEVEL_CHANGED event.
+// BonusEventID is the BONUS event of the reached bonus; nil when none was paid.
+type LevelChangeOut struct {
+	EventID      int64  `validate:"required,gt=0"`
+	FromLevel    string `validate:"-"`
+	ToLevel      string `validate:"required"`
+	BonusPoints  int    `validate:"gte=0"`
+	BonusEventID *int64 `validate:"omitempty"`
+}
+
+// CheckoutOut is returned by the Checkout usecase.
+// SpendEvent is nil when no points were redeemed, EarnEvent is nil when the whole bill was paid with points.
+type CheckoutOut struct {
+	OperationID      string        `validate:"required,uuid"`
+	OpType           OperationType `validate:"required,eq=CHECKOUT"`
+	BillMoney        string        `validate:"required"`
+	DiscountMoney    string        `validate:"required"`
+	PaidMoney        string        `validate:"required"`
+	SpendEvent       *EventOut     `validate:"omitempty"`
+	EarnEvent        *EventOut     `validate:"omitempty"`
+	Balance          BalanceOut    `validate:"required"`
+	IdempotentReplay bool          `validate:"-"`
+
+	LevelChange *LevelChangeOut `validate:"omitempty"`
+}
+
+// EarnQuoteOut is the preview of an EARN: RulesetPoints are computed (and capped) under the ruleset,
+// Points add the rewards of the active campaigns. PercentEarn is the percent of LevelBefore.
+type EarnQuoteOut struct {
+	PublicCode      string               `validate:"required"`
+	AmountMoney     string               `validate:"required"`
+	RulesetID       int64                `validate:"required,gt=0"`
+	BaseRubPerPoint string               `validate:"required"`
+	PercentEarn     string               `validate:"required"`
+	LevelBefore     string               `validate:"required"`
+	LevelAfter      string               `validate:"required"`
+	RulesetPoints   int                  `validate:"gte=0"`
+	Campaigns       []AppliedCampaignOut `validate:"omitempty"`
+	Points          int                  `validate:"gte=0"`
+	Ts              time.Time            `validate:"required"`
+}
+
+// BatchOut has one entry per input item, in input order.
+type BatchOut struct {
+	Items []BatchItemOut `validate:"required"`
+}
+
+// BatchItemOut holds either the operation result or the error the item failed with.
+type BatchItemOut struct {
+	Index       int           `validate:"gte=0"`
+	Type        OperationType `validate:"required"`
+	OperationID string        `validate:"required"`
+	Result      *OperationOut `validate:"omitempty"`
+	Err         error         `validate:"-"`
+}
+
+type HoldStatus string
+
+const (
+	HoldActive   HoldStatus = "ACTIVE"
+	HoldCaptured HoldStatus = "CAPTURED"
+	HoldReleased HoldStatus = "RELEASED"
+	HoldExpired  HoldStatus = "EXPIRED"
+)
+
+type HoldOut struct {
+	ID             int64      `validate:"required,gt=0"`
+	AccountID      int64      `validate:"required,gt=0"`
+	OperationID    string     `validate:"required,uuid"`
+	Points         int        `validate:"required,gt=0"`
+	Status         HoldStatus `validate:"required,oneof=ACTIVE CAPTURED RELEASED EXPIRED"`
+	ExpiresAt      time.Time  `validate:"required"`
+	CapturedPoints *int       `validate:"omitempty,gt=0"`
+	CreatedAt      time.Time  `validate:"required"`
+	ClosedAt       *time.Time `validate:"omitempty"`
+}
+
+// HoldResultOut is returned by AuthorizeHold/CaptureHold/ReleaseHold.
+// Event is the SPEND written by a capture (nil otherwise).
+type HoldResultOut struct {
+	Hold             HoldOut    `validate:"required"`
+	Event            *EventOut  `validate:"omitempty"`
+	Balance          BalanceOut `validate:"required"`
+	IdempotentReplay bool       `validate:"-"`
 }
@@ -8,13 +8,32 @@ type ClientProfileOut struct {
 	Account AccountBase   `validate:"required"`
 }
 
+// SetBirthdayIn — вход в usecase Client.SetBirthday; nil clears the birthday.
+type SetBirthdayIn struct {
+	Birthday *string `validate:"omitempty"` // YYYY-MM-DD
+}
+
 // BalanceOut — ответ для Client.GetBalance(userID)
 type BalanceOut struct {
 	AccountID       int64     `validate:"required,gt=0"`
-	BalancePoints   int       `validate:"required,gte=0"`
+	BalancePoints   int       `validate:"required,gte=0"` // ledger balance, includes HeldPoints
+	HeldPoints      int       `validate:"gte=0"`          // reserved by active holds
+	AvailablePoints int       `validate:"gte=0"`          // BalancePoints - HeldPoints (never below 0)
 	TotalSpendMoney string    `validate:"required"`
 	LevelCode       string    `validate:"required,min=1,max=64"`
 	AsOf            time.Time `validate:"required"`
+
+	// nearest expiry: ExpiringPoints points expire on ExpiringOn (UTC date); nil if nothing expires
+	ExpiringPoints *int       `validate:"omitempty,gt=0"`
+	ExpiringOn     *time.Time `validate:"omitempty"`
+}
+
+// TransferIn — вход для Client.Transfer(userID, in); получатель задаётся ровно одним из RecipientPublicCode / RecipientPhone
+type TransferIn struct {
+	OperationID         string  `validate:"required,uuid"`
+	RecipientPublicCode *string `validate:"omitempty,min=6,max=64"`
+	RecipientPhone      *string `validate:"omitempty,phone"`
+	Points              int     `validate:"required,gt=0"`
 }
 
 // EventsIn — вход для Client.GetEvents(userID, in)
@@ -33,19 +52,48 @@ type EventsOut struct {
 type EventType string
 
 const (
-	EventEarn  EventType = "EARN"
-	EventSpend EventType = "SPEND"
+	EventEarn         EventType = "EARN"
+	EventSpend        EventType = "SPEND"
+	EventRefund       EventType = "REFUND"
+	EventVoid         EventType = "VOID"
+	EventExpire       EventType = "EXPIRE"
+	EventAdjust       EventType = "ADJUST"
+	EventTransferOut  EventType = "TRANSFER_OUT"
+	EventTransferIn   EventType = "TRANSFER_IN"
+	EventBonus        EventType = "BONUS"
+	EventLevelChanged EventType = "LEVEL_CHANGED"
 )
 
 // EventOut — строка истории для клиента
 type EventOut struct {
 	ID           int64     `validate:"required,gt=0"`
 	AccountID    int64     `validate:"required,gt=0"`
-	Type         EventType `validate:"required,oneof=EARN SPEND"`
+	Type         EventType `validate:"required,oneof=EARN SPEND REFUND VOID EXPIRE ADJUST TRANSFER_OUT TRANSFER_IN BONUS LEVEL_CHANGED"`
 	DeltaPoints  int       `validate:"required"`
 	BalanceAfter int       `validate:"required,gte=0"`
 	AmountMoney  *string   `validate:"omitempty"`
 	RulesetID    *int64    `validate:"omitempty"`
 	ActorUserID  *int64    `validate:"omitempty"`
+	RefEventID   *int64    `validate:"omitempty"`
 	Ts           time.Time `validate:"required"`
+
+	// Campaigns applied to an EARN; set on operation results only.
+	Campaigns []AppliedCampaignOut `validate:"omitempty"`
+}
+
+// AppliedCampaignOut is a campaign that took part in an EARN and the points it added.
+type AppliedCampaignOut struct {
+	CampaignID  int64 `validate:"required,gt=0"`
+	BonusPoints int   `validate:"gte=0"`
+}
+
+// ReferralStatsOut — ответ для Client.GetReferrals(userID)
+type ReferralStatsOut struct {
+	ReferralCode      string `validate:"required"`
+	ReferredCount     int    `validate:"gte=0"` // registered with the code
+	RewardedCount     int    `validate:"gte=0"` // made their first EARN
+	PendingCount      int    `validate:"gte=0"`
+	ReferredThisMonth int    `validate:"gte=0"`          // calendar month, UTC
+	MonthlyLimit      *int   `validate:"omitempty,gt=0"` // nil: no cap
+	BonusPointsEarned int    `validate:"gte=0"`          // referral bonuses credited to the caller
 }
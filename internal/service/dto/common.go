@@ -11,10 +11,11 @@ const (
 )
 
 type UserBase struct {
-	ID        int64     `validate:"required,gt=0"`
-	Phone     string    `validate:"required,phone"`
-	IsActive  bool      `validate:"-"`
-	CreatedAt time.Time `validate:"required"`
+	ID        int64      `validate:"required,gt=0"`
+	Phone     string     `validate:"required,phone"`
+	IsActive  bool       `validate:"-"`
+	Birthday  *time.Time `validate:"omitempty"` // date, UTC midnight
+	CreatedAt time.Time  `validate:"required"`
 }
 
 type UserWithRoles struct {
@@ -0,0 +1,95 @@
+package expiry
+
+import (
+	"context"
+	"time"
+
+	"Beanefits/internal/domain/errs"
+	"Beanefits/internal/domain/ledger"
+	pg "Beanefits/internal/repository/postgres"
+	pgdto "Beanefits/internal/repository/postgres/dto"
+	"Beanefits/internal/service/mapper"
+)
+
+// ReleaseExpiredHolds closes up to batch ACTIVE holds past their TTL as EXPIRED and returns
+// their points to the available balance, one transaction per hold.
+// It returns the number of holds that were expired.
+func (s *Service) ReleaseExpiredHolds(ctx context.Context, batch int) (int, error) {
+	start := time.Now()
+	at := s.now()
+
+	var holds []pgdto.HoldRow
+	err := s.txm.WithinTx(ctx, func(ctx context.Context, tx pg.DBTX) error {
+		rows, err := s.holds.ListExpired(ctx, tx, at, batch)
+		if err != nil {
+			return errs.Wrap(errs.CodeInternal, "holds.list_expired", err)
+		}
+		holds = rows
+		return nil
+	})
+	if err != nil {
+		s.log.ErrorContext(ctx, "expiry.release_expired_holds failed", "ms", time.Since(start).Milliseconds(), "err", err)
+		return 0, err
+	}
+
+	expired := 0
+	for _, h := range holds {
+		ok, err := s.expireHold(ctx, h.ID, h.AccountID, at)
+		if err != nil {
+			s.log.ErrorContext(ctx, "expiry.expire_hold failed", "holdID", h.ID, "err", err)
+			continue
+		}
+		if ok {
+			expired++
+		}
+	}
+
+	if len(holds) > 0 {
+		s.log.InfoContext(ctx, "expiry.release_expired_holds ok", "ms", time.Since(start).Milliseconds(), "holds", len(holds), "expired", expired)
+	}
+	return expired, nil
+}
+
+func (s *Service) expireHold(ctx context.Context, holdID, accountID int64, at time.Time) (bool, error) {
+	written := false
+
+	err := s.txm.WithinTx(ctx, func(ctx context.Context, tx pg.DBTX) error {
+		// same gate as cashier capture/release: the hold is re-read under the account lock
+		lockedRow, err := s.accounts.LockByID(ctx, tx, accountID)
+		if err != nil {
+			return errs.Wrap(errs.CodeInternal, "accounts.lock_by_id", err)
+		}
+
+		hold, ok, err := s.holds.GetByID(ctx, tx, holdID)
+		if err != nil {
+			return errs.Wrap(errs.CodeInternal, "holds.get_by_id", err)
+		}
+		if !ok || hold.Status != pgdto.HoldActive || at.Before(hold.ExpiresAt) {
+			return nil
+		}
+
+		agg, err := mapper.Account(lockedRow)
+		if err != nil {
+			return errs.Wrap(errs.CodeInternal, "account aggregate build failed", err)
+		}
+		updatedAgg := agg.ApplyReleaseHold(ledger.Points(hold.Points))
+
+		if _, err := s.accounts.SetHeld(ctx, tx, updatedAgg.ID, updatedAgg.Held.Int()); err != nil {
+			return errs.Wrap(errs.CodeInternal, "accounts.set_held", err)
+		}
+
+		if _, err := s.holds.Close(ctx, tx, pgdto.HoldClose{
+			ID:       hold.ID,
+			Status:   pgdto.HoldExpired,
+			ClosedAt: at,
+		}); err != nil {
+			return errs.Wrap(errs.CodeInternal, "holds.close", err)
+		}
+
+		s.log.InfoContext(ctx, "expiry.expire_hold ok", "holdID", hold.ID, "accountID", accountID, "points", hold.Points)
+		written = true
+		return nil
+	})
+
+	return written, err
+}
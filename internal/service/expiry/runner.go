@@ -0,0 +1,75 @@
+package expiry
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type RunConfig struct {
+	Interval time.Duration
+	Batch    int
+}
+
+// Start runs ExpireDue every Interval until stop is called.
+// A full batch is followed by another pass right away so that a backlog drains quickly.
+func Start(parent context.Context, s *Service, cfg RunConfig) (stop func()) {
+	if s == nil {
+		return func() {}
+	}
+	return run(parent, s, "expiry runner", cfg, time.Hour, s.ExpireDue)
+}
+
+// StartHolds runs ReleaseExpiredHolds every Interval until stop is called.
+func StartHolds(parent context.Context, s *Service, cfg RunConfig) (stop func()) {
+	if s == nil {
+		return func() {}
+	}
+	return run(parent, s, "holds expiry runner", cfg, time.Minute, s.ReleaseExpiredHolds)
+}
+
+func run(parent context.Context, s *Service, name string, cfg RunConfig, defInterval time.Duration, pass func(ctx context.Context, batch int) (int, error)) (stop func()) {
+	interval := cfg.Interval
+	if interval <= 0 {
+		interval = defInterval
+	}
+	batch := cfg.Batch
+	if batch <= 0 {
+		batch = 100
+	}
+
+	ctx, cancel := context.WithCancel(parent)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+
+	s.log.InfoContext(ctx, name+" started", "interval", interval, "batch", batch)
+
+	go func() {
+		defer wg.Done()
+
+		t := time.NewTicker(interval)
+		defer t.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				s.log.Info(name + " stopped")
+				return
+
+			case <-t.C:
+				for ctx.Err() == nil {
+					n, err := pass(ctx, batch)
+					if err != nil || n < batch {
+						break
+					}
+				}
+			}
+		}
+	}()
+
+	return func() {
+		cancel()
+		wg.Wait()
+	}
+}
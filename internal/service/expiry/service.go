@@ -0,0 +1,169 @@
+package expiry
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"Beanefits/internal/domain/errs"
+	pg "Beanefits/internal/repository/postgres"
+	"Beanefits/internal/service/lots"
+	"Beanefits/internal/service/mapper"
+)
+
+type Clock func() time.Time
+
+// Service writes off points of expired lots with EXPIRE events and releases holds past their TTL.
+type Service struct {
+	txm pg.TxManager
+
+	accounts pg.AccountsRepo
+	events   pg.EventsRepo
+	lotsRepo pg.LotsRepo
+	holds    pg.HoldsRepo
+	lots     *lots.Book
+
+	now Clock
+	log *slog.Logger
+}
+
+type Deps struct {
+	TXM pg.TxManager
+
+	Accounts pg.AccountsRepo
+	Events   pg.EventsRepo
+	LotsRepo pg.LotsRepo
+	Holds    pg.HoldsRepo
+	Lots     *lots.Book
+
+	Now Clock
+	Log *slog.Logger
+}
+
+func New(deps Deps) *Service {
+	n := deps.Now
+	if n == nil {
+		n = time.Now
+	}
+
+	l := deps.Log
+	if l == nil {
+		l = slog.Default()
+	}
+	l = l.With("layer", "service", "svc", "expiry")
+
+	return &Service{
+		txm:      deps.TXM,
+		accounts: deps.Accounts,
+		events:   deps.Events,
+		lotsRepo: deps.LotsRepo,
+		holds:    deps.Holds,
+		lots:     deps.Lots,
+		now:      n,
+		log:      l,
+	}
+}
+
+// AgeLegacyLots re-ages the lots opened for pre-lot balances with the configured points lifetime.
+// It runs once at startup, before the expiry runner.
+func (s *Service) AgeLegacyLots(ctx context.Context) (int, error) {
+	start := time.Now()
+
+	var aged int
+	err := s.txm.WithinTx(ctx, func(ctx context.Context, tx pg.DBTX) error {
+		var err error
+		aged, err = s.lots.AgeLegacy(ctx, tx)
+		return err
+	})
+	if err != nil {
+		s.log.ErrorContext(ctx, "expiry.age_legacy_lots failed", "ms", time.Since(start).Milliseconds(), "err", err)
+		return 0, err
+	}
+
+	if aged > 0 {
+		s.log.InfoContext(ctx, "expiry.age_legacy_lots ok", "ms", time.Since(start).Milliseconds(), "lots", aged)
+	}
+	return aged, nil
+}
+
+// ExpireDue processes up to batch accounts that have expired lots, one transaction per account.
+// It returns the number of accounts that got an EXPIRE event.
+func (s *Service) ExpireDue(ctx context.Context, batch int) (int, error) {
+	start := time.Now()
+	at := s.now()
+
+	var accountIDs []int64
+	err := s.txm.WithinTx(ctx, func(ctx context.Context, tx pg.DBTX) error {
+		ids, err := s.lotsRepo.ListAccountsWithExpired(ctx, tx, at, batch)
+		if err != nil {
+			return errs.Wrap(errs.CodeInternal, "lots.list_accounts_with_expired", err)
+		}
+		accountIDs = ids
+		return nil
+	})
+	if err != nil {
+		s.log.ErrorContext(ctx, "expiry.expire_due failed", "ms", time.Since(start).Milliseconds(), "err", err)
+		return 0, err
+	}
+
+	expired := 0
+	for _, id := range accountIDs {
+		ok, err := s.expireAccount(ctx, id, at)
+		if err != nil {
+			s.log.ErrorContext(ctx, "expiry.expire_account failed", "accountID", id, "err", err)
+			continue
+		}
+		if ok {
+			expired++
+		}
+	}
+
+	if len(accountIDs) > 0 {
+		s.log.InfoContext(ctx, "expiry.expire_due ok", "ms", time.Since(start).Milliseconds(), "accounts", len(accountIDs), "expired", expired)
+	}
+	return expired, nil
+}
+
+func (s *Service) expireAccount(ctx context.Context, accountID int64, at time.Time) (bool, error) {
+	written := false
+
+	err := s.txm.WithinTx(ctx, func(ctx context.Context, tx pg.DBTX) error {
+		// same gate as cashier operations: balance_after stays consistent
+		lockedRow, err := s.accounts.LockByID(ctx, tx, accountID)
+		if err != nil {
+			return errs.Wrap(errs.CodeInternal, "accounts.lock_by_id", err)
+		}
+
+		points, err := s.lots.Expire(ctx, tx, accountID, at)
+		if err != nil {
+			return err
+		}
+		if points == 0 {
+			return nil
+		}
+
+		agg, err := mapper.Account(lockedRow)
+		if err != nil {
+			return errs.Wrap(errs.CodeInternal, "account aggregate build failed", err)
+		}
+
+		updatedAgg, evDraft, err := agg.ApplyExpire(points, at)
+		if err != nil {
+			return err
+		}
+
+		if _, err := s.events.Insert(ctx, tx, mapper.EventInsert(evDraft)); err != nil {
+			return errs.Wrap(errs.CodeInternal, "events.insert", err)
+		}
+
+		if _, err := s.accounts.UpdateAfterSpend(ctx, tx, updatedAgg.ID, updatedAgg.Balance.Int()); err != nil {
+			return errs.Wrap(errs.CodeInternal, "accounts.update_after_spend", err)
+		}
+
+		s.log.InfoContext(ctx, "expiry.expire_account ok", "accountID", accountID, "points", points.Int(), "balanceAfter", updatedAgg.Balance.Int())
+		written = true
+		return nil
+	})
+
+	return written, err
+}
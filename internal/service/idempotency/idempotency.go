@@ -0,0 +1,238 @@
+// Package idempotency caches operation outcomes in the operations table, keyed by
+// (account, op type, operationId), so that a retried request gets the original result.
+// Calls must run inside the transaction that inserted the pending operation.
+package idempotency
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"Beanefits/internal/domain/errs"
+	pg "Beanefits/internal/repository/postgres"
+	pgdto "Beanefits/internal/repository/postgres/dto"
+)
+
+type errCache struct {
+	Code errs.Code `json:"code"`
+	Msg  string    `json:"msg"`
+}
+
+// Replay returns the cached business error or decodes the cached success response into out.
+// request is the canonical request of the retry; if it differs from the stored one,
+// the operationId was reused for another operation and IDEMPOTENCY_KEY_REUSED is returned.
+func Replay(ctx context.Context, ops pg.OperationsRepo, tx pg.DBTX, accountID int64, opType pgdto.OperationType, operationID string, request pgdto.JSON, out any) error {
+	rec, ok, err := ops.Get(ctx, tx, accountID, opType, operationID)
+	if err != nil {
+		return errs.Wrap(errs.CodeInternal, "operations.get", err)
+	}
+	if !ok || rec.HTTPStatus == nil || rec.ResponseJSON == nil {
+		return errs.Wrap(errs.CodeInternal, "idempotency record incomplete", errors.New("missing cached response"))
+	}
+
+	same, err := sameRequest(rec.RequestJSON, request, rec.CanonicalRequest)
+	if err != nil {
+		return errs.Wrap(errs.CodeInternal, "compare operation request", err)
+	}
+	if !same {
+		return errs.New(errs.CodeIdempotencyKeyReused, "operationId was already used with a different request")
+	}
+
+	if *rec.HTTPStatus != 200 {
+		var cached errCache
+		if err := json.Unmarshal(*rec.ResponseJSON, &cached); err != nil {
+			return errs.Wrap(errs.CodeInternal, "unmarshal cached error", err)
+		}
+		if cached.Code == "" {
+			return errs.Wrap(errs.CodeInternal, "cached error code missing", errors.New("invalid cache"))
+		}
+		return errs.New(cached.Code, cached.Msg)
+	}
+
+	if err := json.Unmarshal(*rec.ResponseJSON, out); err != nil {
+		return errs.Wrap(errs.CodeInternal, "unmarshal cached success", err)
+	}
+	return nil
+}
+
+// RejectExpired must be called after a new pending record was inserted. If the key belonged to a record
+// removed by retention, the original operation may have been applied already, so running it again
+// would duplicate it: OPERATION_EXPIRED is returned and the pending record is rolled back with the transaction.
+func RejectExpired(ctx context.Context, ops pg.OperationsRepo, tx pg.DBTX, accountID int64, opType pgdto.OperationType, operationID string) error {
+	expired, err := ops.IsExpired(ctx, tx, accountID, opType, operationID)
+	if err != nil {
+		return errs.Wrap(errs.CodeInternal, "operations.is_expired", err)
+	}
+	if expired {
+		return errs.New(errs.CodeOperationExpired, "operationId is older than the idempotency retention window")
+	}
+	return nil
+}
+
+// Finalize stores a success response for replay; eventID is the main event written by the operation.
+func Finalize(ctx context.Context, ops pg.OperationsRepo, tx pg.DBTX, accountID int64, opType pgdto.OperationType, operationID string, out any, eventID *int64) error {
+	b, err := json.Marshal(out)
+	if err != nil {
+		return errs.Wrap(errs.CodeInternal, "marshal operation out", err)
+	}
+
+	return ops.Finalize(ctx, tx, pgdto.OperationFinalize{
+		AccountID:    accountID,
+		OpType:       opType,
+		OperationID:  operationID,
+		HTTPStatus:   200,
+		ResponseJSON: json.RawMessage(b),
+		EventID:      eventID,
+	})
+}
+
+// cachedErr is a business error already stored for replay by BusinessErr.
+type cachedErr struct {
+	err error
+}
+
+func (e *cachedErr) Error() string { return e.err.Error() }
+func (e *cachedErr) Unwrap() error { return e.err }
+
+// BusinessErr stores a business error for replay and returns it. The operation must run in WithinTx,
+// which commits the cached record instead of rolling it back, so it may only be returned
+// before the operation has written anything else.
+func BusinessErr(ctx context.Context, ops pg.OperationsRepo, tx pg.DBTX, accountID int64, opType pgdto.OperationType, operationID string, code errs.Code, msg string) error {
+	if err := finalizeErr(ctx, ops, tx, accountID, opType, operationID, code, msg); err != nil {
+		return err
+	}
+	return &cachedErr{err: errs.New(code, msg)}
+}
+
+// WithinTx runs fn in a transaction of txm. A business error returned by BusinessErr commits the
+// transaction, so a retry replays it, and is then returned as is; any other error rolls it back.
+func WithinTx(ctx context.Context, txm pg.TxManager, fn func(ctx context.Context, tx pg.DBTX) error) error {
+	var cached *cachedErr
+	err := txm.WithinTx(ctx, func(ctx context.Context, tx pg.DBTX) error {
+		err := fn(ctx, tx)
+		if errors.As(err, &cached) {
+			return nil
+		}
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	if cached != nil {
+		return cached.err
+	}
+	return nil
+}
+
+// finalizeErr stores a business error for replay.
+func finalizeErr(ctx context.Context, ops pg.OperationsRepo, tx pg.DBTX, accountID int64, opType pgdto.OperationType, operationID string, code errs.Code, msg string) error {
+	b, err := json.Marshal(errCache{Code: code, Msg: msg})
+	if err != nil {
+		return errs.Wrap(errs.CodeInternal, "marshal cached error", err)
+	}
+
+	// For MVP: store “transport-ish” status approximation for replay logic.
+	status := 409
+	return ops.Finalize(ctx, tx, pgdto.OperationFinalize{
+		AccountID:    accountID,
+		OpType:       opType,
+		OperationID:  operationID,
+		HTTPStatus:   status,
+		ResponseJSON: json.RawMessage(b),
+	})
+}
+
+// tsKey and moneyKeys are the request fields whose values are compared as an instant and as a decimal.
+const tsKey = "ts"
+
+var moneyKeys = map[string]bool{
+	"amountMoney": true,
+	"billMoney":   true,
+	"lineTotal":   true,
+}
+
+// sameRequest compares two requests as JSON values: request_json is jsonb,
+// so key order and formatting of the stored copy differ from what was marshalled.
+// A ts sent on one side only is a different request, unless the stored request is not canonical:
+// such records got their ts from the server when the client sent none, so a retry without ts matches them.
+func sameRequest(stored, incoming pgdto.JSON, canonical bool) (bool, error) {
+	var a, b map[string]any
+	if err := json.Unmarshal(stored, &a); err != nil {
+		return false, err
+	}
+	if err := json.Unmarshal(incoming, &b); err != nil {
+		return false, err
+	}
+	if _, ok := b[tsKey]; !ok && !canonical {
+		delete(a, tsKey)
+	}
+	return sameValue(a, b, ""), nil
+}
+
+// sameValue is reflect.DeepEqual on decoded JSON, except that the same instant under tsKey
+// and the same decimal number under moneyKeys are equal; key is the field holding a and b.
+func sameValue(a, b any, key string) bool {
+	switch x := a.(type) {
+	case map[string]any:
+		y, ok := b.(map[string]any)
+		if !ok || len(x) != len(y) {
+			return false
+		}
+		for k, v := range x {
+			w, ok := y[k]
+			if !ok || !sameValue(v, w, k) {
+				return false
+			}
+		}
+		return true
+	case []any:
+		y, ok := b.([]any)
+		if !ok || len(x) != len(y) {
+			return false
+		}
+		for i := range x {
+			if !sameValue(x[i], y[i], key) {
+				return false
+			}
+		}
+		return true
+	case string:
+		y, ok := b.(string)
+		if !ok {
+			return false
+		}
+		switch {
+		case x == y:
+			return true
+		case key == tsKey:
+			return sameInstant(x, y)
+		case moneyKeys[key]:
+			return sameDecimal(x, y)
+		}
+		return false
+	default:
+		return reflect.DeepEqual(a, b)
+	}
+}
+
+func sameInstant(a, b string) bool {
+	ta, err := time.Parse(time.RFC3339Nano, a)
+	if err != nil {
+		return false
+	}
+	tb, err := time.Parse(time.RFC3339Nano, b)
+	return err == nil && ta.Equal(tb)
+}
+
+func sameDecimal(a, b string) bool {
+	da, err := decimal.NewFromString(a)
+	if err != nil {
+		return false
+	}
+	db, err := decimal.NewFromString(b)
+	return err == nil && da.Equal(db)
+}
@@ -0,0 +1,172 @@
+package idempotency
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"Beanefits/internal/domain/errs"
+	pg "Beanefits/internal/repository/postgres"
+)
+
+func TestSameRequest(t *testing.T) {
+	tests := []struct {
+		name      string
+		stored    string
+		incoming  string
+		canonical bool
+		want      bool
+	}{
+		{
+			name:      "same request, other key order",
+			stored:    `{"publicCode":"p","operationId":"o","amountMoney":"10.00"}`,
+			incoming:  `{"operationId":"o","publicCode":"p","amountMoney":"10.00"}`,
+			canonical: true,
+			want:      true,
+		},
+		{
+			name:      "other amount",
+			stored:    `{"operationId":"o","amountMoney":"10.00"}`,
+			incoming:  `{"operationId":"o","amountMoney":"10.01"}`,
+			canonical: true,
+			want:      false,
+		},
+		{
+			name:      "legacy money digits",
+			stored:    `{"operationId":"o","amountMoney":"10"}`,
+			incoming:  `{"operationId":"o","amountMoney":"10.00"}`,
+			canonical: false,
+			want:      true,
+		},
+		{
+			name:      "legacy server ts, retry without ts",
+			stored:    `{"operationId":"o","amountPoints":5,"ts":"2026-01-02T10:00:00.123456+03:00"}`,
+			incoming:  `{"operationId":"o","amountPoints":5}`,
+			canonical: false,
+			want:      true,
+		},
+		{
+			name:      "same instant in another offset",
+			stored:    `{"operationId":"o","ts":"2026-01-02T10:00:00+03:00"}`,
+			incoming:  `{"operationId":"o","ts":"2026-01-02T07:00:00Z"}`,
+			canonical: true,
+			want:      true,
+		},
+		{
+			name:      "other ts",
+			stored:    `{"operationId":"o","ts":"2026-01-02T10:00:00Z"}`,
+			incoming:  `{"operationId":"o","ts":"2026-01-02T10:00:01Z"}`,
+			canonical: true,
+			want:      false,
+		},
+		{
+			name:      "ts sent on retry only",
+			stored:    `{"operationId":"o"}`,
+			incoming:  `{"operationId":"o","ts":"2026-01-02T10:00:00Z"}`,
+			canonical: true,
+			want:      false,
+		},
+		{
+			name:      "ts dropped on retry",
+			stored:    `{"operationId":"o","amountPoints":5,"ts":"2026-01-02T10:00:00Z"}`,
+			incoming:  `{"operationId":"o","amountPoints":5}`,
+			canonical: true,
+			want:      false,
+		},
+		{
+			name:      "decimal outside money fields",
+			stored:    `{"operationId":"1","publicCode":"10"}`,
+			incoming:  `{"operationId":"1.0","publicCode":"10.00"}`,
+			canonical: true,
+			want:      false,
+		},
+		{
+			name:      "other public code",
+			stored:    `{"operationId":"o","publicCode":"a"}`,
+			incoming:  `{"operationId":"o","publicCode":"b"}`,
+			canonical: true,
+			want:      false,
+		},
+		{
+			name:      "receipt lines compared in order",
+			stored:    `{"operationId":"o","lines":[{"sku":"a","lineTotal":"1"},{"sku":"b","lineTotal":"2.00"}]}`,
+			incoming:  `{"operationId":"o","lines":[{"sku":"a","lineTotal":"1.00"},{"sku":"b","lineTotal":"2.00"}]}`,
+			canonical: true,
+			want:      true,
+		},
+		{
+			name:      "receipt line missing",
+			stored:    `{"operationId":"o","lines":[{"sku":"a"},{"sku":"b"}]}`,
+			incoming:  `{"operationId":"o","lines":[{"sku":"a"}]}`,
+			canonical: true,
+			want:      false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := sameRequest([]byte(tt.stored), []byte(tt.incoming), tt.canonical)
+			if err != nil {
+				t.Fatalf("sameRequest: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("sameRequest = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// fakeTxm commits when fn succeeds and rolls back otherwise, like the pgx TxManager.
+type fakeTxm struct {
+	committed bool
+}
+
+func (m *fakeTxm) WithinTx(ctx context.Context, fn func(ctx context.Context, tx pg.DBTX) error) error {
+	if err := fn(ctx, nil); err != nil {
+		return err
+	}
+	m.committed = true
+	return nil
+}
+
+func TestWithinTx(t *testing.T) {
+	business := errs.New(errs.CodeNotEnoughBalance, "not enough balance")
+
+	tests := []struct {
+		name          string
+		fnErr         error
+		wantCode      errs.Code
+		wantCommitted bool
+	}{
+		{name: "success", wantCommitted: true},
+		{name: "cached business error commits", fnErr: &cachedErr{err: business}, wantCode: errs.CodeNotEnoughBalance, wantCommitted: true},
+		{name: "wrapped cached error commits", fnErr: fmt.Errorf("spend: %w", &cachedErr{err: business}), wantCode: errs.CodeNotEnoughBalance, wantCommitted: true},
+		{name: "uncached business error rolls back", fnErr: business, wantCode: errs.CodeNotEnoughBalance},
+		{name: "internal error rolls back", fnErr: errs.New(errs.CodeInternal, "boom"), wantCode: errs.CodeInternal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			txm := &fakeTxm{}
+			err := WithinTx(context.Background(), txm, func(context.Context, pg.DBTX) error { return tt.fnErr })
+
+			if txm.committed != tt.wantCommitted {
+				t.Errorf("committed = %v, want %v", txm.committed, tt.wantCommitted)
+			}
+			if tt.wantCode == "" {
+				if err != nil {
+					t.Fatalf("WithinTx: %v", err)
+				}
+				return
+			}
+			if code, _ := errs.CodeOf(err); code != tt.wantCode {
+				t.Errorf("WithinTx error = %v, want code %s", err, tt.wantCode)
+			}
+			var cached *cachedErr
+			if errors.As(err, &cached) {
+				t.Error("the cached marker leaked to the caller")
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package limits
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"Beanefits/internal/domain/errs"
+	pg "Beanefits/internal/repository/postgres"
+	pgdto "Beanefits/internal/repository/postgres/dto"
+)
+
+// Guard enforces the admin-managed velocity limits of cashier EARN/SPEND operations;
+// SPEND covers every SPEND event a cashier writes (spend, checkout, hold capture).
+// Usage is counted from events by server time (created_at), so backdated ts cannot dodge a window.
+type Guard struct {
+	limits pg.LimitsRepo
+	events pg.EventsRepo
+}
+
+func NewGuard(limits pg.LimitsRepo, events pg.EventsRepo) *Guard {
+	return &Guard{limits: limits, events: events}
+}
+
+// Usage is an operation about to be written.
+type Usage struct {
+	OpType      pgdto.EventType // EARN or SPEND
+	AccountID   int64
+	ActorUserID int64
+	Points      int
+	At          time.Time // server time
+}
+
+// Exceeded reports the first limit of u.OpType that the operation would break.
+// Must be called with the account locked so concurrent operations on it see each other;
+// the per-cashier count is not serialized across accounts, so parallel tills may overshoot it slightly.
+func (g *Guard) Exceeded(ctx context.Context, tx pg.DBTX, u Usage) (string, bool, error) {
+	if g == nil {
+		return "", false, nil
+	}
+
+	rows, err := g.limits.ListByOpType(ctx, tx, u.OpType)
+	if err != nil {
+		return "", false, errs.Wrap(errs.CodeInternal, "limits.list_by_op_type", err)
+	}
+
+	for _, l := range rows {
+		switch l.Kind {
+		case pgdto.LimitPointsPerOperation:
+			if u.Points > l.MaxValue {
+				return fmt.Sprintf("%s of %d points exceeds the limit of %d points per operation", u.OpType, u.Points, l.MaxValue), true, nil
+			}
+
+		case pgdto.LimitAccountPointsPerDay:
+			dayStart := u.At.UTC().Truncate(24 * time.Hour)
+			used, err := g.events.SumByTypeCreatedSince(ctx, tx, u.AccountID, u.OpType, dayStart)
+			if err != nil {
+				return "", false, errs.Wrap(errs.CodeInternal, "events.sum_by_type_created_since", err)
+			}
+			if used.Points+u.Points > l.MaxValue {
+				return fmt.Sprintf("daily %s limit of %d points per account exceeded", u.OpType, l.MaxValue), true, nil
+			}
+
+		case pgdto.LimitCashierOperationsPerHour:
+			n, err := g.events.CountByActorCreatedSince(ctx, tx, u.ActorUserID, u.OpType, u.At.Add(-time.Hour))
+			if err != nil {
+				return "", false, errs.Wrap(errs.CodeInternal, "events.count_by_actor_created_since", err)
+			}
+			if n+1 > l.MaxValue {
+				return fmt.Sprintf("hourly limit of %d %s operations per cashier reached", l.MaxValue, u.OpType), true, nil
+			}
+		}
+	}
+	return "", false, nil
+}
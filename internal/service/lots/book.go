@@ -0,0 +1,188 @@
+// Package lots keeps point_lots in step with balance changes written by the services.
+// Every call must run inside the transaction that changes accounts.balance_points,
+// after the account row is locked.
+package lots
+
+import (
+	"context"
+	"time"
+
+	"Beanefits/internal/domain/errs"
+	"Beanefits/internal/domain/ledger"
+	pg "Beanefits/internal/repository/postgres"
+	pgdto "Beanefits/internal/repository/postgres/dto"
+)
+
+const defaultLifetimeMonths = 12
+
+// legacyLifetimeMonths is the lifetime migration 0014 gave the lots it opened for pre-lot balances;
+// AgeLegacy re-ages them with the configured one.
+const legacyLifetimeMonths = 12
+
+type Book struct {
+	repo           pg.LotsRepo
+	lifetimeMonths int
+}
+
+func NewBook(repo pg.LotsRepo, lifetimeMonths int) *Book {
+	if lifetimeMonths <= 0 {
+		lifetimeMonths = defaultLifetimeMonths
+	}
+	return &Book{repo: repo, lifetimeMonths: lifetimeMonths}
+}
+
+// Credit opens a lot for points added to the balance at earnedAt.
+func (b *Book) Credit(ctx context.Context, tx pg.DBTX, accountID int64, sourceEventID int64, p ledger.Points, earnedAt time.Time) error {
+	if p <= 0 {
+		return nil
+	}
+	src := sourceEventID
+	_, err := b.repo.Insert(ctx, tx, pgdto.LotInsert{
+		AccountID:     accountID,
+		SourceEventID: &src,
+		Points:        p.Int(),
+		EarnedAt:      earnedAt,
+		ExpiresAt:     ledger.LotExpiresAt(earnedAt, b.lifetimeMonths),
+	})
+	if err != nil {
+		return errs.Wrap(errs.CodeInternal, "lots.insert", err)
+	}
+	return nil
+}
+
+// Debit takes p points from open lots, oldest expiry first.
+// The lot opened by preferSourceEventID (if any) is drained before the others.
+func (b *Book) Debit(ctx context.Context, tx pg.DBTX, accountID int64, p ledger.Points, preferSourceEventID *int64) error {
+	if p <= 0 {
+		return nil
+	}
+
+	rows, err := b.repo.ListOpenForUpdate(ctx, tx, accountID)
+	if err != nil {
+		return errs.Wrap(errs.CodeInternal, "lots.list_open_for_update", err)
+	}
+
+	lots := make([]ledger.Lot, 0, len(rows))
+	if preferSourceEventID != nil {
+		for _, r := range rows {
+			if r.SourceEventID != nil && *r.SourceEventID == *preferSourceEventID {
+				lots = append(lots, lotFromRow(r))
+			}
+		}
+	}
+	for _, r := range rows {
+		if preferSourceEventID != nil && r.SourceEventID != nil && *r.SourceEventID == *preferSourceEventID {
+			continue
+		}
+		lots = append(lots, lotFromRow(r))
+	}
+
+	takes, err := ledger.TakeFIFO(lots, p)
+	if err != nil {
+		return err
+	}
+	return b.apply(ctx, tx, takes)
+}
+
+// Move takes p points from open lots of fromAccountID (oldest expiry first) and opens lots for
+// them on toAccountID with the same earned/expiry dates, so a transfer never renews points.
+// sourceEventID is the event that added the points to the recipient.
+func (b *Book) Move(ctx context.Context, tx pg.DBTX, fromAccountID, toAccountID int64, sourceEventID int64, p ledger.Points) error {
+	if p <= 0 {
+		return nil
+	}
+
+	rows, err := b.repo.ListOpenForUpdate(ctx, tx, fromAccountID)
+	if err != nil {
+		return errs.Wrap(errs.CodeInternal, "lots.list_open_for_update", err)
+	}
+
+	lots := make([]ledger.Lot, 0, len(rows))
+	byID := make(map[int64]pgdto.LotRow, len(rows))
+	for _, r := range rows {
+		lots = append(lots, lotFromRow(r))
+		byID[r.ID] = r
+	}
+
+	takes, err := ledger.TakeFIFO(lots, p)
+	if err != nil {
+		return err
+	}
+	if err := b.apply(ctx, tx, takes); err != nil {
+		return err
+	}
+
+	src := sourceEventID
+	for _, t := range takes {
+		from := byID[t.LotID]
+		_, err := b.repo.Insert(ctx, tx, pgdto.LotInsert{
+			AccountID:     toAccountID,
+			SourceEventID: &src,
+			Points:        t.Taken.Int(),
+			EarnedAt:      from.EarnedAt,
+			ExpiresAt:     from.ExpiresAt,
+		})
+		if err != nil {
+			return errs.Wrap(errs.CodeInternal, "lots.insert", err)
+		}
+	}
+	return nil
+}
+
+// AgeLegacy sets the expiry of the lots migration 0014 opened for pre-lot balances from the configured
+// lifetime and returns the number of lots changed. Lots already re-aged, or with a lifetime of 12 months,
+// are left as they are, so it is safe to run on every start.
+func (b *Book) AgeLegacy(ctx context.Context, tx pg.DBTX) (int, error) {
+	if b.lifetimeMonths == legacyLifetimeMonths {
+		return 0, nil
+	}
+
+	rows, err := b.repo.ListLegacyForUpdate(ctx, tx)
+	if err != nil {
+		return 0, errs.Wrap(errs.CodeInternal, "lots.list_legacy_for_update", err)
+	}
+	for _, r := range rows {
+		if err := b.repo.SetExpiresAt(ctx, tx, r.ID, ledger.LotExpiresAt(r.EarnedAt, b.lifetimeMonths)); err != nil {
+			return 0, errs.Wrap(errs.CodeInternal, "lots.set_expires_at", err)
+		}
+	}
+	return len(rows), nil
+}
+
+// Expire empties lots of the account that expired at or before at and returns the written-off points.
+func (b *Book) Expire(ctx context.Context, tx pg.DBTX, accountID int64, at time.Time) (ledger.Points, error) {
+	rows, err := b.repo.ListExpiredForUpdate(ctx, tx, accountID, at)
+	if err != nil {
+		return 0, errs.Wrap(errs.CodeInternal, "lots.list_expired_for_update", err)
+	}
+
+	var total ledger.Points
+	takes := make([]ledger.LotTake, 0, len(rows))
+	for _, r := range rows {
+		p := ledger.Points(r.RemainingPoints)
+		total += p
+		takes = append(takes, ledger.LotTake{LotID: r.ID, Taken: p, Remaining: 0})
+	}
+
+	if err := b.apply(ctx, tx, takes); err != nil {
+		return 0, err
+	}
+	return total, nil
+}
+
+func (b *Book) apply(ctx context.Context, tx pg.DBTX, takes []ledger.LotTake) error {
+	for _, t := range takes {
+		if err := b.repo.SetRemaining(ctx, tx, t.LotID, t.Remaining.Int()); err != nil {
+			return errs.Wrap(errs.CodeInternal, "lots.set_remaining", err)
+		}
+	}
+	return nil
+}
+
+func lotFromRow(r pgdto.LotRow) ledger.Lot {
+	return ledger.Lot{
+		ID:        r.ID,
+		Remaining: ledger.Points(r.RemainingPoints),
+		ExpiresAt: r.ExpiresAt,
+	}
+}
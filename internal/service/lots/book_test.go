@@ -0,0 +1,246 @@
+package lots
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"Beanefits/internal/domain/ledger"
+	pg "Beanefits/internal/repository/postgres"
+	pgdto "Beanefits/internal/repository/postgres/dto"
+)
+
+// fakeLots keeps lots in memory and lists them the way the queries do.
+type fakeLots struct {
+	rows []pgdto.LotRow
+}
+
+func (f *fakeLots) Insert(_ context.Context, _ pg.DBTX, in pgdto.LotInsert) (pgdto.LotRow, error) {
+	r := pgdto.LotRow{
+		ID:              int64(len(f.rows) + 1),
+		AccountID:       in.AccountID,
+		SourceEventID:   in.SourceEventID,
+		Points:          in.Points,
+		RemainingPoints: in.Points,
+		EarnedAt:        in.EarnedAt,
+		ExpiresAt:       in.ExpiresAt,
+	}
+	f.rows = append(f.rows, r)
+	return r, nil
+}
+
+func (f *fakeLots) ListOpenForUpdate(_ context.Context, _ pg.DBTX, accountID int64) ([]pgdto.LotRow, error) {
+	return f.list(func(r pgdto.LotRow) bool { return r.AccountID == accountID && r.RemainingPoints > 0 }), nil
+}
+
+func (f *fakeLots) ListExpiredForUpdate(_ context.Context, _ pg.DBTX, accountID int64, at time.Time) ([]pgdto.LotRow, error) {
+	return f.list(func(r pgdto.LotRow) bool {
+		return r.AccountID == accountID && r.RemainingPoints > 0 && !r.ExpiresAt.After(at)
+	}), nil
+}
+
+func (f *fakeLots) SetRemaining(_ context.Context, _ pg.DBTX, lotID int64, remaining int) error {
+	f.rows[lotID-1].RemainingPoints = remaining
+	return nil
+}
+
+func (f *fakeLots) ListLegacyForUpdate(_ context.Context, _ pg.DBTX) ([]pgdto.LotRow, error) {
+	return f.list(func(r pgdto.LotRow) bool {
+		return r.SourceEventID == nil && r.RemainingPoints > 0 && r.ExpiresAt.Equal(r.EarnedAt.AddDate(0, 12, 0))
+	}), nil
+}
+
+func (f *fakeLots) SetExpiresAt(_ context.Context, _ pg.DBTX, lotID int64, expiresAt time.Time) error {
+	f.rows[lotID-1].ExpiresAt = expiresAt
+	return nil
+}
+
+func (f *fakeLots) ListAccountsWithExpired(context.Context, pg.DBTX, time.Time, int) ([]int64, error) {
+	return nil, errors.New("not used")
+}
+
+func (f *fakeLots) NextExpiring(context.Context, pg.DBTX, int64) (pgdto.ExpiringPoints, bool, error) {
+	return pgdto.ExpiringPoints{}, false, errors.New("not used")
+}
+
+func (f *fakeLots) list(keep func(pgdto.LotRow) bool) []pgdto.LotRow {
+	out := make([]pgdto.LotRow, 0, len(f.rows))
+	for _, r := range f.rows {
+		if keep(r) {
+			out = append(out, r)
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		if !out[i].ExpiresAt.Equal(out[j].ExpiresAt) {
+			return out[i].ExpiresAt.Before(out[j].ExpiresAt)
+		}
+		return out[i].ID < out[j].ID
+	})
+	return out
+}
+
+func (f *fakeLots) remaining() []int {
+	out := make([]int, 0, len(f.rows))
+	for _, r := range f.rows {
+		out = append(out, r.RemainingPoints)
+	}
+	return out
+}
+
+var day0 = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// lot opens a lot of account 1 earned on day0+earnedDay by event src.
+func lot(src int64, points, earnedDay int) pgdto.LotRow {
+	earned := day0.AddDate(0, 0, earnedDay)
+	return pgdto.LotRow{
+		AccountID:       1,
+		SourceEventID:   &src,
+		Points:          points,
+		RemainingPoints: points,
+		EarnedAt:        earned,
+		ExpiresAt:       ledger.LotExpiresAt(earned, 12),
+	}
+}
+
+func newFake(lots ...pgdto.LotRow) *fakeLots {
+	f := &fakeLots{}
+	for i := range lots {
+		lots[i].ID = int64(i + 1)
+	}
+	f.rows = lots
+	return f
+}
+
+func TestBookDebit(t *testing.T) {
+	src := func(id int64) *int64 { return &id }
+
+	tests := []struct {
+		name    string
+		lots    []pgdto.LotRow
+		points  ledger.Points
+		prefer  *int64
+		want    []int
+		wantErr error
+	}{
+		{name: "nothing to take", lots: []pgdto.LotRow{lot(1, 10, 0)}, points: 0, want: []int{10}},
+		{name: "oldest expiry first", lots: []pgdto.LotRow{lot(1, 10, 5), lot(2, 10, 0)}, points: 4, want: []int{10, 6}},
+		{name: "spills into the next lot", lots: []pgdto.LotRow{lot(1, 10, 0), lot(2, 10, 5)}, points: 15, want: []int{0, 5}},
+		{name: "same expiry in insertion order", lots: []pgdto.LotRow{lot(1, 10, 0), lot(2, 10, 0)}, points: 12, want: []int{0, 8}},
+		{name: "empty lots are skipped", lots: []pgdto.LotRow{{AccountID: 1, Points: 5, ExpiresAt: day0}, lot(2, 10, 0)}, points: 3, want: []int{0, 7}},
+		{name: "preferred lot first", lots: []pgdto.LotRow{lot(1, 10, 0), lot(2, 10, 5)}, points: 12, prefer: src(2), want: []int{8, 0}},
+		{name: "unknown preferred lot", lots: []pgdto.LotRow{lot(1, 10, 0), lot(2, 10, 5)}, points: 12, prefer: src(9), want: []int{0, 8}},
+		{name: "other accounts untouched", lots: []pgdto.LotRow{{AccountID: 2, Points: 10, RemainingPoints: 10, ExpiresAt: day0}, lot(1, 10, 5)}, points: 10, want: []int{10, 0}},
+		{name: "exhausted", lots: []pgdto.LotRow{lot(1, 10, 0), lot(2, 10, 5)}, points: 21, want: []int{10, 10}, wantErr: ledger.ErrLotsExhausted},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newFake(tt.lots...)
+			err := NewBook(repo, 12).Debit(context.Background(), nil, 1, tt.points, tt.prefer)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Debit error = %v, want %v", err, tt.wantErr)
+			}
+			if got := repo.remaining(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("remaining = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBookMoveKeepsExpiry(t *testing.T) {
+	repo := newFake(lot(1, 10, 0), lot(2, 10, 5))
+
+	if err := NewBook(repo, 12).Move(context.Background(), nil, 1, 2, 99, 15); err != nil {
+		t.Fatalf("Move: %v", err)
+	}
+
+	if got, want := repo.remaining(), []int{0, 5, 10, 5}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("remaining = %v, want %v", got, want)
+	}
+	for i, from := range repo.rows[:2] {
+		to := repo.rows[2+i]
+		if to.AccountID != 2 || *to.SourceEventID != 99 {
+			t.Errorf("lot %d: account %d, source %d, want 2, 99", to.ID, to.AccountID, *to.SourceEventID)
+		}
+		if !to.EarnedAt.Equal(from.EarnedAt) || !to.ExpiresAt.Equal(from.ExpiresAt) {
+			t.Errorf("lot %d: earned %s, expires %s, want %s, %s", to.ID, to.EarnedAt, to.ExpiresAt, from.EarnedAt, from.ExpiresAt)
+		}
+	}
+}
+
+func TestBookExpire(t *testing.T) {
+	repo := newFake(lot(1, 10, 0), lot(2, 7, 3), lot(3, 10, 30))
+	repo.rows[0].RemainingPoints = 4
+
+	got, err := NewBook(repo, 12).Expire(context.Background(), nil, 1, ledger.LotExpiresAt(day0.AddDate(0, 0, 3), 12))
+	if err != nil {
+		t.Fatalf("Expire: %v", err)
+	}
+	if got != 11 {
+		t.Errorf("Expire = %d, want 11", got)
+	}
+	if got, want := repo.remaining(), []int{0, 0, 10}; !reflect.DeepEqual(got, want) {
+		t.Errorf("remaining = %v, want %v", got, want)
+	}
+}
+
+func TestBookAgeLegacy(t *testing.T) {
+	legacy := func(remaining int, expiresMonths int) pgdto.LotRow {
+		return pgdto.LotRow{
+			AccountID:       1,
+			Points:          10,
+			RemainingPoints: remaining,
+			EarnedAt:        day0,
+			ExpiresAt:       day0.AddDate(0, expiresMonths, 0),
+		}
+	}
+
+	tests := []struct {
+		name     string
+		lifetime int
+		lots     []pgdto.LotRow
+		aged     int
+		want     []time.Time
+	}{
+		{
+			name:     "same lifetime",
+			lifetime: 12,
+			lots:     []pgdto.LotRow{legacy(10, 12)},
+			want:     []time.Time{day0.AddDate(0, 12, 0)},
+		},
+		{
+			name:     "longer lifetime",
+			lifetime: 24,
+			lots:     []pgdto.LotRow{legacy(10, 12), lot(1, 10, 0)},
+			aged:     1,
+			want:     []time.Time{day0.AddDate(0, 24, 0), day0.AddDate(0, 12, 0)},
+		},
+		{
+			name:     "already aged and spent lots",
+			lifetime: 6,
+			lots:     []pgdto.LotRow{legacy(10, 6), legacy(0, 12)},
+			want:     []time.Time{day0.AddDate(0, 6, 0), day0.AddDate(0, 12, 0)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newFake(tt.lots...)
+			aged, err := NewBook(repo, tt.lifetime).AgeLegacy(context.Background(), nil)
+			if err != nil {
+				t.Fatalf("AgeLegacy: %v", err)
+			}
+			if aged != tt.aged {
+				t.Errorf("AgeLegacy = %d, want %d", aged, tt.aged)
+			}
+			for i, r := range repo.rows {
+				if !r.ExpiresAt.Equal(tt.want[i]) {
+					t.Errorf("lot %d expires %s, want %s", r.ID, r.ExpiresAt, tt.want[i])
+				}
+			}
+		})
+	}
+}
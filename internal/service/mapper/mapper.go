@@ -1,8 +1,14 @@
 package mapper
 
 import (
+	"fmt"
+	"strings"
 	"time"
 
+	"Beanefits/internal/domain/account"
+	"Beanefits/internal/domain/errs"
+	"Beanefits/internal/domain/ledger"
+	"Beanefits/internal/domain/rules"
 	pgdto "Beanefits/internal/repository/postgres/dto"
 	sdto "Beanefits/internal/service/dto"
 )
@@ -32,6 +38,7 @@ func UserWithRoles(u pgdto.UserRow, roles []pgdto.RoleCode) sdto.UserWithRoles {
 			ID:        u.ID,
 			Phone:     u.Phone,
 			IsActive:  u.IsActive,
+			Birthday:  u.Birthday,
 			CreatedAt: u.CreatedAt,
 		},
 		Roles: Roles(roles),
@@ -55,9 +62,15 @@ func AccountBase(a pgdto.AccountRow) sdto.AccountBase {
 }
 
 func BalanceOut(a pgdto.AccountRow, asOf time.Time) sdto.BalanceOut {
+	available := a.BalancePoints - a.HeldPoints
+	if available < 0 {
+		available = 0
+	}
 	return sdto.BalanceOut{
 		AccountID:       a.ID,
 		BalancePoints:   a.BalancePoints,
+		HeldPoints:      a.HeldPoints,
+		AvailablePoints: available,
 		TotalSpendMoney: MoneyFixed2(a.TotalSpendMoney),
 		LevelCode:       a.LevelCode,
 		AsOf:            asOf,
@@ -77,6 +90,22 @@ func EventOut(e pgdto.EventRow) sdto.EventOut {
 		typ = sdto.EventEarn
 	case pgdto.EventSpend:
 		typ = sdto.EventSpend
+	case pgdto.EventRefund:
+		typ = sdto.EventRefund
+	case pgdto.EventVoid:
+		typ = sdto.EventVoid
+	case pgdto.EventExpire:
+		typ = sdto.EventExpire
+	case pgdto.EventAdjust:
+		typ = sdto.EventAdjust
+	case pgdto.EventTransferOut:
+		typ = sdto.EventTransferOut
+	case pgdto.EventTransferIn:
+		typ = sdto.EventTransferIn
+	case pgdto.EventBonus:
+		typ = sdto.EventBonus
+	case pgdto.EventLevelChanged:
+		typ = sdto.EventLevelChanged
 	default:
 		typ = sdto.EventType(e.Type)
 	}
@@ -90,10 +119,124 @@ func EventOut(e pgdto.EventRow) sdto.EventOut {
 		AmountMoney:  amount,
 		RulesetID:    e.RulesetID,
 		ActorUserID:  e.ActorUserID,
+		RefEventID:   e.RefEventID,
 		Ts:           e.Ts,
 	}
 }
 
+func HoldOut(h pgdto.HoldRow) sdto.HoldOut {
+	return sdto.HoldOut{
+		ID:             h.ID,
+		AccountID:      h.AccountID,
+		OperationID:    h.OperationID,
+		Points:         h.Points,
+		Status:         sdto.HoldStatus(h.Status),
+		ExpiresAt:      h.ExpiresAt,
+		CapturedPoints: h.CapturedPoints,
+		CreatedAt:      h.CreatedAt,
+		ClosedAt:       h.ClosedAt,
+	}
+}
+
+func LimitOut(l pgdto.VelocityLimitRow) sdto.LimitOut {
+	return sdto.LimitOut{
+		ID:        l.ID,
+		Kind:      sdto.LimitKind(l.Kind),
+		OpType:    sdto.OperationType(l.OpType),
+		MaxValue:  l.MaxValue,
+		UpdatedBy: l.UpdatedBy,
+		UpdatedAt: l.UpdatedAt,
+	}
+}
+
+// CategoryRates indexes the category earn factors of a ruleset.
+func CategoryRates(rows []pgdto.CategoryEarnRateRow) rules.CategoryRates {
+	out := make(rules.CategoryRates, len(rows))
+	for _, r := range rows {
+		out[r.Category] = r.EarnFactor
+	}
+	return out
+}
+
+// Campaign builds the domain campaign (with its timezone loaded) from a stored row.
+func Campaign(r pgdto.CampaignRow) (rules.Campaign, error) {
+	loc, err := time.LoadLocation(r.Timezone)
+	if err != nil {
+		return rules.Campaign{}, fmt.Errorf("campaign %d timezone: %w", r.ID, err)
+	}
+
+	weekdays := make([]time.Weekday, 0, len(r.Weekdays))
+	for _, d := range r.Weekdays {
+		weekdays = append(weekdays, rules.WeekdayFromISO(d))
+	}
+
+	c := rules.Campaign{
+		ID: r.ID,
+		Schedule: rules.Schedule{
+			StartsOn: r.StartsOn,
+			EndsOn:   r.EndsOn,
+			Weekdays: weekdays,
+			TimeFrom: r.TimeFrom,
+			TimeTo:   r.TimeTo,
+			Location: loc,
+		},
+	}
+	if r.Multiplier != nil {
+		m := *r.Multiplier
+		c.Multiplier = &m
+	}
+	if r.BonusPoints != nil {
+		c.BonusPoints = ledger.Points(*r.BonusPoints)
+	}
+	return c, nil
+}
+
+func CampaignOut(r pgdto.CampaignRow) sdto.CampaignOut {
+	weekdays := make([]string, 0, len(r.Weekdays))
+	for _, d := range r.Weekdays {
+		weekdays = append(weekdays, strings.ToUpper(rules.WeekdayFromISO(d).String()[:3]))
+	}
+
+	out := sdto.CampaignOut{
+		ID:          r.ID,
+		Name:        r.Name,
+		StartsOn:    r.StartsOn,
+		EndsOn:      r.EndsOn,
+		Weekdays:    weekdays,
+		TimeFrom:    timeOfDayPtr(r.TimeFrom),
+		TimeTo:      timeOfDayPtr(r.TimeTo),
+		Timezone:    r.Timezone,
+		BonusPoints: r.BonusPoints,
+		UpdatedBy:   r.UpdatedBy,
+		UpdatedAt:   r.UpdatedAt,
+	}
+	if r.Multiplier != nil {
+		m := MoneyPtrFixed2(r.Multiplier)
+		out.Multiplier = &m
+	}
+	return out
+}
+
+func AppliedCampaigns(in []rules.AppliedCampaign) []sdto.AppliedCampaignOut {
+	if len(in) == 0 {
+		return nil
+	}
+	out := make([]sdto.AppliedCampaignOut, 0, len(in))
+	for _, a := range in {
+		out = append(out, sdto.AppliedCampaignOut{CampaignID: a.CampaignID, BonusPoints: a.BonusPoints.Int()})
+	}
+	return out
+}
+
+// timeOfDayPtr formats a time of day as "HH:MM".
+func timeOfDayPtr(d *time.Duration) *string {
+	if d == nil {
+		return nil
+	}
+	s := fmt.Sprintf("%02d:%02d", int(d.Hours()), int(d.Minutes())%60)
+	return &s
+}
+
 func RulesetOut(r pgdto.RulesetWithLevels) sdto.RulesetOut {
 	levels := make([]sdto.LevelRuleOut, 0, len(r.Levels))
 	for _, lv := range r.Levels {
@@ -102,15 +245,51 @@ func RulesetOut(r pgdto.RulesetWithLevels) sdto.RulesetOut {
 			LevelCode:           lv.LevelCode,
 			ThresholdTotalSpend: MoneyFixed2(lv.ThresholdTotalSpend),
 			PercentEarn:         MoneyFixed2(lv.PercentEarn),
+			ReachedBonusPoints:  lv.ReachedBonusPoints,
+		})
+	}
+
+	rates := make([]sdto.CategoryRateOut, 0, len(r.CategoryRates))
+	for _, cr := range r.CategoryRates {
+		rates = append(rates, sdto.CategoryRateOut{
+			Category:   cr.Category,
+			EarnFactor: MoneyFixed2(cr.EarnFactor),
+		})
+	}
+
+	history := make([]sdto.RulesetHistoryOut, 0, len(r.History))
+	for _, h := range r.History {
+		history = append(history, sdto.RulesetHistoryOut{
+			Action:      string(h.Action),
+			ActorUserID: h.ActorUserID,
+			Comment:     h.Comment,
+			At:          h.CreatedAt,
 		})
 	}
 
 	return sdto.RulesetOut{
-		ID:              r.Ruleset.ID,
-		EffectiveFrom:   r.Ruleset.EffectiveFrom,
-		BaseRubPerPoint: MoneyFixed2(r.Ruleset.BaseRubPerPoint),
-		Levels:          levels,
-		CreatedAt:       r.Ruleset.CreatedAt,
+		ID:                r.Ruleset.ID,
+		EffectiveFrom:     r.Ruleset.EffectiveFrom,
+		EffectiveTo:       r.Ruleset.EffectiveTo,
+		BaseRubPerPoint:   MoneyFixed2(r.Ruleset.BaseRubPerPoint),
+		RedeemRubPerPoint: MoneyFixed2(r.Ruleset.RedeemRubPerPoint),
+		Levels:            levels,
+		CreatedAt:         r.Ruleset.CreatedAt,
+
+		QualificationWindowDays: r.Ruleset.QualificationWindowDays,
+		CategoryRates:           rates,
+		WelcomeBonusPoints:      r.Ruleset.WelcomeBonusPoints,
+		BirthdayBonusPoints:     r.Ruleset.BirthdayBonusPoints,
+		ReferrerBonusPoints:     r.Ruleset.ReferrerBonusPoints,
+		RefereeBonusPoints:      r.Ruleset.RefereeBonusPoints,
+		RoundingMode:            r.Ruleset.RoundingMode,
+		RoundingStage:           r.Ruleset.RoundingStage,
+		MinEarnAmount:           MoneyFixed2(r.Ruleset.MinEarnAmount),
+		MaxPointsPerPurchase:    r.Ruleset.MaxPointsPerPurchase,
+		MaxPointsPerDay:         r.Ruleset.MaxPointsPerDay,
+
+		Status:  string(r.Ruleset.Status),
+		History: history,
 	}
 }
 
@@ -134,3 +313,154 @@ func MoneyPtrFixed2(m *pgdto.Money) string {
 	}
 	return m.StringFixed(2)
 }
+
+// Account builds the domain aggregate from a repository row.
+func Account(r pgdto.AccountRow) (account.Account, error) {
+	ts, err := ledger.ParseMoney(r.TotalSpendMoney.String())
+	if err != nil {
+		return account.Account{}, err
+	}
+	return account.Account{
+		ID:         r.ID,
+		PublicCode: account.PublicCode(r.PublicCode),
+		Balance:    ledger.Points(r.BalancePoints),
+		TotalSpend: ts,
+		LevelCode:  rules.LevelCode(r.LevelCode),
+		Held:       ledger.Points(r.HeldPoints),
+	}, nil
+}
+
+// LevelRules builds domain level rules from repository rows (unsorted, unvalidated).
+func LevelRules(in []pgdto.LevelRuleRow) ([]rules.LevelRule, error) {
+	out := make([]rules.LevelRule, 0, len(in))
+	for _, lv := range in {
+		thr, err := ledger.ParseMoney(lv.ThresholdTotalSpend.String())
+		if err != nil {
+			return nil, errs.Wrap(errs.CodeInvalidLevels, fmt.Sprintf("thresholdTotalSpend parse failed: %s", lv.ThresholdTotalSpend.String()), err)
+		}
+		perc, err := rules.ParsePercent(lv.PercentEarn.String())
+		if err != nil {
+			return nil, errs.Wrap(errs.CodeInvalidLevels, fmt.Sprintf("percentEarn parse failed: %s", lv.PercentEarn.String()), err)
+		}
+		out = append(out, rules.LevelRule{
+			ID:                  lv.ID,
+			LevelCode:           rules.LevelCode(lv.LevelCode),
+			ThresholdTotalSpend: thr,
+			PercentEarn:         perc,
+			ReachedBonusPoints:  ledger.Points(lv.ReachedBonusPoints),
+		})
+	}
+	return out, nil
+}
+
+// Rounding builds the earn rounding policy of a stored ruleset.
+func Rounding(rs pgdto.RulesetRow) (rules.Rounding, error) {
+	r, err := rules.ParseRounding(rs.RoundingMode, rs.RoundingStage)
+	if err != nil {
+		return rules.Rounding{}, errs.Wrap(errs.CodeInvalidRuleset, "rounding parse failed", err)
+	}
+	return r, nil
+}
+
+// Ruleset builds the domain ruleset of the earn computation: levels validated and sorted, rounding and caps.
+func Ruleset(rs pgdto.RulesetWithLevels) (rules.Ruleset, error) {
+	base, err := ledger.ParseMoney(rs.Ruleset.BaseRubPerPoint.String())
+	if err != nil {
+		return rules.Ruleset{}, errs.Wrap(errs.CodeInvalidRuleset, "baseRubPerPoint parse failed", err)
+	}
+
+	levels, err := LevelRules(rs.Levels)
+	if err != nil {
+		return rules.Ruleset{}, err
+	}
+	if err := rules.ValidateLevels(levels); err != nil {
+		return rules.Ruleset{}, err
+	}
+	rules.SortLevels(levels)
+
+	rounding, err := Rounding(rs.Ruleset)
+	if err != nil {
+		return rules.Ruleset{}, err
+	}
+
+	caps, err := EarnCaps(rs.Ruleset)
+	if err != nil {
+		return rules.Ruleset{}, err
+	}
+	if err := caps.Validate(); err != nil {
+		return rules.Ruleset{}, err
+	}
+
+	return rules.Ruleset{
+		ID:              rs.Ruleset.ID,
+		BaseRubPerPoint: base,
+		Levels:          levels,
+		Rounding:        rounding,
+		Caps:            caps,
+	}, nil
+}
+
+// EarnCaps builds the earn caps of a stored ruleset.
+func EarnCaps(rs pgdto.RulesetRow) (rules.EarnCaps, error) {
+	minAmount, err := ledger.ParseMoney(rs.MinEarnAmount.String())
+	if err != nil {
+		return rules.EarnCaps{}, errs.Wrap(errs.CodeInvalidRuleset, "minEarnAmount parse failed", err)
+	}
+	c := rules.EarnCaps{MinPurchase: minAmount}
+	if rs.MaxPointsPerPurchase != nil {
+		p := ledger.Points(*rs.MaxPointsPerPurchase)
+		c.MaxPointsPerPurchase = &p
+	}
+	if rs.MaxPointsPerDay != nil {
+		p := ledger.Points(*rs.MaxPointsPerDay)
+		c.MaxPointsPerDay = &p
+	}
+	return c, nil
+}
+
+// EventInsert maps a domain event draft to a repository insert.
+func EventInsert(d ledger.EventDraft) pgdto.EventInsert {
+	var typ pgdto.EventType
+	switch d.Type {
+	case ledger.EventEarn:
+		typ = pgdto.EventEarn
+	case ledger.EventSpend:
+		typ = pgdto.EventSpend
+	case ledger.EventRefund:
+		typ = pgdto.EventRefund
+	case ledger.EventVoid:
+		typ = pgdto.EventVoid
+	case ledger.EventExpire:
+		typ = pgdto.EventExpire
+	case ledger.EventAdjust:
+		typ = pgdto.EventAdjust
+	case ledger.EventTransferOut:
+		typ = pgdto.EventTransferOut
+	case ledger.EventTransferIn:
+		typ = pgdto.EventTransferIn
+	case ledger.EventBonus:
+		typ = pgdto.EventBonus
+	case ledger.EventLevelChanged:
+		typ = pgdto.EventLevelChanged
+	default:
+		typ = pgdto.EventType(d.Type)
+	}
+
+	var amt *pgdto.Money
+	if d.AmountMoney != nil {
+		m := pgdto.Money(d.AmountMoney.Decimal())
+		amt = &m
+	}
+
+	return pgdto.EventInsert{
+		AccountID:    d.AccountID,
+		Type:         typ,
+		DeltaPoints:  int(d.DeltaPoints),
+		BalanceAfter: int(d.BalanceAfter),
+		AmountMoney:  amt,
+		RulesetID:    d.RulesetID,
+		ActorUserID:  d.ActorUserID,
+		RefEventID:   d.RefEventID,
+		Ts:           d.Ts,
+	}
+}
@@ -14,8 +14,11 @@ type Auth interface {
 
 type Client interface {
 	GetMe(ctx context.Context, userID int64) (dto.ClientProfileOut, error)
+	SetBirthday(ctx context.Context, userID int64, in dto.SetBirthdayIn) (dto.ClientProfileOut, error)
 	GetBalance(ctx context.Context, userID int64) (dto.BalanceOut, error)
 	GetEvents(ctx context.Context, userID int64, in dto.EventsIn) (dto.EventsOut, error)
+	Transfer(ctx context.Context, userID int64, in dto.TransferIn) (dto.OperationOut, error)
+	GetReferrals(ctx context.Context, userID int64) (dto.ReferralStatsOut, error)
 }
 
 type Cashier interface {
@@ -24,6 +27,15 @@ type Cashier interface {
 
 	Earn(ctx context.Context, actorUserID int64, in dto.EarnIn) (dto.OperationOut, error)
 	Spend(ctx context.Context, actorUserID int64, in dto.SpendIn) (dto.OperationOut, error)
+	Refund(ctx context.Context, actorUserID int64, in dto.RefundIn) (dto.OperationOut, error)
+	Void(ctx context.Context, actorUserID int64, in dto.VoidIn) (dto.OperationOut, error)
+	Checkout(ctx context.Context, actorUserID int64, in dto.CheckoutIn) (dto.CheckoutOut, error)
+	QuoteEarn(ctx context.Context, in dto.EarnQuoteIn) (dto.EarnQuoteOut, error)
+	Batch(ctx context.Context, actorUserID int64, in dto.BatchIn) (dto.BatchOut, error)
+
+	AuthorizeHold(ctx context.Context, actorUserID int64, in dto.HoldAuthorizeIn) (dto.HoldResultOut, error)
+	CaptureHold(ctx context.Context, actorUserID int64, in dto.HoldCaptureIn) (dto.HoldResultOut, error)
+	ReleaseHold(ctx context.Context, actorUserID int64, holdID int64) (dto.HoldResultOut, error)
 }
 
 type Admin interface {
@@ -33,4 +45,24 @@ type Admin interface {
 	CreateRuleset(ctx context.Context, actorUserID int64, in dto.CreateRulesetIn) (dto.RulesetOut, error)
 	ListRulesets(ctx context.Context, in dto.ListRulesetsIn) (dto.RulesetsOut, error)
 	GetCurrentRuleset(ctx context.Context, at time.Time) (dto.RulesetOut, error)
+	GetRuleset(ctx context.Context, rulesetID int64) (dto.RulesetOut, error)
+	UpdateRuleset(ctx context.Context, actorUserID, rulesetID int64, in dto.CreateRulesetIn) (dto.RulesetOut, error)
+	DeleteRuleset(ctx context.Context, rulesetID int64) error
+	SubmitRuleset(ctx context.Context, actorUserID, rulesetID int64) (dto.RulesetOut, error)
+	ApproveRuleset(ctx context.Context, actorUserID, rulesetID int64) (dto.RulesetOut, error)
+	RejectRuleset(ctx context.Context, actorUserID, rulesetID int64, in dto.RejectRulesetIn) (dto.RulesetOut, error)
+	SimulateRuleset(ctx context.Context, in dto.SimulateRulesetIn) (dto.SimulateRulesetOut, error)
+
+	AdjustBalance(ctx context.Context, actorUserID int64, in dto.AdjustBalanceIn) (dto.AdjustmentOut, error)
+	VerifyLedger(ctx context.Context, in dto.VerifyLedgerIn) (dto.LedgerReportOut, error)
+
+	ListLimits(ctx context.Context) ([]dto.LimitOut, error)
+	SetLimit(ctx context.Context, actorUserID int64, in dto.SetLimitIn) (dto.LimitOut, error)
+	DeleteLimit(ctx context.Context, limitID int64) error
+
+	ListCampaigns(ctx context.Context) ([]dto.CampaignOut, error)
+	GetCampaign(ctx context.Context, campaignID int64) (dto.CampaignOut, error)
+	CreateCampaign(ctx context.Context, actorUserID int64, in dto.CampaignIn) (dto.CampaignOut, error)
+	UpdateCampaign(ctx context.Context, actorUserID, campaignID int64, in dto.CampaignIn) (dto.CampaignOut, error)
+	DeleteCampaign(ctx context.Context, campaignID int64) error
 }
@@ -0,0 +1,252 @@
+// Package referrals runs the referral program: every account has a code, a client registering
+// with it becomes the referee of its owner, and the referee's first qualifying EARN pays a BONUS to both sides.
+package referrals
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"Beanefits/internal/domain/errs"
+	"Beanefits/internal/domain/ledger"
+	pg "Beanefits/internal/repository/postgres"
+	pgdto "Beanefits/internal/repository/postgres/dto"
+	"Beanefits/internal/service/bonuses"
+)
+
+type CodeGenerator interface {
+	New(ctx context.Context) (string, error)
+}
+
+// Program keeps referral codes and referrals in the ledger. Every call must run inside a transaction.
+type Program struct {
+	accounts  pg.AccountsRepo
+	users     pg.UsersRepo
+	referrals pg.ReferralsRepo
+	bonuses   *bonuses.Granter
+	codeGen   CodeGenerator
+
+	monthlyLimit int
+	codeRetries  int
+}
+
+type Config struct {
+	// MonthlyLimit caps the referrals one referrer can collect per calendar month (UTC); 0: no cap.
+	MonthlyLimit int
+
+	// CodeRetries is how many codes are tried when a generated one is taken (default 8).
+	CodeRetries int
+}
+
+const defaultCodeRetries = 8
+
+func NewProgram(
+	accounts pg.AccountsRepo,
+	users pg.UsersRepo,
+	referrals pg.ReferralsRepo,
+	granter *bonuses.Granter,
+	codeGen CodeGenerator,
+	cfg Config,
+) *Program {
+	retries := cfg.CodeRetries
+	if retries <= 0 {
+		retries = defaultCodeRetries
+	}
+	return &Program{
+		accounts:     accounts,
+		users:        users,
+		referrals:    referrals,
+		bonuses:      granter,
+		codeGen:      codeGen,
+		monthlyLimit: cfg.MonthlyLimit,
+		codeRetries:  retries,
+	}
+}
+
+// MonthlyLimit is the configured cap of referrals per referrer and month; 0: no cap.
+func (p *Program) MonthlyLimit() int { return p.monthlyLimit }
+
+// AssignCode gives a new account its referral code.
+func (p *Program) AssignCode(ctx context.Context, tx pg.DBTX, accountID int64) error {
+	var lastCollision error
+	for i := 0; i < p.codeRetries; i++ {
+		code, err := p.codeGen.New(ctx)
+		if err != nil {
+			return errs.Wrap(errs.CodeInternal, "referral_code.new", err)
+		}
+
+		err = p.referrals.InsertCode(ctx, tx, accountID, code)
+		if err == nil {
+			return nil
+		}
+		if pg.IsUniqueViolation(err, "uq_referral_codes_code") {
+			lastCollision = err
+			continue
+		}
+		return errs.Wrap(errs.CodeInternal, "referrals.insert_code", err)
+	}
+	return errs.Wrap(errs.CodePublicCodeCollision, "referral code collision retries exhausted", lastCollision)
+}
+
+// Attach makes the owner of code the referrer of a just created account.
+// The referrer is locked while its monthly referrals are counted, so concurrent registrations
+// cannot overshoot the cap.
+func (p *Program) Attach(ctx context.Context, tx pg.DBTX, refereeAccountID int64, code string, ts time.Time) error {
+	code = NormalizeCode(code)
+	if code == "" {
+		return errs.New(errs.CodeInvalidReferralCode, "referral code is empty")
+	}
+
+	referrerID, ok, err := p.referrals.GetAccountIDByCode(ctx, tx, code)
+	if err != nil {
+		return errs.Wrap(errs.CodeInternal, "referrals.get_account_id_by_code", err)
+	}
+	if !ok {
+		return errs.New(errs.CodeInvalidReferralCode, "unknown referral code")
+	}
+	if referrerID == refereeAccountID {
+		return errs.New(errs.CodeInvalidReferralCode, "cannot use own referral code")
+	}
+
+	referrer, err := p.accounts.LockByID(ctx, tx, referrerID)
+	if err != nil {
+		return errs.Wrap(errs.CodeInternal, "accounts.lock_by_id", err)
+	}
+	u, ok, err := p.users.GetByID(ctx, tx, referrer.UserID)
+	if err != nil {
+		return errs.Wrap(errs.CodeInternal, "users.get_by_id", err)
+	}
+	if !ok || !u.IsActive {
+		return errs.New(errs.CodeInvalidReferralCode, "referral code owner is inactive")
+	}
+
+	if p.monthlyLimit > 0 {
+		n, err := p.referrals.CountByReferrerSince(ctx, tx, referrerID, MonthStart(ts))
+		if err != nil {
+			return errs.Wrap(errs.CodeInternal, "referrals.count_by_referrer_since", err)
+		}
+		if n >= p.monthlyLimit {
+			return errs.New(errs.CodeReferralLimitExceeded,
+				fmt.Sprintf("referral code owner reached the limit of %d referrals this month", p.monthlyLimit))
+		}
+	}
+
+	if err := p.referrals.Insert(ctx, tx, refereeAccountID, referrerID, ts); err != nil {
+		return errs.Wrap(errs.CodeInternal, "referrals.insert", err)
+	}
+	return nil
+}
+
+// LockReferrer locks the referrer of a referee whose referral is still pending.
+// Call it before locking the referee: the referrer is the older account, so locks stay in id order.
+func (p *Program) LockReferrer(ctx context.Context, tx pg.DBTX, refereeAccountID int64) error {
+	if p == nil {
+		return nil
+	}
+
+	ref, ok, err := p.referrals.GetPendingByReferee(ctx, tx, refereeAccountID)
+	if err != nil {
+		return errs.Wrap(errs.CodeInternal, "referrals.get_pending_by_referee", err)
+	}
+	if !ok {
+		return nil
+	}
+	if _, err := p.accounts.LockByID(ctx, tx, ref.ReferrerAccountID); err != nil {
+		return errs.Wrap(errs.CodeInternal, "accounts.lock_by_id", err)
+	}
+	return nil
+}
+
+// Reward pays the referral bonuses of the ruleset on the referee's first qualifying EARN (earnEventID
+// of purchase) and returns the referee account after its bonus. An EARN qualifies if its purchase is
+// above zero and reaches the ruleset's minEarnAmount; other EARNs leave the referral pending.
+// The referral is closed once it qualifies, so it pays at most once; amounts of 0 close it without a bonus.
+// Both accounts must be locked (see LockReferrer).
+func (p *Program) Reward(ctx context.Context, tx pg.DBTX, referee pgdto.AccountRow, rs pgdto.RulesetRow, earnEventID int64, purchase ledger.Money, ts time.Time) (pgdto.AccountRow, error) {
+	if p == nil {
+		return referee, nil
+	}
+
+	minEarn, err := ledger.ParseMoney(rs.MinEarnAmount.String())
+	if err != nil {
+		return pgdto.AccountRow{}, errs.Wrap(errs.CodeInvalidRuleset, "minEarnAmount parse failed", err)
+	}
+	if !purchase.GT(ledger.ZeroMoney()) || purchase.LT(minEarn) {
+		return referee, nil
+	}
+
+	ref, ok, err := p.referrals.GetPendingByReferee(ctx, tx, referee.ID)
+	if err != nil {
+		return pgdto.AccountRow{}, errs.Wrap(errs.CodeInternal, "referrals.get_pending_by_referee", err)
+	}
+	if !ok {
+		return referee, nil
+	}
+
+	upd := pgdto.ReferralRewardUpdate{
+		RefereeAccountID:  referee.ID,
+		RewardedAt:        ts,
+		QualifyingEventID: earnEventID,
+	}
+
+	if rs.RefereeBonusPoints > 0 {
+		updated, ev, err := p.bonuses.Credit(ctx, tx, referee, ledger.Points(rs.RefereeBonusPoints), rs.ID, ts)
+		if err != nil {
+			return pgdto.AccountRow{}, err
+		}
+		referee = updated
+		upd.RefereeEventID = &ev.ID
+	}
+
+	if rs.ReferrerBonusPoints > 0 {
+		referrer, err := p.accounts.LockByID(ctx, tx, ref.ReferrerAccountID)
+		if err != nil {
+			return pgdto.AccountRow{}, errs.Wrap(errs.CodeInternal, "accounts.lock_by_id", err)
+		}
+		_, ev, err := p.bonuses.Credit(ctx, tx, referrer, ledger.Points(rs.ReferrerBonusPoints), rs.ID, ts)
+		if err != nil {
+			return pgdto.AccountRow{}, err
+		}
+		upd.ReferrerEventID = &ev.ID
+	}
+
+	closed, err := p.referrals.MarkRewarded(ctx, tx, upd)
+	if err != nil {
+		return pgdto.AccountRow{}, errs.Wrap(errs.CodeInternal, "referrals.mark_rewarded", err)
+	}
+	if !closed {
+		// the referee is locked, so nobody else can close it in between
+		return pgdto.AccountRow{}, errs.New(errs.CodeInternal, "referral was rewarded concurrently")
+	}
+	return referee, nil
+}
+
+// Stats returns the code of an account and the referrals it made; referredThisMonth counts
+// the ones of the calendar month of now.
+func (p *Program) Stats(ctx context.Context, db pg.DBTX, accountID int64, now time.Time) (string, pgdto.ReferralStatsRow, error) {
+	code, ok, err := p.referrals.GetCode(ctx, db, accountID)
+	if err != nil {
+		return "", pgdto.ReferralStatsRow{}, errs.Wrap(errs.CodeInternal, "referrals.get_code", err)
+	}
+	if !ok {
+		return "", pgdto.ReferralStatsRow{}, errs.New(errs.CodeInternal, "account has no referral code")
+	}
+
+	st, err := p.referrals.GetStats(ctx, db, accountID, MonthStart(now))
+	if err != nil {
+		return "", pgdto.ReferralStatsRow{}, errs.Wrap(errs.CodeInternal, "referrals.get_stats", err)
+	}
+	return code, st, nil
+}
+
+// NormalizeCode makes code lookups case-insensitive.
+func NormalizeCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// MonthStart is the first moment of the UTC calendar month of t.
+func MonthStart(t time.Time) time.Time {
+	u := t.UTC()
+	return time.Date(u.Year(), u.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,71 @@
+package retention
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type RunConfig struct {
+	Interval time.Duration
+	Batch    int
+}
+
+// Start runs PurgeOperations and PurgeTombstones every Interval until stop is called.
+// A full batch is followed by another one right away, each in its own transaction,
+// so a backlog drains without holding locks for long.
+func Start(parent context.Context, s *Service, cfg RunConfig) (stop func()) {
+	if s == nil || (s.retention <= 0 && s.tombstoneTTL <= 0) {
+		return func() {}
+	}
+
+	interval := cfg.Interval
+	if interval <= 0 {
+		interval = time.Hour
+	}
+	batch := cfg.Batch
+	if batch <= 0 {
+		batch = 500
+	}
+
+	ctx, cancel := context.WithCancel(parent)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+
+	s.log.InfoContext(ctx, "retention runner started", "retention", s.retention, "tombstoneTTL", s.tombstoneTTL, "interval", interval, "batch", batch)
+
+	go func() {
+		defer wg.Done()
+
+		t := time.NewTicker(interval)
+		defer t.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				s.log.Info("retention runner stopped")
+				return
+
+			case <-t.C:
+				drain(ctx, batch, s.PurgeOperations)
+				drain(ctx, batch, s.PurgeTombstones)
+			}
+		}
+	}()
+
+	return func() {
+		cancel()
+		wg.Wait()
+	}
+}
+
+// drain runs pass until it removes less than a full batch, fails or ctx is done.
+func drain(ctx context.Context, batch int, pass func(ctx context.Context, batch int) (int, error)) {
+	for ctx.Err() == nil {
+		n, err := pass(ctx, batch)
+		if err != nil || n < batch {
+			return
+		}
+	}
+}
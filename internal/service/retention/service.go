@@ -0,0 +1,163 @@
+package retention
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"Beanefits/internal/domain/errs"
+	pg "Beanefits/internal/repository/postgres"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+type Clock func() time.Time
+
+var (
+	operationsRemoved = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "beanefits",
+		Subsystem: "operations_gc",
+		Name:      "removed_total",
+		Help:      "Idempotency records removed by the retention job.",
+	})
+	tombstonesRemoved = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "beanefits",
+		Subsystem: "operations_gc",
+		Name:      "tombstones_removed_total",
+		Help:      "Keys of expired idempotency records removed by the retention job.",
+	})
+	purgeBatches = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "beanefits",
+		Subsystem: "operations_gc",
+		Name:      "batches_total",
+		Help:      "Retention job batches by result (ok, error).",
+	}, []string{"result"})
+	lastPurge = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: "beanefits",
+		Subsystem: "operations_gc",
+		Name:      "last_success_timestamp_seconds",
+		Help:      "Unix time of the last successful retention batch.",
+	})
+)
+
+// Service removes idempotency records (operations) older than the retention window.
+// Only their keys are kept, so an expired operationId is rejected instead of being applied again;
+// the keys themselves are removed once they are older than the tombstone TTL.
+type Service struct {
+	txm        pg.TxManager
+	operations pg.OperationsRepo
+
+	retention    time.Duration
+	tombstoneTTL time.Duration
+
+	now Clock
+	log *slog.Logger
+}
+
+type Deps struct {
+	TXM        pg.TxManager
+	Operations pg.OperationsRepo
+
+	// Retention is how long an operationId can be replayed; <= 0 keeps records forever.
+	Retention time.Duration
+
+	// TombstoneTTL is how long the key of a removed record keeps rejecting late retries; <= 0 keeps keys forever.
+	TombstoneTTL time.Duration
+
+	Now Clock
+	Log *slog.Logger
+}
+
+func New(deps Deps) *Service {
+	n := deps.Now
+	if n == nil {
+		n = time.Now
+	}
+
+	l := deps.Log
+	if l == nil {
+		l = slog.Default()
+	}
+	l = l.With("layer", "service", "svc", "retention")
+
+	return &Service{
+		txm:          deps.TXM,
+		operations:   deps.Operations,
+		retention:    deps.Retention,
+		tombstoneTTL: deps.TombstoneTTL,
+		now:          n,
+		log:          l,
+	}
+}
+
+// PurgeOperations removes up to batch records older than the retention window in one short transaction.
+// It returns the number of records removed.
+func (s *Service) PurgeOperations(ctx context.Context, batch int) (int, error) {
+	if s.retention <= 0 {
+		return 0, nil
+	}
+
+	start := time.Now()
+	before := s.now().Add(-s.retention)
+
+	var removed int
+	err := s.txm.WithinTx(ctx, func(ctx context.Context, tx pg.DBTX) error {
+		n, err := s.operations.ExpireBefore(ctx, tx, before, batch)
+		if err != nil {
+			return errs.Wrap(errs.CodeInternal, "operations.expire_before", err)
+		}
+		removed = n
+		return nil
+	})
+	if err != nil {
+		purgeBatches.WithLabelValues("error").Inc()
+		s.log.ErrorContext(ctx, "retention.purge_operations failed", "ms", time.Since(start).Milliseconds(), "err", err)
+		return 0, err
+	}
+
+	purgeBatches.WithLabelValues("ok").Inc()
+	operationsRemoved.Add(float64(removed))
+	lastPurge.SetToCurrentTime()
+
+	if removed > 0 {
+		s.log.InfoContext(ctx, "retention.purge_operations ok", "ms", time.Since(start).Milliseconds(), "before", before, "removed", removed)
+	}
+	return removed, nil
+}
+
+// PurgeTombstones removes up to batch keys of expired records older than the tombstone TTL
+// in one short transaction. A retry with such a key is no longer rejected; the TTL is meant to be
+// far longer than any client retries. It returns the number of keys removed.
+func (s *Service) PurgeTombstones(ctx context.Context, batch int) (int, error) {
+	if s.tombstoneTTL <= 0 {
+		return 0, nil
+	}
+
+	start := time.Now()
+	before := s.now().Add(-s.tombstoneTTL)
+
+	var removed int
+	err := s.txm.WithinTx(ctx, func(ctx context.Context, tx pg.DBTX) error {
+		n, err := s.operations.PurgeExpiredBefore(ctx, tx, before, batch)
+		if err != nil {
+			return errs.Wrap(errs.CodeInternal, "operations.purge_expired_before", err)
+		}
+		removed = n
+		return nil
+	})
+	if err != nil {
+		purgeBatches.WithLabelValues("error").Inc()
+		s.log.ErrorContext(ctx, "retention.purge_tombstones failed", "ms", time.Since(start).Milliseconds(), "err", err)
+		return 0, err
+	}
+
+	purgeBatches.WithLabelValues("ok").Inc()
+	tombstonesRemoved.Add(float64(removed))
+	lastPurge.SetToCurrentTime()
+
+	if removed > 0 {
+		s.log.InfoContext(ctx, "retention.purge_tombstones ok", "ms", time.Since(start).Milliseconds(), "before", before, "removed", removed)
+	}
+	return removed, nil
+}
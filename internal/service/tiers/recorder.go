@@ -0,0 +1,107 @@
+package tiers
+
+import (
+	"context"
+	"time"
+
+	"Beanefits/internal/domain/errs"
+	"Beanefits/internal/domain/ledger"
+	pg "Beanefits/internal/repository/postgres"
+	pgdto "Beanefits/internal/repository/postgres/dto"
+	"Beanefits/internal/service/bonuses"
+	"Beanefits/internal/service/mapper"
+)
+
+// Recorder writes level transitions into the ledger as LEVEL_CHANGED events and pays
+// the reached bonus of a level. Every call must run inside a transaction, after the account row is locked.
+type Recorder struct {
+	events  pg.EventsRepo
+	changes pg.LevelChangesRepo
+	bonuses *bonuses.Granter
+}
+
+func NewRecorder(events pg.EventsRepo, changes pg.LevelChangesRepo, granter *bonuses.Granter) *Recorder {
+	return &Recorder{events: events, changes: changes, bonuses: granter}
+}
+
+// Change is a recorded level transition; BonusEventID is set when the reached bonus was paid.
+type Change struct {
+	EventID      int64
+	FromLevel    string
+	ToLevel      string
+	BonusPoints  int
+	BonusEventID *int64
+}
+
+// Record writes a LEVEL_CHANGED event if acc (already updated) is no longer at level from and returns
+// the account after the reached bonus, if any. The bonus of a level is paid on a move up only,
+// and only the first time the account reaches that level.
+// refEventID is the operation that caused the change; nil for the nightly requalification.
+func (r *Recorder) Record(
+	ctx context.Context,
+	tx pg.DBTX,
+	acc pgdto.AccountRow,
+	from string,
+	rs pgdto.RulesetWithLevels,
+	actorUserID *int64,
+	refEventID *int64,
+	ts time.Time,
+) (pgdto.AccountRow, *Change, error) {
+	if r == nil || acc.LevelCode == from {
+		return acc, nil, nil
+	}
+
+	bonus := 0
+	if to, ok := levelRow(rs.Levels, acc.LevelCode); ok && to.ReachedBonusPoints > 0 {
+		prev, known := levelRow(rs.Levels, from)
+		if !known || to.ThresholdTotalSpend.Cmp(prev.ThresholdTotalSpend) > 0 {
+			reached, err := r.changes.HasReached(ctx, tx, acc.ID, acc.LevelCode)
+			if err != nil {
+				return pgdto.AccountRow{}, nil, errs.Wrap(errs.CodeInternal, "level_changes.has_reached", err)
+			}
+			if !reached {
+				bonus = to.ReachedBonusPoints
+			}
+		}
+	}
+
+	rsID := rs.Ruleset.ID
+	evDraft := ledger.NewLevelChangedDraft(acc.ID, ledger.Points(acc.BalancePoints), &rsID, actorUserID, refEventID, ts)
+	evRow, err := r.events.Insert(ctx, tx, mapper.EventInsert(evDraft))
+	if err != nil {
+		return pgdto.AccountRow{}, nil, errs.Wrap(errs.CodeInternal, "events.insert", err)
+	}
+
+	ch := &Change{EventID: evRow.ID, FromLevel: from, ToLevel: acc.LevelCode}
+
+	if bonus > 0 {
+		updated, bonusEv, err := r.bonuses.Credit(ctx, tx, acc, ledger.Points(bonus), rsID, ts)
+		if err != nil {
+			return pgdto.AccountRow{}, nil, err
+		}
+		acc = updated
+		ch.BonusPoints = bonus
+		ch.BonusEventID = &bonusEv.ID
+	}
+
+	err = r.changes.Insert(ctx, tx, pgdto.LevelChangeRow{
+		EventID:      ch.EventID,
+		AccountID:    acc.ID,
+		FromLevel:    ch.FromLevel,
+		ToLevel:      ch.ToLevel,
+		BonusEventID: ch.BonusEventID,
+	})
+	if err != nil {
+		return pgdto.AccountRow{}, nil, errs.Wrap(errs.CodeInternal, "level_changes.insert", err)
+	}
+	return acc, ch, nil
+}
+
+func levelRow(levels []pgdto.LevelRuleRow, code string) (pgdto.LevelRuleRow, bool) {
+	for _, lv := range levels {
+		if lv.LevelCode == code {
+			return lv, true
+		}
+	}
+	return pgdto.LevelRuleRow{}, false
+}
@@ -0,0 +1,219 @@
+package tiers
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"Beanefits/internal/domain/errs"
+	"Beanefits/internal/domain/ledger"
+	"Beanefits/internal/domain/rules"
+	pg "Beanefits/internal/repository/postgres"
+	pgdto "Beanefits/internal/repository/postgres/dto"
+	"Beanefits/internal/service/mapper"
+)
+
+type Clock func() time.Time
+
+// Service requalifies levels under a ruleset with a rolling qualification window:
+// accounts whose spend in the window has fallen below their level's threshold are moved down.
+// Upgrades are not its business: EARN resolves the level on every purchase.
+type Service struct {
+	txm pg.TxManager
+
+	rules    pg.RulesRepo
+	accounts pg.AccountsRepo
+	events   pg.EventsRepo
+	levels   *Recorder
+
+	now Clock
+	log *slog.Logger
+}
+
+type Deps struct {
+	TXM pg.TxManager
+
+	Rules    pg.RulesRepo
+	Accounts pg.AccountsRepo
+	Events   pg.EventsRepo
+
+	// Levels records every downgrade as a LEVEL_CHANGED event; nil records none.
+	Levels *Recorder
+
+	Now Clock
+	Log *slog.Logger
+}
+
+func New(deps Deps) *Service {
+	n := deps.Now
+	if n == nil {
+		n = time.Now
+	}
+
+	l := deps.Log
+	if l == nil {
+		l = slog.Default()
+	}
+	l = l.With("layer", "service", "svc", "tiers")
+
+	return &Service{
+		txm:      deps.TXM,
+		rules:    deps.Rules,
+		accounts: deps.Accounts,
+		events:   deps.Events,
+		levels:   deps.Levels,
+		now:      n,
+		log:      l,
+	}
+}
+
+// Requalify checks every account against the ruleset effective now, batch accounts per page
+// and one transaction per account. It is a no-op under a lifetime ruleset.
+// It returns the number of accounts that were downgraded.
+func (s *Service) Requalify(ctx context.Context, batch int) (int, error) {
+	start := time.Now()
+	at := s.now()
+
+	var rs pgdto.RulesetWithLevels
+	found := false
+	err := s.txm.WithinTx(ctx, func(ctx context.Context, tx pg.DBTX) error {
+		var err error
+		rs, found, err = s.rules.GetEffectiveAt(ctx, tx, at)
+		if err != nil {
+			return errs.Wrap(errs.CodeInternal, "rules.get_effective_at", err)
+		}
+		return nil
+	})
+	if err != nil {
+		s.log.ErrorContext(ctx, "tiers.requalify failed", "ms", time.Since(start).Milliseconds(), "err", err)
+		return 0, err
+	}
+	if !found || rs.Ruleset.QualificationWindowDays == nil {
+		return 0, nil
+	}
+
+	levels, err := mapper.LevelRules(rs.Levels)
+	if err == nil {
+		err = rules.ValidateLevels(levels)
+	}
+	if err != nil {
+		s.log.ErrorContext(ctx, "tiers.requalify failed", "rulesetID", rs.Ruleset.ID, "err", err)
+		return 0, err
+	}
+	rules.SortLevels(levels)
+
+	since := rules.QualificationSince(at, *rs.Ruleset.QualificationWindowDays)
+
+	var (
+		afterID    int64
+		checked    int
+		downgraded int
+	)
+	for {
+		var ids []int64
+		err := s.txm.WithinTx(ctx, func(ctx context.Context, tx pg.DBTX) error {
+			var err error
+			ids, err = s.accounts.ListIDsAfter(ctx, tx, afterID, batch)
+			if err != nil {
+				return errs.Wrap(errs.CodeInternal, "accounts.list_ids_after", err)
+			}
+			return nil
+		})
+		if err != nil {
+			s.log.ErrorContext(ctx, "tiers.requalify failed", "ms", time.Since(start).Milliseconds(), "err", err)
+			return downgraded, err
+		}
+
+		for _, id := range ids {
+			if err := ctx.Err(); err != nil {
+				return downgraded, err
+			}
+			ok, err := s.requalifyAccount(ctx, id, rs, levels, since, at)
+			if err != nil {
+				s.log.ErrorContext(ctx, "tiers.requalify_account failed", "accountID", id, "err", err)
+				continue
+			}
+			checked++
+			if ok {
+				downgraded++
+			}
+		}
+
+		if len(ids) < batch {
+			break
+		}
+		afterID = ids[len(ids)-1]
+	}
+
+	s.log.InfoContext(ctx, "tiers.requalify ok",
+		"ms", time.Since(start).Milliseconds(),
+		"rulesetID", rs.Ruleset.ID,
+		"accounts", checked,
+		"downgraded", downgraded,
+	)
+	return downgraded, nil
+}
+
+func (s *Service) requalifyAccount(ctx context.Context, accountID int64, rs pgdto.RulesetWithLevels, levels []rules.LevelRule, since, at time.Time) (bool, error) {
+	changed := false
+
+	err := s.txm.WithinTx(ctx, func(ctx context.Context, tx pg.DBTX) error {
+		// same gate as cashier operations: an EARN in flight resolves the level itself
+		acc, err := s.accounts.LockByID(ctx, tx, accountID)
+		if err != nil {
+			return errs.Wrap(errs.CodeInternal, "accounts.lock_by_id", err)
+		}
+
+		current, ok := levelByCode(levels, acc.LevelCode)
+		if !ok || current.ThresholdTotalSpend.IsZero() {
+			// a level of another ruleset is left to the next EARN; the baseline cannot go lower
+			return nil
+		}
+
+		sum, err := s.events.SumQualifyingSpendSince(ctx, tx, accountID, since)
+		if err != nil {
+			return errs.Wrap(errs.CodeInternal, "events.sum_qualifying_spend_since", err)
+		}
+		spend, err := ledger.ParseMoney(sum.String())
+		if err != nil {
+			return errs.Wrap(errs.CodeInternal, "qualifying spend parse failed", err)
+		}
+
+		lr, err := rules.ResolveLevel(spend, levels)
+		if err != nil {
+			return err
+		}
+		if !lr.ThresholdTotalSpend.LT(current.ThresholdTotalSpend) {
+			return nil
+		}
+
+		updated, err := s.accounts.SetLevel(ctx, tx, accountID, string(lr.LevelCode))
+		if err != nil {
+			return errs.Wrap(errs.CodeInternal, "accounts.set_level", err)
+		}
+		// a system entry: no actor and no operation behind it
+		if _, _, err := s.levels.Record(ctx, tx, updated, acc.LevelCode, rs, nil, nil, at); err != nil {
+			return err
+		}
+
+		s.log.InfoContext(ctx, "tiers.downgrade ok",
+			"accountID", accountID,
+			"from", acc.LevelCode,
+			"to", lr.LevelCode,
+			"qualifyingSpend", spend.Decimal().StringFixed(2),
+		)
+		changed = true
+		return nil
+	})
+
+	return changed, err
+}
+
+func levelByCode(levels []rules.LevelRule, code string) (rules.LevelRule, bool) {
+	for _, lr := range levels {
+		if string(lr.LevelCode) == code {
+			return lr, true
+		}
+	}
+	return rules.LevelRule{}, false
+}
@@ -98,6 +98,7 @@ func ParseDecimal2(s string) (decimal.Decimal, error) {
 // - unique LevelCode
 // - ThresholdTotalSpend: decimal2, >= 0; unique by normalized StringFixed(2)
 // - PercentEarn: decimal2, > 0
+// - ReachedBonusPoints: >= 0
 // - sort by threshold ascending
 // - require baseline threshold 0.00 (important for deterministic ResolveLevel)
 func ValidateAndMapLevelRules(in []sdto.LevelRuleIn) ([]pgdto.LevelRuleRow, error) {
@@ -146,6 +147,10 @@ func ValidateAndMapLevelRules(in []sdto.LevelRuleIn) ([]pgdto.LevelRuleRow, erro
 			return nil, errs.New(errs.CodeInvalidLevels, fmt.Sprintf("levels[%d].percentEarn must be > 0", i))
 		}
 
+		if lv.ReachedBonusPoints < 0 {
+			return nil, errs.New(errs.CodeInvalidLevels, fmt.Sprintf("levels[%d].reachedBonusPoints must be >= 0", i))
+		}
+
 		out = append(out, pgdto.LevelRuleRow{
 			// ID/RulesetID set by DB layer.
 			ID:                  0,
@@ -153,6 +158,7 @@ func ValidateAndMapLevelRules(in []sdto.LevelRuleIn) ([]pgdto.LevelRuleRow, erro
 			LevelCode:           code,
 			ThresholdTotalSpend: pgdto.Money(thr),
 			PercentEarn:         pgdto.Money(perc),
+			ReachedBonusPoints:  lv.ReachedBonusPoints,
 		})
 	}
 
@@ -167,3 +173,77 @@ func ValidateAndMapLevelRules(in []sdto.LevelRuleIn) ([]pgdto.LevelRuleRow, erro
 
 	return out, nil
 }
+
+// ValidateAndMapEarnCaps validates the earn caps of a new ruleset and maps the minimum purchase to pgdto.Money.
+// Rules enforced:
+// - MinEarnAmount: decimal2, >= 0 (nil = 0.00)
+// - MaxPointsPerPurchase, MaxPointsPerDay: > 0 when set
+// - MaxPointsPerDay >= MaxPointsPerPurchase when both are set (a purchase could never earn its cap otherwise)
+func ValidateAndMapEarnCaps(in sdto.CreateRulesetIn) (pgdto.Money, error) {
+	minAmount := decimal.Zero
+	if in.MinEarnAmount != nil {
+		m, err := ParseDecimal2(*in.MinEarnAmount)
+		if err != nil {
+			return pgdto.Money{}, errs.Wrap(errs.CodeInvalidMoney, "minEarnAmount invalid", err)
+		}
+		if m.Cmp(decimal.Zero) < 0 {
+			return pgdto.Money{}, errs.New(errs.CodeInvalidRuleset, "minEarnAmount must be >= 0")
+		}
+		minAmount = m
+	}
+
+	if p := in.MaxPointsPerPurchase; p != nil && *p <= 0 {
+		return pgdto.Money{}, errs.New(errs.CodeInvalidRuleset, "maxPointsPerPurchase must be > 0")
+	}
+	if d := in.MaxPointsPerDay; d != nil && *d <= 0 {
+		return pgdto.Money{}, errs.New(errs.CodeInvalidRuleset, "maxPointsPerDay must be > 0")
+	}
+	if in.MaxPointsPerPurchase != nil && in.MaxPointsPerDay != nil && *in.MaxPointsPerDay < *in.MaxPointsPerPurchase {
+		return pgdto.Money{}, errs.New(errs.CodeInvalidRuleset, "maxPointsPerDay must be >= maxPointsPerPurchase")
+	}
+
+	return pgdto.Money(minAmount), nil
+}
+
+// ValidateAndMapCategoryRates validates sdto.CategoryRateIn[] and maps to pgdto.CategoryEarnRateRow[].
+// Rules enforced:
+// - Category trimmed, non-empty, <= 64, unique
+// - EarnFactor: decimal2, 0..10 (1.00 earns at the level percent, 0 earns nothing)
+func ValidateAndMapCategoryRates(in []sdto.CategoryRateIn) ([]pgdto.CategoryEarnRateRow, error) {
+	const (
+		maxCategoryLen = 64
+		maxEarnFactor  = 10
+	)
+
+	seen := make(map[string]struct{}, len(in))
+	out := make([]pgdto.CategoryEarnRateRow, 0, len(in))
+
+	for i, r := range in {
+		category := strings.TrimSpace(r.Category)
+		if category == "" {
+			return nil, errs.New(errs.CodeInvalidRuleset, fmt.Sprintf("categoryRates[%d].category is required", i))
+		}
+		if len(category) > maxCategoryLen {
+			return nil, errs.New(errs.CodeInvalidRuleset, fmt.Sprintf("categoryRates[%d].category too long", i))
+		}
+		if _, ok := seen[category]; ok {
+			return nil, errs.New(errs.CodeInvalidRuleset, fmt.Sprintf("duplicate category: %s", category))
+		}
+		seen[category] = struct{}{}
+
+		factor, err := ParseDecimal2(r.EarnFactor)
+		if err != nil {
+			return nil, errs.Wrap(errs.CodeInvalidRuleset, fmt.Sprintf("categoryRates[%d].earnFactor invalid", i), err)
+		}
+		if factor.IsNegative() || factor.GreaterThan(decimal.NewFromInt(maxEarnFactor)) {
+			return nil, errs.New(errs.CodeInvalidRuleset, fmt.Sprintf("categoryRates[%d].earnFactor must be 0..10", i))
+		}
+
+		out = append(out, pgdto.CategoryEarnRateRow{
+			Category:   category,
+			EarnFactor: pgdto.Money(factor),
+		})
+	}
+
+	return out, nil
+}